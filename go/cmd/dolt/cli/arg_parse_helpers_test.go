@@ -84,3 +84,41 @@ func TestParseAuthor(t *testing.T) {
 		})
 	}
 }
+
+func TestAddRemoteParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		scheme    string
+		args      []string
+		expParams map[string]string
+		expErr    bool
+	}{
+		{"no params", "aws", []string{"add", "origin", "url"}, map[string]string{}, false},
+		{"short key", "aws", []string{"add", "origin", "url", "--param", "region=us-east-1"}, map[string]string{"aws-region": "us-east-1"}, false},
+		{"full key", "aws", []string{"add", "origin", "url", "--param", "aws-region=us-east-1"}, map[string]string{"aws-region": "us-east-1"}, false},
+		{"several", "aws", []string{"add", "origin", "url", "--param", "region=us-east-1", "creds-type=file", "creds-file=/creds"}, map[string]string{"aws-region": "us-east-1", "aws-creds-type": "file", "aws-creds-file": "/creds"}, false},
+		{"oss", "oss", []string{"add", "origin", "url", "--param", "creds-profile=dev"}, map[string]string{"oss-creds-profile": "dev"}, false},
+		{"invalid creds type", "aws", []string{"add", "origin", "url", "--param", "creds-type=magic"}, nil, true},
+		{"unknown key", "aws", []string{"add", "origin", "url", "--param", "bucket=b"}, nil, true},
+		{"other scheme's key", "oss", []string{"add", "origin", "url", "--param", "region=us-east-1"}, nil, true},
+		{"missing value", "aws", []string{"add", "origin", "url", "--param", "region"}, nil, true},
+		{"repeated key", "aws", []string{"add", "origin", "url", "--param", "region=us-east-1", "aws-region=us-west-2"}, nil, true},
+		{"file remote", "file", []string{"add", "origin", "url", "--param", "region=us-east-1"}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apr, err := CreateRemoteArgParser().Parse(test.args)
+			require.NoError(t, err)
+
+			params := map[string]string{}
+			err = AddRemoteParams(test.scheme, apr, params)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expParams, params)
+			}
+		})
+	}
+}
@@ -78,6 +78,7 @@ func ParseAuthor(authorStr string) (string, string, error) {
 const (
 	AllowEmptyFlag   = "allow-empty"
 	DateParam        = "date"
+	AuthorDateParam  = "author-date"
 	MessageArg       = "message"
 	AuthorParam      = "author"
 	ForceFlag        = "force"
@@ -89,12 +90,16 @@ const (
 	SoftResetParam   = "soft"
 	CheckoutCoBranch = "b"
 	NoFFParam        = "no-ff"
+	FFOnlyParam      = "ff-only"
 	SquashParam      = "squash"
 	AbortParam       = "abort"
+	ContinueFlag     = "continue"
 	CopyFlag         = "copy"
 	MoveFlag         = "move"
 	DeleteFlag       = "delete"
 	DeleteForceFlag  = "D"
+	OrphanFlag       = "orphan"
+	SetDescFlag      = "set-description"
 	OutputOnlyFlag   = "output-only"
 	RemoteParam      = "remote"
 	BranchParam      = "branch"
@@ -110,14 +115,24 @@ const (
 	MergesFlag       = "merges"
 	ParentsFlag      = "parents"
 	MinParentsFlag   = "min-parents"
+	MaxParentsFlag   = "max-parents"
 	DecorateFlag     = "decorate"
 	OneLineFlag      = "oneline"
+	DataOnlyFlag     = "data-only"
+	SchemaOnlyFlag   = "schema-only"
 	ShallowFlag      = "shallow"
 	CachedFlag       = "cached"
 	ListFlag         = "list"
 	UserParam        = "user"
+	VerifyFlag       = "verify"
 	NoPrettyFlag     = "no-pretty"
 	ShowIgnoredFlag  = "ignored"
+	PreserveHistory  = "preserve-history"
+	PrefixParam      = "prefix"
+	CharsetParam     = "charset"
+	CollateParam     = "collate"
+	RemoteParamFlag  = "param"
+	TagParam         = "tag"
 )
 
 const (
@@ -142,18 +157,24 @@ func CreateCommitArgParser() *argparser.ArgParser {
 	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message.")
 	ap.SupportsFlag(AllowEmptyFlag, "", "Allow recording a commit that has the exact same data as its sole parent. This is usually a mistake, so it is disabled by default. This option bypasses that safety.")
 	ap.SupportsString(DateParam, "", "date", "Specify the date used in the commit. If not specified the current system time is used.")
+	ap.SupportsString(AuthorDateParam, "", "date", "Specify the author date of the commit, when it differs from the commit date. If not specified the author date is the same as the commit date, or the author date of the amended commit with --amend.")
 	ap.SupportsFlag(ForceFlag, "f", "Ignores any foreign key warnings and proceeds with the commit.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
 	ap.SupportsFlag(AllFlag, "a", "Adds all existing, changed tables (but not new tables) in the working set to the staged set.")
 	ap.SupportsFlag(UpperCaseAllFlag, "A", "Adds all tables (including new tables) in the working set to the staged set.")
 	ap.SupportsFlag(AmendFlag, "", "Amend previous commit")
+	ap.SupportsString(TagParam, "", "tag", "Create a tag named {{.LessThan}}tag{{.GreaterThan}} pointing at the new commit. The commit is not made if the tag already exists.")
 	return ap
 }
 
+// InteractiveDataFlag is the DOLT_CONFLICTS_RESOLVE flag to resolve a single conflicting row to explicit values
+const InteractiveDataFlag = "interactive-data"
+
 func CreateConflictsResolveArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("conflicts resolve")
 	ap.SupportsFlag(OursFlag, "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag(TheirsFlag, "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsFlag(InteractiveDataFlag, "", "Resolve the conflict of a single row to the values given. Takes the table, the row's primary key as a JSON object, and the resolved row as a JSON object")
 	return ap
 }
 
@@ -163,6 +184,7 @@ func CreateMergeArgParser() *argparser.ArgParser {
 		return fmt.Errorf("Error: Dolt does not support merging from multiple commits. You probably meant to checkout one and then merge from the other.")
 	}
 	ap.SupportsFlag(NoFFParam, "", "Create a merge commit even when the merge resolves as a fast-forward.")
+	ap.SupportsFlag(FFOnlyParam, "", "Refuse to merge unless the merge resolves as a fast-forward, so a merge commit is never created.")
 	ap.SupportsFlag(SquashParam, "", "Merge changes to the working set without updating the commit history")
 	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message.")
 	ap.SupportsFlag(AbortParam, "", mergeAbortDetails)
@@ -204,6 +226,30 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateCreateFromArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("create_from", 2)
+	ap.SupportsFlag(PreserveHistory, "", "Copy the template database's commit history into the new database, instead of starting it with a single commit.")
+	ap.SupportsString(PrefixParam, "", "prefix", "A prefix for the name of the new database, so that databases created from the same template share a namespace, e.g. {{.EmphasisLeft}}--prefix tenant_{{.EmphasisRight}} with the name {{.EmphasisLeft}}acme{{.EmphasisRight}} creates {{.EmphasisLeft}}tenant_acme{{.EmphasisRight}}.")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"template", "The database to copy."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the new database."})
+	return ap
+}
+
+func CreateCreateDatabaseArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("create_database", 1)
+	ap.SupportsString(CharsetParam, "", "charset", "The default character set of the new database's tables. If no collation is given, the character set's default collation is used.")
+	ap.SupportsString(CollateParam, "", "collation", "The default collation of the new database's tables. It must be valid for the character set, if one is given.")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the new database."})
+	return ap
+}
+
+func CreateVerifySyncArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("verify_sync", 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"remote", "The remote to compare against."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"branch", "The branch to compare, locally and on the remote."})
+	return ap
+}
+
 func CreateResetArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("reset")
 	ap.SupportsFlag(HardResetParam, "", "Resets the working tables and staged tables. Any changes to tracked tables in the working tree since {{.LessThan}}commit{{.GreaterThan}} are discarded.")
@@ -217,6 +263,8 @@ func CreateRemoteArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsFlag(VerifyFlag, "", "When adding a remote, check that it can be reached and holds a dolt database before saving it.")
+	ap.SupportsStringList(RemoteParamFlag, "", "key=value", "When adding a remote, sets parameters used to connect to it, e.g. region=us-east-1 for an aws remote. Keys may omit the scheme's prefix, so region and aws-region are the same.")
 	return ap
 }
 
@@ -236,6 +284,7 @@ func CreateCheckoutArgParser() *argparser.ArgParser {
 
 func CreateCherryPickArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("cherrypick", 1)
+	ap.SupportsFlag(NoCommitFlag, "", "Apply and stage the changes of the commit without committing them.")
 	ap.TooManyArgsErrorFunc = func(receivedArgs []string) error {
 		return fmt.Errorf("cherry-picking multiple commits is not supported yet.")
 	}
@@ -250,6 +299,7 @@ func CreateFetchArgParser() *argparser.ArgParser {
 
 func CreateRevertArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("revert")
+	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message instead of the generated one.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision",
 		"The commit revisions. If multiple revisions are given, they're applied in the order given."})
@@ -257,6 +307,14 @@ func CreateRevertArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateRebaseArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("rebase", 1)
+	ap.SupportsFlag(AbortParam, "", "Abort the rebase in progress, resetting the branch to where it was before the rebase started.")
+	ap.SupportsFlag(ContinueFlag, "", "Commit the resolved conflicts of the rebase in progress and replay the rest of its commits.")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"upstream", "The branch or commit to replay the commits of the current branch on top of."})
+	return ap
+}
+
 func CreatePullArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("pull", 2)
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"remote", "The name of the remote to pull from."})
@@ -279,6 +337,8 @@ func CreateBranchArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(DeleteFlag, "d", "Delete a branch. The branch must be fully merged in its upstream branch.")
 	ap.SupportsFlag(DeleteForceFlag, "", "Shortcut for {{.EmphasisLeft}}--delete --force{{.EmphasisRight}}.")
 	ap.SupportsString(TrackFlag, "t", "", "When creating a new branch, set up 'upstream' configuration.")
+	ap.SupportsFlag(OrphanFlag, "", "Create a new branch with no history, starting from an empty commit with no parents.")
+	ap.SupportsFlag(SetDescFlag, "", "Set the description of a branch. An empty description removes it.")
 
 	return ap
 }
@@ -318,14 +378,33 @@ func CreateLogArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("log")
 	ap.SupportsInt(NumberFlag, "n", "num_commits", "Limit the number of commits to output.")
 	ap.SupportsInt(MinParentsFlag, "", "parent_count", "The minimum number of parents a commit must have to be included in the log.")
+	ap.SupportsInt(MaxParentsFlag, "", "parent_count", "The maximum number of parents a commit can have to be included in the log.")
 	ap.SupportsFlag(MergesFlag, "", "Equivalent to min-parents == 2, this will limit the log to commits with 2 or more parents.")
 	ap.SupportsFlag(ParentsFlag, "", "Shows all parents of each commit in the log.")
 	ap.SupportsString(DecorateFlag, "", "decorate_fmt", "Shows refs next to commits. Valid options are short, full, no, and auto")
 	ap.SupportsFlag(OneLineFlag, "", "Shows logs in a compact format.")
 	ap.SupportsStringList(NotFlag, "", "revision", "Excludes commits from revision.")
+	ap.SupportsFlag(DataOnlyFlag, "", "Limits the log to commits that changed table data, excluding commits that only changed schema and empty commits.")
+	ap.SupportsFlag(SchemaOnlyFlag, "", "Limits the log to commits that changed a table schema, excluding commits that only changed data and empty commits.")
+	return ap
+}
+
+// IncludeUntrackedFlag is the dolt stash flag to stash untracked tables too
+const IncludeUntrackedFlag = "include-untracked"
+
+// CreateStashArgParser creates the argparser shared by dolt stash cli and DOLT_STASH.
+func CreateStashArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("stash", 0)
+	ap.SupportsFlag(IncludeUntrackedFlag, "u", "Untracked tables are also stashed.")
+	ap.SupportsFlag(AllFlag, "a", "All tables are stashed, including untracked and ignored tables.")
 	return ap
 }
 
+// CreateStashPopArgParser creates the argparser shared by dolt stash pop cli and DOLT_STASH_POP.
+func CreateStashPopArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs("pop", 1)
+}
+
 func CreateGCArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("gc", 0)
 	ap.SupportsFlag(ShallowFlag, "s", "perform a fast, but incomplete garbage collection pass")
@@ -390,6 +469,57 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+// AddRemoteParams adds the key=value pairs given with --param to |params|, for a remote with the |scheme| given. Keys
+// are the parameters supported by the scheme, like aws-region, and may omit the scheme's prefix, like region. Returns
+// an error for a key that the scheme doesn't support, or one that's already in |params|.
+func AddRemoteParams(scheme string, apr *argparser.ArgParseResults, params map[string]string) error {
+	pairs, ok := apr.GetValueList(RemoteParamFlag)
+	if !ok {
+		return nil
+	}
+
+	var supported []string
+	switch scheme {
+	case dbfactory.AWSScheme:
+		supported = awsParams
+	case dbfactory.OSSScheme:
+		supported = ossParams
+	default:
+		return fmt.Errorf("--%s is only valid for aws and oss remotes", RemoteParamFlag)
+	}
+
+	for _, pair := range pairs {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || len(key) == 0 {
+			return fmt.Errorf("invalid remote param '%s', expected key=value", pair)
+		}
+
+		name := ""
+		for _, p := range supported {
+			if key == p || scheme+"-"+key == p {
+				name = p
+				break
+			}
+		}
+		if len(name) == 0 {
+			return fmt.Errorf("unknown param '%s' for %s remotes, valid params are %s", key, scheme, strings.Join(supported, ", "))
+		}
+
+		if name == dbfactory.AWSCredsTypeParam {
+			if err := argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes)(val); err != nil {
+				return err
+			}
+		}
+
+		if _, ok := params[name]; ok {
+			return fmt.Errorf("multiple values provided for param '%s'", name)
+		}
+		params[name] = val
+	}
+
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
@@ -46,13 +46,19 @@ With a {{.EmphasisLeft}}-m{{.EmphasisRight}}, {{.LessThan}}oldbranch{{.GreaterTh
 
 The {{.EmphasisLeft}}-c{{.EmphasisRight}} options have the exact same semantics as {{.EmphasisLeft}}-m{{.EmphasisRight}}, except instead of the branch being renamed it will be copied to a new name.
 
-With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.`,
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.
+
+With {{.EmphasisLeft}}--orphan{{.EmphasisRight}}, the new branch {{.LessThan}}branchname{{.GreaterThan}} shares no history with any other branch. It points to a new commit with no parents and no tables.
+
+With {{.EmphasisLeft}}--set-description{{.EmphasisRight}}, {{.LessThan}}description{{.GreaterThan}} is recorded as the description of {{.LessThan}}branchname{{.GreaterThan}}, and shown in the {{.EmphasisLeft}}dolt_branches{{.EmphasisRight}} system table. An empty description removes it.`,
 	Synopsis: []string{
 		`[--list] [-v] [-a] [-r]`,
 		`[-f] {{.LessThan}}branchname{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`-m [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-c [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-d [-f] [-r] {{.LessThan}}branchname{{.GreaterThan}}...`,
+		`--orphan [-f] {{.LessThan}}branchname{{.GreaterThan}}`,
+		`--set-description {{.LessThan}}branchname{{.GreaterThan}} {{.LessThan}}description{{.GreaterThan}}`,
 	},
 }
 
@@ -112,6 +118,10 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return deleteBranches(ctx, dEnv, apr, usage, apr.Contains(cli.ForceFlag))
 	case apr.Contains(cli.DeleteForceFlag):
 		return deleteBranches(ctx, dEnv, apr, usage, true)
+	case apr.Contains(cli.OrphanFlag):
+		return createOrphanBranch(ctx, dEnv, apr, usage)
+	case apr.Contains(cli.SetDescFlag):
+		return setBranchDescription(ctx, dEnv, apr, usage)
 	case apr.Contains(cli.ListFlag):
 		return printBranches(ctx, dEnv, apr, usage)
 	case apr.Contains(showCurrentFlag):
@@ -401,6 +411,40 @@ func createBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	return 0
 }
 
+// createOrphanBranch creates a new branch with no history, which starts from an empty commit with no parents
+func createOrphanBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() != 1 || apr.Contains(cli.TrackFlag) {
+		usage()
+		return 1
+	}
+
+	name, email, err := env.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError(err.Error()).Build(), usage)
+	}
+
+	err = actions.CreateOrphanBranch(ctx, dEnv.DbData(), apr.Arg(0), name, email, apr.Contains(cli.ForceFlag), nil)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError(err.Error()).Build(), usage)
+	}
+
+	return 0
+}
+
+func setBranchDescription(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() != 2 {
+		usage()
+		return 1
+	}
+
+	err := actions.SetBranchDescription(ctx, dEnv.DbData(), apr.Arg(0), apr.Arg(1))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError(err.Error()).Build(), usage)
+	}
+
+	return 0
+}
+
 func HandleVErrAndExitCode(verr errhand.VerboseError, usage cli.UsagePrinter) int {
 	if verr != nil {
 		if msg := verr.Verbose(); strings.TrimSpace(msg) != "" {
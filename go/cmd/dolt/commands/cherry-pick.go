@@ -38,9 +38,11 @@ Applies the changes from an existing commit and creates a new commit from the cu
 Cherry-picking merge commits or commits with schema changes or rename or drop tables is not currently supported. Row data changes are allowed as long as the two table schemas are exactly identical.
 
 If applying the row data changes from the cherry-picked commit results in a data conflict, the cherry-pick operation is aborted and no changes are made to the working tree or committed.
+
+With {{.EmphasisLeft}}--no-commit{{.EmphasisRight}}, the changes are applied and staged, but not committed.
 `,
 	Synopsis: []string{
-		`{{.LessThan}}commit{{.GreaterThan}}`,
+		`[--no-commit] {{.LessThan}}commit{{.GreaterThan}}`,
 	},
 }
 
@@ -97,12 +99,12 @@ func (cmd CherryPickCmd) Exec(ctx context.Context, commandStr string, args []str
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
-	verr := cherryPick(ctx, dEnv, cherryStr)
+	verr := cherryPick(ctx, dEnv, cherryStr, apr.Contains(cli.NoCommitFlag))
 	return HandleVErrAndExitCode(verr, usage)
 }
 
 // cherryPick returns error if any step of cherry-picking fails. It receives cherry-picked commit and performs cherry-picking and commits.
-func cherryPick(ctx context.Context, dEnv *env.DoltEnv, cherryStr string) errhand.VerboseError {
+func cherryPick(ctx context.Context, dEnv *env.DoltEnv, cherryStr string, noCommit bool) errhand.VerboseError {
 	// check for clean working state
 	headRoot, err := dEnv.HeadRoot(ctx)
 	if err != nil {
@@ -160,6 +162,9 @@ func cherryPick(ctx context.Context, dEnv *env.DoltEnv, cherryStr string) errhan
 	if res != 0 {
 		return errhand.BuildDError("dolt add failed").AddCause(err).Build()
 	}
+	if noCommit {
+		return nil
+	}
 
 	commitParams := []string{"-m", commitMsg}
 	res = CommitCmd{}.Exec(ctx, "commit", commitParams, dEnv, nil)
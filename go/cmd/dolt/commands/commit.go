@@ -21,6 +21,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	goisatty "github.com/mattn/go-isatty"
@@ -32,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -48,7 +50,9 @@ The content to be added can be specified by using dolt add to incrementally \"ad
 
 The log message can be added with the parameter {{.EmphasisLeft}}-m <msg>{{.EmphasisRight}}.  If the {{.LessThan}}-m{{.GreaterThan}} parameter is not provided an editor will be opened where you can review the commit and provide a log message.
 
-The commit timestamp can be modified using the --date parameter.  Dates can be specified in the formats {{.LessThan}}YYYY-MM-DD{{.GreaterThan}}, {{.LessThan}}YYYY-MM-DDTHH:MM:SS{{.GreaterThan}}, or {{.LessThan}}YYYY-MM-DDTHH:MM:SSZ07:00{{.GreaterThan}} (where {{.LessThan}}07:00{{.GreaterThan}} is the time zone offset)."`,
+The commit timestamp can be modified using the --date parameter.  Dates can be specified in the formats {{.LessThan}}YYYY-MM-DD{{.GreaterThan}}, {{.LessThan}}YYYY-MM-DDTHH:MM:SS{{.GreaterThan}}, or {{.LessThan}}YYYY-MM-DDTHH:MM:SSZ07:00{{.GreaterThan}} (where {{.LessThan}}07:00{{.GreaterThan}} is the time zone offset).
+
+The new commit can be tagged with {{.EmphasisLeft}}--tag <tag>{{.EmphasisRight}}. If the tag already exists, nothing is committed."`,
 	Synopsis: []string{
 		"[options]",
 	},
@@ -115,6 +119,16 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		}
 	}
 
+	tagName, tagOk := apr.GetValue(cli.TagParam)
+	if tagOk {
+		err = actions.CheckNewTagName(ctx, dEnv.DoltDB, tagName)
+		if err == actions.ErrAlreadyExists {
+			return HandleVErrAndExitCode(errhand.BuildDError("fatal: tag '%s' already exists", tagName).Build(), usage)
+		} else if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("fatal: cannot create tag '%s'", tagName).AddCause(err).Build(), usage)
+		}
+	}
+
 	headCommit, _ := dEnv.HeadCommit(ctx)
 	headHash, _ := headCommit.HashOf()
 
@@ -160,6 +174,20 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		}
 	}
 
+	var authorDate time.Time
+	if authorDateStr, ok := apr.GetValue(cli.AuthorDateParam); ok {
+		authorDate, err = cli.ParseDate(authorDateStr)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: invalid author date").AddCause(err).Build(), usage)
+		}
+	} else if apr.Contains(cli.AmendFlag) && !apr.Contains(cli.DateParam) {
+		commitMeta, err := headCommit.GetCommitMeta(ctx)
+		if err != nil {
+			return handleCommitErr(ctx, dEnv, err, usage)
+		}
+		authorDate = commitMeta.AuthorTime()
+	}
+
 	var parentsHeadForAmend []*doltdb.Commit
 	if apr.Contains(cli.AmendFlag) {
 		numParentsHeadForAmend := headCommit.NumParents()
@@ -201,6 +229,7 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 	pendingCommit, err := actions.GetCommitStaged(ctx, roots, ws, mergeParentCommits, dEnv.DbData().Ddb, actions.CommitStagedProps{
 		Message:    msg,
 		Date:       t,
+		AuthorDate: authorDate,
 		AllowEmpty: apr.Contains(cli.AllowEmptyFlag) || apr.Contains(cli.AmendFlag),
 		Force:      apr.Contains(cli.ForceFlag),
 		Name:       name,
@@ -225,7 +254,7 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 	if err != nil {
 		return handleCommitErr(ctx, dEnv, err, usage)
 	}
-	_, err = dEnv.DoltDB.CommitWithWorkingSet(
+	newCommit, err := dEnv.DoltDB.CommitWithWorkingSet(
 		ctx,
 		headRef,
 		ws.Ref(),
@@ -250,6 +279,16 @@ func performCommit(ctx context.Context, commandStr string, args []string, dEnv *
 		return HandleVErrAndExitCode(errhand.BuildDError("Couldn't commit").AddCause(err).Build(), usage)
 	}
 
+	if tagOk {
+		err = dEnv.DoltDB.NewTagAtCommit(ctx, ref.NewTagRef(tagName), newCommit, datas.NewTagMeta(name, email, ""))
+		if err == nil {
+			err = actions.RecordTagCreation(ctx, dEnv.RepoStateWriter(), tagName)
+		}
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("Couldn't create tag '%s'", tagName).AddCause(err).Build(), usage)
+		}
+	}
+
 	return 0
 }
 
@@ -143,6 +143,9 @@ func NewSqlEngine(
 	}).WithBackgroundThreads(bThreads)
 	engine.Analyzer.Catalog.MySQLDb.SetPersister(persister)
 
+	// Database write locks are released when the connection holding them closes
+	engine.ProcessList = pro.DatabaseWriteLocks().ReleasingProcessList(engine.ProcessList)
+
 	engine.Analyzer.Catalog.MySQLDb.SetPlugins(map[string]mysql_db.PlaintextAuthPlugin{
 		"authentication_dolt_jwt": NewAuthenticateDoltJWTPlugin(config.JwksConfig),
 	})
@@ -0,0 +1,119 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer/analyzererrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestReadOnlyFileSystem(t *testing.T) {
+	ctx := context.Background()
+	dir, homeDir := t.TempDir(), t.TempDir()
+	hdp := func() (string, error) { return homeDir, nil }
+
+	fs, err := filesys.LocalFilesysWithWorkingDir(dir)
+	require.NoError(t, err)
+	dEnv := env.Load(ctx, hdp, fs, doltdb.LocalDirDoltDB, "test")
+	require.NoError(t, dEnv.InitRepo(ctx, types.Format_Default, "billy bob", "bigbillieb@fake.horse", env.DefaultInitBranch))
+	dEnv = env.Load(ctx, hdp, fs, doltdb.LocalDirDoltDB, "test")
+
+	se, dbName, err := NewSqlEngineForEnv(ctx, dEnv)
+	require.NoError(t, err)
+	sqlCtx, err := se.NewLocalContext(ctx)
+	require.NoError(t, err)
+	sqlCtx.SetCurrentDatabase(dbName)
+	for _, q := range []string{
+		"create table t (pk int primary key, v int)",
+		"insert into t values (1, 1), (2, 2)",
+		"call dolt_commit('-Am', 'add t', '--author', 'billy bob <bigbillieb@fake.horse>')",
+	} {
+		_, err = queryRows(sqlCtx, se, q)
+		require.NoError(t, err, q)
+	}
+	_ = se.Close()
+	require.NoError(t, dEnv.DoltDB.Close())
+	require.NoError(t, dbfactory.DeleteFromSingletonCache(filepath.ToSlash(dir)+"/.dolt/noms"))
+
+	before := listFiles(t, dir)
+
+	// reopen the database as if it were on a read-only mount
+	dEnv = env.Load(ctx, hdp, filesys.NewReadOnlyFS(fs), doltdb.LocalDirDoltDB, "test")
+	require.NoError(t, dEnv.DBLoadError)
+	require.True(t, dEnv.IsReadOnly())
+
+	se, dbName, err = NewSqlEngineForEnv(ctx, dEnv)
+	require.NoError(t, err)
+	defer se.Close()
+	sqlCtx, err = se.NewLocalContext(ctx)
+	require.NoError(t, err)
+	sqlCtx.SetCurrentDatabase(dbName)
+
+	rows, err := queryRows(sqlCtx, se, "select * from t order by pk")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(1)}, {int32(2), int32(2)}}, rows)
+	rows, err = queryRows(sqlCtx, se, "select message from dolt_log limit 1")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{"add t"}}, rows)
+
+	for _, q := range []string{
+		"insert into t values (3, 3)",
+		"create table t2 (pk int primary key)",
+		"call dolt_commit('--allow-empty', '-m', 'empty', '--author', 'billy bob <bigbillieb@fake.horse>')",
+		"call dolt_branch('b1')",
+		"call dolt_tag('v1')",
+	} {
+		_, err = queryRows(sqlCtx, se, q)
+		assert.True(t, analyzererrors.ErrReadOnlyDatabase.Is(err), "%s: %v", q, err)
+	}
+
+	assert.Equal(t, before, listFiles(t, dir))
+}
+
+func queryRows(ctx *sql.Context, se *SqlEngine, query string) ([]sql.Row, error) {
+	_, iter, err := se.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, nil, iter)
+}
+
+// listFiles returns the size and modification time of every file under |dir|, by path
+func listFiles(t *testing.T, dir string) map[string]string {
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		files[path] = fmt.Sprintf("%d %s", info.Size(), info.ModTime())
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
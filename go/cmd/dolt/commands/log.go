@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
@@ -39,8 +40,11 @@ type logOpts struct {
 	numLines             int
 	showParents          bool
 	minParents           int
+	maxParents           int
 	decoration           string
 	oneLine              bool
+	dataOnly             bool
+	schemaOnly           bool
 	excludingCommitSpecs []*doltdb.CommitSpec
 	commitSpecs          []*doltdb.CommitSpec
 	tableName            string
@@ -152,8 +156,15 @@ func parseLogArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 		numLines:    apr.GetIntOrDefault(cli.NumberFlag, -1),
 		showParents: apr.Contains(cli.ParentsFlag),
 		minParents:  minParents,
+		maxParents:  apr.GetIntOrDefault(cli.MaxParentsFlag, -1),
 		oneLine:     apr.Contains(cli.OneLineFlag),
 		decoration:  decorateOption,
+		dataOnly:    apr.Contains(cli.DataOnlyFlag),
+		schemaOnly:  apr.Contains(cli.SchemaOnlyFlag),
+	}
+
+	if opts.dataOnly && opts.schemaOnly {
+		return nil, fmt.Errorf("cannot use --%s with --%s", cli.DataOnlyFlag, cli.SchemaOnlyFlag)
 	}
 
 	err := opts.parseRefsAndTable(ctx, apr, dEnv)
@@ -182,6 +193,31 @@ func parseLogArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	return opts, nil
 }
 
+// matchesCommit returns a function reporting whether a commit should be included in the log, given the --min-parents,
+// --max-parents, --data-only and --schema-only options.
+func (opts *logOpts) matchesCommit(ctx context.Context) func(*doltdb.Commit) (bool, error) {
+	return func(commit *doltdb.Commit) (bool, error) {
+		if commit.NumParents() < opts.minParents {
+			return false, nil
+		}
+		if opts.maxParents >= 0 && commit.NumParents() > opts.maxParents {
+			return false, nil
+		}
+		if !opts.dataOnly && !opts.schemaOnly {
+			return true, nil
+		}
+
+		dataChanged, schemaChanged, err := diff.GetCommitChanges(ctx, commit)
+		if err != nil {
+			return false, err
+		}
+		if opts.dataOnly {
+			return dataChanged, nil
+		}
+		return schemaChanged, nil
+	}
+}
+
 func (opts *logOpts) parseRefsAndTable(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) error {
 	// `dolt log`
 	if apr.NArg() == 0 {
@@ -360,9 +396,7 @@ func logCommits(ctx context.Context, dEnv *env.DoltEnv, opts *logOpts) int {
 		return handleErrAndExit(err)
 	}
 
-	matchFunc := func(c *doltdb.Commit) (bool, error) {
-		return c.NumParents() >= opts.minParents, nil
-	}
+	matchFunc := opts.matchesCommit(ctx)
 
 	var commits []*doltdb.Commit
 	if len(opts.excludingCommitSpecs) == 0 {
@@ -480,9 +514,7 @@ func logTableCommits(ctx context.Context, dEnv *env.DoltEnv, opts *logOpts) erro
 		hashes[i] = h
 	}
 
-	matchFunc := func(commit *doltdb.Commit) (bool, error) {
-		return commit.NumParents() >= opts.minParents, nil
-	}
+	matchFunc := opts.matchesCommit(ctx)
 
 	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, dEnv.DoltDB, hashes, matchFunc)
 	if err != nil && err != io.EOF {
@@ -47,6 +47,7 @@ The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be
 	Synopsis: []string{
 		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
+		"--ff-only {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
 }
@@ -87,6 +88,10 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 		cli.PrintErrf("error: Flags '--%s' and '--%s' cannot be used together.\n", cli.SquashParam, cli.NoFFParam)
 		return 1
 	}
+	if apr.ContainsAll(cli.FFOnlyParam, cli.NoFFParam) {
+		cli.PrintErrf("error: Flags '--%s' and '--%s' cannot be used together.\n", cli.FFOnlyParam, cli.NoFFParam)
+		return 1
+	}
 
 	// This command may create a commit, so we need user identity
 	if !cli.CheckUserNameAndEmail(dEnv) {
@@ -173,7 +178,7 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 			if apr.Contains(cli.NoCommitFlag) && apr.Contains(cli.CommitFlag) {
 				return HandleVErrAndExitCode(errhand.BuildDError("cannot define both 'commit' and 'no-commit' flags at the same time").Build(), usage)
 			}
-			spec, err := merge.NewMergeSpec(ctx, dEnv.RepoStateReader(), dEnv.DoltDB, roots, name, email, msg, commitSpecStr, apr.Contains(cli.SquashParam), apr.Contains(cli.NoFFParam), apr.Contains(cli.ForceFlag), apr.Contains(cli.NoCommitFlag), apr.Contains(cli.NoEditFlag), t)
+			spec, err := merge.NewMergeSpec(ctx, dEnv.RepoStateReader(), dEnv.DoltDB, roots, name, email, msg, commitSpecStr, apr.Contains(cli.SquashParam), apr.Contains(cli.NoFFParam), apr.Contains(cli.FFOnlyParam), apr.Contains(cli.ForceFlag), apr.Contains(cli.NoCommitFlag), apr.Contains(cli.NoEditFlag), t)
 			if err != nil {
 				return handleCommitErr(ctx, dEnv, errhand.VerboseErrorFromError(err), usage)
 			}
@@ -264,6 +269,8 @@ func validateMergeSpec(ctx context.Context, spec *merge.MergeSpec) errhand.Verbo
 		}
 	} else if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
 		cli.Println("Already up to date.")
+	} else if err == nil && spec.FFOnly {
+		return errhand.VerboseErrorFromError(merge.ErrNotFastForward)
 	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
@@ -46,17 +47,21 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	role: Use the credentials installed for the current user
 	env: Looks for environment variables AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
 	file: Uses the credentials file specified by the parameter aws-creds-file
+
+Parameters can also be given as a list of {{.EmphasisLeft}}key=value{{.EmphasisRight}} pairs with {{.EmphasisLeft}}--param{{.EmphasisRight}}, where the key may leave out the scheme's prefix, e.g. {{.EmphasisLeft}}--param region=us-east-1 creds-type=role{{.EmphasisRight}}.
 	
 GCP remote urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google.
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+Remotes are added without contacting them. With {{.EmphasisLeft}}--verify{{.EmphasisRight}}, the remote is first checked to be reachable and to hold a dolt database, and is not added if the check fails. A file remote must already hold a database to pass the check.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
 Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
-		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--param {{.LessThan}}key=value{{.GreaterThan}}...] [--verify] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
 	},
 }
@@ -112,7 +117,7 @@ func (cmd RemoteCmd) Exec(ctx context.Context, commandStr string, args []string,
 	case apr.NArg() == 0:
 		verr = printRemotes(dEnv, apr)
 	case apr.Arg(0) == addRemoteId:
-		verr = addRemote(dEnv, apr)
+		verr = addRemote(ctx, dEnv, apr)
 	case apr.Arg(0) == removeRemoteId:
 		verr = removeRemote(ctx, dEnv, apr)
 	case apr.Arg(0) == removeRemoteShortId:
@@ -148,7 +153,7 @@ func removeRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	}
 }
 
-func addRemote(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+func addRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
 	if apr.NArg() != 3 {
 		return errhand.BuildDError("").SetPrintUsage().Build()
 	}
@@ -166,6 +171,16 @@ func addRemote(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.Verbos
 		return verr
 	}
 
+	if apr.Contains(cli.VerifyFlag) {
+		absRemote := env.NewRemote(remoteName, absRemoteUrl, params)
+		err = env.VerifyRemote(ctx, absRemote, func(ctx context.Context, r env.Remote) (*doltdb.DoltDB, error) {
+			return r.GetRemoteDBWithoutCaching(ctx, dEnv.DoltDB.ValueReadWriter().Format(), dEnv)
+		})
+		if err != nil {
+			return errhand.BuildDError("error: unable to verify remote '%s'.", remoteName).AddCause(err).Build()
+		}
+	}
+
 	r := env.NewRemote(remoteName, remoteUrl, params)
 	err = dEnv.AddRemote(r)
 
@@ -197,6 +212,9 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 	default:
 		err = cli.VerifyNoAwsParams(apr)
 	}
+	if err == nil {
+		err = cli.AddRemoteParams(scheme, apr, params)
+	}
 	if err != nil {
 		return nil, errhand.VerboseErrorFromError(err)
 	}
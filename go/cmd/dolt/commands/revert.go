@@ -36,7 +36,7 @@ var revertDocs = cli.CommandDocumentationContent{
 		"commit in the order specified. This requires a clean working set." +
 		"\n\nAny conflicts or constraint violations caused by the merge cause the command to fail.",
 	Synopsis: []string{
-		"<revision>...",
+		"[-m {{.LessThan}}msg{{.GreaterThan}}] <revision>...",
 	},
 }
 
@@ -127,7 +127,9 @@ func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string,
 	}
 	opts := editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir}
 	workingRoot, revertMessage, err := merge.Revert(ctx, dEnv.DoltDB, workingRoot, headCommit, commits, opts)
-	if err != nil {
+	if err == doltdb.ErrUnresolvedConflictsOrViolations {
+		return HandleVErrAndExitCode(errhand.BuildDError("revert currently does not handle conflicts").Build(), usage)
+	} else if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
@@ -150,7 +152,7 @@ func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string,
 	}
 
 	// Pass in the final parameters for the author string.
-	commitParams := []string{"-m", revertMessage}
+	commitParams := []string{"-m", apr.GetValueOrDefault(cli.MessageArg, revertMessage)}
 	authorStr, ok := apr.GetValue(cli.AuthorParam)
 	if ok {
 		commitParams = append(commitParams, "--author", authorStr)
@@ -23,10 +23,8 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
-	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
-	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
@@ -60,8 +58,7 @@ func (cmd StashPopCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd StashPopCmd) ArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
-	return ap
+	return cli.CreateStashPopArgParser()
 }
 
 // EventType returns the type of the event to log
@@ -94,12 +91,7 @@ func (cmd StashPopCmd) Exec(ctx context.Context, commandStr string, args []strin
 		}
 	}
 
-	workingRoot, err := dEnv.WorkingRoot(ctx)
-	if err != nil {
-		return handleStashPopErr(usage, err)
-	}
-
-	success, err := applyStashAtIdx(ctx, dEnv, workingRoot, idx)
+	success, err := applyStashAtIdx(ctx, dEnv, idx)
 	if err != nil {
 		return handleStashPopErr(usage, err)
 	}
@@ -119,29 +111,8 @@ func (cmd StashPopCmd) Exec(ctx context.Context, commandStr string, args []strin
 	return 0
 }
 
-func applyStashAtIdx(ctx context.Context, dEnv *env.DoltEnv, curWorkingRoot *doltdb.RootValue, idx int) (bool, error) {
-	stashRoot, headCommit, meta, err := dEnv.DoltDB.GetStashRootAndHeadCommitAtIdx(ctx, idx)
-	if err != nil {
-		return false, err
-	}
-
-	hch, err := headCommit.HashOf()
-	if err != nil {
-		return false, err
-	}
-	headCommitSpec, err := doltdb.NewCommitSpec(hch.String())
-	if err != nil {
-		return false, err
-	}
-	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
-	if err != nil {
-		return false, err
-	}
-	parentCommit, err := dEnv.DoltDB.Resolve(ctx, headCommitSpec, headRef)
-	if err != nil {
-		return false, err
-	}
-	parentRoot, err := parentCommit.GetRootValue(ctx)
+func applyStashAtIdx(ctx context.Context, dEnv *env.DoltEnv, idx int) (bool, error) {
+	roots, err := dEnv.Roots(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -152,18 +123,11 @@ func applyStashAtIdx(ctx context.Context, dEnv *env.DoltEnv, curWorkingRoot *dol
 	}
 
 	opts := editor.Options{Deaf: dEnv.BulkDbEaFactory(), Tempdir: tmpDir}
-	result, err := merge.MergeRoots(ctx, curWorkingRoot, stashRoot, parentRoot, stashRoot, parentCommit, opts, merge.MergeOpts{IsCherryPick: false})
+	roots, _, tablesWithConflict, err := actions.ApplyStash(ctx, dEnv.DoltDB, roots, idx, opts)
 	if err != nil {
 		return false, err
 	}
 
-	var tablesWithConflict []string
-	for tbl, stats := range result.Stats {
-		if stats.HasConflicts() {
-			tablesWithConflict = append(tablesWithConflict, tbl)
-		}
-	}
-
 	if len(tablesWithConflict) > 0 {
 		tblNames := strings.Join(tablesWithConflict, "', '")
 		cli.Printf("error: Your local changes to the following tables would be overwritten by applying stash %d:\n"+
@@ -172,23 +136,6 @@ func applyStashAtIdx(ctx context.Context, dEnv *env.DoltEnv, curWorkingRoot *dol
 		return false, nil
 	}
 
-	err = dEnv.UpdateWorkingRoot(ctx, result.Root)
-	if err != nil {
-		return false, err
-	}
-
-	roots, err := dEnv.Roots(ctx)
-	if err != nil {
-		return false, err
-	}
-
-	// added tables need to be staged
-	// since these tables are coming from a stash, don't filter for ignored table names.
-	roots, err = actions.StageTables(ctx, roots, meta.TablesToStage, false)
-	if err != nil {
-		return false, err
-	}
-
 	err = dEnv.UpdateRoots(ctx, roots)
 	if err != nil {
 		return false, err
@@ -16,22 +16,19 @@ package stashcmds
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
-	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
-	"github.com/dolthub/dolt/go/store/datas"
 )
 
-var ErrStashNotSupportedForOldFormat = errors.New("stash is not supported for old storage format")
+var ErrStashNotSupportedForOldFormat = actions.ErrStashNotSupportedForOldFormat
 
 var StashCommands = cli.NewSubCommandHandlerWithUnspecified("stash", "Stash the changes in a dirty working directory away.", false, StashCmd{}, []cli.Command{
 	StashClearCmd{},
@@ -40,11 +37,6 @@ var StashCommands = cli.NewSubCommandHandlerWithUnspecified("stash", "Stash the
 	StashPopCmd{},
 })
 
-const (
-	IncludeUntrackedFlag = "include-untracked"
-	AllFlag              = "all"
-)
-
 var stashDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Stash the changes in a dirty working directory away.",
 	LongDesc: `Use dolt stash when you want to record the current state of the working directory and the index, but want to go back to a clean working directory. 
@@ -78,10 +70,7 @@ func (cmd StashCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd StashCmd) ArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
-	ap.SupportsFlag(IncludeUntrackedFlag, "u", "Untracked tables are also stashed.")
-	ap.SupportsFlag(AllFlag, "a", "All tables are stashed, including untracked and ignored tables.")
-	return ap
+	return cli.CreateStashArgParser()
 }
 
 // EventType returns the type of the event to log
@@ -111,119 +100,12 @@ func (cmd StashCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return 0
 }
 
-func hasLocalChanges(ctx context.Context, dEnv *env.DoltEnv, roots doltdb.Roots, apr *argparser.ArgParseResults) (bool, error) {
-	headRoot, err := dEnv.HeadRoot(ctx)
-	if err != nil {
-		return false, err
-	}
-	workingRoot, err := dEnv.WorkingRoot(ctx)
-	if err != nil {
-		return false, err
-	}
-	stagedRoot, err := dEnv.StagedRoot(ctx)
-	if err != nil {
-		return false, err
-	}
-	headHash, err := headRoot.HashOf()
-	if err != nil {
-		return false, err
-	}
-	workingHash, err := workingRoot.HashOf()
-	if err != nil {
-		return false, err
-	}
-	stagedHash, err := stagedRoot.HashOf()
-	if err != nil {
-		return false, err
-	}
-
-	// Are there staged changes? If so, stash them.
-	if !headHash.Equal(stagedHash) {
-		return true, nil
-	}
-
-	// No staged changes, but are there any unstaged changes? If not, no work is needed.
-	if headHash.Equal(workingHash) {
-		return false, nil
-	}
-
-	// There are unstaged changes, is --all set? If so, nothing else matters. Stash them.
-	if apr.Contains(AllFlag) {
-		return true, nil
-	}
-
-	// --all was not set, so we can ignore tables. Is every table ignored?
-	allIgnored, err := workingSetContainsOnlyIgnoredTables(ctx, roots)
-	if err != nil {
-		return false, err
-	}
-
-	if allIgnored {
-		return false, nil
-	}
-
-	// There are unignored, unstaged tables. Is --include-untracked set. If so, nothing else matters. Stash them.
-	if apr.Contains(IncludeUntrackedFlag) {
-		return true, nil
-	}
-
-	// --include-untracked was not set, so we can skip untracked tables. Is every table untracked?
-	allUntracked, err := workingSetContainsOnlyUntrackedTables(ctx, roots)
-	if err != nil {
-		return false, err
-	}
-
-	if allUntracked {
-		return false, nil
-	}
-
-	// There are changes to tracked tables. Stash them.
-	return true, nil
-}
-
 func stashChanges(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) error {
-
 	roots, err := dEnv.Roots(ctx)
 	if err != nil {
 		return fmt.Errorf("couldn't get working root, cause: %s", err.Error())
 	}
 
-	hasChanges, err := hasLocalChanges(ctx, dEnv, roots, apr)
-	if err != nil {
-		return err
-	}
-	if !hasChanges {
-		cli.Println("No local changes to save")
-		return nil
-	}
-
-	roots, err = actions.StageModifiedAndDeletedTables(ctx, roots)
-	if err != nil {
-		return err
-	}
-
-	// all tables with changes that are going to be stashed are staged at this point
-
-	allTblsToBeStashed, addedTblsToStage, err := stashedTableSets(ctx, roots)
-	if err != nil {
-		return err
-	}
-
-	// stage untracked files to include them in the stash,
-	// but do not include them in added table set,
-	// because they should not be staged when popped.
-	if apr.Contains(IncludeUntrackedFlag) || apr.Contains(AllFlag) {
-		allTblsToBeStashed, err = doltdb.UnionTableNames(ctx, roots.Staged, roots.Working)
-		if err != nil {
-			return err
-		}
-
-		roots, err = actions.StageTables(ctx, roots, allTblsToBeStashed, !apr.Contains(AllFlag))
-		if err != nil {
-			return err
-		}
-	}
-
 	curHeadRef, err := dEnv.RepoStateReader().CWBHeadRef()
 	if err != nil {
 		return err
@@ -237,29 +119,25 @@ func stashChanges(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	if err != nil {
 		return err
 	}
-	commitMeta, err := commit.GetCommitMeta(ctx)
-	if err != nil {
-		return err
-	}
 
-	err = dEnv.DoltDB.AddStash(ctx, commit, roots.Staged, datas.NewStashMeta(curBranchName, commitMeta.Description, addedTblsToStage))
+	roots, stashed, err := actions.StashChanges(ctx, dEnv.DoltDB, roots, curHeadRef, commit, apr.Contains(cli.IncludeUntrackedFlag), apr.Contains(cli.AllFlag))
 	if err != nil {
 		return err
 	}
+	if !stashed {
+		cli.Println("No local changes to save")
+		return nil
+	}
 
-	// setting STAGED to current HEAD RootValue resets staged set of changed, so
-	// these changes are now in working set of changes, which needs to be checked out
-	roots.Staged = roots.Head
-	roots, err = actions.MoveTablesFromHeadToWorking(ctx, roots, allTblsToBeStashed)
+	err = dEnv.UpdateRoots(ctx, roots)
 	if err != nil {
 		return err
 	}
 
-	err = dEnv.UpdateRoots(ctx, roots)
+	commitMeta, err := commit.GetCommitMeta(ctx)
 	if err != nil {
 		return err
 	}
-
 	commitHash, err := commit.HashOf()
 	if err != nil {
 		return err
@@ -267,74 +145,3 @@ func stashChanges(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	cli.Println(fmt.Sprintf("Saved working directory and index state WIP on %s: %s %s", curBranchName, commitHash.String(), commitMeta.Description))
 	return nil
 }
-
-// workingSetContainsOnlyUntrackedTables returns true if all changes in working set are untracked files/added tables.
-// Untracked files are part of working set changes, but should not be stashed unless staged or --include-untracked flag is used.
-func workingSetContainsOnlyUntrackedTables(ctx context.Context, roots doltdb.Roots) (bool, error) {
-	_, unstaged, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
-	if err != nil {
-		return false, err
-	}
-
-	// All ignored files are also untracked files
-	for _, tableDelta := range unstaged {
-		if !tableDelta.IsAdd() {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
-// workingSetContainsOnlyIgnoredTables returns true if all changes in working set are ignored tables.
-// Note that only unstaged tables are subject to dolt_ignore (this is consistent with what git does.)
-func workingSetContainsOnlyIgnoredTables(ctx context.Context, roots doltdb.Roots) (bool, error) {
-	_, unstaged, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
-	if err != nil {
-		return false, err
-	}
-
-	ignorePatterns, err := doltdb.GetIgnoredTablePatterns(ctx, roots)
-	if err != nil {
-		return false, err
-	}
-
-	for _, tableDelta := range unstaged {
-		if !(tableDelta.IsAdd()) {
-			return false, nil
-		}
-		isIgnored, err := ignorePatterns.IsTableNameIgnored(tableDelta.ToName)
-		if err != nil {
-			return false, err
-		}
-		if isIgnored != doltdb.Ignore {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
-// stashedTableSets returns array of table names for all tables that are being stashed and added tables in staged.
-// These table names are determined from all tables in the staged set of changes as they are being stashed only.
-func stashedTableSets(ctx context.Context, roots doltdb.Roots) ([]string, []string, error) {
-	var addedTblsInStaged []string
-	var allTbls []string
-	staged, _, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	for _, tableDelta := range staged {
-		tblName := tableDelta.ToName
-		if tableDelta.IsAdd() {
-			addedTblsInStaged = append(addedTblsInStaged, tableDelta.ToName)
-		}
-		if tableDelta.IsDrop() {
-			tblName = tableDelta.FromName
-		}
-		allTbls = append(allTbls, tblName)
-	}
-
-	return allTbls, addedTblsInStaged, nil
-}
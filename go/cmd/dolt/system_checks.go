@@ -20,6 +20,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/util/tempfiles"
 )
 
@@ -51,9 +52,10 @@ func canMoveTempFile() bool {
 	return true
 }
 
-// If we cannot verify that we can move files for any reason, use a ./.dolt/tmp as the temp dir.
+// If we cannot verify that we can move files for any reason, use a ./.dolt/tmp as the temp dir. Databases on a read-only
+// mount are loaded read-only and never move temp files into the database, so the default temp dir is kept there.
 func reconfigIfTempFileMoveFails(dEnv *env.DoltEnv) error {
-	if !canMoveTempFile() {
+	if !filesys.IsOnReadOnlyMount(dEnv.FS, ".") && !canMoveTempFile() {
 		tmpDir := "./.dolt/tmp"
 
 		if !dEnv.HasDoltDir() {
@@ -226,7 +226,19 @@ func (rcv *Commit) MutateUserTimestampMillis(n int64) bool {
 	return rcv._tab.MutateInt64Slot(20, n)
 }
 
-const CommitNumFields = 9
+func (rcv *Commit) AuthorTimestampMillis() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(22))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Commit) MutateAuthorTimestampMillis(n int64) bool {
+	return rcv._tab.MutateInt64Slot(22, n)
+}
+
+const CommitNumFields = 10
 
 func CommitStart(builder *flatbuffers.Builder) {
 	builder.StartObject(CommitNumFields)
@@ -267,6 +279,9 @@ func CommitAddTimestampMillis(builder *flatbuffers.Builder, timestampMillis uint
 func CommitAddUserTimestampMillis(builder *flatbuffers.Builder, userTimestampMillis int64) {
 	builder.PrependInt64Slot(8, userTimestampMillis, 0)
 }
+func CommitAddAuthorTimestampMillis(builder *flatbuffers.Builder, authorTimestampMillis int64) {
+	builder.PrependInt64Slot(9, authorTimestampMillis, 0)
+}
 func CommitEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -47,6 +47,13 @@ const (
 	DataDir = "noms"
 
 	ChunkJournalParam = "journal"
+
+	// manifestFileName is the name of the manifest file found in the directory of every database
+	manifestFileName = "manifest"
+
+	// ReadOnlyParam opens the database without writing to its files, e.g. for a snapshot mounted on a read-only file
+	// system. No lock file is taken, the chunk journal is never written or trued-up, and commits fail.
+	ReadOnlyParam = "read_only"
 )
 
 // DoltDataDir is the directory where noms files will be stored
@@ -131,14 +138,18 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		return nil, nil, nil, err
 	}
 
-	var useJournal bool
+	var useJournal, readOnly bool
 	if params != nil {
 		_, useJournal = params[ChunkJournalParam]
+		_, readOnly = params[ReadOnlyParam]
 	}
 
 	var newGenSt *nbs.NomsBlockStore
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	if useJournal && chunkJournalFeatureFlag {
+	if readOnly {
+		// a read-only journaling store reads stores with or without a chunk journal, and never writes either
+		newGenSt, err = nbs.NewLocalReadOnlyJournalingStore(ctx, nbf.VersionString(), path, q)
+	} else if useJournal && chunkJournalFeatureFlag {
 		newGenSt, err = nbs.NewLocalJournalingStore(ctx, nbf.VersionString(), path, q)
 	} else {
 		newGenSt, err = nbs.NewLocalStore(ctx, nbf.VersionString(), path, defaultMemTableSize, q)
@@ -151,7 +162,7 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 	oldgenPath := filepath.Join(path, "oldgen")
 	err = validateDir(oldgenPath)
 	if err != nil {
-		if !errors.Is(err, os.ErrNotExist) {
+		if !errors.Is(err, os.ErrNotExist) || readOnly {
 			return nil, nil, nil, err
 		}
 
@@ -161,7 +172,12 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 		}
 	}
 
-	oldGenSt, err := nbs.NewLocalStore(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q)
+	var oldGenSt *nbs.NomsBlockStore
+	if readOnly {
+		oldGenSt, err = nbs.NewLocalReadOnlyJournalingStore(ctx, newGenSt.Version(), oldgenPath, q)
+	} else {
+		oldGenSt, err = nbs.NewLocalStore(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q)
+	}
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -183,6 +199,30 @@ func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat,
 	return ddb, vrw, ns, nil
 }
 
+// ValidateFileDB returns an error unless the path of the file URL |u| is a directory holding a database. Unlike
+// CreateDB, it never creates anything at the path.
+func ValidateFileDB(u *url.URL) error {
+	path, err := url.PathUnescape(u.Path)
+	if err != nil {
+		return err
+	}
+
+	path = filepath.FromSlash(path)
+	path = u.Host + path
+
+	if err = validateDir(path); err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(filepath.Join(path, manifestFileName)); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s is not a dolt database", path)
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func validateDir(path string) error {
 	info, err := os.Stat(path)
 
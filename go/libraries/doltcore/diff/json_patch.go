@@ -0,0 +1,160 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	JsonPatchAdd     = "add"
+	JsonPatchRemove  = "remove"
+	JsonPatchReplace = "replace"
+)
+
+// JsonPatch returns the JSON Patch, as defined by RFC 6902, that transforms the JSON document |from| into |to|. Both
+// documents are unmarshalled JSON values, like those held by go-mysql-server's JSONDocument. The patch is an array of
+// operation objects, each with an "op" of add, remove or replace, a "path" that is a JSON Pointer (RFC 6901) into the
+// document, and for add and replace, the "value" at that path. Objects are diffed by key and arrays by index, so that
+// only the values that changed are included; a value whose type changed is replaced as a whole. The patch is empty if
+// the documents are equal.
+func JsonPatch(from, to interface{}) []interface{} {
+	patch := make([]interface{}, 0)
+	return appendJsonPatch(patch, "", from, to)
+}
+
+func appendJsonPatch(patch []interface{}, path string, from, to interface{}) []interface{} {
+	switch fromVal := from.(type) {
+	case map[string]interface{}:
+		if toVal, ok := to.(map[string]interface{}); ok {
+			return appendJsonObjectPatch(patch, path, fromVal, toVal)
+		}
+	case []interface{}:
+		if toVal, ok := to.([]interface{}); ok {
+			return appendJsonArrayPatch(patch, path, fromVal, toVal)
+		}
+	default:
+		if !isJsonContainer(to) && jsonScalarsEqual(from, to) {
+			return patch
+		}
+	}
+	return append(patch, jsonPatchOp(JsonPatchReplace, path, to))
+}
+
+// appendJsonObjectPatch appends the operations for the keys removed, added and changed between two objects, in key
+// order
+func appendJsonObjectPatch(patch []interface{}, path string, from, to map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fromVal, inFrom := from[k]
+		toVal, inTo := to[k]
+		keyPath := path + "/" + escapeJsonPointerToken(k)
+		switch {
+		case !inTo:
+			patch = append(patch, jsonPatchOp(JsonPatchRemove, keyPath, nil))
+		case !inFrom:
+			patch = append(patch, jsonPatchOp(JsonPatchAdd, keyPath, toVal))
+		default:
+			patch = appendJsonPatch(patch, keyPath, fromVal, toVal)
+		}
+	}
+	return patch
+}
+
+// appendJsonArrayPatch appends the operations for the elements changed between two arrays at the same index, then
+// for the elements added to the end of the array, or removed from it. Removals are in descending index order, so that
+// each operation's path is valid after the operations before it are applied.
+func appendJsonArrayPatch(patch []interface{}, path string, from, to []interface{}) []interface{} {
+	common := len(from)
+	if len(to) < common {
+		common = len(to)
+	}
+
+	for i := 0; i < common; i++ {
+		patch = appendJsonPatch(patch, path+"/"+strconv.Itoa(i), from[i], to[i])
+	}
+	for i := common; i < len(to); i++ {
+		patch = append(patch, jsonPatchOp(JsonPatchAdd, path+"/"+strconv.Itoa(i), to[i]))
+	}
+	for i := len(from) - 1; i >= common; i-- {
+		patch = append(patch, jsonPatchOp(JsonPatchRemove, path+"/"+strconv.Itoa(i), nil))
+	}
+	return patch
+}
+
+func jsonPatchOp(op, path string, value interface{}) map[string]interface{} {
+	o := map[string]interface{}{"op": op, "path": path}
+	if op != JsonPatchRemove {
+		o["value"] = value
+	}
+	return o
+}
+
+func isJsonContainer(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonScalarsEqual returns whether two JSON scalars are equal, comparing numbers by value regardless of their Go type
+func jsonScalarsEqual(from, to interface{}) bool {
+	if f, ok := jsonNumber(from); ok {
+		t, ok := jsonNumber(to)
+		return ok && f == t
+	}
+	return from == to
+}
+
+func jsonNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// escapeJsonPointerToken escapes a key for use as a reference token of a JSON Pointer, as defined by RFC 6901
+func escapeJsonPointerToken(k string) string {
+	k = strings.ReplaceAll(k, "~", "~0")
+	return strings.ReplaceAll(k, "/", "~1")
+}
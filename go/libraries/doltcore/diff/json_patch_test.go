@@ -0,0 +1,141 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJsonPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "equal documents",
+			from:     `{"a": 1, "b": [1, 2, {"c": null}]}`,
+			to:       `{"b": [1, 2, {"c": null}], "a": 1}`,
+			expected: `[]`,
+		},
+		{
+			name:     "equal scalars",
+			from:     `"abc"`,
+			to:       `"abc"`,
+			expected: `[]`,
+		},
+		{
+			name:     "changed scalar",
+			from:     `1`,
+			to:       `2`,
+			expected: `[{"op": "replace", "path": "", "value": 2}]`,
+		},
+		{
+			name:     "object keys added, removed and changed",
+			from:     `{"a": 1, "b": 2, "c": 3}`,
+			to:       `{"a": 1, "c": 4, "d": 5}`,
+			expected: `[{"op": "remove", "path": "/b"}, {"op": "replace", "path": "/c", "value": 4}, {"op": "add", "path": "/d", "value": 5}]`,
+		},
+		{
+			name:     "nested objects",
+			from:     `{"a": {"b": {"c": 1, "d": true}, "e": "x"}}`,
+			to:       `{"a": {"b": {"c": 2, "d": true}, "e": "x"}}`,
+			expected: `[{"op": "replace", "path": "/a/b/c", "value": 2}]`,
+		},
+		{
+			name:     "object added within an object",
+			from:     `{"a": {}}`,
+			to:       `{"a": {"b": {"c": [1, 2]}}}`,
+			expected: `[{"op": "add", "path": "/a/b", "value": {"c": [1, 2]}}]`,
+		},
+		{
+			name:     "array element changed",
+			from:     `[1, 2, 3]`,
+			to:       `[1, 5, 3]`,
+			expected: `[{"op": "replace", "path": "/1", "value": 5}]`,
+		},
+		{
+			name:     "array elements appended",
+			from:     `[1]`,
+			to:       `[1, 2, 3]`,
+			expected: `[{"op": "add", "path": "/1", "value": 2}, {"op": "add", "path": "/2", "value": 3}]`,
+		},
+		{
+			name:     "array elements removed from the end",
+			from:     `[1, 2, 3]`,
+			to:       `[1]`,
+			expected: `[{"op": "remove", "path": "/2"}, {"op": "remove", "path": "/1"}]`,
+		},
+		{
+			name:     "objects within arrays",
+			from:     `{"items": [{"id": 1, "tags": ["a"]}, {"id": 2, "tags": []}]}`,
+			to:       `{"items": [{"id": 1, "tags": ["a", "b"]}, {"id": 2}]}`,
+			expected: `[{"op": "add", "path": "/items/0/tags/1", "value": "b"}, {"op": "remove", "path": "/items/1/tags"}]`,
+		},
+		{
+			name:     "scalar type changed",
+			from:     `{"a": "1", "b": true, "c": null}`,
+			to:       `{"a": 1, "b": 1, "c": false}`,
+			expected: `[{"op": "replace", "path": "/a", "value": 1}, {"op": "replace", "path": "/b", "value": 1}, {"op": "replace", "path": "/c", "value": false}]`,
+		},
+		{
+			name:     "object changed to an array",
+			from:     `{"a": {"b": 1}}`,
+			to:       `{"a": [1]}`,
+			expected: `[{"op": "replace", "path": "/a", "value": [1]}]`,
+		},
+		{
+			name:     "array changed to a scalar",
+			from:     `{"a": [1, 2]}`,
+			to:       `{"a": null}`,
+			expected: `[{"op": "replace", "path": "/a", "value": null}]`,
+		},
+		{
+			name:     "document type changed",
+			from:     `{"a": 1}`,
+			to:       `[{"a": 1}]`,
+			expected: `[{"op": "replace", "path": "", "value": [{"a": 1}]}]`,
+		},
+		{
+			name:     "keys escaped in paths",
+			from:     `{"a/b": {"c~d": 1}}`,
+			to:       `{"a/b": {"c~d": 2}}`,
+			expected: `[{"op": "replace", "path": "/a~1b/c~0d", "value": 2}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, unmarshalTestJson(t, test.expected), JsonPatch(unmarshalTestJson(t, test.from), unmarshalTestJson(t, test.to)))
+		})
+	}
+
+	t.Run("numbers compared by value", func(t *testing.T) {
+		from := map[string]interface{}{"a": int64(1), "b": float64(2)}
+		to := map[string]interface{}{"a": float64(1), "b": int64(2)}
+		assert.Empty(t, JsonPatch(from, to))
+	})
+}
+
+func unmarshalTestJson(t *testing.T, s string) interface{} {
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"crypto/sha512"
+	"io"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/diff"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// GetCommitPatchID returns the patch id of |commit|, a hash of the changes it made compared to its first parent, like
+// `git patch-id`. Two commits with the same patch id made the same changes, e.g. when one was cherry-picked from the
+// other onto a different parent. A commit without parents is compared to an empty root.
+//
+// The rows changed in a table are part of the patch id when the table's schema didn't change. Otherwise the table's
+// schema and rows on either side of the change are, since the rows of the two sides can't be compared.
+func GetCommitPatchID(ctx context.Context, commit *doltdb.Commit) (hash.Hash, error) {
+	deltas, err := GetCommitTableDeltas(ctx, commit)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	h := sha512.New()
+	for _, delta := range deltas {
+		h.Write([]byte(delta.FromName))
+		h.Write([]byte{0})
+		h.Write([]byte(delta.ToName))
+		h.Write([]byte{0})
+
+		schemaChanged, err := delta.HasSchemaChanged(ctx)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		if schemaChanged {
+			err = writeTableHashes(ctx, h, delta.FromTable)
+			if err != nil {
+				return hash.Hash{}, err
+			}
+			err = writeTableHashes(ctx, h, delta.ToTable)
+		} else {
+			err = writeRowChanges(ctx, h, delta)
+		}
+		if err != nil {
+			return hash.Hash{}, err
+		}
+	}
+
+	return hash.New(h.Sum(nil)[:hash.ByteLen]), nil
+}
+
+// writeTableHashes writes the schema hash and row data hash of |tbl| to |w|, or a marker if the table doesn't exist
+func writeTableHashes(ctx context.Context, w io.Writer, tbl *doltdb.Table) error {
+	if tbl == nil {
+		w.Write([]byte{0})
+		return nil
+	}
+
+	schHash, err := tbl.GetSchemaHash(ctx)
+	if err != nil {
+		return err
+	}
+	rowsHash, err := tbl.GetRowDataHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.Write([]byte{1})
+	w.Write(schHash[:])
+	w.Write(rowsHash[:])
+	return nil
+}
+
+// writeRowChanges writes the key and the old and new values of each row changed in |delta| to |w|
+func writeRowChanges(ctx context.Context, w io.Writer, delta TableDelta) error {
+	from, to, err := delta.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	if types.IsFormat_DOLT(delta.Format()) {
+		err = prolly.DiffMaps(ctx, durable.ProllyMapFromIndex(from), durable.ProllyMapFromIndex(to), func(ctx context.Context, d tree.Diff) error {
+			for _, tup := range [][]byte{d.Key, d.From, d.To} {
+				w.Write(tup)
+				w.Write([]byte{0})
+			}
+			return nil
+		})
+		if err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	}
+
+	return writeNomsRowChanges(ctx, w, durable.NomsMapFromIndex(from), durable.NomsMapFromIndex(to))
+}
+
+// writeNomsRowChanges is writeRowChanges for the old storage format, writing the hashes of the keys and values
+func writeNomsRowChanges(ctx context.Context, w io.Writer, from, to types.Map) (err error) {
+	ad := NewAsyncDiffer(1024)
+	ad.Start(ctx, from, to)
+	defer func() {
+		if cerr := ad.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for {
+		var diffs []*diff.Difference
+		var more bool
+		diffs, more, err = ad.GetDiffs(100, time.Millisecond)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range diffs {
+			for _, v := range []types.Value{d.KeyValue, d.OldValue, d.NewValue} {
+				if v == nil {
+					w.Write([]byte{0})
+					continue
+				}
+				vh, err := v.Hash(from.Format())
+				if err != nil {
+					return err
+				}
+				w.Write(vh[:])
+			}
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
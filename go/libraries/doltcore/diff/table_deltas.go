@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -759,3 +760,73 @@ func isPrimaryKeyIndex(index schema.Index, sch schema.Schema) bool {
 
 	return true
 }
+
+// GetCommitChanges returns whether |commit| changed any table data and whether it changed any table schema, compared
+// to its first parent. A commit without parents is compared to an empty root.
+func GetCommitChanges(ctx context.Context, commit *doltdb.Commit) (dataChanged, schemaChanged bool, err error) {
+	deltas, err := GetCommitTableDeltas(ctx, commit)
+	if err != nil {
+		return false, false, err
+	}
+
+	for _, delta := range deltas {
+		summary, err := delta.GetSummary(ctx)
+		if err != nil {
+			return false, false, err
+		}
+		dataChanged = dataChanged || summary.DataChange
+		schemaChanged = schemaChanged || summary.SchemaChange
+		if dataChanged && schemaChanged {
+			break
+		}
+	}
+
+	return dataChanged, schemaChanged, nil
+}
+
+// CommitChangesTables returns whether |commit| changed any of the tables named, compared to its first parent. Table
+// names are matched case-insensitively, and a table that was renamed matches by either its old or its new name.
+func CommitChangesTables(ctx context.Context, commit *doltdb.Commit, tableNames []string) (bool, error) {
+	deltas, err := GetCommitTableDeltas(ctx, commit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, delta := range deltas {
+		for _, name := range tableNames {
+			if strings.EqualFold(delta.FromName, name) || strings.EqualFold(delta.ToName, name) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// GetCommitTableDeltas returns the table deltas between |commit| and its first parent, or an empty root for a commit
+// without parents.
+func GetCommitTableDeltas(ctx context.Context, commit *doltdb.Commit) ([]TableDelta, error) {
+	toRoot, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromRoot *doltdb.RootValue
+	if commit.NumParents() > 0 {
+		parent, err := commit.GetParent(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		fromRoot, err = parent.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		fromRoot, err = doltdb.EmptyRootValue(ctx, toRoot.VRW(), toRoot.NodeStore())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return GetTableDeltas(ctx, fromRoot, toRoot)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// TableHashMatch compares the addresses of a table in two roots. A table missing from one of the roots has an empty
+// hash on that side.
+type TableHashMatch struct {
+	TableName string
+	FromHash  hash.Hash
+	ToHash    hash.Hash
+}
+
+// Matches returns whether the table exists in both roots and has the same address in each.
+func (m TableHashMatch) Matches() bool {
+	return !m.FromHash.IsEmpty() && m.FromHash == m.ToHash
+}
+
+// GetTableHashMatches compares the addresses of every table in |fromRoot| and |toRoot|, sorted by table name. Only
+// the roots' table maps are read, so no table data is loaded or scanned.
+func GetTableHashMatches(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) ([]TableHashMatch, error) {
+	fromHashes, err := fromRoot.MapTableHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	toHashes, err := toRoot.MapTableHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]TableHashMatch, 0, len(fromHashes))
+	for name, h := range fromHashes {
+		matches = append(matches, TableHashMatch{TableName: name, FromHash: h, ToHash: toHashes[name]})
+	}
+	for name, h := range toHashes {
+		if _, ok := fromHashes[name]; !ok {
+			matches = append(matches, TableHashMatch{TableName: name, ToHash: h})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].TableName < matches[j].TableName
+	})
+
+	return matches, nil
+}
@@ -44,6 +44,9 @@ type commitItr struct {
 	added       map[hash.Hash]bool
 	unprocessed []hash.Hash
 	curr        *Commit
+
+	// stop, when set, matches the commits that aren't returned or walked past to their parents
+	stop CommitFilter
 }
 
 // CommitItrForAllBranches returns a CommitItr which will iterate over all commits in all branches in a DoltDB
@@ -79,6 +82,18 @@ func CommitItrForRoots(ddb *DoltDB, rootCommits ...*Commit) CommitItr {
 	}
 }
 
+// CommitItrForRootsUntil returns a CommitItr like CommitItrForRoots, except that the commits matching |stop| aren't
+// returned and their parents aren't walked, so ancestors that are only reachable through them aren't returned either.
+func CommitItrForRootsUntil(ddb *DoltDB, stop CommitFilter, rootCommits ...*Commit) CommitItr {
+	return &commitItr{
+		ddb:         ddb,
+		rootCommits: rootCommits,
+		added:       make(map[hash.Hash]bool, 4096),
+		unprocessed: make([]hash.Hash, 0, 4096),
+		stop:        stop,
+	}
+}
+
 func (cmItr *commitItr) Reset(ctx context.Context) error {
 	cmItr.curr = nil
 	cmItr.currentRoot = 0
@@ -105,8 +120,14 @@ func (cmItr *commitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
 
 		if !cmItr.added[h] {
 			cmItr.added[h] = true
-			cmItr.curr = cm
-			return h, cmItr.curr, nil
+			stopped, err := cmItr.stopAt(ctx, h, cm)
+			if err != nil {
+				return hash.Hash{}, nil, err
+			}
+			if !stopped {
+				cmItr.curr = cm
+				return h, cmItr.curr, nil
+			}
 		}
 
 		cmItr.currentRoot++
@@ -125,23 +146,42 @@ func (cmItr *commitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
 		}
 	}
 
-	numUnprocessed := len(cmItr.unprocessed)
+	for {
+		numUnprocessed := len(cmItr.unprocessed)
 
-	if numUnprocessed == 0 {
-		cmItr.curr = nil
-		cmItr.currentRoot++
-		return cmItr.Next(ctx)
-	}
+		if numUnprocessed == 0 {
+			cmItr.curr = nil
+			cmItr.currentRoot++
+			return cmItr.Next(ctx)
+		}
 
-	next := cmItr.unprocessed[numUnprocessed-1]
-	cmItr.unprocessed = cmItr.unprocessed[:numUnprocessed-1]
-	cmItr.curr, err = HashToCommit(ctx, cmItr.ddb.ValueReadWriter(), cmItr.ddb.ns, next)
+		next := cmItr.unprocessed[numUnprocessed-1]
+		cmItr.unprocessed = cmItr.unprocessed[:numUnprocessed-1]
+		cm, err := HashToCommit(ctx, cmItr.ddb.ValueReadWriter(), cmItr.ddb.ns, next)
 
-	if err != nil {
-		return hash.Hash{}, nil, err
+		if err != nil {
+			return hash.Hash{}, nil, err
+		}
+
+		stopped, err := cmItr.stopAt(ctx, next, cm)
+		if err != nil {
+			return hash.Hash{}, nil, err
+		}
+		if stopped {
+			continue
+		}
+
+		cmItr.curr = cm
+		return next, cmItr.curr, nil
 	}
+}
 
-	return next, cmItr.curr, nil
+// stopAt returns whether the commit given matches the stop filter of this iterator
+func (cmItr *commitItr) stopAt(ctx context.Context, h hash.Hash, cm *Commit) (bool, error) {
+	if cmItr.stop == nil {
+		return false, nil
+	}
+	return cmItr.stop(ctx, h, cm)
 }
 
 func HashToCommit(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore, h hash.Hash) (*Commit, error) {
@@ -194,6 +234,42 @@ func (itr FilteringCommitItr) Reset(ctx context.Context) error {
 	return itr.itr.Reset(ctx)
 }
 
+// LimitingCommitItr is a CommitItr that returns at most a given number of the commits of the CommitItr it wraps. Once
+// it has returned that many it returns io.EOF without calling the wrapped iterator again, so a lazy iterator stops
+// walking the commit graph.
+type LimitingCommitItr struct {
+	itr   CommitItr
+	limit int
+	count int
+}
+
+var _ CommitItr = (*LimitingCommitItr)(nil)
+
+func NewLimitingCommitItr(itr CommitItr, limit int) *LimitingCommitItr {
+	return &LimitingCommitItr{itr: itr, limit: limit}
+}
+
+// Next returns the hash of the next commit, and a pointer to that commit, or io.EOF once the limit is reached.
+func (itr *LimitingCommitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
+	if itr.count >= itr.limit {
+		return hash.Hash{}, nil, io.EOF
+	}
+
+	h, cm, err := itr.itr.Next(ctx)
+	if err != nil {
+		return hash.Hash{}, nil, err
+	}
+
+	itr.count++
+	return h, cm, nil
+}
+
+// Reset the commit iterator back to the start
+func (itr *LimitingCommitItr) Reset(ctx context.Context) error {
+	itr.count = 0
+	return itr.itr.Reset(ctx)
+}
+
 func NewCommitSliceIter(cm []*Commit, h []hash.Hash) *CommitSliceIter {
 	return &CommitSliceIter{cm: cm, h: h}
 }
@@ -15,6 +15,7 @@
 package doltdb
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -23,6 +24,11 @@ import (
 
 var hashRegex = regexp.MustCompile(`^[0-9a-v]{32}$`)
 
+// MinCommitHashPrefixLen is the shortest abbreviation of a commit hash that's resolved to the commit it abbreviates
+const MinCommitHashPrefixLen = 8
+
+var hashPrefixRegex = regexp.MustCompile(fmt.Sprintf(`^[0-9a-v]{%d,31}$`, MinCommitHashPrefixLen))
+
 const head string = "head"
 
 // IsValidUserBranchName returns true if name isn't a valid commit hash, it is not named "head" and
@@ -49,6 +55,12 @@ func IsValidCommitHash(s string) bool {
 	return hashRegex.MatchString(s)
 }
 
+// IsCommitHashPrefix returns whether |s| can be an abbreviated commit hash, which is at least MinCommitHashPrefixLen
+// characters of a hash but not a whole one. An abbreviated hash is also a valid ref name, so refs are looked for first.
+func IsCommitHashPrefix(s string) bool {
+	return hashPrefixRegex.MatchString(s)
+}
+
 type commitSpecType string
 
 const (
@@ -74,7 +86,8 @@ type CommitSpec struct {
 // * head -- the literal string HEAD specifies the HEAD reference of the
 // current working set.
 // * a commit hash, like 46m0aqr8c1vuv76ml33cdtr8722hsbhn -- a fully specified
-// commit hash.
+// commit hash. A ref that doesn't exist and is at least MinCommitHashPrefixLen
+// characters of a hash, like 46m0aqr8, is resolved as an abbreviated hash.
 // * a ref -- referring to a branch or tag reference in the current dolt database.
 // Examples of branch refs include `master`, `heads/master`, `refs/heads/master`,
 // `origin/master`, `refs/remotes/origin/master`.
@@ -17,6 +17,8 @@ package doltdb
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/dolthub/dolt/go/libraries/utils/test"
 	"github.com/dolthub/dolt/go/store/hash"
 )
@@ -36,6 +38,15 @@ func TestCommitRegex(t *testing.T) {
 	}
 }
 
+func TestIsCommitHashPrefix(t *testing.T) {
+	assert.True(t, IsCommitHashPrefix("46m0aqr8"))
+	assert.True(t, IsCommitHashPrefix("46m0aqr8c1vuv76ml33cdtr8722hsbh"))
+	assert.False(t, IsCommitHashPrefix("46m0aqr"))
+	assert.False(t, IsCommitHashPrefix("46m0aqr8c1vuv76ml33cdtr8722hsbhn"))
+	assert.False(t, IsCommitHashPrefix("46m0aqr8-branch"))
+	assert.False(t, IsCommitHashPrefix("zzzzzzzz"))
+}
+
 func TestNewCommitSpec(t *testing.T) {
 	tests := []struct {
 		inputStr        string
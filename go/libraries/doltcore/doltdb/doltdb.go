@@ -91,7 +91,7 @@ func HackDatasDatabaseFromDoltDB(ddb *DoltDB) datas.Database {
 
 // LoadDoltDB will acquire a reference to the underlying noms db.  If the Location is InMemDoltDB then a reference
 // to a newly created in memory database will be used. If the location is LocalDirDoltDB, the directory must exist or
-// this returns nil.
+// this returns nil. A local database on a filesys.ReadOnlyFS is opened read-only.
 func LoadDoltDB(ctx context.Context, nbf *types.NomsBinFormat, urlStr string, fs filesys.Filesys) (*DoltDB, error) {
 	return LoadDoltDBWithParams(ctx, nbf, urlStr, fs, nil)
 }
@@ -116,6 +116,9 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 			params = make(map[string]any)
 		}
 		params[dbfactory.ChunkJournalParam] = struct{}{}
+		if _, ok := fs.(filesys.ReadOnlyFS); ok {
+			params[dbfactory.ReadOnlyParam] = struct{}{}
+		}
 	}
 
 	db, vrw, ns, err := dbfactory.CreateDB(ctx, nbf, urlStr, params)
@@ -368,6 +371,14 @@ func (ddb *DoltDB) getHashFromCommitSpec(ctx context.Context, cs *CommitSpec, cw
 				return nil, err
 			}
 		}
+		if IsCommitHashPrefix(cs.baseSpec) {
+			h, err := ddb.resolveCommitHashPrefix(ctx, cs.baseSpec, nomsRoot)
+			if err == nil {
+				return &h, nil
+			} else if err != ErrHashNotFound {
+				return nil, err
+			}
+		}
 		return nil, fmt.Errorf("%w: %s", ErrBranchNotFound, cs.baseSpec)
 	case headCommitSpec:
 		if cwb == nil {
@@ -568,6 +579,74 @@ func (ddb *DoltDB) ReadRootValue(ctx context.Context, h hash.Hash) (*RootValue,
 	return decodeRootNomsValue(ddb.vrw, ddb.ns, val)
 }
 
+// ResolveCommitHashPrefix returns the hash of the commit that |prefix| abbreviates. Only commits reachable from a
+// branch, tag or other ref are considered. Returns ErrHashNotFound if no such commit's hash starts with |prefix|, and
+// an ErrAmbiguousCommitHash error if more than one does.
+func (ddb *DoltDB) ResolveCommitHashPrefix(ctx context.Context, prefix string) (hash.Hash, error) {
+	return ddb.resolveCommitHashPrefix(ctx, prefix, hash.Hash{})
+}
+
+func (ddb *DoltDB) resolveCommitHashPrefix(ctx context.Context, prefix string, nomsRoot hash.Hash) (hash.Hash, error) {
+	var pending []hash.Hash
+	visit := func(r ref.DoltRef, _ hash.Hash) error {
+		var h *hash.Hash
+		var err error
+		if nomsRoot.IsEmpty() {
+			h, err = ddb.GetHashForRefStr(ctx, r.String())
+		} else {
+			h, err = ddb.GetHashForRefStrByNomsRoot(ctx, r.String(), nomsRoot)
+		}
+		if err != nil {
+			return err
+		}
+		pending = append(pending, *h)
+		return nil
+	}
+
+	var err error
+	if nomsRoot.IsEmpty() {
+		err = ddb.VisitRefsOfType(ctx, ref.HeadRefTypes, visit)
+	} else {
+		err = ddb.VisitRefsOfTypeByNomsRoot(ctx, ref.HeadRefTypes, nomsRoot, visit)
+	}
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	var found *hash.Hash
+	seen := hash.NewHashSet()
+	for len(pending) > 0 {
+		h := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen.Has(h) {
+			continue
+		}
+		seen.Insert(h)
+
+		if strings.HasPrefix(h.String(), prefix) {
+			if found != nil {
+				return hash.Hash{}, fmt.Errorf("%w: %s", ErrAmbiguousCommitHash, prefix)
+			}
+			found = &h
+		}
+
+		cm, err := ddb.ReadCommit(ctx, h)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		pending = append(pending, parents...)
+	}
+
+	if found == nil {
+		return hash.Hash{}, ErrHashNotFound
+	}
+	return *found, nil
+}
+
 // ReadCommit reads the Commit whose hash is |h|, if one exists.
 func (ddb *DoltDB) ReadCommit(ctx context.Context, h hash.Hash) (*Commit, error) {
 	c, err := datas.LoadCommitAddr(ctx, ddb.vrw, h)
@@ -749,6 +828,31 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 	return ddb.CommitDangling(ctx, val, commitOpts)
 }
 
+// CommitDanglingOrphan creates a new Commit with no parents for the root value |valHash|. Like the commits from
+// CommitDanglingWithParentCommits, it isn't referenced by any DoltRef until one is created for it.
+func (ddb *DoltDB) CommitDanglingOrphan(ctx context.Context, valHash hash.Hash, cm *datas.CommitMeta) (*Commit, error) {
+	val, err := ddb.vrw.ReadValue(ctx, valHash)
+	if err != nil {
+		return nil, err
+	}
+	if !isRootValue(ddb.vrw.Format(), val) {
+		return nil, errors.New("can't commit a value that is not a valid root value")
+	}
+
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	dcommit, err := datas.NewOrphanCommitForValue(ctx, cs, ddb.vrw, ddb.ns, val, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ddb.vrw.WriteValue(ctx, dcommit.NomsValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCommit(ctx, ddb.vrw, ddb.ns, dcommit)
+}
+
 // CommitDangling creates a new Commit for |val| that is not referenced by any DoltRef.
 func (ddb *DoltDB) CommitDangling(ctx context.Context, val types.Value, opts datas.CommitOptions) (*Commit, error) {
 	cs := datas.ChunkStoreFromDatabase(ddb.db)
@@ -1371,6 +1475,10 @@ func (ddb *DoltDB) Rebase(ctx context.Context) error {
 
 // GC performs garbage collection on this ddb.
 //
+// |concurrency| bounds the number of goroutines used to read the chunks that
+// are kept. If it is less than 1, the default of types.DefaultGCConcurrency is
+// used.
+//
 // If |safepointF| is non-nil, it will be called at some point after the GC begins
 // and before the GC ends. It will be called without
 // Database/ValueStore/NomsBlockStore locks held. If should establish
@@ -1379,7 +1487,7 @@ func (ddb *DoltDB) Rebase(ctx context.Context) error {
 // until no possibly-stale ChunkStore state is retained in memory, or failing
 // certain in-progress operations which cannot be finalized in a timely manner,
 // etc.
-func (ddb *DoltDB) GC(ctx context.Context, safepointF func() error) error {
+func (ddb *DoltDB) GC(ctx context.Context, concurrency int, safepointF func() error) error {
 	collector, ok := ddb.db.Database.(datas.GarbageCollector)
 	if !ok {
 		return fmt.Errorf("this database does not support garbage collection")
@@ -1423,7 +1531,7 @@ func (ddb *DoltDB) GC(ctx context.Context, safepointF func() error) error {
 		return err
 	}
 
-	return collector.GC(ctx, oldGen, newGen, safepointF)
+	return collector.GC(ctx, oldGen, newGen, concurrency, safepointF)
 }
 
 func (ddb *DoltDB) ShallowGC(ctx context.Context) error {
@@ -147,6 +147,8 @@ func TestSystemTableTags(t *testing.T) {
 		assert.Equal(t, doltHistoryMin+0, schema.HistoryCommitterTag)
 		assert.Equal(t, doltHistoryMin+1, schema.HistoryCommitHashTag)
 		assert.Equal(t, doltHistoryMin+2, schema.HistoryCommitDateTag)
+		assert.Equal(t, doltHistoryMin+3, schema.HistoryCommitOrderTag)
+		assert.Equal(t, doltHistoryMin+4, schema.HistoryDeletedTag)
 	})
 	t.Run("dolt_diff_ tags", func(t *testing.T) {
 		diffTableMin := sysTableMin + uint64(2000)
@@ -209,6 +211,55 @@ func TestEmptyInMemoryRepoCreation(t *testing.T) {
 	}
 }
 
+func TestResolveCommitHashPrefix(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+
+	err = ddb.WriteEmptyRepo(ctx, "master", "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	first, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	firstHash, err := first.HashOf()
+	require.NoError(t, err)
+
+	h, err := ddb.ResolveCommitHashPrefix(ctx, firstHash.String()[:MinCommitHashPrefixLen])
+	require.NoError(t, err)
+	assert.Equal(t, firstHash, h)
+
+	cs, _ = NewCommitSpec(firstHash.String()[:MinCommitHashPrefixLen] + "~0")
+	resolved, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	resolvedHash, err := resolved.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, firstHash, resolvedHash)
+
+	root, err := first.GetRootValue(ctx)
+	require.NoError(t, err)
+	rootHash, err := root.HashOf()
+	require.NoError(t, err)
+	meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "second commit")
+	require.NoError(t, err)
+	second, err := ddb.CommitWithParentCommits(ctx, rootHash, ref.NewBranchRef("other"), []*Commit{first}, meta)
+	require.NoError(t, err)
+	secondHash, err := second.HashOf()
+	require.NoError(t, err)
+
+	h, err = ddb.ResolveCommitHashPrefix(ctx, secondHash.String()[:MinCommitHashPrefixLen])
+	require.NoError(t, err)
+	assert.Equal(t, secondHash, h)
+
+	// every hash starts with the empty string
+	_, err = ddb.ResolveCommitHashPrefix(ctx, "")
+	assert.ErrorIs(t, err, ErrAmbiguousCommitHash)
+
+	_, err = ddb.ResolveCommitHashPrefix(ctx, "zzzzzzzz")
+	assert.Equal(t, ErrHashNotFound, err)
+}
+
 func TestLoadNonExistentLocalFSRepo(t *testing.T) {
 	_, err := test.ChangeToTestDir("TestLoadRepo")
 
@@ -30,6 +30,7 @@ var ErrInvalidHash = errors.New("string is not a valid hash")
 
 var ErrFoundHashNotACommit = errors.New("the value retrieved for this hash is not a commit")
 var ErrHashNotFound = errors.New("could not find a value for this hash")
+var ErrAmbiguousCommitHash = errors.New("ambiguous commit hash")
 var ErrBranchNotFound = errors.New("branch not found")
 var ErrTagNotFound = errors.New("tag not found")
 var ErrWorkingSetNotFound = errors.New("working set not found")
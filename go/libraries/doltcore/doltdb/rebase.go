@@ -0,0 +1,100 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"path"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+const (
+	rebaseRefPrefix   = "rebase"
+	rebaseOrigHeadRef = "orig-head"
+	rebaseOntoRef     = "onto"
+	rebaseStoppedRef  = "stopped"
+)
+
+// RebaseState is the state of a rebase of a branch that stopped with conflicts. It's stored as internal refs to its
+// commits, so the rebase can be continued or aborted by any session.
+type RebaseState struct {
+	// OrigHead is the head of the branch before the rebase started
+	OrigHead *Commit
+	// Onto is the commit the branch is rebased onto
+	Onto *Commit
+	// Stopped is the commit whose replay left conflicts in the working set
+	Stopped *Commit
+}
+
+// rebaseStateRef returns the internal ref named |name| of the rebase state of |branch|
+func rebaseStateRef(branch ref.DoltRef, name string) ref.DoltRef {
+	return ref.NewInternalRef(path.Join(rebaseRefPrefix, branch.GetPath(), name))
+}
+
+// GetRebaseState returns the state of the rebase stopped on |branch|, or nil if there is none.
+func (ddb *DoltDB) GetRebaseState(ctx context.Context, branch ref.DoltRef) (*RebaseState, error) {
+	names := []string{rebaseOrigHeadRef, rebaseOntoRef, rebaseStoppedRef}
+	commits := make([]*Commit, len(names))
+	for i, name := range names {
+		r := rebaseStateRef(branch, name)
+		ok, err := ddb.HasRef(ctx, r)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, nil
+		}
+		commits[i], err = ddb.ResolveCommitRef(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &RebaseState{OrigHead: commits[0], Onto: commits[1], Stopped: commits[2]}, nil
+}
+
+// SetRebaseState stores |state| as the state of the rebase stopped on |branch|, replacing any state stored before.
+func (ddb *DoltDB) SetRebaseState(ctx context.Context, branch ref.DoltRef, state *RebaseState) error {
+	// the stopped commit is written last, since the state is only read once all of its refs exist
+	for _, r := range []struct {
+		name string
+		cm   *Commit
+	}{
+		{rebaseOrigHeadRef, state.OrigHead},
+		{rebaseOntoRef, state.Onto},
+		{rebaseStoppedRef, state.Stopped},
+	} {
+		if err := ddb.SetHeadToCommit(ctx, rebaseStateRef(branch, r.name), r.cm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearRebaseState removes the state of the rebase stopped on |branch|, if there is one.
+func (ddb *DoltDB) ClearRebaseState(ctx context.Context, branch ref.DoltRef) error {
+	for _, name := range []string{rebaseStoppedRef, rebaseOntoRef, rebaseOrigHeadRef} {
+		r := rebaseStateRef(branch, name)
+		ok, err := ddb.HasRef(ctx, r)
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		if err = ddb.deleteRef(ctx, r, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
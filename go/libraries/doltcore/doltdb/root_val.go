@@ -293,9 +293,18 @@ func newRootValue(vrw types.ValueReadWriter, ns tree.NodeStore, v types.Value) (
 }
 
 // LoadRootValueFromRootIshAddr takes the hash of the commit or the hash of a
-// working set and returns the corresponding RootValue.
+// working set and returns the corresponding RootValue. The hash of a RootValue
+// itself, such as the root of a stash entry, is also accepted.
 func LoadRootValueFromRootIshAddr(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore, h hash.Hash) (*RootValue, error) {
-	val, err := datas.LoadRootNomsValueFromRootIshAddr(ctx, vrw, h)
+	val, err := vrw.ReadValue(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	if val != nil && isRootValue(vrw.Format(), val) {
+		return newRootValue(vrw, ns, val)
+	}
+
+	val, err = datas.LoadRootNomsValueFromRootIshAddr(ctx, vrw, h)
 	if err != nil {
 		return nil, err
 	}
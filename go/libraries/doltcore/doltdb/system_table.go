@@ -171,6 +171,7 @@ var generatedSystemTables = []string{
 	CommitAncestorsTableName,
 	StatusTableName,
 	RemotesTableName,
+	BackupsTableName,
 }
 
 var generatedSystemViewPrefixes = []string{
@@ -291,6 +292,9 @@ const (
 	// RemotesTableName is the remotes system table name
 	RemotesTableName = "dolt_remotes"
 
+	// BackupsTableName is the backups system table name
+	BackupsTableName = "dolt_backups"
+
 	// CommitsTableName is the commits system table name
 	CommitsTableName = "dolt_commits"
 
@@ -306,6 +310,9 @@ const (
 	// TagsTableName is the tags table name
 	TagsTableName = "dolt_tags"
 
+	// StashesTableName is the stashes table name
+	StashesTableName = "dolt_stashes"
+
 	IgnoreTableName = "dolt_ignore"
 )
 
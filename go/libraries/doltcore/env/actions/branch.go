@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -72,11 +73,66 @@ func RenameBranch(ctx context.Context, dbData env.DbData, oldBranch, newBranch s
 		}
 	}
 
-	return DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true}, remoteDbPro, rsc)
+	branchMeta, err := dbData.Rsr.GetBranchMeta()
+	if err != nil {
+		return err
+	}
+	descriptions, err := dbData.Rsr.GetBranchDescriptions()
+	if err != nil {
+		return err
+	}
+
+	err = DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true}, remoteDbPro, rsc)
+	if err != nil {
+		return err
+	}
+
+	// A renamed branch keeps the creation metadata and description of the original
+	var meta *env.RefMeta
+	if m, ok := branchMeta[oldBranch]; ok {
+		meta = &m
+	}
+	err = dbData.Rsw.UpdateBranchMeta(newBranch, meta)
+	if err != nil {
+		return err
+	}
+	return dbData.Rsw.UpdateBranchDescription(newBranch, descriptions[oldBranch])
+}
+
+// SetBranchDescription sets the description of the named branch, or removes it if |description| is empty.
+func SetBranchDescription(ctx context.Context, dbData env.DbData, branchName, description string) error {
+	hasRef, err := dbData.Ddb.HasRef(ctx, ref.NewBranchRef(branchName))
+	if err != nil {
+		return err
+	} else if !hasRef {
+		return doltdb.ErrBranchNotFound
+	}
+
+	return dbData.Rsw.UpdateBranchDescription(branchName, description)
 }
 
 func CopyBranch(ctx context.Context, dEnv *env.DoltEnv, oldBranch, newBranch string, force bool) error {
-	return CopyBranchOnDB(ctx, dEnv.DoltDB, oldBranch, newBranch, force, nil)
+	err := CopyBranchOnDB(ctx, dEnv.DoltDB, oldBranch, newBranch, force, nil)
+	if err != nil {
+		return err
+	}
+
+	return RecordBranchCreation(ctx, dEnv.RepoStateWriter(), newBranch)
+}
+
+// RecordBranchCreation records that the named branch was just created. The creator is the user of the SQL session in
+// |ctx|, and is left empty when the branch is created outside of SQL.
+func RecordBranchCreation(ctx context.Context, rsw env.RepoStateWriter, branchName string) error {
+	meta := env.NewRefMeta(refCreator(ctx))
+	return rsw.UpdateBranchMeta(branchName, &meta)
+}
+
+// refCreator returns the user of the SQL session in |ctx|, or an empty string outside of SQL
+func refCreator(ctx context.Context) string {
+	if session := branch_control.GetBranchAwareSession(ctx); session != nil {
+		return session.GetUser()
+	}
+	return ""
 }
 
 func CopyBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, oldBranch, newBranch string, force bool, rsc *doltdb.ReplicationStatusController) error {
@@ -159,7 +215,7 @@ func DeleteBranchOnDB(ctx context.Context, dbdata env.DbData, branchRef ref.Dolt
 
 		trackedBranch, hasUpstream := trackedBranches[branchRef.GetPath()]
 		if hasUpstream {
-			err = validateBranchMergedIntoUpstream(ctx, dbdata, branchRef, trackedBranch.Remote, pro)
+			err = validateBranchMergedIntoUpstream(ctx, dbdata, branchRef, trackedBranch, pro)
 			if err != nil {
 				return err
 			}
@@ -183,7 +239,19 @@ func DeleteBranchOnDB(ctx context.Context, dbdata env.DbData, branchRef ref.Dolt
 		}
 	}
 
-	return ddb.DeleteBranch(ctx, branchRef, rsc)
+	err = ddb.DeleteBranch(ctx, branchRef, rsc)
+	if err != nil {
+		return err
+	}
+
+	if branchRef.GetType() == ref.BranchRefType {
+		err = dbdata.Rsw.UpdateBranchMeta(branchRef.GetPath(), nil)
+		if err != nil {
+			return err
+		}
+		return dbdata.Rsw.UpdateBranchDescription(branchRef.GetPath(), "")
+	}
+	return nil
 }
 
 // validateBranchMergedIntoCurrentWorkingBranch returns an error if the given branch is not fully merged into the HEAD of the current branch.
@@ -231,39 +299,31 @@ func validateBranchMergedIntoCurrentWorkingBranch(ctx context.Context, dbdata en
 	return nil
 }
 
-// validateBranchMergedIntoUpstream returns an error if the branch provided is not fully merged into its upstream
-func validateBranchMergedIntoUpstream(ctx context.Context, dbdata env.DbData, branch ref.DoltRef, remoteName string, pro env.RemoteDbProvider) error {
+// validateBranchMergedIntoUpstream returns an error if the branch provided is not fully merged into its upstream. The
+// upstream's remote-tracking ref is used when it exists, so that a branch which has been pushed can be deleted without
+// contacting the remote. Otherwise the upstream branch is resolved on the remote itself. If the upstream's remote no
+// longer exists, the branch must be merged into the current branch instead.
+func validateBranchMergedIntoUpstream(ctx context.Context, dbdata env.DbData, branch ref.DoltRef, upstream env.BranchConfig, pro env.RemoteDbProvider) error {
 	remotes, err := dbdata.Rsr.GetRemotes()
 	if err != nil {
 		return err
 	}
-	remote, ok := remotes[remoteName]
+	remote, ok := remotes[upstream.Remote]
 	if !ok {
-		// TODO: skip error?
-		return fmt.Errorf("remote %s not found", remoteName)
-	}
-
-	remoteDb, err := pro.GetRemoteDB(ctx, dbdata.Ddb.ValueReadWriter().Format(), remote, false)
-	if err != nil {
-		return err
-	}
-
-	cs, err := doltdb.NewCommitSpec(branch.GetPath())
-	if err != nil {
-		return err
+		return validateBranchMergedIntoCurrentWorkingBranch(ctx, dbdata, branch)
 	}
 
-	remoteBranchHead, err := remoteDb.Resolve(ctx, cs, nil)
+	upstreamHead, err := resolveUpstreamHead(ctx, dbdata, upstream.Merge.Ref, remote, pro)
 	if err != nil {
 		return err
 	}
 
-	localBranchHead, err := dbdata.Ddb.Resolve(ctx, cs, nil)
+	localBranchHead, err := dbdata.Ddb.ResolveCommitRef(ctx, branch)
 	if err != nil {
 		return err
 	}
 
-	canFF, err := localBranchHead.CanFastForwardTo(ctx, remoteBranchHead)
+	canFF, err := localBranchHead.CanFastForwardTo(ctx, upstreamHead)
 	if err != nil {
 		if errors.Is(err, doltdb.ErrUpToDate) {
 			return nil
@@ -281,6 +341,32 @@ func validateBranchMergedIntoUpstream(ctx context.Context, dbdata env.DbData, br
 	return nil
 }
 
+// resolveUpstreamHead returns the head of the upstream branch given, from its remote-tracking ref if there is one, or
+// from the remote database otherwise.
+func resolveUpstreamHead(ctx context.Context, dbdata env.DbData, upstreamBranch ref.DoltRef, remote env.Remote, pro env.RemoteDbProvider) (*doltdb.Commit, error) {
+	trackingRef, err := env.GetTrackingRef(upstreamBranch, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	if trackingRef != nil {
+		hasRef, err := dbdata.Ddb.HasRef(ctx, trackingRef)
+		if err != nil {
+			return nil, err
+		}
+		if hasRef {
+			return dbdata.Ddb.ResolveCommitRef(ctx, trackingRef)
+		}
+	}
+
+	remoteDb, err := pro.GetRemoteDB(ctx, dbdata.Ddb.ValueReadWriter().Format(), remote, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return remoteDb.ResolveCommitRef(ctx, upstreamBranch)
+}
+
 func CreateBranchWithStartPt(ctx context.Context, dbData env.DbData, newBranch, startPt string, force bool, rsc *doltdb.ReplicationStatusController) error {
 	err := createBranch(ctx, dbData, newBranch, startPt, force, rsc)
 
@@ -300,7 +386,7 @@ func CreateBranchWithStartPt(ctx context.Context, dbData env.DbData, newBranch,
 		return err
 	}
 
-	return nil
+	return RecordBranchCreation(ctx, dbData.Rsw, newBranch)
 }
 
 func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, startingPoint string, force bool, headRef ref.DoltRef, rsc *doltdb.ReplicationStatusController) error {
@@ -336,6 +422,65 @@ func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, starti
 	return nil
 }
 
+// CreateOrphanBranch creates a branch named |newBranch| with no history, like `git checkout --orphan`. Since a branch
+// must point at a commit, the new branch points at a commit of an empty root value with no parents, made by the user
+// |name| and |email|, which shares no history with any other branch.
+func CreateOrphanBranch(ctx context.Context, dbData env.DbData, newBranch, name, email string, force bool, rsc *doltdb.ReplicationStatusController) error {
+	err := createOrphanBranchOnDB(ctx, dbData.Ddb, newBranch, name, email, force, rsc)
+
+	if err != nil {
+		if err == ErrAlreadyExists {
+			return fmt.Errorf("fatal: A branch named '%s' already exists.", newBranch)
+		} else if err == doltdb.ErrInvBranchName {
+			return fmt.Errorf("fatal: '%s' is an invalid branch name.", newBranch)
+		} else {
+			return fmt.Errorf("fatal: Unexpected error creating branch '%s' : %v", newBranch, err)
+		}
+	}
+	err = branch_control.AddAdminForContext(ctx, newBranch)
+	if err != nil {
+		return err
+	}
+
+	return RecordBranchCreation(ctx, dbData.Rsw, newBranch)
+}
+
+func createOrphanBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, name, email string, force bool, rsc *doltdb.ReplicationStatusController) error {
+	branchRef := ref.NewBranchRef(newBranch)
+	hasRef, err := ddb.HasRef(ctx, branchRef)
+	if err != nil {
+		return err
+	}
+
+	if !force && hasRef {
+		return ErrAlreadyExists
+	}
+
+	if !doltdb.IsValidUserBranchName(newBranch) {
+		return doltdb.ErrInvBranchName
+	}
+
+	root, err := doltdb.EmptyRootValue(ctx, ddb.ValueReadWriter(), ddb.NodeStore())
+	if err != nil {
+		return err
+	}
+	_, rootHash, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	meta, err := datas.NewCommitMeta(name, email, fmt.Sprintf("Initialize branch %s", newBranch))
+	if err != nil {
+		return err
+	}
+	cm, err := ddb.CommitDanglingOrphan(ctx, rootHash, meta)
+	if err != nil {
+		return err
+	}
+
+	return ddb.NewBranchAtCommit(ctx, branchRef, cm, rsc)
+}
+
 func createBranch(ctx context.Context, dbData env.DbData, newBranch, startingPoint string, force bool, rsc *doltdb.ReplicationStatusController) error {
 	headRef, err := dbData.Rsr.CWBHeadRef()
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 type CommitStagedProps struct {
 	Message    string
 	Date       time.Time
+	AuthorDate time.Time
 	AllowEmpty bool
 	Amend      bool
 	Force      bool
@@ -101,6 +102,9 @@ func GetCommitStaged(
 	if err != nil {
 		return nil, err
 	}
+	if !props.AuthorDate.IsZero() {
+		meta.SetAuthorTime(props.AuthorDate)
+	}
 
 	return db.NewPendingCommit(ctx, roots, mergeParents, meta)
 }
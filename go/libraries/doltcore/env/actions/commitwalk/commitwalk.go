@@ -68,7 +68,11 @@ func (q *q) Less(i, j int) bool {
 		return true
 	}
 	if q.pending[i].height == q.pending[j].height {
-		return q.pending[i].meta.UserTimestamp > q.pending[j].meta.UserTimestamp
+		if q.pending[i].meta.UserTimestamp != q.pending[j].meta.UserTimestamp {
+			return q.pending[i].meta.UserTimestamp > q.pending[j].meta.UserTimestamp
+		}
+		// commits with the same timestamp, e.g. from an import, are ordered by hash so the order is deterministic
+		return q.pending[i].hash.Less(q.pending[j].hash)
 	}
 	return false
 }
@@ -155,7 +159,8 @@ func newQueue() *q {
 // to `num` commits, in reverse topological order starting at `includedHeads`,
 // with tie breaking based on the height of commit graph between
 // concurrent commits --- higher commits appear first. Remaining
-// ties are broken by timestamp; newer commits appear first.
+// ties are broken by timestamp; newer commits appear first, and then
+// by commit hash.
 //
 // Roughly mimics `git log main..feature` or `git log main...feature` (if
 // more than one `includedHead` is provided).
@@ -182,7 +187,7 @@ func GetDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, included
 
 // GetTopologicalOrderCommits returns the commits reachable from the commits in `startCommitHashes`
 // in reverse topological order, with tiebreaking done by the height of the commit graph -- higher commits
-// appear first. Remaining ties are broken by timestamp; newer commits appear first.
+// appear first. Remaining ties are broken by timestamp; newer commits appear first, and then by commit hash.
 func GetTopologicalOrderCommits(ctx context.Context, ddb *doltdb.DoltDB, startCommitHashes []hash.Hash) ([]*doltdb.Commit, error) {
 	return GetTopNTopoOrderedCommitsMatching(ctx, ddb, startCommitHashes, -1, nil)
 }
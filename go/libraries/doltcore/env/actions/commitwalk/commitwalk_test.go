@@ -238,12 +238,70 @@ func TestGetDotDotRevisions(t *testing.T) {
 	assertEqualHashes(t, featureCommits[1], res[2])
 }
 
+func TestGetTopologicalOrderCommitsWithEqualTimestamps(t *testing.T) {
+	dEnv := createUninitializedEnv()
+	err := dEnv.InitRepo(context.Background(), types.Format_Default, "Bill Billerson", "bill@billerson.com", env.DefaultInitBranch)
+	require.NoError(t, err)
+
+	cs, err := doltdb.NewCommitSpec(env.DefaultInitBranch)
+	require.NoError(t, err)
+	commit, err := dEnv.DoltDB.Resolve(context.Background(), cs, nil)
+	require.NoError(t, err)
+
+	rv, err := commit.GetRootValue(context.Background())
+	require.NoError(t, err)
+	_, rvh, err := dEnv.DoltDB.WriteRootValue(context.Background(), rv)
+	require.NoError(t, err)
+
+	err = dEnv.DoltDB.NewBranchAtCommit(context.Background(), ref.NewBranchRef("feature"), commit, nil)
+	require.NoError(t, err)
+
+	// Concurrent commits on main and feature with the same timestamp, merged into main.
+	//
+	//         feature: F1
+	//                 /   \
+	// main: M0------M1----M2
+	ts := MonotonicNow()
+	main1 := mustCreateCommitAt(t, dEnv.DoltDB, env.DefaultInitBranch, rvh, "main commit", ts, commit)
+	feature1 := mustCreateCommitAt(t, dEnv.DoltDB, "feature", rvh, "feature commit", ts, commit)
+	merge := mustCreateCommit(t, dEnv.DoltDB, env.DefaultInitBranch, rvh, main1, feature1)
+
+	first, second := main1, feature1
+	if mustGetHash(t, feature1).Less(mustGetHash(t, main1)) {
+		first, second = feature1, main1
+	}
+
+	// The concurrent commits are ordered by hash, whichever of them is visited first.
+	for _, heads := range [][]*doltdb.Commit{{merge}, {main1, feature1}, {feature1, main1}} {
+		hashes := make([]hash.Hash, len(heads))
+		for i, head := range heads {
+			hashes[i] = mustGetHash(t, head)
+		}
+
+		res, err := GetTopologicalOrderCommits(context.Background(), dEnv.DoltDB, hashes)
+		require.NoError(t, err)
+		if len(heads) == 1 {
+			require.Len(t, res, 4)
+			assertEqualHashes(t, merge, res[0])
+			res = res[1:]
+		}
+		require.Len(t, res, 3)
+		assertEqualHashes(t, first, res[0])
+		assertEqualHashes(t, second, res[1])
+		assertEqualHashes(t, commit, res[2])
+	}
+}
+
 func assertEqualHashes(t *testing.T, lc, rc *doltdb.Commit) {
 	assert.Equal(t, mustGetHash(t, lc), mustGetHash(t, rc))
 }
 
 func mustCreateCommit(t *testing.T, ddb *doltdb.DoltDB, bn string, rvh hash.Hash, parents ...*doltdb.Commit) *doltdb.Commit {
-	cm, err := datas.NewCommitMetaWithUserTS("Bill Billerson", "bill@billerson.com", "A New Commit.", MonotonicNow())
+	return mustCreateCommitAt(t, ddb, bn, rvh, "A New Commit.", MonotonicNow(), parents...)
+}
+
+func mustCreateCommitAt(t *testing.T, ddb *doltdb.DoltDB, bn string, rvh hash.Hash, msg string, ts time.Time, parents ...*doltdb.Commit) *doltdb.Commit {
+	cm, err := datas.NewCommitMetaWithUserTS("Bill Billerson", "bill@billerson.com", msg, ts)
 	require.NoError(t, err)
 	pcs := make([]*doltdb.CommitSpec, 0, len(parents))
 	for _, parent := range parents {
@@ -48,6 +48,71 @@ var ErrUnknownPushErr = errors.New("unknown push error")
 type ProgStarter func(ctx context.Context) (*sync.WaitGroup, chan pull.Stats)
 type ProgStopper func(cancel context.CancelFunc, wg *sync.WaitGroup, statsCh chan pull.Stats)
 
+// RefUpdateType is how a fetch changed a remote tracking ref
+type RefUpdateType string
+
+const (
+	RefUpdateNewBranch   RefUpdateType = "new-branch"
+	RefUpdateFastForward RefUpdateType = "fast-forward"
+	RefUpdateForced      RefUpdateType = "forced"
+	RefUpdateUpToDate    RefUpdateType = "up-to-date"
+)
+
+// RefUpdate is the change a fetch made to a remote tracking ref. OldHash is empty for a new branch.
+type RefUpdate struct {
+	Ref     ref.DoltRef
+	OldHash hash.Hash
+	NewHash hash.Hash
+	Type    RefUpdateType
+}
+
+// GetRefUpdate returns the change that moving |trackRef| in |ddb| to |commit| makes. It must be called before the ref
+// is moved.
+func GetRefUpdate(ctx context.Context, ddb *doltdb.DoltDB, trackRef ref.DoltRef, commit *doltdb.Commit) (RefUpdate, error) {
+	newHash, err := commit.HashOf()
+	if err != nil {
+		return RefUpdate{}, err
+	}
+	update := RefUpdate{Ref: trackRef, NewHash: newHash}
+
+	oldHash, err := ddb.GetHashForRefStr(ctx, trackRef.String())
+	if err == doltdb.ErrBranchNotFound {
+		update.Type = RefUpdateNewBranch
+		return update, nil
+	} else if err != nil {
+		return RefUpdate{}, err
+	}
+	update.OldHash = *oldHash
+
+	if update.OldHash == newHash {
+		update.Type = RefUpdateUpToDate
+		return update, nil
+	}
+
+	oldCommit, err := ddb.ReadCommit(ctx, update.OldHash)
+	if err != nil {
+		return RefUpdate{}, err
+	}
+	ancestor, err := doltdb.GetCommitAncestor(ctx, oldCommit, commit)
+	if errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		update.Type = RefUpdateForced
+		return update, nil
+	} else if err != nil {
+		return RefUpdate{}, err
+	}
+	ancestorHash, err := ancestor.HashOf()
+	if err != nil {
+		return RefUpdate{}, err
+	}
+
+	if ancestorHash == update.OldHash {
+		update.Type = RefUpdateFastForward
+	} else {
+		update.Type = RefUpdateForced
+	}
+	return update, nil
+}
+
 // Push will update a destination branch, in a given destination database if it can be done as a fast forward merge.
 // This is accomplished first by verifying that the remote tracking reference for the source database can be updated to
 // the given commit via a fast forward merge.  If this is the case, an attempt will be made to update the branch in the
@@ -387,15 +452,16 @@ func FetchRemoteBranch(
 
 // FetchRefSpecs is the common SQL and CLI entrypoint for fetching branches, tags, and heads from a remote.
 // This function takes dbData which is a env.DbData object for handling repoState read and write, and srcDB is
-// a remote *doltdb.DoltDB object that is used to fetch remote branches from.
-func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec, remote env.Remote, mode ref.UpdateMode, progStarter ProgStarter, progStopper ProgStopper) error {
+// a remote *doltdb.DoltDB object that is used to fetch remote branches from. Returns the change made to each remote
+// tracking ref.
+func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec, remote env.Remote, mode ref.UpdateMode, progStarter ProgStarter, progStopper ProgStopper) ([]RefUpdate, error) {
 	var branchRefs []doltdb.RefWithHash
 	err := srcDB.VisitRefsOfType(ctx, ref.HeadRefTypes, func(r ref.DoltRef, addr hash.Hash) error {
 		branchRefs = append(branchRefs, doltdb.RefWithHash{Ref: r, Hash: addr})
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
+		return nil, fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
 	}
 
 	// We build up two structures:
@@ -419,14 +485,14 @@ func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB,
 			}
 		}
 		if !rsSeen {
-			return fmt.Errorf("%w: '%s'", ref.ErrInvalidRefSpec, rs.GetRemRefToLocal())
+			return nil, fmt.Errorf("%w: '%s'", ref.ErrInvalidRefSpec, rs.GetRemRefToLocal())
 		}
 	}
 
 	// Now we fetch all the new HEADs we need.
 	tmpDir, err := dbData.Rsw.TempTableFilesDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = func() error {
@@ -448,30 +514,37 @@ func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB,
 		return err
 	}()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	updates := make([]RefUpdate, 0, len(newHeads))
 	for _, newHead := range newHeads {
 		commit, err := dbData.Ddb.ReadCommit(ctx, newHead.Hash)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		remoteTrackRef := newHead.Ref
 
+		update, err := GetRefUpdate(ctx, dbData.Ddb, remoteTrackRef, commit)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+
 		switch mode {
 		case ref.ForceUpdate:
 			// TODO: can't be used safely in a SQL context
 			err := dbData.Ddb.SetHeadToCommit(ctx, remoteTrackRef, commit)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		case ref.FastForwardOnly:
 			ok, err := dbData.Ddb.CanFastForward(ctx, remoteTrackRef, commit)
 			if err != nil && !errors.Is(err, doltdb.ErrUpToDate) {
-				return fmt.Errorf("%w: %s", ErrCantFF, err.Error())
+				return nil, fmt.Errorf("%w: %s", ErrCantFF, err.Error())
 			}
 			if !ok {
-				return ErrCantFF
+				return nil, ErrCantFF
 			}
 
 			switch err {
@@ -480,20 +553,20 @@ func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB,
 				// TODO: can't be used safely in a SQL context
 				err = dbData.Ddb.FastForward(ctx, remoteTrackRef, commit)
 				if err != nil && !errors.Is(err, doltdb.ErrUpToDate) {
-					return fmt.Errorf("%w: %s", ErrCantFF, err.Error())
+					return nil, fmt.Errorf("%w: %s", ErrCantFF, err.Error())
 				}
 			default:
-				return fmt.Errorf("%w: %s", ErrCantFF, err.Error())
+				return nil, fmt.Errorf("%w: %s", ErrCantFF, err.Error())
 			}
 		}
 	}
 
 	err = FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, progStarter, progStopper)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return updates, nil
 }
 
 // SyncRoots is going to copy the root hash of the database from srcDb to destDb.
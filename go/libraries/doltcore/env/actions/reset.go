@@ -17,6 +17,7 @@ package actions
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
@@ -303,21 +304,45 @@ func IsValidRef(ctx context.Context, cSpecStr string, ddb *doltdb.DoltDB, rsr en
 // CleanUntracked deletes untracked tables from the working root.
 // Evaluates untracked tables as: all working tables - all staged tables.
 func CleanUntracked(ctx context.Context, roots doltdb.Roots, tables []string, dryrun bool, force bool) (doltdb.Roots, error) {
+	toDelete, err := UntrackedTables(ctx, roots, tables)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	newRoot, err := roots.Working.RemoveTables(ctx, force, force, toDelete...)
+	if err != nil {
+		return doltdb.Roots{}, fmt.Errorf("failed to remove tables; %w", err)
+	}
+
+	if dryrun {
+		return roots, nil
+	}
+	roots.Working = newRoot
+
+	return roots, nil
+}
+
+// UntrackedTables returns the sorted names of the tables CleanUntracked would delete: those of |tables|, or of all
+// working tables if none are given, that are not in the staged root.
+func UntrackedTables(ctx context.Context, roots doltdb.Roots, tables []string) ([]string, error) {
 	untrackedTables := make(map[string]struct{})
 
 	var err error
 	if len(tables) == 0 {
 		tables, err = roots.Working.GetTableNames(ctx)
 		if err != nil {
-			return doltdb.Roots{}, nil
+			return nil, err
 		}
 	}
 
 	for i := range tables {
 		name := tables[i]
-		_, _, err = roots.Working.GetTable(ctx, name)
+		_, ok, err := roots.Working.GetTable(ctx, name)
 		if err != nil {
-			return doltdb.Roots{}, err
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s'", doltdb.ErrTableNotFound, name)
 		}
 		untrackedTables[name] = struct{}{}
 	}
@@ -325,30 +350,20 @@ func CleanUntracked(ctx context.Context, roots doltdb.Roots, tables []string, dr
 	// untracked tables = working tables - staged tables
 	headTblNames, err := roots.Staged.GetTableNames(ctx)
 	if err != nil {
-		return doltdb.Roots{}, err
+		return nil, err
 	}
 
 	for _, name := range headTblNames {
 		delete(untrackedTables, name)
 	}
 
-	newRoot := roots.Working
-	var toDelete []string
+	untracked := make([]string, 0, len(untrackedTables))
 	for t := range untrackedTables {
-		toDelete = append(toDelete, t)
+		untracked = append(untracked, t)
 	}
+	sort.Strings(untracked)
 
-	newRoot, err = newRoot.RemoveTables(ctx, force, force, toDelete...)
-	if err != nil {
-		return doltdb.Roots{}, fmt.Errorf("failed to remove tables; %w", err)
-	}
-
-	if dryrun {
-		return roots, nil
-	}
-	roots.Working = newRoot
-
-	return roots, nil
+	return untracked, nil
 }
 
 // mapColumnTags takes a map from table name to schema.Schema and generates
@@ -0,0 +1,261 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var ErrStashNotSupportedForOldFormat = errors.New("stash is not supported for old storage format")
+
+// StashChanges saves the local changes in |roots| as a new stash entry of |ddb|, made on the branch |headRef| whose
+// head is |headCommit|, and returns the roots with those changes removed. Untracked tables are only stashed with
+// |includeUntracked| or |all|, and ignored tables only with |all|. If there are no changes to stash, no stash entry is
+// made and false is returned.
+func StashChanges(ctx context.Context, ddb *doltdb.DoltDB, roots doltdb.Roots, headRef ref.DoltRef, headCommit *doltdb.Commit, includeUntracked, all bool) (doltdb.Roots, bool, error) {
+	hasChanges, err := hasLocalChanges(ctx, roots, includeUntracked, all)
+	if err != nil {
+		return doltdb.Roots{}, false, err
+	}
+	if !hasChanges {
+		return roots, false, nil
+	}
+
+	roots, err = StageModifiedAndDeletedTables(ctx, roots)
+	if err != nil {
+		return doltdb.Roots{}, false, err
+	}
+
+	// all tables with changes that are going to be stashed are staged at this point
+
+	allTblsToBeStashed, addedTblsToStage, err := stashedTableSets(ctx, roots)
+	if err != nil {
+		return doltdb.Roots{}, false, err
+	}
+
+	// stage untracked files to include them in the stash,
+	// but do not include them in added table set,
+	// because they should not be staged when popped.
+	if includeUntracked || all {
+		allTblsToBeStashed, err = doltdb.UnionTableNames(ctx, roots.Staged, roots.Working)
+		if err != nil {
+			return doltdb.Roots{}, false, err
+		}
+
+		roots, err = StageTables(ctx, roots, allTblsToBeStashed, !all)
+		if err != nil {
+			return doltdb.Roots{}, false, err
+		}
+	}
+
+	commitMeta, err := headCommit.GetCommitMeta(ctx)
+	if err != nil {
+		return doltdb.Roots{}, false, err
+	}
+
+	err = ddb.AddStash(ctx, headCommit, roots.Staged, datas.NewStashMeta(headRef.String(), commitMeta.Description, addedTblsToStage))
+	if err != nil {
+		return doltdb.Roots{}, false, err
+	}
+
+	// setting STAGED to current HEAD RootValue resets staged set of changed, so
+	// these changes are now in working set of changes, which needs to be checked out
+	roots.Staged = roots.Head
+	roots, err = MoveTablesFromHeadToWorking(ctx, roots, allTblsToBeStashed)
+	if err != nil {
+		return doltdb.Roots{}, false, err
+	}
+
+	return roots, true, nil
+}
+
+// ApplyStash merges the stash entry of |ddb| at |idx| into the working root of |roots| and returns the resulting
+// roots, with the tables added by the stash staged again. If applying the stash produces conflicts, the roots are
+// returned unchanged along with the working root containing the conflicts and the names of the tables that have them.
+func ApplyStash(ctx context.Context, ddb *doltdb.DoltDB, roots doltdb.Roots, idx int, opts editor.Options) (doltdb.Roots, *doltdb.RootValue, []string, error) {
+	stashRoot, headCommit, meta, err := ddb.GetStashRootAndHeadCommitAtIdx(ctx, idx)
+	if err != nil {
+		return doltdb.Roots{}, nil, nil, err
+	}
+
+	parentRoot, err := headCommit.GetRootValue(ctx)
+	if err != nil {
+		return doltdb.Roots{}, nil, nil, err
+	}
+
+	result, err := merge.MergeRoots(ctx, roots.Working, stashRoot, parentRoot, stashRoot, headCommit, opts, merge.MergeOpts{IsCherryPick: false})
+	if err != nil {
+		return doltdb.Roots{}, nil, nil, err
+	}
+
+	var tablesWithConflict []string
+	for tbl, stats := range result.Stats {
+		if stats.HasConflicts() {
+			tablesWithConflict = append(tablesWithConflict, tbl)
+		}
+	}
+	if len(tablesWithConflict) > 0 {
+		return roots, result.Root, tablesWithConflict, nil
+	}
+
+	roots.Working = result.Root
+
+	// added tables need to be staged
+	// since these tables are coming from a stash, don't filter for ignored table names.
+	roots, err = StageTables(ctx, roots, meta.TablesToStage, false)
+	if err != nil {
+		return doltdb.Roots{}, nil, nil, err
+	}
+
+	return roots, nil, nil, nil
+}
+
+// hasLocalChanges returns whether |roots| have any changes to stash
+func hasLocalChanges(ctx context.Context, roots doltdb.Roots, includeUntracked, all bool) (bool, error) {
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return false, err
+	}
+	workingHash, err := roots.Working.HashOf()
+	if err != nil {
+		return false, err
+	}
+	stagedHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return false, err
+	}
+
+	// Are there staged changes? If so, stash them.
+	if !headHash.Equal(stagedHash) {
+		return true, nil
+	}
+
+	// No staged changes, but are there any unstaged changes? If not, no work is needed.
+	if headHash.Equal(workingHash) {
+		return false, nil
+	}
+
+	// There are unstaged changes, is --all set? If so, nothing else matters. Stash them.
+	if all {
+		return true, nil
+	}
+
+	// --all was not set, so we can ignore tables. Is every table ignored?
+	allIgnored, err := workingSetContainsOnlyIgnoredTables(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+
+	if allIgnored {
+		return false, nil
+	}
+
+	// There are unignored, unstaged tables. Is --include-untracked set. If so, nothing else matters. Stash them.
+	if includeUntracked {
+		return true, nil
+	}
+
+	// --include-untracked was not set, so we can skip untracked tables. Is every table untracked?
+	allUntracked, err := workingSetContainsOnlyUntrackedTables(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+
+	if allUntracked {
+		return false, nil
+	}
+
+	// There are changes to tracked tables. Stash them.
+	return true, nil
+}
+
+// workingSetContainsOnlyUntrackedTables returns true if all changes in working set are untracked files/added tables.
+// Untracked files are part of working set changes, but should not be stashed unless staged or --include-untracked flag is used.
+func workingSetContainsOnlyUntrackedTables(ctx context.Context, roots doltdb.Roots) (bool, error) {
+	_, unstaged, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+
+	// All ignored files are also untracked files
+	for _, tableDelta := range unstaged {
+		if !tableDelta.IsAdd() {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// workingSetContainsOnlyIgnoredTables returns true if all changes in working set are ignored tables.
+// Note that only unstaged tables are subject to dolt_ignore (this is consistent with what git does.)
+func workingSetContainsOnlyIgnoredTables(ctx context.Context, roots doltdb.Roots) (bool, error) {
+	_, unstaged, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+
+	ignorePatterns, err := doltdb.GetIgnoredTablePatterns(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+
+	for _, tableDelta := range unstaged {
+		if !(tableDelta.IsAdd()) {
+			return false, nil
+		}
+		isIgnored, err := ignorePatterns.IsTableNameIgnored(tableDelta.ToName)
+		if err != nil {
+			return false, err
+		}
+		if isIgnored != doltdb.Ignore {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// stashedTableSets returns array of table names for all tables that are being stashed and added tables in staged.
+// These table names are determined from all tables in the staged set of changes as they are being stashed only.
+func stashedTableSets(ctx context.Context, roots doltdb.Roots) ([]string, []string, error) {
+	var addedTblsInStaged []string
+	var allTbls []string
+	staged, _, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, tableDelta := range staged {
+		tblName := tableDelta.ToName
+		if tableDelta.IsAdd() {
+			addedTblsInStaged = append(addedTblsInStaged, tableDelta.ToName)
+		}
+		if tableDelta.IsDrop() {
+			tblName = tableDelta.FromName
+		}
+		allTbls = append(allTbls, tblName)
+	}
+
+	return allTbls, addedTblsInStaged, nil
+}
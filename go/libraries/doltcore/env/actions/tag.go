@@ -36,24 +36,24 @@ func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startPoint strin
 	if err != nil {
 		return err
 	}
-	return CreateTagOnDB(ctx, dEnv.DoltDB, tagName, startPoint, props, headRef)
-}
-
-func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint string, props TagProps, headRef ref.DoltRef) error {
-	tagRef := ref.NewTagRef(tagName)
-
-	hasRef, err := ddb.HasRef(ctx, tagRef)
-
+	err = CreateTagOnDB(ctx, dEnv.DoltDB, tagName, startPoint, props, headRef)
 	if err != nil {
 		return err
 	}
 
-	if hasRef {
-		return ErrAlreadyExists
-	}
+	return RecordTagCreation(ctx, dEnv.RepoStateWriter(), tagName)
+}
 
-	if !ref.IsValidTagName(tagName) {
-		return doltdb.ErrInvTagName
+// RecordTagCreation records that the named tag was just created, with the same creator as RecordBranchCreation.
+func RecordTagCreation(ctx context.Context, rsw env.RepoStateWriter, tagName string) error {
+	meta := env.NewRefMeta(refCreator(ctx))
+	return rsw.UpdateTagMeta(tagName, &meta)
+}
+
+func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint string, props TagProps, headRef ref.DoltRef) error {
+	err := CheckNewTagName(ctx, ddb, tagName)
+	if err != nil {
+		return err
 	}
 
 	cs, err := doltdb.NewCommitSpec(startPoint)
@@ -70,11 +70,44 @@ func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint
 
 	meta := datas.NewTagMeta(props.TaggerName, props.TaggerEmail, props.Description)
 
-	return ddb.NewTagAtCommit(ctx, tagRef, cm, meta)
+	return ddb.NewTagAtCommit(ctx, ref.NewTagRef(tagName), cm, meta)
+}
+
+// CheckNewTagName returns an error if a tag named |tagName| can't be created in |ddb|, either because a tag with that
+// name already exists or because the name isn't valid.
+func CheckNewTagName(ctx context.Context, ddb *doltdb.DoltDB, tagName string) error {
+	hasRef, err := ddb.HasRef(ctx, ref.NewTagRef(tagName))
+	if err != nil {
+		return err
+	}
+	if hasRef {
+		return ErrAlreadyExists
+	}
+
+	if !ref.IsValidTagName(tagName) {
+		return doltdb.ErrInvTagName
+	}
+	return nil
 }
 
 func DeleteTags(ctx context.Context, dEnv *env.DoltEnv, tagNames ...string) error {
-	return DeleteTagsOnDB(ctx, dEnv.DoltDB, tagNames...)
+	err := DeleteTagsOnDB(ctx, dEnv.DoltDB, tagNames...)
+	if err != nil {
+		return err
+	}
+
+	return RecordTagDeletion(dEnv.RepoStateWriter(), tagNames...)
+}
+
+// RecordTagDeletion removes the creation metadata of the named tags, so that a tag created again with the same name
+// doesn't keep the metadata of the deleted one.
+func RecordTagDeletion(rsw env.RepoStateWriter, tagNames ...string) error {
+	for _, tn := range tagNames {
+		if err := rsw.UpdateTagMeta(tn, nil); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func DeleteTagsOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagNames ...string) error {
@@ -139,7 +139,12 @@ func LoadWithoutDB(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys,
 }
 
 // Load loads the DoltEnv for the .dolt directory determined by resolving the specified urlStr with the specified Filesys.
+// A .dolt directory on a read-only mount, like a file system snapshot, is loaded read-only, see IsReadOnly.
 func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr string, version string) *DoltEnv {
+	if filesys.IsOnReadOnlyMount(fs, dbfactory.DoltDir) {
+		fs = filesys.NewReadOnlyFS(fs)
+	}
+
 	dEnv := LoadWithoutDB(ctx, hdp, fs, version)
 
 	ddb, dbLoadErr := doltdb.LoadDoltDB(ctx, types.Format_Default, urlStr, fs)
@@ -148,7 +153,7 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr s
 	dEnv.DBLoadError = dbLoadErr
 	dEnv.urlStr = urlStr
 
-	if dbLoadErr == nil && dEnv.HasDoltDir() {
+	if dbLoadErr == nil && dEnv.HasDoltDir() && !dEnv.IsReadOnly() {
 		if !dEnv.HasDoltTempTableDir() {
 			tmpDir, err := dEnv.TempTableFilesDir()
 			if err != nil {
@@ -973,6 +978,72 @@ func (dEnv *DoltEnv) UpdateBranch(name string, new BranchConfig) error {
 	return nil
 }
 
+func (dEnv *DoltEnv) GetBranchMeta() (map[string]RefMeta, error) {
+	if dEnv.RSLoadErr != nil {
+		return nil, dEnv.RSLoadErr
+	}
+
+	return dEnv.RepoState.BranchMeta, nil
+}
+
+func (dEnv *DoltEnv) UpdateBranchMeta(name string, meta *RefMeta) error {
+	if dEnv.RSLoadErr != nil {
+		return dEnv.RSLoadErr
+	}
+
+	dEnv.RepoState.UpdateBranchMeta(name, meta)
+
+	err := dEnv.RepoState.Save(dEnv.FS)
+	if err != nil {
+		return ErrFailedToWriteRepoState
+	}
+	return nil
+}
+
+func (dEnv *DoltEnv) GetTagMeta() (map[string]RefMeta, error) {
+	if dEnv.RSLoadErr != nil {
+		return nil, dEnv.RSLoadErr
+	}
+
+	return dEnv.RepoState.TagMeta, nil
+}
+
+func (dEnv *DoltEnv) UpdateTagMeta(name string, meta *RefMeta) error {
+	if dEnv.RSLoadErr != nil {
+		return dEnv.RSLoadErr
+	}
+
+	dEnv.RepoState.UpdateTagMeta(name, meta)
+
+	err := dEnv.RepoState.Save(dEnv.FS)
+	if err != nil {
+		return ErrFailedToWriteRepoState
+	}
+	return nil
+}
+
+func (dEnv *DoltEnv) GetBranchDescriptions() (map[string]string, error) {
+	if dEnv.RSLoadErr != nil {
+		return nil, dEnv.RSLoadErr
+	}
+
+	return dEnv.RepoState.BranchDescriptions, nil
+}
+
+func (dEnv *DoltEnv) UpdateBranchDescription(name string, description string) error {
+	if dEnv.RSLoadErr != nil {
+		return dEnv.RSLoadErr
+	}
+
+	dEnv.RepoState.UpdateBranchDescription(name, description)
+
+	err := dEnv.RepoState.Save(dEnv.FS)
+	if err != nil {
+		return ErrFailedToWriteRepoState
+	}
+	return nil
+}
+
 var ErrNotACred = errors.New("not a valid credential key id or public key")
 
 func (dEnv *DoltEnv) FindCreds(credsDir, pubKeyOrId string) (string, error) {
@@ -1144,6 +1215,13 @@ func (dEnv *DoltEnv) LockFile() string {
 	return f
 }
 
+// IsReadOnly returns whether this environment is read-only, e.g. because it was loaded from a read-only mount. The
+// database of a read-only environment is opened without writing to its files, and no lock file is written for it.
+func (dEnv *DoltEnv) IsReadOnly() bool {
+	_, ok := dEnv.FS.(filesys.ReadOnlyFS)
+	return ok
+}
+
 // IsLocked returns true if this database's lockfile exists and the pid contained in lockfile is alive.
 func (dEnv *DoltEnv) IsLocked() bool {
 	if dEnv.IgnoreLockFile {
@@ -1178,7 +1256,7 @@ func NewDBLock(port int) DBLock {
 
 // Lock writes this database's lockfile with the pid of the calling process or errors if it already exists
 func (dEnv *DoltEnv) Lock(lock DBLock) error {
-	if dEnv.IgnoreLockFile {
+	if dEnv.IgnoreLockFile || dEnv.IsReadOnly() {
 		return nil
 	}
 
@@ -1233,7 +1311,7 @@ func LoadDBLockFile(fs filesys.Filesys, lockFilePath string) (lock *DBLock, err
 
 // Unlock deletes this database's lockfile
 func (dEnv *DoltEnv) Unlock() error {
-	if dEnv.IgnoreLockFile {
+	if dEnv.IgnoreLockFile || dEnv.IsReadOnly() {
 		return nil
 	}
 
@@ -223,6 +223,30 @@ func (m MemoryRepoState) UpdateBranch(name string, new BranchConfig) error {
 	return nil
 }
 
+func (m MemoryRepoState) GetBranchMeta() (map[string]RefMeta, error) {
+	return make(map[string]RefMeta), nil
+}
+
+func (m MemoryRepoState) UpdateBranchMeta(name string, meta *RefMeta) error {
+	return nil
+}
+
+func (m MemoryRepoState) GetTagMeta() (map[string]RefMeta, error) {
+	return make(map[string]RefMeta), nil
+}
+
+func (m MemoryRepoState) UpdateTagMeta(name string, meta *RefMeta) error {
+	return nil
+}
+
+func (m MemoryRepoState) GetBranchDescriptions() (map[string]string, error) {
+	return make(map[string]string), nil
+}
+
+func (m MemoryRepoState) UpdateBranchDescription(name string, description string) error {
+	return nil
+}
+
 func (m MemoryRepoState) RemoveRemote(ctx context.Context, name string) error {
 	return fmt.Errorf("cannot delete a remote from a memory database")
 }
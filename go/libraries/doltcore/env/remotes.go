@@ -114,6 +114,33 @@ func (r *Remote) GetRemoteDBWithoutCaching(ctx context.Context, nbf *types.NomsB
 	return doltdb.LoadDoltDBWithParams(ctx, nbf, r.Url, filesys2.LocalFS, params)
 }
 
+// VerifyRemote checks that the remote |r| can be reached and holds a dolt database, by loading it with |loadRemoteDB|
+// and listing its branches. Nothing is written to the remote. File remotes are checked for an existing database
+// before loading, since loading one from an empty directory would create it.
+func VerifyRemote(ctx context.Context, r Remote, loadRemoteDB func(context.Context, Remote) (*doltdb.DoltDB, error)) error {
+	u, err := earl.Parse(r.Url)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRemoteURL, err.Error())
+	}
+
+	if u.Scheme == dbfactory.FileScheme {
+		if err = dbfactory.ValidateFileDB(u); err != nil {
+			return fmt.Errorf("failed to verify remote '%s': %w", r.Name, err)
+		}
+	}
+
+	ddb, err := loadRemoteDB(ctx, r)
+	if err != nil {
+		return fmt.Errorf("failed to verify remote '%s': %w", r.Name, err)
+	}
+
+	if _, err = ddb.GetBranches(ctx); err != nil {
+		return fmt.Errorf("failed to verify remote '%s': %w", r.Name, err)
+	}
+
+	return nil
+}
+
 type PushOpts struct {
 	SrcRef      ref.DoltRef
 	DestRef     ref.DoltRef
@@ -0,0 +1,92 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/grpcendpoint"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var errUnreachable = errors.New("connection refused")
+
+// unreachableDialer fails to dial any endpoint
+type unreachableDialer struct{}
+
+func (unreachableDialer) GetGRPCDialParams(grpcendpoint.Config) (dbfactory.GRPCRemoteConfig, error) {
+	return dbfactory.GRPCRemoteConfig{}, errUnreachable
+}
+
+func TestVerifyRemote(t *testing.T) {
+	ctx := context.Background()
+	loadWith := func(dialer dbfactory.GRPCDialProvider) func(context.Context, Remote) (*doltdb.DoltDB, error) {
+		return func(ctx context.Context, r Remote) (*doltdb.DoltDB, error) {
+			return r.GetRemoteDBWithoutCaching(ctx, types.Format_Default, dialer)
+		}
+	}
+	fileUrl := func(path string) string {
+		return earl.FileUrlFromPath(filepath.ToSlash(path), os.PathSeparator)
+	}
+
+	t.Run("file remote", func(t *testing.T) {
+		dir := t.TempDir()
+		ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, fileUrl(dir), filesys.LocalFS)
+		require.NoError(t, err)
+		require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bill@billerson.com"))
+
+		r := NewRemote("origin", fileUrl(dir), nil)
+		assert.NoError(t, VerifyRemote(ctx, r, loadWith(nil)))
+	})
+
+	t.Run("missing file remote", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "missing")
+		r := NewRemote("origin", fileUrl(dir), nil)
+		assert.Error(t, VerifyRemote(ctx, r, loadWith(nil)))
+
+		_, err := os.Stat(dir)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("file remote without a database", func(t *testing.T) {
+		dir := t.TempDir()
+		r := NewRemote("origin", fileUrl(dir), nil)
+		err := VerifyRemote(ctx, r, loadWith(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a dolt database")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("unreachable grpc remote", func(t *testing.T) {
+		r := NewRemote("origin", "https://remotes.example.com/org/repo", nil)
+		err := VerifyRemote(ctx, r, loadWith(unreachableDialer{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errUnreachable.Error())
+	})
+}
@@ -17,10 +17,12 @@ package env
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -32,6 +34,9 @@ type RepoStateReader interface {
 	GetRemotes() (map[string]Remote, error)
 	GetBackups() (map[string]Remote, error)
 	GetBranches() (map[string]BranchConfig, error)
+	GetBranchMeta() (map[string]RefMeta, error)
+	GetTagMeta() (map[string]RefMeta, error)
+	GetBranchDescriptions() (map[string]string, error)
 }
 
 type RepoStateWriter interface {
@@ -43,6 +48,12 @@ type RepoStateWriter interface {
 	RemoveBackup(ctx context.Context, name string) error
 	TempTableFilesDir() (string, error)
 	UpdateBranch(name string, new BranchConfig) error
+	// UpdateBranchMeta records the creation metadata of the named branch, or removes it if |meta| is nil.
+	UpdateBranchMeta(name string, meta *RefMeta) error
+	// UpdateTagMeta records the creation metadata of the named tag, or removes it if |meta| is nil.
+	UpdateTagMeta(name string, meta *RefMeta) error
+	// UpdateBranchDescription sets the description of the named branch, or removes it if |description| is empty.
+	UpdateBranchDescription(name string, description string) error
 }
 
 // RemoteDbProvider is an interface for getting a database from a remote
@@ -61,11 +72,32 @@ type BranchConfig struct {
 	Remote string             `json:"remote"`
 }
 
+// RefMeta records who created a branch or tag and when. It's kept in the repo state rather than in the database, so it
+// isn't pushed or cloned, and refs created before it was recorded have none.
+type RefMeta struct {
+	Creator string `json:"creator"`
+	// CreatedAt is the creation time in milliseconds since the epoch
+	CreatedAt uint64 `json:"created_at"`
+}
+
+// NewRefMeta returns a RefMeta for a ref created now by |creator|.
+func NewRefMeta(creator string) RefMeta {
+	return RefMeta{Creator: creator, CreatedAt: uint64(datas.CommitNowFunc().UnixMilli())}
+}
+
+// Time returns the creation time as a time.Time
+func (rm RefMeta) Time() time.Time {
+	return time.UnixMilli(int64(rm.CreatedAt)).In(datas.CommitLoc)
+}
+
 type RepoState struct {
-	Head     ref.MarshalableRef      `json:"head"`
-	Remotes  map[string]Remote       `json:"remotes"`
-	Backups  map[string]Remote       `json:"backups"`
-	Branches map[string]BranchConfig `json:"branches"`
+	Head               ref.MarshalableRef      `json:"head"`
+	Remotes            map[string]Remote       `json:"remotes"`
+	Backups            map[string]Remote       `json:"backups"`
+	Branches           map[string]BranchConfig `json:"branches"`
+	BranchMeta         map[string]RefMeta      `json:"branch_meta,omitempty"`
+	TagMeta            map[string]RefMeta      `json:"tag_meta,omitempty"`
+	BranchDescriptions map[string]string       `json:"branch_descriptions,omitempty"`
 	// |staged|, |working|, and |merge| are legacy fields left over from when Dolt repos stored this info in the repo
 	// state file, not in the DB directly. They're still here so that we can migrate existing repositories forward to the
 	// new storage format, but they should be used only for this purpose and are no longer written.
@@ -77,25 +109,31 @@ type RepoState struct {
 // repoStateLegacy only exists to unmarshall legacy repo state files, since the JSON marshaller can't work with
 // unexported fields
 type repoStateLegacy struct {
-	Head     ref.MarshalableRef      `json:"head"`
-	Remotes  map[string]Remote       `json:"remotes"`
-	Backups  map[string]Remote       `json:"backups"`
-	Branches map[string]BranchConfig `json:"branches"`
-	Staged   string                  `json:"staged,omitempty"`
-	Working  string                  `json:"working,omitempty"`
-	Merge    *mergeState             `json:"merge,omitempty"`
+	Head               ref.MarshalableRef      `json:"head"`
+	Remotes            map[string]Remote       `json:"remotes"`
+	Backups            map[string]Remote       `json:"backups"`
+	Branches           map[string]BranchConfig `json:"branches"`
+	BranchMeta         map[string]RefMeta      `json:"branch_meta,omitempty"`
+	TagMeta            map[string]RefMeta      `json:"tag_meta,omitempty"`
+	BranchDescriptions map[string]string       `json:"branch_descriptions,omitempty"`
+	Staged             string                  `json:"staged,omitempty"`
+	Working            string                  `json:"working,omitempty"`
+	Merge              *mergeState             `json:"merge,omitempty"`
 }
 
 // repoStateLegacyFromRepoState creates a new repoStateLegacy from a RepoState file. Only for testing.
 func repoStateLegacyFromRepoState(rs *RepoState) *repoStateLegacy {
 	return &repoStateLegacy{
-		Head:     rs.Head,
-		Remotes:  rs.Remotes,
-		Backups:  rs.Backups,
-		Branches: rs.Branches,
-		Staged:   rs.staged,
-		Working:  rs.working,
-		Merge:    rs.merge,
+		Head:               rs.Head,
+		Remotes:            rs.Remotes,
+		Backups:            rs.Backups,
+		Branches:           rs.Branches,
+		BranchMeta:         rs.BranchMeta,
+		TagMeta:            rs.TagMeta,
+		BranchDescriptions: rs.BranchDescriptions,
+		Staged:             rs.staged,
+		Working:            rs.working,
+		Merge:              rs.merge,
 	}
 }
 
@@ -106,13 +144,16 @@ type mergeState struct {
 
 func (rs *repoStateLegacy) toRepoState() *RepoState {
 	return &RepoState{
-		Head:     rs.Head,
-		Remotes:  rs.Remotes,
-		Backups:  rs.Backups,
-		Branches: rs.Branches,
-		staged:   rs.Staged,
-		working:  rs.Working,
-		merge:    rs.Merge,
+		Head:               rs.Head,
+		Remotes:            rs.Remotes,
+		Backups:            rs.Backups,
+		Branches:           rs.Branches,
+		BranchMeta:         rs.BranchMeta,
+		TagMeta:            rs.TagMeta,
+		BranchDescriptions: rs.BranchDescriptions,
+		staged:             rs.Staged,
+		working:            rs.Working,
+		merge:              rs.Merge,
 	}
 }
 
@@ -221,3 +262,39 @@ func (rs *RepoState) AddBackup(r Remote) {
 func (rs *RepoState) RemoveBackup(r Remote) {
 	delete(rs.Backups, r.Name)
 }
+
+// UpdateBranchMeta sets the creation metadata of the named branch, or removes it if |meta| is nil.
+func (rs *RepoState) UpdateBranchMeta(name string, meta *RefMeta) {
+	if meta == nil {
+		delete(rs.BranchMeta, name)
+		return
+	}
+	if rs.BranchMeta == nil {
+		rs.BranchMeta = make(map[string]RefMeta)
+	}
+	rs.BranchMeta[name] = *meta
+}
+
+// UpdateTagMeta sets the creation metadata of the named tag, or removes it if |meta| is nil.
+func (rs *RepoState) UpdateTagMeta(name string, meta *RefMeta) {
+	if meta == nil {
+		delete(rs.TagMeta, name)
+		return
+	}
+	if rs.TagMeta == nil {
+		rs.TagMeta = make(map[string]RefMeta)
+	}
+	rs.TagMeta[name] = *meta
+}
+
+// UpdateBranchDescription sets the description of the named branch, or removes it if |description| is empty.
+func (rs *RepoState) UpdateBranchDescription(name string, description string) {
+	if description == "" {
+		delete(rs.BranchDescriptions, name)
+		return
+	}
+	if rs.BranchDescriptions == nil {
+		rs.BranchDescriptions = make(map[string]string)
+	}
+	rs.BranchDescriptions[name] = description
+}
@@ -32,6 +32,7 @@ var ErrFailedToReadDatabase = errors.New("failed to read database")
 var ErrMergeFailedToUpdateDocs = errors.New("failed to update docs to the new working root")
 var ErrMergeFailedToUpdateRepoState = errors.New("unable to execute repo state update")
 var ErrFailedToDetermineMergeability = errors.New("failed to determine mergeability")
+var ErrNotFastForward = errors.New("fatal: Not possible to fast-forward, aborting")
 
 type MergeSpec struct {
 	HeadH           hash.Hash
@@ -44,6 +45,7 @@ type MergeSpec struct {
 	Squash          bool
 	Msg             string
 	Noff            bool
+	FFOnly          bool
 	NoCommit        bool
 	NoEdit          bool
 	Force           bool
@@ -54,9 +56,9 @@ type MergeSpec struct {
 }
 
 // NewMergeSpec returns MergeSpec object using arguments passed into this function, which are doltdb.Roots, username,
-// user email, commit msg, commitSpecStr, to squash, to noff, to force, noCommit, noEdit and date. This function
+// user email, commit msg, commitSpecStr, to squash, to noff, to ffOnly, to force, noCommit, noEdit and date. This function
 // resolves head and merge commit, and it gets current diffs between current head and working set if it exists.
-func NewMergeSpec(ctx context.Context, rsr env.RepoStateReader, ddb *doltdb.DoltDB, roots doltdb.Roots, name, email, msg, commitSpecStr string, squash, noff, force, noCommit, noEdit bool, date time.Time) (*MergeSpec, error) {
+func NewMergeSpec(ctx context.Context, rsr env.RepoStateReader, ddb *doltdb.DoltDB, roots doltdb.Roots, name, email, msg, commitSpecStr string, squash, noff, ffOnly, force, noCommit, noEdit bool, date time.Time) (*MergeSpec, error) {
 	headCS, err := doltdb.NewCommitSpec("HEAD")
 	if err != nil {
 		return nil, err
@@ -109,6 +111,7 @@ func NewMergeSpec(ctx context.Context, rsr env.RepoStateReader, ddb *doltdb.Dolt
 		Squash:          squash,
 		Msg:             msg,
 		Noff:            noff,
+		FFOnly:          ffOnly,
 		NoCommit:        noCommit,
 		NoEdit:          noEdit,
 		Force:           force,
@@ -31,7 +31,9 @@ import (
 // Theirs: HEAD~2
 //
 // The root is updated with the merged result, and this process is repeated for each commit given, in the order given.
-// Currently, we error on conflicts or constraint violations generated by the merge.
+// If reverting a commit generates conflicts, the merged root is returned with doltdb.ErrUnresolvedConflictsOrViolations
+// so that the conflicts can be resolved, and the commits after it aren't reverted. Currently, we error on constraint
+// violations generated by the merge.
 func Revert(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, headCommit *doltdb.Commit, commits []*doltdb.Commit, opts editor.Options) (*doltdb.RootValue, string, error) {
 	revertMessage := "Revert"
 
@@ -75,15 +77,16 @@ func Revert(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, hea
 		}
 		root = result.Root
 
-		if ok, err := result.Root.HasConflicts(ctx); err != nil {
+		if ok, err := result.Root.HasConstraintViolations(ctx); err != nil {
 			return nil, "", err
 		} else if ok {
-			return nil, "", fmt.Errorf("revert currently does not handle conflicts")
+			return nil, "", fmt.Errorf("revert currently does not handle constraint violations")
 		}
-		if ok, err := result.Root.HasConstraintViolations(ctx); err != nil {
+		if ok, err := result.Root.HasConflicts(ctx); err != nil {
 			return nil, "", err
 		} else if ok {
-			return nil, "", fmt.Errorf("revert currently does not handle constraint violations")
+			// this error is recoverable, so we return the conflicted root along with the error
+			return root, revertMessage, doltdb.ErrUnresolvedConflictsOrViolations
 		}
 	}
 
@@ -42,6 +42,8 @@ const (
 	HistoryCommitterTag = iota + SystemTableReservedMin + uint64(1000)
 	HistoryCommitHashTag
 	HistoryCommitDateTag
+	HistoryCommitOrderTag
+	HistoryDeletedTag
 )
 
 // Tags for dolt_diff_ table
@@ -177,3 +177,15 @@ func (n noopRepoStateWriter) TempTableFilesDir() (string, error) {
 func (n noopRepoStateWriter) UpdateBranch(name string, new env.BranchConfig) error {
 	return nil
 }
+
+func (n noopRepoStateWriter) UpdateBranchMeta(name string, meta *env.RefMeta) error {
+	return nil
+}
+
+func (n noopRepoStateWriter) UpdateTagMeta(name string, meta *env.RefMeta) error {
+	return nil
+}
+
+func (n noopRepoStateWriter) UpdateBranchDescription(name string, description string) error {
+	return nil
+}
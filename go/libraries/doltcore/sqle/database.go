@@ -365,7 +365,9 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 	case doltdb.RemoteBranchesTableName:
 		dt, found = dtables.NewRemoteBranchesTable(ctx, db), true
 	case doltdb.RemotesTableName:
-		dt, found = dtables.NewRemotesTable(ctx, db.ddb), true
+		dt, found = dtables.NewRemotesTable(ctx, db.name, db.ddb), true
+	case doltdb.BackupsTableName:
+		dt, found = dtables.NewBackupsTable(ctx, db.name, db.ddb), true
 	case doltdb.CommitsTableName:
 		dt, found = dtables.NewCommitsTable(ctx, db.ddb), true
 	case doltdb.CommitAncestorsTableName:
@@ -385,7 +387,9 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 	case doltdb.MergeStatusTableName:
 		dt, found = dtables.NewMergeStatusTable(db.name), true
 	case doltdb.TagsTableName:
-		dt, found = dtables.NewTagsTable(ctx, db.ddb), true
+		dt, found = dtables.NewTagsTable(ctx, db), true
+	case doltdb.StashesTableName:
+		dt, found = dtables.NewStashesTable(ctx, db.ddb), true
 	case dtables.AccessTableName:
 		basCtx := branch_control.GetBranchAwareSession(ctx)
 		if basCtx != nil {
@@ -405,7 +409,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if err != nil {
 			return nil, false, err
 		}
-		dt, found = dtables.NewIgnoreTable(ctx, db.ddb, backingTable), true
+		dt, found = dtables.NewIgnoreTable(ctx, db.name, db.ddb, backingTable), true
 	}
 
 	if found {
@@ -481,7 +485,7 @@ func resolveAsOfCommitRef(ctx *sql.Context, db Database, head ref.DoltRef, commi
 
 	if commitRef == doltdb.Working || commitRef == doltdb.Staged {
 		sess := dsess.DSessFromSess(ctx.Session)
-		root, _, _, err := sess.ResolveRootForRef(ctx, ctx.GetCurrentDatabase(), commitRef)
+		root, _, _, err := sess.ResolveRootForRef(ctx, db.Name(), commitRef)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1276,11 +1280,15 @@ func (db Database) UpdateEvent(ctx *sql.Context, originalName string, ed sql.Eve
 	return db.SaveEvent(ctx, ed)
 }
 
-// GetStoredProcedure implements sql.StoredProcedureDatabase.
+// GetStoredProcedure implements sql.StoredProcedureDatabase. Like all stored procedure methods, it reads the
+// dolt_procedures table of this database's root in the current session, so that CALL, SHOW CREATE PROCEDURE,
+// SHOW PROCEDURE STATUS and information_schema.routines all see the procedures of the session's current branch, or of
+// the revision this database names. Nothing is cached across roots, so switching branches with dolt_checkout is
+// reflected by the next statement.
 func (db Database) GetStoredProcedure(ctx *sql.Context, name string) (sql.StoredProcedureDetails, bool, error) {
 	procedures, err := DoltProceduresGetAll(ctx, db, strings.ToLower(name))
 	if err != nil {
-		return sql.StoredProcedureDetails{}, false, nil
+		return sql.StoredProcedureDetails{}, false, err
 	}
 	if len(procedures) == 1 {
 		return procedures[0], true, nil
@@ -1288,7 +1296,8 @@ func (db Database) GetStoredProcedure(ctx *sql.Context, name string) (sql.Stored
 	return sql.StoredProcedureDetails{}, false, nil
 }
 
-// GetStoredProcedures implements sql.StoredProcedureDatabase.
+// GetStoredProcedures implements sql.StoredProcedureDatabase. Procedures are read from the current session root,
+// see GetStoredProcedure.
 func (db Database) GetStoredProcedures(ctx *sql.Context) ([]sql.StoredProcedureDetails, error) {
 	return DoltProceduresGetAll(ctx, db, "")
 }
@@ -1491,3 +1500,15 @@ func (n noopRepoStateWriter) TempTableFilesDir() (string, error) {
 func (n noopRepoStateWriter) UpdateBranch(name string, new env.BranchConfig) error {
 	return nil
 }
+
+func (n noopRepoStateWriter) UpdateBranchMeta(name string, meta *env.RefMeta) error {
+	return nil
+}
+
+func (n noopRepoStateWriter) UpdateTagMeta(name string, meta *env.RefMeta) error {
+	return nil
+}
+
+func (n noopRepoStateWriter) UpdateBranchDescription(name string, description string) error {
+	return nil
+}
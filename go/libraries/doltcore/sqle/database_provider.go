@@ -36,6 +36,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -54,6 +56,10 @@ type DoltDatabaseProvider struct {
 
 	dbFactoryUrl string
 	isStandby    *bool
+	writeLocks   *dsess.DatabaseWriteLocks
+	// readOnlyDbs holds the lowercased names of databases served read-only, e.g. from a snapshot mounted on a
+	// read-only file system
+	readOnlyDbs map[string]struct{}
 }
 
 var _ sql.DatabaseProvider = (*DoltDatabaseProvider)(nil)
@@ -91,9 +97,14 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		dbs[strings.ToLower(db.Name())] = db
 	}
 
+	// Databases located on a read-only file system, e.g. a snapshot mounted read-only, are served read-only
 	dbLocations := make(map[string]filesys.Filesys, len(locations))
+	readOnlyDbs := make(map[string]struct{})
 	for i, dbLocation := range locations {
 		dbLocations[databases[i].Name()] = dbLocation
+		if _, ok := dbLocation.(filesys.ReadOnlyFS); ok {
+			readOnlyDbs[formatDbMapKeyName(databases[i].Name())] = struct{}{}
+		}
 	}
 
 	funcs := make(map[string]sql.Function, len(dfunctions.DoltFunctions))
@@ -124,6 +135,8 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		dbFactoryUrl:       dbFactoryUrl,
 		InitDatabaseHook:   ConfigureReplicationDatabaseHook,
 		isStandby:          new(bool),
+		writeLocks:         dsess.NewDatabaseWriteLocks(),
+		readOnlyDbs:        readOnlyDbs,
 	}, nil
 }
 
@@ -152,10 +165,37 @@ func (p DoltDatabaseProvider) WithRemoteDialer(provider dbfactory.GRPCDialProvid
 	return p
 }
 
+// WithReadOnlyDatabases returns a copy of this provider that serves the databases named as read-only. Writes to these
+// databases, and to their revision databases, are rejected, and their file systems reject any attempt to write to
+// them. The databases' storage should itself be opened read-only, see dbfactory.ReadOnlyParam.
+func (p DoltDatabaseProvider) WithReadOnlyDatabases(names ...string) DoltDatabaseProvider {
+	readOnlyDbs := make(map[string]struct{}, len(p.readOnlyDbs)+len(names))
+	for name := range p.readOnlyDbs {
+		readOnlyDbs[name] = struct{}{}
+	}
+	for _, name := range names {
+		readOnlyDbs[formatDbMapKeyName(name)] = struct{}{}
+	}
+	p.readOnlyDbs = readOnlyDbs
+	return p
+}
+
+// IsReadOnlyDatabase implements dsess.DoltDatabaseProvider
+func (p DoltDatabaseProvider) IsReadOnlyDatabase(dbName string) bool {
+	baseName := strings.SplitN(dbName, dsess.DbRevisionDelimiter, 2)[0]
+	_, ok := p.readOnlyDbs[strings.ToLower(baseName)]
+	return ok
+}
+
 func (p DoltDatabaseProvider) FileSystem() filesys.Filesys {
 	return p.fs
 }
 
+// DatabaseWriteLocks implements dsess.DoltDatabaseProvider
+func (p DoltDatabaseProvider) DatabaseWriteLocks() *dsess.DatabaseWriteLocks {
+	return p.writeLocks
+}
+
 // SetIsStandby sets whether this provider is set to standby |true|. Standbys return every dolt database as a read only
 // database. Set back to |false| to get read-write behavior from dolt databases again.
 func (p DoltDatabaseProvider) SetIsStandby(standby bool) {
@@ -176,6 +216,9 @@ func (p DoltDatabaseProvider) FileSystemForDatabase(dbname string) (filesys.File
 		return nil, sql.ErrDatabaseNotFound.New(dbname)
 	}
 
+	if p.IsReadOnlyDatabase(dbname) {
+		return filesys.NewReadOnlyFS(dbLocation), nil
+	}
 	return dbLocation, nil
 }
 
@@ -359,10 +402,31 @@ func (p DoltDatabaseProvider) GetRemoteDB(ctx context.Context, format *types.Nom
 	return r.GetRemoteDBWithoutCaching(ctx, format, p.remoteDialer)
 }
 
+// CreateDatabase implements the sql.MutableDatabaseProvider interface.
+//
+// TODO: CREATE DATABASE ... FROM REMOTE <url> can't be supported here yet. The parser doesn't accept the clause, and the
+// engine only passes the name and collation of the new database to the provider. Once it does, the statement should
+// delegate to CloneDatabaseFromRemote, which already cleans up after a failed clone. Until then, use dolt_clone.
 func (p DoltDatabaseProvider) CreateDatabase(ctx *sql.Context, name string) error {
 	return p.CreateCollatedDatabase(ctx, name, sql.Collation_Default)
 }
 
+// CreateDatabaseWithCharset creates a new database whose tables default to the character set and collation named, like
+// CREATE DATABASE ... CHARACTER SET ... COLLATE .... Either name may be empty: a character set alone uses its default
+// collation, and a collation alone implies its character set. The root value only stores the collation, which
+// determines the character set.
+func (p DoltDatabaseProvider) CreateDatabaseWithCharset(ctx *sql.Context, name string, charset string, collation string) error {
+	collationId, err := sql.ParseCollation(&charset, &collation, false)
+	if err != nil {
+		return err
+	}
+	if collationId == sql.Collation_Unspecified {
+		collationId = sql.Collation_Default
+	}
+	return p.CreateCollatedDatabase(ctx, name, collationId)
+}
+
+// CreateCollatedDatabase implements the sql.CollatedDatabaseProvider interface.
 func (p DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name string, collation sql.CollationID) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -422,12 +486,18 @@ func (p DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name stri
 		}
 	}
 
+	return p.registerNewDatabase(ctx, name, newEnv)
+}
+
+// registerNewDatabase adds the database newly created in |newEnv| to this provider under the name given. Callers must
+// hold |p.mu|.
+func (p DoltDatabaseProvider) registerNewDatabase(ctx *sql.Context, name string, newEnv *env.DoltEnv) error {
 	// If we're running in a sql-server context, ensure the new database is locked so that it can't
 	// be edited from the CLI. We can't rely on looking for an existing lock file, since this could
 	// be the first db creation if sql-server was started from a bare directory.
 	_, lckDeets := sqlserver.GetRunningServer()
 	if lckDeets != nil {
-		err = newEnv.Lock(*lckDeets)
+		err := newEnv.Lock(*lckDeets)
 		if err != nil {
 			ctx.GetLogger().Warnf("Failed to lock newly created database: %s", err.Error())
 		}
@@ -464,6 +534,112 @@ func (p DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name stri
 	return nil
 }
 
+// CreateDatabaseFromCommit implements DoltDatabaseProvider interface
+func (p DoltDatabaseProvider) CreateDatabaseFromCommit(ctx *sql.Context, name string, srcDB *doltdb.DoltDB, commit *doltdb.Commit, preserveHistory bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.databases[formatDbMapKeyName(name)]; ok {
+		return sql.ErrDatabaseExists.New(name)
+	}
+
+	exists, isDir := p.fs.Exists(name)
+	if exists && isDir {
+		return sql.ErrDatabaseExists.New(name)
+	} else if exists {
+		return fmt.Errorf("Cannot create DB, file exists at %s", name)
+	}
+
+	err := p.fs.MkDirs(name)
+	if err != nil {
+		return err
+	}
+
+	newEnv, err := p.createDatabaseFromCommit(ctx, name, srcDB, commit, preserveHistory)
+	if err != nil {
+		// Make a best effort to clean up the partially created database before we return the error
+		if deleteErr := p.fs.Delete(name, true); deleteErr != nil {
+			err = fmt.Errorf("%s: unable to clean up failed database creation in directory '%s'", err.Error(), name)
+		}
+		return err
+	}
+
+	return p.registerNewDatabase(ctx, name, newEnv)
+}
+
+// createDatabaseFromCommit initializes the database in the directory |name| from the commit given. The chunks of the
+// commit's root value, and of its history if |preserveHistory| is set, are copied from |srcDB| as-is, so no table
+// data is rewritten. Without |preserveHistory| the new database's history is a single commit of the copied root value.
+func (p DoltDatabaseProvider) createDatabaseFromCommit(ctx *sql.Context, name string, srcDB *doltdb.DoltDB, commit *doltdb.Commit, preserveHistory bool) (*env.DoltEnv, error) {
+	newFs, err := p.fs.WithWorkingDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: fill in version appropriately
+	newEnv := env.Load(ctx, env.GetCurrentUserHomeDir, newFs, p.dbFactoryUrl, "TODO")
+	err = newEnv.InitRepoWithNoData(ctx, srcDB.Format())
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := newEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	branchRef := ref.NewBranchRef(p.defaultBranch)
+	if preserveHistory {
+		commitHash, err := commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		err = newEnv.DoltDB.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{commitHash}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = newEnv.DoltDB.SetHead(ctx, branchRef, commitHash)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		root, err := commit.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		rootHash, err := root.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		err = newEnv.DoltDB.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{rootHash}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		sess := dsess.DSessFromSess(ctx.Session)
+		meta, err := datas.NewCommitMeta(sess.Username(), sess.Email(), "Initialize data repository")
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = newEnv.DoltDB.CommitWithParentCommits(ctx, rootHash, branchRef, nil, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = newEnv.InitializeRepoState(ctx, p.defaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEnv, nil
+}
+
 type InitDatabaseHook func(ctx *sql.Context, pro DoltDatabaseProvider, name string, env *env.DoltEnv) error
 
 // ConfigureReplicationDatabaseHook sets up replication for a newly created database as necessary
@@ -853,6 +1029,15 @@ func revisionDbType(ctx *sql.Context, srcDb dsess.SqlDatabase, revSpec string) (
 		return dsess.RevisionTypeCommit, resolvedRevSpec, nil
 	}
 
+	if doltdb.IsCommitHashPrefix(resolvedRevSpec) {
+		_, err = srcDb.DbData().Ddb.ResolveCommitHashPrefix(ctx, resolvedRevSpec)
+		if err == nil {
+			return dsess.RevisionTypeCommit, resolvedRevSpec, nil
+		} else if err != doltdb.ErrHashNotFound {
+			return 0, "", err
+		}
+	}
+
 	return dsess.RevisionTypeNone, "", nil
 }
 
@@ -1017,7 +1202,7 @@ func readReplicationActive(ctx *sql.Context) bool {
 }
 
 // resolveAncestorSpec resolves the specified revSpec to a specific commit hash if it contains an ancestor reference
-// such as ~ or ^. If no ancestor reference is present, the specified revSpec is returned as is. If any unexpected
+// such as ~ or ^. The revSpec can start with a ref name or a commit hash, which may be abbreviated. If no ancestor reference is present, the specified revSpec is returned as is. If any unexpected
 // problems are encountered, an error is returned.
 func resolveAncestorSpec(ctx *sql.Context, revSpec string, ddb *doltdb.DoltDB) (string, error) {
 	refname, ancestorSpec, err := doltdb.SplitAncestorSpec(revSpec)
@@ -1028,14 +1213,25 @@ func resolveAncestorSpec(ctx *sql.Context, revSpec string, ddb *doltdb.DoltDB) (
 		return revSpec, nil
 	}
 
+	var cm *doltdb.Commit
 	ref, err := ddb.GetRefByNameInsensitive(ctx, refname)
 	if err != nil {
-		return "", err
-	}
-
-	cm, err := ddb.ResolveCommitRef(ctx, ref)
-	if err != nil {
-		return "", err
+		if !doltdb.IsValidCommitHash(refname) && !doltdb.IsCommitHashPrefix(refname) {
+			return "", err
+		}
+		spec, err := doltdb.NewCommitSpec(refname)
+		if err != nil {
+			return "", err
+		}
+		cm, err = ddb.Resolve(ctx, spec, nil)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		cm, err = ddb.ResolveCommitRef(ctx, ref)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	cm, err = cm.GetAncestor(ctx, ancestorSpec)
@@ -1059,7 +1255,7 @@ func (p DoltDatabaseProvider) SessionDatabase(ctx *sql.Context, name string) (ds
 	standby := *p.isStandby
 	p.mu.RUnlock()
 	if ok {
-		return wrapForStandby(db, standby), true, nil
+		return wrapForStandby(db, standby || p.IsReadOnlyDatabase(name)), true, nil
 	}
 
 	// Revision databases aren't tracked in the map, just instantiated on demand
@@ -1081,7 +1277,7 @@ func (p DoltDatabaseProvider) SessionDatabase(ctx *sql.Context, name string) (ds
 		}
 	}
 
-	return wrapForStandby(db, standby), true, nil
+	return wrapForStandby(db, standby || p.IsReadOnlyDatabase(name)), true, nil
 }
 
 // Function implements the FunctionProvider interface
@@ -1123,9 +1319,39 @@ func (p DoltDatabaseProvider) TableFunction(_ *sql.Context, name string) (sql.Ta
 	case "dolt_log":
 		dtf := &LogTableFunction{}
 		return dtf, nil
+	case "dolt_blame":
+		dtf := &BlameTableFunction{}
+		return dtf, nil
+	case "dolt_conflicts_preview":
+		dtf := &ConflictsPreviewTableFunction{}
+		return dtf, nil
 	case "dolt_patch":
 		dtf := &PatchTableFunction{}
 		return dtf, nil
+	case "dolt_table_match":
+		dtf := &TableMatchTableFunction{}
+		return dtf, nil
+	case "dolt_row_history":
+		dtf := &RowHistoryTableFunction{}
+		return dtf, nil
+	case "dolt_storage_report":
+		dtf := &StorageReportTableFunction{}
+		return dtf, nil
+	case "dolt_index_diff":
+		dtf := &IndexDiffTableFunction{}
+		return dtf, nil
+	case "dolt_schema_diff":
+		dtf := &SchemaDiffTableFunction{}
+		return dtf, nil
+	case "dolt_metrics":
+		dtf := &MetricsTableFunction{}
+		return dtf, nil
+	case "dolt_branches_containing":
+		dtf := &BranchesContainingTableFunction{}
+		return dtf, nil
+	case "dolt_branches":
+		dtf := &BranchesTableFunction{}
+		return dtf, nil
 	}
 
 	return nil, sql.ErrTableFunctionNotFound.New(name)
@@ -0,0 +1,83 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const DatabaseLockStatusFuncName = "dolt_database_lock_status"
+
+// DatabaseLockStatusFunc returns a description of the advisory write lock held on the current database, or NULL if
+// the database is not locked.
+type DatabaseLockStatusFunc struct {
+}
+
+// NewDatabaseLockStatusFunc creates a new DatabaseLockStatusFunc expression.
+func NewDatabaseLockStatusFunc() sql.Expression {
+	return &DatabaseLockStatusFunc{}
+}
+
+// Eval implements the Expression interface.
+func (f *DatabaseLockStatusFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, nil
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	lock, ok := dSess.Provider().DatabaseWriteLocks().Status(dbName)
+	if !ok {
+		return nil, nil
+	}
+
+	return lock.String(), nil
+}
+
+// String implements the Stringer interface.
+func (f *DatabaseLockStatusFunc) String() string {
+	return "DOLT_DATABASE_LOCK_STATUS()"
+}
+
+// IsNullable implements the Expression interface.
+func (f *DatabaseLockStatusFunc) IsNullable() bool {
+	return true
+}
+
+// Resolved implements the Expression interface.
+func (*DatabaseLockStatusFunc) Resolved() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (f *DatabaseLockStatusFunc) Type() sql.Type {
+	return types.Text
+}
+
+// Children implements the Expression interface.
+func (*DatabaseLockStatusFunc) Children() []sql.Expression {
+	return nil
+}
+
+// WithChildren implements the Expression interface.
+func (f *DatabaseLockStatusFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 0)
+	}
+	return NewDatabaseLockStatusFunc(), nil
+}
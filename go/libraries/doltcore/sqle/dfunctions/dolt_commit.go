@@ -0,0 +1,104 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+)
+
+const DoltCommitFuncName = "dolt_commit"
+
+// DoltCommitFunc is the function form of the dolt_commit stored procedure. It returns the hash of the new commit, so
+// that it can be captured directly, e.g. SET @c = (SELECT DOLT_COMMIT('-am', 'message')).
+type DoltCommitFunc struct {
+	children []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*DoltCommitFunc)(nil)
+var _ sql.NonDeterministicExpression = (*DoltCommitFunc)(nil)
+
+// NewDoltCommitFunc creates a new DoltCommitFunc expression.
+func NewDoltCommitFunc(args ...sql.Expression) (sql.Expression, error) {
+	return &DoltCommitFunc{children: args}, nil
+}
+
+// Eval implements the Expression interface.
+func (d *DoltCommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := dprocedures.GetDoltArgs(ctx, row, d.children)
+	if err != nil {
+		return nil, err
+	}
+	return dprocedures.DoDoltCommit(ctx, args)
+}
+
+// String implements the Stringer interface.
+func (d *DoltCommitFunc) String() string {
+	childrenStrings := make([]string, len(d.children))
+	for i, child := range d.children {
+		childrenStrings[i] = child.String()
+	}
+	return fmt.Sprintf("DOLT_COMMIT(%s)", strings.Join(childrenStrings, ","))
+}
+
+// FunctionName implements the FunctionExpression interface
+func (d *DoltCommitFunc) FunctionName() string {
+	return DoltCommitFuncName
+}
+
+// Description implements the FunctionExpression interface
+func (d *DoltCommitFunc) Description() string {
+	return "creates a new commit and returns its hash"
+}
+
+// IsNonDeterministic implements the NonDeterministicExpression interface.
+func (d *DoltCommitFunc) IsNonDeterministic() bool {
+	return true
+}
+
+// IsNullable implements the Expression interface.
+func (d *DoltCommitFunc) IsNullable() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (d *DoltCommitFunc) Resolved() bool {
+	for _, child := range d.children {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Type implements the Expression interface.
+func (d *DoltCommitFunc) Type() sql.Type {
+	return types.Text
+}
+
+// Children implements the Expression interface.
+func (d *DoltCommitFunc) Children() []sql.Expression {
+	return d.children
+}
+
+// WithChildren implements the Expression interface.
+func (d *DoltCommitFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltCommitFunc(children...)
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const DoltCountCommitsFuncName = "dolt_count_commits"
+
+// CountCommits is the DOLT_COUNT_COMMITS function, which returns the number of commits on each side of two revisions
+// that aren't on the other, like `git rev-list --left-right --count a...b`. The counts are returned as a JSON object
+// with an ahead key, the commits only on the first revision, and a behind key, the commits only on the second.
+type CountCommits struct {
+	expression.BinaryExpression
+}
+
+// NewCountCommits returns a CountCommits sql function.
+func NewCountCommits(left, right sql.Expression) sql.Expression {
+	return &CountCommits{expression.BinaryExpression{Left: left, Right: right}}
+}
+
+// Eval implements the sql.Expression interface.
+func (d CountCommits) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if _, ok := d.Left.Type().(sql.StringType); !ok {
+		return nil, sql.ErrInvalidType.New(d.Left.Type())
+	}
+	if _, ok := d.Right.Type().(sql.StringType); !ok {
+		return nil, sql.ErrInvalidType.New(d.Right.Type())
+	}
+
+	leftSpec, err := d.Left.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	rightSpec, err := d.Right.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if leftSpec == nil || rightSpec == nil {
+		return nil, nil
+	}
+
+	left, err := resolveCommitSpec(ctx, leftSpec.(string))
+	if err != nil {
+		return nil, err
+	}
+	right, err := resolveCommitSpec(ctx, rightSpec.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	ddb, ok := dsess.DSessFromSess(ctx.Session).GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	ahead, err := countCommitsNotIn(ctx, ddb, left, right)
+	if err != nil {
+		return nil, err
+	}
+	behind, err := countCommitsNotIn(ctx, ddb, right, left)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.JSONDocument{Val: map[string]interface{}{"ahead": ahead, "behind": behind}}, nil
+}
+
+// countCommitsNotIn returns the number of commits reachable from |include| that aren't reachable from |exclude|
+func countCommitsNotIn(ctx *sql.Context, ddb *doltdb.DoltDB, include, exclude *doltdb.Commit) (int64, error) {
+	includeHash, err := include.HashOf()
+	if err != nil {
+		return 0, err
+	}
+	excludeHash, err := exclude.HashOf()
+	if err != nil {
+		return 0, err
+	}
+
+	itr, err := commitwalk.GetDotDotRevisionsIterator(ctx, ddb, []hash.Hash{includeHash}, ddb, []hash.Hash{excludeHash}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for {
+		_, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
+
+// String implements the sql.Expression interface.
+func (d CountCommits) String() string {
+	return fmt.Sprintf("DOLT_COUNT_COMMITS(%s,%s)", d.Left.String(), d.Right.String())
+}
+
+// Type implements the sql.Expression interface.
+func (d CountCommits) Type() sql.Type {
+	return types.JSON
+}
+
+// WithChildren implements the sql.Expression interface.
+func (d CountCommits) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewCountCommits(children[0], children[1]), nil
+}
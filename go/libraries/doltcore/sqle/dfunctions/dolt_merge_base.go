@@ -21,9 +21,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
-	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
-	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
 const DoltMergeBaseFuncName = "dolt_merge_base"
@@ -59,56 +57,21 @@ func (d MergeBase) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	left, right, err := resolveRefSpecs(ctx, leftSpec.(string), rightSpec.(string))
+	left, err := resolveCommitSpec(ctx, leftSpec.(string))
 	if err != nil {
 		return nil, err
 	}
-
-	mergeBase, err := merge.MergeBase(ctx, left, right)
+	right, err := resolveCommitSpec(ctx, rightSpec.(string))
 	if err != nil {
 		return nil, err
 	}
 
-	return mergeBase.String(), nil
-}
-
-func resolveRefSpecs(ctx *sql.Context, leftSpec, rightSpec string) (left, right *doltdb.Commit, err error) {
-	lcs, err := doltdb.NewCommitSpec(leftSpec)
-	if err != nil {
-		return nil, nil, err
-	}
-	rcs, err := doltdb.NewCommitSpec(rightSpec)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	sess := dsess.DSessFromSess(ctx.Session)
-	dbName := ctx.GetCurrentDatabase()
-
-	dbData, ok := sess.GetDbData(ctx, dbName)
-	if !ok {
-		return nil, nil, sql.ErrDatabaseNotFound.New(dbName)
-	}
-	doltDB, ok := sess.GetDoltDB(ctx, dbName)
-	if !ok {
-		return nil, nil, sql.ErrDatabaseNotFound.New(dbName)
-	}
-
-	headRef, err := dbData.Rsr.CWBHeadRef()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	left, err = doltDB.Resolve(ctx, lcs, headRef)
-	if err != nil {
-		return nil, nil, err
-	}
-	right, err = doltDB.Resolve(ctx, rcs, headRef)
+	mergeBase, err := merge.MergeBase(ctx, left, right)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return
+	return mergeBase.String(), nil
 }
 
 // String implements the sql.Expression interface.
@@ -59,7 +59,24 @@ func (t *HashOf) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, errors.New("branch name is not a string")
 	}
 
-	name, as, err := doltdb.SplitAncestorSpec(paramStr)
+	cm, err := resolveCommitSpec(ctx, paramStr)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// resolveCommitSpec returns the commit of the current database named by |spec|, which is a branch, tag or other ref
+// name, HEAD, or a commit hash, which may be abbreviated, optionally followed by an ancestor spec such as ~2. HEAD is the head of the session's
+// working set, which can be ahead of the branch within a transaction.
+func resolveCommitSpec(ctx *sql.Context, spec string) (*doltdb.Commit, error) {
+	name, as, err := doltdb.SplitAncestorSpec(spec)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +105,18 @@ func (t *HashOf) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 				if err != nil {
 					return nil, orgErr
 				}
+			} else if doltdb.IsCommitHashPrefix(name) {
+				orgErr := err
+				hsh, err = ddb.ResolveCommitHashPrefix(ctx, name)
+				if err == doltdb.ErrHashNotFound {
+					return nil, orgErr
+				} else if err != nil {
+					return nil, err
+				}
+				cm, err = ddb.ReadCommit(ctx, hsh)
+				if err != nil {
+					return nil, err
+				}
 			} else {
 				return nil, err
 			}
@@ -99,17 +128,7 @@ func (t *HashOf) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	cm, err = cm.GetAncestor(ctx, as)
-	if err != nil {
-		return nil, err
-	}
-
-	h, err := cm.HashOf()
-	if err != nil {
-		return nil, err
-	}
-
-	return h.String(), nil
+	return cm.GetAncestor(ctx, as)
 }
 
 // String implements the Stringer interface.
@@ -22,6 +22,9 @@ var DoltFunctions = []sql.Function{
 	sql.Function0{Name: StorageFormatFuncName, Fn: NewStorageFormat},
 	sql.Function0{Name: ActiveBranchFuncName, Fn: NewActiveBranchFunc},
 	sql.Function2{Name: DoltMergeBaseFuncName, Fn: NewMergeBase},
+	sql.Function2{Name: DoltCountCommitsFuncName, Fn: NewCountCommits},
+	sql.Function0{Name: DatabaseLockStatusFuncName, Fn: NewDatabaseLockStatusFunc},
+	sql.FunctionN{Name: DoltCommitFuncName, Fn: NewDoltCommitFunc},
 }
 
 // DolthubApiFunctions are the DoltFunctions that get exposed to Dolthub Api.
@@ -0,0 +1,419 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ sql.TableFunction = (*BlameTableFunction)(nil)
+var _ sql.ExecSourceRel = (*BlameTableFunction)(nil)
+
+// BlameTableFunction is the dolt_blame table function, which shows the last commit to add or modify each row of a table
+// as of a revision, e.g. dolt_blame('HEAD~5', 't'). Its rows are those of the dolt_blame_<table> system view, which is
+// scoped to HEAD of the current branch: the primary key columns of the row, ordered by primary key, followed by the
+// commit and its date, committer, email and message.
+type BlameTableFunction struct {
+	ctx *sql.Context
+
+	revisionExpr  sql.Expression
+	tableNameExpr sql.Expression
+	database      sql.Database
+
+	tableName string
+	// pkSch is the schema of the primary key columns of the table at the revision
+	pkSch  sql.Schema
+	sqlSch sql.Schema
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (bt *BlameTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &BlameTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (bt *BlameTableFunction) Database() sql.Database {
+	return bt.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (bt *BlameTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nbt := *bt
+	nbt.database = database
+	return &nbt, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (bt *BlameTableFunction) Name() string {
+	return "dolt_blame"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (bt *BlameTableFunction) Resolved() bool {
+	return bt.revisionExpr.Resolved() && bt.tableNameExpr.Resolved()
+}
+
+// String implements the Stringer interface
+func (bt *BlameTableFunction) String() string {
+	return fmt.Sprintf("DOLT_BLAME(%s, %s)", bt.revisionExpr.String(), bt.tableNameExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (bt *BlameTableFunction) Schema() sql.Schema {
+	return bt.sqlSch
+}
+
+// Children implements the sql.Node interface.
+func (bt *BlameTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (bt *BlameTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return bt, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (bt *BlameTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return opChecker.UserHasPrivileges(ctx,
+		sql.NewPrivilegedOperation(bt.database.Name(), bt.tableName, "", sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (bt *BlameTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{bt.revisionExpr, bt.tableNameExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (bt *BlameTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(bt.Name(), 2, len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(bt.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(bt.Name(), expr.String())
+		}
+		if !gmstypes.IsText(expr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(bt.Name(), expr.String())
+		}
+	}
+
+	nbt := *bt
+	nbt.revisionExpr = expression[0]
+	nbt.tableNameExpr = expression[1]
+
+	if err := nbt.generateSchema(nbt.ctx); err != nil {
+		return nil, err
+	}
+
+	return &nbt, nil
+}
+
+// generateSchema resolves the table at the revision given and builds the schema of the rows returned from its primary
+// key columns
+func (bt *BlameTableFunction) generateSchema(ctx *sql.Context) error {
+	tableName, err := expressionToString(ctx, bt.tableNameExpr)
+	if err != nil {
+		return err
+	}
+
+	cm, err := bt.resolveRevision(ctx)
+	if err != nil {
+		return err
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+
+	table, resolvedName, ok, err := root.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableNotFound.New(tableName)
+	}
+	bt.tableName = resolvedName
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if schema.IsKeyless(sch) {
+		return fmt.Errorf("%s requires a table with a primary key, but %s has none", bt.Name(), resolvedName)
+	}
+
+	tableSch, err := sqlutil.FromDoltSchema("", sch)
+	if err != nil {
+		return err
+	}
+
+	bt.pkSch = nil
+	bt.sqlSch = nil
+	for _, col := range tableSch.Schema {
+		if !col.PrimaryKey {
+			continue
+		}
+		bt.pkSch = append(bt.pkSch, col)
+		bt.sqlSch = append(bt.sqlSch, &sql.Column{Name: col.Name, Type: col.Type, Nullable: false})
+	}
+	bt.sqlSch = append(bt.sqlSch,
+		&sql.Column{Name: "commit", Type: gmstypes.Text, Nullable: false},
+		&sql.Column{Name: "commit_date", Type: gmstypes.Datetime, Nullable: false},
+		&sql.Column{Name: "committer", Type: gmstypes.Text, Nullable: false},
+		&sql.Column{Name: "email", Type: gmstypes.Text, Nullable: false},
+		&sql.Column{Name: "message", Type: gmstypes.Text, Nullable: false},
+	)
+
+	return nil
+}
+
+// resolveRevision returns the commit of the revision argument
+func (bt *BlameTableFunction) resolveRevision(ctx *sql.Context) (*doltdb.Commit, error) {
+	sqledb, ok := bt.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", bt.database)
+	}
+
+	revision, err := expressionToString(ctx, bt.revisionExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	return dsess.DSessFromSess(ctx.Session).ResolveCommit(ctx, sqledb.Name(), revision)
+}
+
+// blameEntry is the latest change to a row found in the table's diffs
+type blameEntry struct {
+	pk         sql.Row
+	commit     string
+	commitDate time.Time
+	removed    bool
+}
+
+// RowIter implements the sql.Node interface
+func (bt *BlameTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	sqledb, ok := bt.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", bt.database)
+	}
+	ddb := sqledb.DbData().Ddb
+
+	cm, err := bt.resolveRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The diffs of the table in each commit reachable from the revision, as in dolt_diff_<table>. The revision's own
+	// root stands in for the working set, so there are no working changes.
+	dt, err := dtables.NewDiffTable(ctx, bt.tableName, ddb, root, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := bt.latestChanges(ctx, dt)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	metas := make(map[string]*blameCommitMeta)
+	for _, e := range entries {
+		if e.removed {
+			continue
+		}
+
+		meta, ok := metas[e.commit]
+		if !ok {
+			meta, err = loadCommitMeta(ctx, ddb, e.commit)
+			if err != nil {
+				return nil, err
+			}
+			metas[e.commit] = meta
+		}
+
+		r := append(sql.Row{}, e.pk...)
+		rows = append(rows, append(r, e.commit, e.commitDate, meta.name, meta.email, meta.description))
+	}
+
+	// rows are ordered by primary key, as in dolt_blame_<table>
+	var sortErr error
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, col := range bt.pkSch {
+			cmp, err := col.Type.Compare(rows[i][k], rows[j][k])
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// latestChanges returns the latest change to each row in the diffs of |dt|, by the date of the change's commit, like
+// the ROW_NUMBER() window of the dolt_blame_<table> view
+func (bt *BlameTableFunction) latestChanges(ctx *sql.Context, dt sql.Table) ([]*blameEntry, error) {
+	diffSch := dt.Schema()
+	toPkIdxs := make([]int, len(bt.pkSch))
+	fromPkIdxs := make([]int, len(bt.pkSch))
+	for i, col := range bt.pkSch {
+		toPkIdxs[i] = diffSch.IndexOfColName(diff.ToColNamer(col.Name))
+		fromPkIdxs[i] = diffSch.IndexOfColName(diff.FromColNamer(col.Name))
+	}
+	toCommitIdx := diffSch.IndexOfColName("to_commit")
+	toCommitDateIdx := diffSch.IndexOfColName("to_commit_date")
+	fromCommitDateIdx := diffSch.IndexOfColName("from_commit_date")
+	diffTypeIdx := diffSch.IndexOfColName("diff_type")
+
+	var entries []*blameEntry
+	byPk := make(map[uint64]*blameEntry)
+
+	partitions, err := dt.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer partitions.Close(ctx)
+
+	for {
+		p, err := partitions.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		err = func() error {
+			iter, err := dt.PartitionRows(ctx, p)
+			if err != nil {
+				return err
+			}
+			defer iter.Close(ctx)
+
+			for {
+				r, err := iter.Next(ctx)
+				if err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+
+				// removed rows only have from_ key columns
+				pk := make(sql.Row, len(bt.pkSch))
+				for i := range bt.pkSch {
+					pk[i] = r[toPkIdxs[i]]
+					if pk[i] == nil {
+						pk[i] = r[fromPkIdxs[i]]
+					}
+				}
+				key, err := sql.HashOf(pk)
+				if err != nil {
+					return err
+				}
+
+				date, _ := r[toCommitDateIdx].(time.Time)
+				if date.IsZero() {
+					date, _ = r[fromCommitDateIdx].(time.Time)
+				}
+
+				e, ok := byPk[key]
+				if ok && !date.After(e.commitDate) {
+					continue
+				}
+				if !ok {
+					e = &blameEntry{pk: pk}
+					byPk[key] = e
+					entries = append(entries, e)
+				}
+
+				commit, _ := r[toCommitIdx].(string)
+				e.commit, e.commitDate = commit, date
+				e.removed = r[diffTypeIdx] == "removed"
+			}
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// blameCommitMeta is the part of a commit's metadata shown by dolt_blame
+type blameCommitMeta struct {
+	name        string
+	email       string
+	description string
+}
+
+// loadCommitMeta returns the metadata of the commit with the hash given
+func loadCommitMeta(ctx *sql.Context, ddb *doltdb.DoltDB, commitHash string) (*blameCommitMeta, error) {
+	h, ok := hash.MaybeParse(commitHash)
+	if !ok {
+		return nil, fmt.Errorf("invalid commit hash: %s", commitHash)
+	}
+	cm, err := ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &blameCommitMeta{name: meta.Name, email: meta.Email, description: meta.Description}, nil
+}
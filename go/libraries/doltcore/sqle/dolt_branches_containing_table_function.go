@@ -0,0 +1,236 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var ErrUnknownCommit = goerrors.NewKind("unknown commit: %s")
+
+var _ sql.TableFunction = (*BranchesContainingTableFunction)(nil)
+var _ sql.ExecSourceRel = (*BranchesContainingTableFunction)(nil)
+
+// BranchesContainingTableFunction lists the branches whose history includes a commit, like `git branch --contains`,
+// e.g. dolt_branches_containing('<hash>'). The commit can be given as any revision, which is resolved relative to the
+// session's current branch. A branch contains a commit if the commit is its head or one of its head's ancestors.
+type BranchesContainingTableFunction struct {
+	ctx *sql.Context
+
+	commitExpr sql.Expression
+	database   sql.Database
+}
+
+var branchesContainingTableSchema = sql.Schema{
+	&sql.Column{Name: "name", Type: gmstypes.Text, Nullable: false},
+	&sql.Column{Name: "hash", Type: gmstypes.Text, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (bc *BranchesContainingTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &BranchesContainingTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (bc *BranchesContainingTableFunction) Database() sql.Database {
+	return bc.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (bc *BranchesContainingTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nbc := *bc
+	nbc.database = database
+	return &nbc, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (bc *BranchesContainingTableFunction) Name() string {
+	return "dolt_branches_containing"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (bc *BranchesContainingTableFunction) Resolved() bool {
+	return bc.commitExpr != nil && bc.commitExpr.Resolved()
+}
+
+// String implements the Stringer interface
+func (bc *BranchesContainingTableFunction) String() string {
+	return fmt.Sprintf("DOLT_BRANCHES_CONTAINING(%s)", bc.commitExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (bc *BranchesContainingTableFunction) Schema() sql.Schema {
+	return branchesContainingTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (bc *BranchesContainingTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (bc *BranchesContainingTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return bc, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (bc *BranchesContainingTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := bc.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		operations = append(operations, sql.NewPrivilegedOperation(bc.database.Name(), tblName, "", sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (bc *BranchesContainingTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{bc.commitExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (bc *BranchesContainingTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(bc.Name(), 1, len(expression))
+	}
+
+	expr := expression[0]
+	if !expr.Resolved() {
+		return nil, ErrInvalidNonLiteralArgument.New(bc.Name(), expr.String())
+	}
+	// prepared statements resolve functions beforehand, so above check fails
+	if _, ok := expr.(sql.FunctionExpression); ok {
+		return nil, ErrInvalidNonLiteralArgument.New(bc.Name(), expr.String())
+	}
+	if !gmstypes.IsText(expr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(bc.Name(), expr.String())
+	}
+
+	nbc := *bc
+	nbc.commitExpr = expr
+	return &nbc, nil
+}
+
+// RowIter implements the sql.Node interface
+func (bc *BranchesContainingTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := bc.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", bc.database)
+	}
+
+	commitStr, err := expressionToString(ctx, bc.commitExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	ddb := sqledb.DbData().Ddb
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.RevisionHeadRef(ctx, sqledb.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := doltdb.NewCommitSpec(commitStr)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := ddb.Resolve(ctx, cs, headRef)
+	if isUnknownCommitErr(err) {
+		return nil, ErrUnknownCommit.New(commitStr)
+	} else if err != nil {
+		return nil, err
+	}
+
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].Ref.GetPath() < branches[j].Ref.GetPath()
+	})
+
+	var rows []sql.Row
+	for _, b := range branches {
+		branchHead, err := ddb.ResolveCommitRef(ctx, b.Ref)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := commitIsAncestorOf(ctx, commit, branchHead)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, sql.Row{b.Ref.GetPath(), b.Hash.String()})
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// isUnknownCommitErr returns whether |err| is the error resolving a commit spec that names no commit
+func isUnknownCommitErr(err error) bool {
+	return doltdb.IsNotACommit(err) || errors.Is(err, datas.ErrCommitNotFound)
+}
+
+// commitIsAncestorOf returns whether |ancestor| is |descendant| or one of its ancestors, i.e. whether it's the common
+// ancestor of the two commits.
+func commitIsAncestorOf(ctx context.Context, ancestor, descendant *doltdb.Commit) (bool, error) {
+	ancestorHash, err := ancestor.HashOf()
+	if err != nil {
+		return false, err
+	}
+
+	common, err := doltdb.GetCommitAncestor(ctx, ancestor, descendant)
+	if errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	commonHash, err := common.HashOf()
+	if err != nil {
+		return false, err
+	}
+	return commonHash == ancestorHash, nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	branchesMergedFlag   = "merged"
+	branchesNoMergedFlag = "no-merged"
+	branchesRemotesFlag  = "remotes"
+)
+
+var _ sql.TableFunction = (*BranchesTableFunction)(nil)
+var _ sql.ExecSourceRel = (*BranchesTableFunction)(nil)
+
+// BranchesTableFunction lists branches along with whether each is merged into a revision, like `git branch --merged`,
+// e.g. dolt_branches('--merged', 'main'). A branch is merged into a revision if its head is reachable from the
+// revision's commit. Without --merged or --no-merged, the merged column is relative to the session's current HEAD.
+// With --remotes, the remote-tracking branches are listed instead of the local ones.
+type BranchesTableFunction struct {
+	ctx *sql.Context
+
+	argExprs []sql.Expression
+	database sql.Database
+
+	mergedInto string
+	noMerged   bool
+	remotes    bool
+}
+
+var branchesTableFunctionSchema = sql.Schema{
+	&sql.Column{Name: "name", Type: gmstypes.Text, Nullable: false},
+	&sql.Column{Name: "hash", Type: gmstypes.Text, Nullable: false},
+	&sql.Column{Name: "latest_committer", Type: gmstypes.Text, Nullable: true},
+	&sql.Column{Name: "latest_commit_date", Type: gmstypes.Datetime, Nullable: true},
+	&sql.Column{Name: "merged", Type: gmstypes.Boolean, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (btf *BranchesTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &BranchesTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (btf *BranchesTableFunction) Database() sql.Database {
+	return btf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (btf *BranchesTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nbtf := *btf
+	nbtf.database = database
+	return &nbtf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (btf *BranchesTableFunction) Name() string {
+	return "dolt_branches"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (btf *BranchesTableFunction) Resolved() bool {
+	for _, expr := range btf.argExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (btf *BranchesTableFunction) String() string {
+	args := make([]string, len(btf.argExprs))
+	for i, expr := range btf.argExprs {
+		args[i] = expr.String()
+	}
+	return fmt.Sprintf("DOLT_BRANCHES(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (btf *BranchesTableFunction) Schema() sql.Schema {
+	return branchesTableFunctionSchema
+}
+
+// Children implements the sql.Node interface.
+func (btf *BranchesTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (btf *BranchesTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return btf, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (btf *BranchesTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := btf.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		operations = append(operations, sql.NewPrivilegedOperation(btf.database.Name(), tblName, "", sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (btf *BranchesTableFunction) Expressions() []sql.Expression {
+	return btf.argExprs
+}
+
+// branchesTableFunctionArgParser returns the parser for the options accepted by dolt_branches
+func branchesTableFunctionArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("dolt_branches", 0)
+	ap.SupportsString(branchesMergedFlag, "", "revision", "Lists only the branches merged into the revision given, i.e. whose heads are reachable from it.")
+	ap.SupportsString(branchesNoMergedFlag, "", "revision", "Lists only the branches not merged into the revision given.")
+	ap.SupportsFlag(branchesRemotesFlag, "r", "Lists the remote-tracking branches instead of the local branches.")
+	return ap
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (btf *BranchesTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(btf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(btf.Name(), expr.String())
+		}
+	}
+
+	args, err := getDoltArgs(btf.ctx, expression, btf.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	apr, err := branchesTableFunctionArgParser().Parse(args)
+	if err != nil {
+		return nil, sql.ErrInvalidArgumentDetails.New(btf.Name(), err.Error())
+	}
+
+	nbtf := *btf
+	nbtf.argExprs = expression
+	nbtf.remotes = apr.Contains(branchesRemotesFlag)
+	nbtf.mergedInto, nbtf.noMerged = "", false
+	if merged, ok := apr.GetValue(branchesMergedFlag); ok {
+		nbtf.mergedInto = merged
+	}
+	if notMerged, ok := apr.GetValue(branchesNoMergedFlag); ok {
+		if apr.Contains(branchesMergedFlag) {
+			return nil, sql.ErrInvalidArgumentDetails.New(btf.Name(), fmt.Sprintf("cannot use --%s with --%s", branchesMergedFlag, branchesNoMergedFlag))
+		}
+		nbtf.mergedInto, nbtf.noMerged = notMerged, true
+	}
+
+	return &nbtf, nil
+}
+
+// RowIter implements the sql.Node interface
+func (btf *BranchesTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := btf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", btf.database)
+	}
+
+	ddb := sqledb.DbData().Ddb
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.RevisionHeadRef(ctx, sqledb.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	mergedInto := btf.mergedInto
+	if len(mergedInto) == 0 {
+		mergedInto = "HEAD"
+	}
+	// the commits reachable from the revision are found with the same commit walk as dolt_log
+	merged, err := getReachableCommits(ctx, ddb, headRef, mergedInto)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := btf.branchesWithHashes(ctx, ddb)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, b := range branches {
+		_, isMerged := merged[b.Hash]
+		if len(btf.mergedInto) > 0 && isMerged == btf.noMerged {
+			continue
+		}
+
+		cm, err := ddb.ResolveCommitRef(ctx, b.Ref)
+		if err != nil {
+			return nil, err
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		name := b.Ref.GetPath()
+		if btf.remotes {
+			name = "remotes/" + name
+		}
+		rows = append(rows, sql.Row{name, b.Hash.String(), meta.Name, meta.Time(), isMerged})
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// branchesWithHashes returns the local branches, or the remote-tracking branches with --remotes, sorted by name
+func (btf *BranchesTableFunction) branchesWithHashes(ctx *sql.Context, ddb *doltdb.DoltDB) ([]doltdb.RefWithHash, error) {
+	var branches []doltdb.RefWithHash
+	if btf.remotes {
+		remotes, err := ddb.GetRemotesWithHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range remotes {
+			branches = append(branches, doltdb.RefWithHash{Ref: r.Ref, Hash: r.Hash})
+		}
+	} else {
+		var err error
+		branches, err = ddb.GetBranchesWithHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].Ref.GetPath() < branches[j].Ref.GetPath()
+	})
+	return branches, nil
+}
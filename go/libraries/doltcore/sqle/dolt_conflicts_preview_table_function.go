@@ -0,0 +1,314 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var _ sql.TableFunction = (*ConflictsPreviewTableFunction)(nil)
+var _ sql.ExecSourceRel = (*ConflictsPreviewTableFunction)(nil)
+
+var conflictsPreviewTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: gmstypes.LongText, Nullable: false},
+	&sql.Column{Name: "base", Type: gmstypes.JSON, Nullable: true},
+	&sql.Column{Name: "ours", Type: gmstypes.JSON, Nullable: true},
+	&sql.Column{Name: "our_diff_type", Type: gmstypes.LongText, Nullable: false},
+	&sql.Column{Name: "theirs", Type: gmstypes.JSON, Nullable: true},
+	&sql.Column{Name: "their_diff_type", Type: gmstypes.LongText, Nullable: false},
+}
+
+// ConflictsPreviewTableFunction is the dolt_conflicts_preview table function, which returns the rows that would conflict
+// if a revision were merged into HEAD of the current branch, e.g. dolt_conflicts_preview('feature'). The merge is
+// performed in memory, so neither the working set nor the merge state is changed. The base, ours and theirs versions of
+// each conflicting row are returned as JSON objects keyed by column name, with the diff types of ours and theirs as in
+// dolt_conflicts_<table>. A merge that would be clean, or a fast-forward, returns no rows.
+type ConflictsPreviewTableFunction struct {
+	ctx *sql.Context
+
+	revisionExpr sql.Expression
+	database     sql.Database
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (cp *ConflictsPreviewTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &ConflictsPreviewTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (cp *ConflictsPreviewTableFunction) Database() sql.Database {
+	return cp.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (cp *ConflictsPreviewTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ncp := *cp
+	ncp.database = database
+	return &ncp, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (cp *ConflictsPreviewTableFunction) Name() string {
+	return "dolt_conflicts_preview"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (cp *ConflictsPreviewTableFunction) Resolved() bool {
+	return cp.revisionExpr.Resolved()
+}
+
+// String implements the Stringer interface
+func (cp *ConflictsPreviewTableFunction) String() string {
+	return fmt.Sprintf("DOLT_CONFLICTS_PREVIEW(%s)", cp.revisionExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (cp *ConflictsPreviewTableFunction) Schema() sql.Schema {
+	return conflictsPreviewTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (cp *ConflictsPreviewTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (cp *ConflictsPreviewTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return cp, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (cp *ConflictsPreviewTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := cp.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		operations = append(operations, sql.NewPrivilegedOperation(cp.database.Name(), tblName, "", sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (cp *ConflictsPreviewTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{cp.revisionExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (cp *ConflictsPreviewTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(cp.Name(), 1, len(expression))
+	}
+
+	expr := expression[0]
+	if !expr.Resolved() {
+		return nil, ErrInvalidNonLiteralArgument.New(cp.Name(), expr.String())
+	}
+	// prepared statements resolve functions beforehand, so above check fails
+	if _, ok := expr.(sql.FunctionExpression); ok {
+		return nil, ErrInvalidNonLiteralArgument.New(cp.Name(), expr.String())
+	}
+	if !gmstypes.IsText(expr.Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(cp.Name(), expr.String())
+	}
+
+	ncp := *cp
+	ncp.revisionExpr = expr
+	return &ncp, nil
+}
+
+// RowIter implements the sql.Node interface
+func (cp *ConflictsPreviewTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	var db Database
+	switch d := cp.database.(type) {
+	case Database:
+		db = d
+	case ReadOnlyDatabase:
+		db = d.Database
+	default:
+		return nil, fmt.Errorf("unexpected database type: %T", cp.database)
+	}
+
+	revision, err := expressionToString(ctx, cp.revisionExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, db.Name())
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := sess.ResolveCommit(ctx, db.Name(), revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// a fast-forward, or a revision already merged, can't conflict
+	canFF, err := head.CanFastForwardTo(ctx, theirs)
+	if err == doltdb.ErrIsAhead || err == doltdb.ErrUpToDate {
+		return sql.RowsToRowIter(), nil
+	} else if err != nil {
+		return nil, err
+	} else if canFF {
+		return sql.RowsToRowIter(), nil
+	}
+
+	result, err := merge.MergeCommits(ctx, head, theirs, db.EditOptions())
+	if err != nil {
+		return nil, err
+	}
+	if result.HasSchemaConflicts() {
+		tblNames := merge.SchemaConflictTableNames(result.SchemaConflicts)
+		return nil, fmt.Errorf("merging %s would produce schema conflicts in tables: %s", revision, strings.Join(tblNames, ", "))
+	}
+
+	tblNames, err := result.Root.TablesWithDataConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, tblName := range tblNames {
+		// the merged root is only read, the conflicts table's root setter is never called
+		ct, ok, err := db.getTableInsensitive(ctx, head, sess, result.Root, doltdb.DoltConfTablePrefix+tblName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, sql.ErrTableNotFound.New(doltdb.DoltConfTablePrefix + tblName)
+		}
+
+		tblRows, err := conflictPreviewRows(ctx, tblName, ct)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, tblRows...)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// conflictPreviewRows returns the rows of the conflicts table |ct| of the table named, with the base, our and their
+// columns of each conflict collected into JSON objects
+func conflictPreviewRows(ctx *sql.Context, tblName string, ct sql.Table) ([]sql.Row, error) {
+	const (
+		basePrefix  = "base_"
+		ourPrefix   = "our_"
+		theirPrefix = "their_"
+	)
+
+	sch := ct.Schema()
+	ourDiffTypeIdx := sch.IndexOfColName("our_diff_type")
+	theirDiffTypeIdx := sch.IndexOfColName("their_diff_type")
+
+	var rows []sql.Row
+	err := iterTableRows(ctx, ct, func(r sql.Row) {
+		base := make(map[string]interface{})
+		ours := make(map[string]interface{})
+		theirs := make(map[string]interface{})
+		for i, col := range sch {
+			if i == ourDiffTypeIdx || i == theirDiffTypeIdx {
+				continue
+			}
+			switch {
+			case strings.HasPrefix(col.Name, basePrefix):
+				base[col.Name[len(basePrefix):]] = r[i]
+			case strings.HasPrefix(col.Name, ourPrefix):
+				ours[col.Name[len(ourPrefix):]] = r[i]
+			case strings.HasPrefix(col.Name, theirPrefix):
+				theirs[col.Name[len(theirPrefix):]] = r[i]
+			}
+		}
+
+		ourDiffType, theirDiffType := r[ourDiffTypeIdx], r[theirDiffTypeIdx]
+		row := sql.Row{tblName, nil, nil, ourDiffType, nil, theirDiffType}
+		if ourDiffType != "added" || theirDiffType != "added" {
+			row[1] = gmstypes.JSONDocument{Val: base}
+		}
+		if ourDiffType != "removed" {
+			row[2] = gmstypes.JSONDocument{Val: ours}
+		}
+		if theirDiffType != "removed" {
+			row[4] = gmstypes.JSONDocument{Val: theirs}
+		}
+		rows = append(rows, row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// iterTableRows calls |cb| with each row of every partition of |tbl|
+func iterTableRows(ctx *sql.Context, tbl sql.Table, cb func(sql.Row)) error {
+	partitions, err := tbl.Partitions(ctx)
+	if err != nil {
+		return err
+	}
+	defer partitions.Close(ctx)
+
+	for {
+		p, err := partitions.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		iter, err := tbl.PartitionRows(ctx, p)
+		if err != nil {
+			return err
+		}
+		for {
+			r, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				iter.Close(ctx)
+				return err
+			}
+			cb(r)
+		}
+		if err := iter.Close(ctx); err != nil {
+			return err
+		}
+	}
+}
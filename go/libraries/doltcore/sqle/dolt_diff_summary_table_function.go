@@ -24,9 +24,17 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	diffSummaryIncludeUnchangedFlag = "include-unchanged"
+	diffSummaryTotalsFlag           = "totals"
+	diffTypeUnchanged               = "unchanged"
 )
 
 var _ sql.TableFunction = (*DiffSummaryTableFunction)(nil)
@@ -39,7 +47,17 @@ type DiffSummaryTableFunction struct {
 	toCommitExpr   sql.Expression
 	dotCommitExpr  sql.Expression
 	tableNameExpr  sql.Expression
+	optionExprs    []sql.Expression
 	database       sql.Database
+
+	// includeUnchanged adds a row for every table that exists at both refs without changes
+	includeUnchanged bool
+	// totals returns a single row counting the tables summarized, instead of a row for each table
+	totals bool
+	// unreadableTables holds the lowercased names of tables the user can't select from, as found by CheckPrivileges
+	// when including unchanged tables. Unchanged tables that can't be read are omitted rather than rejected. The map
+	// is shared by every copy of this node.
+	unreadableTables map[string]struct{}
 }
 
 var diffSummaryTableSchema = sql.Schema{
@@ -50,11 +68,23 @@ var diffSummaryTableSchema = sql.Schema{
 	&sql.Column{Name: "schema_change", Type: types.Boolean, Nullable: false},
 }
 
+var diffSummaryTotalsSchema = sql.Schema{
+	&sql.Column{Name: "table_count", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "added", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "dropped", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "renamed", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "modified", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "unchanged", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "data_change_count", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "schema_change_count", Type: types.Int64, Nullable: false},
+}
+
 // NewInstance creates a new instance of TableFunction interface
 func (ds *DiffSummaryTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
 	newInstance := &DiffSummaryTableFunction{
-		ctx:      ctx,
-		database: db,
+		ctx:              ctx,
+		database:         db,
+		unreadableTables: make(map[string]struct{}),
 	}
 
 	node, err := newInstance.WithExpressions(expressions...)
@@ -99,20 +129,18 @@ func (ds *DiffSummaryTableFunction) Resolved() bool {
 
 // String implements the Stringer interface
 func (ds *DiffSummaryTableFunction) String() string {
-	if ds.dotCommitExpr != nil {
-		if ds.tableNameExpr != nil {
-			return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s, %s)", ds.dotCommitExpr.String(), ds.tableNameExpr.String())
-		}
-		return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s)", ds.dotCommitExpr.String())
+	args := make([]string, 0, 3+len(ds.optionExprs))
+	for _, expr := range ds.Expressions() {
+		args = append(args, expr.String())
 	}
-	if ds.tableNameExpr != nil {
-		return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s, %s, %s)", ds.fromCommitExpr.String(), ds.toCommitExpr.String(), ds.tableNameExpr.String())
-	}
-	return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s, %s)", ds.fromCommitExpr.String(), ds.toCommitExpr.String())
+	return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s)", strings.Join(args, ", "))
 }
 
 // Schema implements the sql.Node interface.
 func (ds *DiffSummaryTableFunction) Schema() sql.Schema {
+	if ds.totals {
+		return diffSummaryTotalsSchema
+	}
 	return diffSummaryTableSchema
 }
 
@@ -155,6 +183,19 @@ func (ds *DiffSummaryTableFunction) CheckPrivileges(ctx *sql.Context, opChecker
 		return false
 	}
 
+	if ds.includeUnchanged {
+		// changed tables the user can't read are rejected when the summary is computed
+		for tblName := range ds.unreadableTables {
+			delete(ds.unreadableTables, tblName)
+		}
+		for _, tblName := range tblNames {
+			if !opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(ds.database.Name(), tblName, "", sql.PrivilegeType_Select)) {
+				ds.unreadableTables[strings.ToLower(tblName)] = struct{}{}
+			}
+		}
+		return true
+	}
+
 	var operations []sql.PrivilegedOperation
 	for _, tblName := range tblNames {
 		operations = append(operations, sql.NewPrivilegedOperation(ds.database.Name(), tblName, "", sql.PrivilegeType_Select))
@@ -174,16 +215,40 @@ func (ds *DiffSummaryTableFunction) Expressions() []sql.Expression {
 	if ds.tableNameExpr != nil {
 		exprs = append(exprs, ds.tableNameExpr)
 	}
-	return exprs
+	return append(exprs, ds.optionExprs...)
 }
 
-// WithExpressions implements the sql.Expressioner interface.
-func (ds *DiffSummaryTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
-	if len(expression) < 1 {
-		return nil, sql.ErrInvalidArgumentNumber.New(ds.Name(), "1 to 3", len(expression))
+// diffSummaryTableFunctionArgParser returns the parser for the options accepted by dolt_diff_summary after its
+// revision and table name arguments.
+func diffSummaryTableFunctionArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("dolt_diff_summary", 0)
+	ap.SupportsFlag(diffSummaryIncludeUnchangedFlag, "", "Also return a row for each table that is unchanged between the two refs.")
+	ap.SupportsFlag(diffSummaryTotalsFlag, "", "Return a single row counting the tables by diff type, instead of a row for each table.")
+	return ap
+}
+
+// addOptions parses the option expressions given and applies them to this DiffSummaryTableFunction
+func (ds *DiffSummaryTableFunction) addOptions(options []sql.Expression) error {
+	args, err := getDoltArgs(ds.ctx, options, ds.Name())
+	if err != nil {
+		return err
 	}
 
-	for _, expr := range expression {
+	apr, err := diffSummaryTableFunctionArgParser().Parse(args)
+	if err != nil {
+		return sql.ErrInvalidArgumentDetails.New(ds.Name(), err.Error())
+	}
+
+	ds.optionExprs = options
+	ds.includeUnchanged = apr.Contains(diffSummaryIncludeUnchangedFlag)
+	ds.totals = apr.Contains(diffSummaryTotalsFlag)
+
+	return nil
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (ds *DiffSummaryTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	for _, expr := range exprs {
 		if !expr.Resolved() {
 			return nil, ErrInvalidNonLiteralArgument.New(ds.Name(), expr.String())
 		}
@@ -193,7 +258,20 @@ func (ds *DiffSummaryTableFunction) WithExpressions(expression ...sql.Expression
 		}
 	}
 
+	expression, options, err := partitionOptionExpressions(ds.ctx, diffSummaryTableFunctionArgParser(), exprs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(expression) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(ds.Name(), "1 to 3", len(expression))
+	}
+
 	newDstf := *ds
+	if err = newDstf.addOptions(options); err != nil {
+		return nil, err
+	}
+
 	if strings.Contains(expression[0].String(), "..") {
 		if len(expression) < 1 || len(expression) > 2 {
 			return nil, sql.ErrInvalidArgumentNumber.New(newDstf.Name(), "1 or 2", len(expression))
@@ -274,9 +352,19 @@ func (ds *DiffSummaryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.
 		summs := []*diff.TableDeltaSummary{}
 		if summ != nil {
 			summs = []*diff.TableDeltaSummary{summ}
+		} else if ds.includeUnchanged && delta.FromTable == nil && delta.ToTable == nil {
+			unchanged, err := getUnchangedSummaries(ctx, toDetails.root, deltas)
+			if err != nil {
+				return nil, err
+			}
+			for _, summ := range unchanged {
+				if strings.EqualFold(summ.TableName, tableName) {
+					summs = append(summs, summ)
+				}
+			}
 		}
 
-		return NewDiffSummaryTableFunctionRowIter(summs), nil
+		return ds.newRowIter(summs), nil
 	}
 
 	var diffSummaries []*diff.TableDeltaSummary
@@ -290,7 +378,76 @@ func (ds *DiffSummaryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.
 		}
 	}
 
-	return NewDiffSummaryTableFunctionRowIter(diffSummaries), nil
+	if ds.includeUnchanged {
+		for _, summ := range diffSummaries {
+			if ds.isUnreadable(summ.FromTableName) || ds.isUnreadable(summ.ToTableName) {
+				client := ctx.Session.Client()
+				return nil, sql.ErrPrivilegeCheckFailed.New(fmt.Sprintf("'%s'@'%s'", client.User, client.Address))
+			}
+		}
+
+		unchanged, err := getUnchangedSummaries(ctx, toDetails.root, deltas)
+		if err != nil {
+			return nil, err
+		}
+		for _, summ := range unchanged {
+			if !ds.isUnreadable(summ.TableName) {
+				diffSummaries = append(diffSummaries, summ)
+			}
+		}
+
+		sort.SliceStable(diffSummaries, func(i, j int) bool {
+			return strings.Compare(diffSummaries[i].ToTableName, diffSummaries[j].ToTableName) < 0
+		})
+	}
+
+	return ds.newRowIter(diffSummaries), nil
+}
+
+// newRowIter returns an iterator over the rows for |summaries|, or over their totals if requested.
+func (ds *DiffSummaryTableFunction) newRowIter(summaries []*diff.TableDeltaSummary) sql.RowIter {
+	if ds.totals {
+		return sql.RowsToRowIter(getTotalsRowFromSummaries(summaries))
+	}
+	return NewDiffSummaryTableFunctionRowIter(summaries)
+}
+
+// isUnreadable returns whether the user was found not to have select privileges on the table named.
+func (ds *DiffSummaryTableFunction) isUnreadable(tableName string) bool {
+	if len(tableName) == 0 {
+		return false
+	}
+	_, ok := ds.unreadableTables[strings.ToLower(tableName)]
+	return ok
+}
+
+// getUnchangedSummaries returns a summary for every table in |toRoot| that has no delta in |deltas|. Tables whose
+// hashes match at both refs are left out of the deltas, so these are exactly the unchanged tables.
+func getUnchangedSummaries(ctx *sql.Context, toRoot *doltdb.RootValue, deltas []diff.TableDelta) ([]*diff.TableDeltaSummary, error) {
+	changed := make(map[string]struct{}, len(deltas))
+	for _, delta := range deltas {
+		changed[delta.ToName] = struct{}{}
+	}
+
+	tblNames, err := toRoot.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var summs []*diff.TableDeltaSummary
+	for _, tblName := range tblNames {
+		if _, ok := changed[tblName]; ok {
+			continue
+		}
+		summs = append(summs, &diff.TableDeltaSummary{
+			TableName:     tblName,
+			FromTableName: tblName,
+			ToTableName:   tblName,
+			DiffType:      diffTypeUnchanged,
+		})
+	}
+
+	return summs, nil
 }
 
 func getSummaryForDelta(ctx *sql.Context, delta diff.TableDelta, sqledb dsess.SqlDatabase, fromDetails, toDetails *refDetails, shouldErrorOnPKChange bool) (*diff.TableDeltaSummary, error) {
@@ -397,6 +554,32 @@ func (d *diffSummaryTableFunctionRowIter) Close(context *sql.Context) error {
 	return nil
 }
 
+// getTotalsRowFromSummaries returns a single row counting |summaries| by diff type and kind of change.
+func getTotalsRowFromSummaries(summaries []*diff.TableDeltaSummary) sql.Row {
+	counts := make(map[string]int64)
+	var dataChanges, schemaChanges int64
+	for _, summ := range summaries {
+		counts[summ.DiffType]++
+		if summ.DataChange {
+			dataChanges++
+		}
+		if summ.SchemaChange {
+			schemaChanges++
+		}
+	}
+
+	return sql.Row{
+		int64(len(summaries)),     // table_count
+		counts["added"],           // added
+		counts["dropped"],         // dropped
+		counts["renamed"],         // renamed
+		counts["modified"],        // modified
+		counts[diffTypeUnchanged], // unchanged
+		dataChanges,               // data_change_count
+		schemaChanges,             // schema_change_count
+	}
+}
+
 func getRowFromSummary(ds *diff.TableDeltaSummary) sql.Row {
 	return sql.Row{
 		ds.FromTableName, // from_table_name
@@ -16,26 +16,67 @@ package sqle
 
 import (
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
 )
 
 var ErrInvalidNonLiteralArgument = errors.NewKind("Invalid argument to %s: %s – only literal values supported")
 
+var ErrNoUpstreamForDiff = errors.NewKind("branch %s has no upstream branch; set one with dolt_push('--set-upstream', <remote>, %s)")
+
+var ErrNoMergeForDiff = errors.NewKind("--%s requires a merge in progress, but branch %s is not merging")
+
+var ErrWorkingDiffBranchNotFound = errors.NewKind("--working requires branches of database %s, but %s is not one")
+
+// upstreamRevisions are the revisions dolt_diff resolves to the remote-tracking branch of the current branch's upstream
+var upstreamRevisions = map[string]struct{}{"@{upstream}": {}, "@{u}": {}}
+
+const (
+	diffKeysOnlyFlag     = "keys-only"
+	diffRawEnumsFlag     = "raw-enums"
+	diffToOnlyFlag       = "to-only"
+	diffContextFlag      = "context"
+	diffUpstreamFlag     = "upstream"
+	diffJsonDiffFlag     = "json-diff"
+	diffMergeOursFlag    = "merge-ours"
+	diffMergeTheirsFlag  = "merge-theirs"
+	diffAsSqlFlag        = "as-sql"
+	diffAllTablesFlag    = "all-tables"
+	diffFullBlobsFlag    = "full-blobs"
+	diffWorkingFlag      = "working"
+	diffOverviewFlag     = "overview"
+	diffTableNameColName = "table_name"
+	diffStatementColName = "statement"
+	diffTypeContext      = "context"
+	diffRowHashColName   = "row_hash"
+	diffPatchColSuffix   = "_patch"
+)
+
 var _ sql.TableFunction = (*DiffTableFunction)(nil)
 var _ sql.ExecSourceRel = (*DiffTableFunction)(nil)
 
@@ -45,10 +86,53 @@ type DiffTableFunction struct {
 	toCommitExpr   sql.Expression
 	dotCommitExpr  sql.Expression
 	tableNameExpr  sql.Expression
+	optionExprs    []sql.Expression
 	database       sql.Database
 	sqlSch         sql.Schema
 	joiner         *rowconv.Joiner
 
+	// keysOnly restricts the output to the primary key columns (or a row hash for keyless tables) and the diff type
+	keysOnly bool
+	// rawEnums outputs enum and set columns as their ordinal values, rather than their labels
+	rawEnums bool
+	// toOnly restricts the output to the table's columns as of the to revision and the diff type
+	toOnly bool
+	// context is the number of unchanged rows to include before and after each changed row, in primary key order
+	context int
+	// upstream diffs the current branch's upstream against HEAD, so only the table name is given
+	upstream bool
+	// mergeParent diffs the working set against a parent of the merge in progress, the merge-ours or merge-theirs flag
+	// naming which, so only the table name is given
+	mergeParent string
+	// jsonDiff outputs the changes to JSON columns of modified rows as JSON patches, rather than both documents
+	jsonDiff bool
+	// jsonPatches are the JSON columns that are diffed as patches with --json-diff
+	jsonPatches *jsonPatchColumns
+	// asSql adds a column with the SQL statement that applies each change, like those of dolt_patch
+	asSql bool
+	// statements generates the statement column for --as-sql
+	statements *diffStatements
+	// diffSch is the schema of the diff's rows before any projection is applied
+	diffSch sql.Schema
+	// projection, when set, maps each row of the full diff schema to a row of |sqlSch|
+	projection func(sql.Row) sql.Row
+	// allTables diffs every table changed between the revisions, so no table name is given
+	allTables bool
+	// tableDiffs are the diffs of each changed table for --all-tables, in table name order
+	tableDiffs []*allTablesDiff
+	// textCols are the columns of |sqlSch| whose type differs between the tables of an --all-tables diff, which are
+	// output as text
+	textCols []bool
+	// fullBlobs outputs binary and blob columns as their contents, rather than a summary of their length and hash
+	fullBlobs bool
+	// working diffs the working sets of the from and to branches, rather than their HEAD commits
+	working bool
+	// overview outputs one row per table changed between the revisions, flagging whether its schema and its data
+	// changed, rather than the rows of a diff, so no table name is given
+	overview bool
+	// overviewDeltas are the deltas of each changed table for --overview, in table name order
+	overviewDeltas []diff.TableDelta
+
 	tableDelta diff.TableDelta
 	fromDate   *types.Timestamp
 	toDate     *types.Timestamp
@@ -83,26 +167,120 @@ func (dtf *DiffTableFunction) WithDatabase(database sql.Database) (sql.Node, err
 
 // Expressions implements the sql.Expressioner interface
 func (dtf *DiffTableFunction) Expressions() []sql.Expression {
-	if dtf.dotCommitExpr != nil {
-		return []sql.Expression{
-			dtf.dotCommitExpr, dtf.tableNameExpr,
-		}
+	var exprs []sql.Expression
+	if dtf.upstream || dtf.mergeParent != "" {
+		exprs = []sql.Expression{}
+	} else if dtf.dotCommitExpr != nil {
+		exprs = []sql.Expression{dtf.dotCommitExpr}
+	} else {
+		exprs = []sql.Expression{dtf.fromCommitExpr, dtf.toCommitExpr}
 	}
-	return []sql.Expression{
-		dtf.fromCommitExpr, dtf.toCommitExpr, dtf.tableNameExpr,
+	if dtf.tableNameExpr != nil {
+		exprs = append(exprs, dtf.tableNameExpr)
 	}
+	return append(exprs, dtf.optionExprs...)
 }
 
-// WithExpressions implements the sql.Expressioner interface
-func (dtf *DiffTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
-	if len(expression) < 2 {
-		return nil, sql.ErrInvalidArgumentNumber.New(dtf.Name(), "2 to 3", len(expression))
+// diffTableFunctionArgParser returns the parser for the options accepted by dolt_diff after its revision and table
+// name arguments.
+func diffTableFunctionArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("dolt_diff", 0)
+	ap.SupportsFlag(diffKeysOnlyFlag, "", "Only output the primary key columns of changed rows, or a hash of the row for keyless tables, along with the diff type.")
+	ap.SupportsFlag(diffRawEnumsFlag, "", "Output enum and set columns as their numeric values instead of their labels.")
+	ap.SupportsFlag(diffToOnlyFlag, "", "Only output the table's columns as of the to revision, without prefixes, along with the diff type. Removed rows only have their primary key columns set.")
+	ap.SupportsInt(diffContextFlag, "", "lines", "Include up to this many unchanged rows before and after each change, in primary key order, with a diff type of context.")
+	ap.SupportsFlag(diffUpstreamFlag, "", "Diff the table from the current branch's upstream to HEAD, as with dolt_diff('@{upstream}', 'HEAD', <table>).")
+	ap.SupportsFlag(diffMergeOursFlag, "", "During a merge, diff the table from the current branch's side of the merge, HEAD, to the working set.")
+	ap.SupportsFlag(diffMergeTheirsFlag, "", "During a merge, diff the table from the commit being merged in to the working set, to review the merge from the other side.")
+	ap.SupportsFlag(diffAsSqlFlag, "", "Add a statement column with the INSERT, UPDATE or DELETE statement that applies each change to the from revision, like those of dolt_patch.")
+	ap.SupportsFlag(diffJsonDiffFlag, "", "For JSON columns of modified rows, output a JSON Patch (RFC 6902) of the changes to the document in a <column>_patch column, instead of the from and to documents.")
+	ap.SupportsFlag(diffAllTablesFlag, "", "Diff every table changed between the revisions, rather than the table named, with a table_name column naming the table of each row.")
+	ap.SupportsFlag(diffFullBlobsFlag, "", "Output binary and blob columns as their contents, instead of a summary of their length and hash.")
+	ap.SupportsFlag(diffWorkingFlag, "", "Diff the working sets of the from and to branches, given as branch names or branch revision databases such as mydb/branch1, instead of their HEAD commits.")
+	ap.SupportsFlag(diffOverviewFlag, "", "Output a row for each table changed between the revisions, rather than the table named, with whether its schema and whether its data changed. Only table and schema hashes are compared, so no rows are diffed.")
+	return ap
+}
+
+// addOptions parses the option expressions given and applies them to this DiffTableFunction
+func (dtf *DiffTableFunction) addOptions(options []sql.Expression) error {
+	args, err := getDoltArgs(dtf.ctx, options, dtf.Name())
+	if err != nil {
+		return err
+	}
+
+	apr, err := diffTableFunctionArgParser().Parse(args)
+	if err != nil {
+		return sql.ErrInvalidArgumentDetails.New(dtf.Name(), err.Error())
+	}
+
+	dtf.optionExprs = options
+	dtf.keysOnly = apr.Contains(diffKeysOnlyFlag)
+	dtf.rawEnums = apr.Contains(diffRawEnumsFlag)
+	dtf.toOnly = apr.Contains(diffToOnlyFlag)
+	dtf.upstream = apr.Contains(diffUpstreamFlag)
+	dtf.jsonDiff = apr.Contains(diffJsonDiffFlag)
+	dtf.asSql = apr.Contains(diffAsSqlFlag)
+	dtf.allTables = apr.Contains(diffAllTablesFlag)
+	dtf.fullBlobs = apr.Contains(diffFullBlobsFlag)
+	dtf.working = apr.Contains(diffWorkingFlag)
+	dtf.overview = apr.Contains(diffOverviewFlag)
+
+	dtf.mergeParent = ""
+	if apr.Contains(diffMergeOursFlag) && apr.Contains(diffMergeTheirsFlag) {
+		return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffMergeOursFlag, diffMergeTheirsFlag))
+	} else if apr.Contains(diffMergeOursFlag) {
+		dtf.mergeParent = diffMergeOursFlag
+	} else if apr.Contains(diffMergeTheirsFlag) {
+		dtf.mergeParent = diffMergeTheirsFlag
 	}
+	if dtf.upstream && dtf.mergeParent != "" {
+		return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffUpstreamFlag, dtf.mergeParent))
+	}
+	// --upstream and --merge-ours / --merge-theirs choose their own revisions
+	for _, flag := range []string{diffUpstreamFlag, diffMergeOursFlag, diffMergeTheirsFlag} {
+		if dtf.working && apr.Contains(flag) {
+			return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffWorkingFlag, flag))
+		}
+	}
+
+	dtf.context = apr.GetIntOrDefault(diffContextFlag, 0)
+	if dtf.context < 0 {
+		return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s must be 0 or more", diffContextFlag))
+	}
+
+	if dtf.keysOnly && dtf.toOnly {
+		return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffKeysOnlyFlag, diffToOnlyFlag))
+	}
+	for _, flag := range []string{diffKeysOnlyFlag, diffToOnlyFlag} {
+		if dtf.jsonDiff && apr.Contains(flag) {
+			return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffJsonDiffFlag, flag))
+		}
+	}
+	for _, flag := range []string{diffKeysOnlyFlag, diffToOnlyFlag, diffJsonDiffFlag} {
+		if dtf.asSql && apr.Contains(flag) {
+			return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffAsSqlFlag, flag))
+		}
+	}
+	// the columns of --to-only aren't prefixed, so they could collide with the table_name column
+	if dtf.allTables && dtf.toOnly {
+		return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffAllTablesFlag, diffToOnlyFlag))
+	}
+	// --overview has no diff rows for these to apply to
+	for _, flag := range []string{diffAllTablesFlag, diffKeysOnlyFlag, diffToOnlyFlag, diffContextFlag, diffJsonDiffFlag, diffAsSqlFlag} {
+		if dtf.overview && apr.Contains(flag) {
+			return sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("--%s and --%s can't be used together", diffOverviewFlag, flag))
+		}
+	}
+
+	return nil
+}
 
+// WithExpressions implements the sql.Expressioner interface
+func (dtf *DiffTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
 	// TODO: For now, we will only support literal / fully-resolved arguments to the
 	//       DiffTableFunction to avoid issues where the schema is needed in the analyzer
 	//       before the arguments could be resolved.
-	for _, expr := range expression {
+	for _, expr := range exprs {
 		if !expr.Resolved() {
 			return nil, ErrInvalidNonLiteralArgument.New(dtf.Name(), expr.String())
 		}
@@ -112,20 +290,60 @@ func (dtf *DiffTableFunction) WithExpressions(expression ...sql.Expression) (sql
 		}
 	}
 
+	expression, options, err := partitionOptionExpressions(dtf.ctx, diffTableFunctionArgParser(), exprs)
+	if err != nil {
+		return nil, err
+	}
+
 	newDtf := *dtf
-	if strings.Contains(expression[0].String(), "..") {
-		if len(expression) != 2 {
-			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%v with .. or ...", newDtf.Name()), 2, len(expression))
+	if err = newDtf.addOptions(options); err != nil {
+		return nil, err
+	}
+
+	// with --all-tables or --overview, no table name follows the revision arguments
+	name, tableArgs := newDtf.Name(), 1
+	if newDtf.allTables {
+		name, tableArgs = fmt.Sprintf("%v with --%s", newDtf.Name(), diffAllTablesFlag), 0
+	} else if newDtf.overview {
+		name, tableArgs = fmt.Sprintf("%v with --%s", newDtf.Name(), diffOverviewFlag), 0
+	}
+
+	if newDtf.upstream {
+		if len(expression) != tableArgs {
+			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%v with --%s", name, diffUpstreamFlag), tableArgs, len(expression))
+		}
+		newDtf.dotCommitExpr = nil
+		newDtf.fromCommitExpr, newDtf.toCommitExpr = upstreamDiffExpressions()
+	} else if newDtf.mergeParent != "" {
+		if len(expression) != tableArgs {
+			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%v with --%s", name, newDtf.mergeParent), tableArgs, len(expression))
+		}
+		newDtf.dotCommitExpr = nil
+		newDtf.fromCommitExpr, newDtf.toCommitExpr, err = mergeParentDiffExpressions(newDtf.ctx, newDtf.database, newDtf.mergeParent)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(expression) < 1+tableArgs {
+		return nil, sql.ErrInvalidArgumentNumber.New(name, fmt.Sprintf("%d to %d", 1+tableArgs, 2+tableArgs), len(expression))
+	} else if strings.Contains(expression[0].String(), "..") {
+		if newDtf.working {
+			return nil, sql.ErrInvalidArgumentDetails.New(newDtf.Name(), fmt.Sprintf("--%s can't be used with .. or ...", diffWorkingFlag))
+		}
+		if len(expression) != 1+tableArgs {
+			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%v with .. or ...", name), 1+tableArgs, len(expression))
 		}
 		newDtf.dotCommitExpr = expression[0]
-		newDtf.tableNameExpr = expression[1]
 	} else {
-		if len(expression) != 3 {
-			return nil, sql.ErrInvalidArgumentNumber.New(newDtf.Name(), 3, len(expression))
+		if len(expression) != 2+tableArgs {
+			return nil, sql.ErrInvalidArgumentNumber.New(name, 2+tableArgs, len(expression))
 		}
 		newDtf.fromCommitExpr = expression[0]
 		newDtf.toCommitExpr = expression[1]
-		newDtf.tableNameExpr = expression[2]
+	}
+
+	newDtf.tableNameExpr = nil
+	if tableArgs > 0 {
+		newDtf.tableNameExpr = expression[len(expression)-1]
 	}
 
 	fromCommitVal, toCommitVal, dotCommitVal, tableName, err := newDtf.evaluateArguments()
@@ -133,7 +351,13 @@ func (dtf *DiffTableFunction) WithExpressions(expression ...sql.Expression) (sql
 		return nil, err
 	}
 
-	err = newDtf.generateSchema(newDtf.ctx, fromCommitVal, toCommitVal, dotCommitVal, tableName)
+	if newDtf.allTables {
+		err = newDtf.generateAllTablesSchema(newDtf.ctx, fromCommitVal, toCommitVal, dotCommitVal)
+	} else if newDtf.overview {
+		err = newDtf.generateOverviewSchema(newDtf.ctx, fromCommitVal, toCommitVal, dotCommitVal)
+	} else {
+		err = newDtf.generateSchema(newDtf.ctx, fromCommitVal, toCommitVal, dotCommitVal, tableName)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +365,39 @@ func (dtf *DiffTableFunction) WithExpressions(expression ...sql.Expression) (sql
 	return &newDtf, nil
 }
 
+// upstreamDiffExpressions returns the from and to revision expressions of dolt_diff with --upstream
+func upstreamDiffExpressions() (sql.Expression, sql.Expression) {
+	return expression.NewLiteral("@{upstream}", gmstypes.LongText), expression.NewLiteral("HEAD", gmstypes.LongText)
+}
+
+// mergeParentDiffExpressions returns the from and to revision expressions of dolt_diff with --merge-ours or
+// --merge-theirs, which diff the working set of |db| against HEAD or the commit being merged in. Returns an error if
+// the current branch has no merge in progress.
+func mergeParentDiffExpressions(ctx *sql.Context, db sql.Database, mergeParent string) (sql.Expression, sql.Expression, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	ws, err := sess.WorkingSet(ctx, db.Name())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ws.MergeActive() {
+		headRef, err := sess.CWBHeadRef(ctx, db.Name())
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrNoMergeForDiff.New(mergeParent, headRef.GetPath())
+	}
+
+	from := "HEAD"
+	if mergeParent == diffMergeTheirsFlag {
+		h, err := ws.MergeState().Commit().HashOf()
+		if err != nil {
+			return nil, nil, err
+		}
+		from = h.String()
+	}
+	return expression.NewLiteral(from, gmstypes.LongText), expression.NewLiteral(doltdb.Working, gmstypes.LongText), nil
+}
+
 // Children implements the sql.Node interface
 func (dtf *DiffTableFunction) Children() []sql.Node {
 	return nil
@@ -152,6 +409,13 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 	// TODO: When we add support for joining on table functions, we'll need to evaluate this against the
 	//       specified row. That row is what has the left_table context in a join query.
 	//       This will expand the test cases we need to cover significantly.
+	if dtf.allTables {
+		return &allTablesDiffRowIter{diffs: dtf.tableDiffs, textCols: dtf.textCols, width: len(dtf.sqlSch)}, nil
+	}
+	if dtf.overview {
+		return overviewRowIter(ctx, dtf.overviewDeltas)
+	}
+
 	fromCommitVal, toCommitVal, dotCommitVal, _, err := dtf.evaluateArguments()
 	if err != nil {
 		return nil, err
@@ -162,15 +426,60 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 		return nil, fmt.Errorf("unable to get dolt database")
 	}
 
-	fromCommitStr, toCommitStr, err := loadCommitStrings(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
-	if err != nil {
-		return nil, err
+	fromCommitStr, toCommitStr := doltdb.Working, doltdb.Working
+	if !dtf.working {
+		fromCommitStr, toCommitStr, err = loadCommitStrings(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	ddb := sqledb.DbData().Ddb
 	dp := dtables.NewDiffPartition(dtf.tableDelta.ToTable, dtf.tableDelta.FromTable, toCommitStr, fromCommitStr, dtf.toDate, dtf.fromDate, dtf.tableDelta.ToSch, dtf.tableDelta.FromSch)
 
-	return dtables.NewDiffPartitionRowIter(*dp, ddb, dtf.joiner), nil
+	var iter sql.RowIter = dtables.NewDiffPartitionRowIter(*dp, ddb, dtf.joiner)
+	if dtf.context > 0 {
+		iter, err = dtf.withContextRows(ctx, iter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dtf.statements != nil {
+		iter = &statementDiffRowIter{child: iter, statements: dtf.statements}
+	}
+
+	if dtf.projection != nil {
+		iter = &projectedDiffRowIter{child: iter, projection: dtf.projection}
+	}
+
+	if dtf.jsonPatches != nil {
+		iter = &jsonPatchDiffRowIter{child: iter, cols: dtf.jsonPatches}
+	}
+
+	return iter, nil
+}
+
+// projectedDiffRowIter applies a projection to each row of a diff, used when options restrict the diff's schema
+type projectedDiffRowIter struct {
+	child      sql.RowIter
+	projection func(sql.Row) sql.Row
+}
+
+var _ sql.RowIter = (*projectedDiffRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (itr *projectedDiffRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.child.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return itr.projection(r), nil
+}
+
+// Close implements the sql.RowIter interface
+func (itr *projectedDiffRowIter) Close(ctx *sql.Context) error {
+	return itr.child.Close(ctx)
 }
 
 // findMatchingDelta returns the best matching table delta for the table name
@@ -225,6 +534,66 @@ func loadDetailsForRefs(ctx *sql.Context, fromRef, toRef, dotRef interface{}, db
 	return fromDetails, toDetails, nil
 }
 
+// loadDetailsForRefs loads the root, hash, and timestamp for the from and to ref values, which are the working sets of
+// branches with --working
+func (dtf *DiffTableFunction) loadDetailsForRefs(ctx *sql.Context, fromRef, toRef, dotRef interface{}, db dsess.SqlDatabase) (*refDetails, *refDetails, error) {
+	if !dtf.working {
+		return loadDetailsForRefs(ctx, fromRef, toRef, dotRef, db)
+	}
+
+	fromDetails, err := loadWorkingDetailsForBranch(ctx, fromRef, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDetails, err := loadWorkingDetailsForBranch(ctx, toRef, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fromDetails, toDetails, nil
+}
+
+// loadWorkingDetailsForBranch loads the working root of the branch of |db| named by |branchRef|, either a branch name
+// or a branch revision database like mydb/branch1. The current branch's working root is the session's own, which
+// includes changes not yet committed in its transaction.
+func loadWorkingDetailsForBranch(ctx *sql.Context, branchRef interface{}, db dsess.SqlDatabase) (*refDetails, error) {
+	branchStr, err := interfaceToString(branchRef)
+	if err != nil {
+		return nil, err
+	}
+
+	baseName, _ := dsess.SplitRevisionDbName(db)
+	branch := branchStr
+	if prefix := baseName + dsess.DbRevisionDelimiter; len(branch) > len(prefix) && strings.EqualFold(branch[:len(prefix)], prefix) {
+		branch = branch[len(prefix):]
+	}
+
+	branch, ok, err := db.DbData().Ddb.HasBranch(ctx, branch)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrWorkingDiffBranchNotFound.New(baseName, branchStr)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, db.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := db.Name()
+	if headRef.GetPath() != branch {
+		dbName = baseName + dsess.DbRevisionDelimiter + branch
+	}
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	// TODO: get from working set update time
+	now := types.Timestamp(time.Now())
+	return &refDetails{root: roots.Working, hashStr: doltdb.Working, commitTime: &now}, nil
+}
+
 func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{}, db dsess.SqlDatabase) (string, string, error) {
 	if dotRef != nil {
 		dotStr, err := interfaceToString(dotRef)
@@ -236,18 +605,16 @@ func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{},
 
 		if strings.Contains(dotStr, "...") {
 			refs := strings.Split(dotStr, "...")
-
-			headRef, err := sess.CWBHeadRef(ctx, db.Name())
-			if err != nil {
+			if err = resolveUpstreamRevisions(ctx, db, refs); err != nil {
 				return "", "", err
 			}
 
-			rightCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, refs[0])
+			rightCm, err := sess.ResolveCommit(ctx, db.Name(), refs[0])
 			if err != nil {
 				return "", "", err
 			}
 
-			leftCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, refs[1])
+			leftCm, err := sess.ResolveCommit(ctx, db.Name(), refs[1])
 			if err != nil {
 				return "", "", err
 			}
@@ -260,6 +627,9 @@ func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{},
 			return mergeBase.String(), refs[1], nil
 		} else {
 			refs := strings.Split(dotStr, "..")
+			if err = resolveUpstreamRevisions(ctx, db, refs); err != nil {
+				return "", "", err
+			}
 			return refs[0], refs[1], nil
 		}
 	}
@@ -274,7 +644,76 @@ func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{},
 		return "", "", err
 	}
 
-	return fromStr, toStr, nil
+	refs := []string{fromStr, toStr}
+	if err = resolveUpstreamRevisions(ctx, db, refs); err != nil {
+		return "", "", err
+	}
+
+	return refs[0], refs[1], nil
+}
+
+// resolveUpstreamRevisions replaces each of the |revisions| that names the upstream of the current branch, such as
+// @{upstream}, with the remote-tracking branch of that upstream, e.g. remotes/origin/main. Returns an error if the
+// current branch has no upstream.
+func resolveUpstreamRevisions(ctx *sql.Context, db dsess.SqlDatabase, revisions []string) error {
+	var trackingRef ref.DoltRef
+	for i, rev := range revisions {
+		if _, ok := upstreamRevisions[strings.ToLower(rev)]; !ok {
+			continue
+		}
+
+		if trackingRef == nil {
+			var err error
+			trackingRef, err = upstreamTrackingRef(ctx, db)
+			if err != nil {
+				return err
+			}
+		}
+		revisions[i] = "remotes/" + trackingRef.GetPath()
+	}
+	return nil
+}
+
+// upstreamTrackingRef returns the remote-tracking branch of the upstream of the current branch of |db|
+func upstreamTrackingRef(ctx *sql.Context, db dsess.SqlDatabase) (ref.DoltRef, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, db.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	dbData, ok := sess.GetDbData(ctx, db.Name())
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(db.Name())
+	}
+
+	branches, err := dbData.Rsr.GetBranches()
+	if err != nil {
+		return nil, err
+	}
+	upstream, ok := branches[headRef.GetPath()]
+	if !ok || upstream.Merge.Ref == nil {
+		return nil, ErrNoUpstreamForDiff.New(headRef.GetPath(), headRef.GetPath())
+	}
+
+	remotes, err := dbData.Rsr.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+	remote, ok := remotes[upstream.Remote]
+	if !ok {
+		return nil, env.ErrRemoteNotFound
+	}
+
+	trackingRef, err := env.GetTrackingRef(upstream.Merge.Ref, remote)
+	if err != nil {
+		return nil, err
+	}
+	if trackingRef == nil {
+		return nil, ErrNoUpstreamForDiff.New(headRef.GetPath(), headRef.GetPath())
+	}
+
+	return trackingRef, nil
 }
 
 // loadCommitStrings gets the to and from commit strings, using the common
@@ -334,6 +773,31 @@ func (dtf *DiffTableFunction) WithChildren(node ...sql.Node) (sql.Node, error) {
 
 // CheckPrivileges implements the sql.Node interface
 func (dtf *DiffTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	if dtf.allTables {
+		// every table with changes must be readable, under both its from and to names
+		var operations []sql.PrivilegedOperation
+		for _, td := range dtf.tableDiffs {
+			delta := td.dtf.tableDelta
+			for _, name := range []string{delta.FromName, delta.ToName} {
+				if name != "" {
+					operations = append(operations, sql.NewPrivilegedOperation(dtf.database.Name(), name, "", sql.PrivilegeType_Select))
+				}
+			}
+		}
+		return opChecker.UserHasPrivileges(ctx, operations...)
+	}
+	if dtf.overview {
+		var operations []sql.PrivilegedOperation
+		for _, delta := range dtf.overviewDeltas {
+			for _, name := range []string{delta.FromName, delta.ToName} {
+				if name != "" {
+					operations = append(operations, sql.NewPrivilegedOperation(dtf.database.Name(), name, "", sql.PrivilegeType_Select))
+				}
+			}
+		}
+		return opChecker.UserHasPrivileges(ctx, operations...)
+	}
+
 	_, _, _, tableName, err := dtf.evaluateArguments()
 	if err != nil {
 		return false
@@ -351,18 +815,22 @@ func (dtf *DiffTableFunction) evaluateArguments() (interface{}, interface{}, int
 		return nil, nil, nil, "", nil
 	}
 
-	if !gmstypes.IsText(dtf.tableNameExpr.Type()) {
-		return nil, nil, nil, "", sql.ErrInvalidArgumentDetails.New(dtf.Name(), dtf.tableNameExpr.String())
-	}
+	var tableName string
+	if dtf.tableNameExpr != nil {
+		if !gmstypes.IsText(dtf.tableNameExpr.Type()) {
+			return nil, nil, nil, "", sql.ErrInvalidArgumentDetails.New(dtf.Name(), dtf.tableNameExpr.String())
+		}
 
-	tableNameVal, err := dtf.tableNameExpr.Eval(dtf.ctx, nil)
-	if err != nil {
-		return nil, nil, nil, "", err
-	}
+		tableNameVal, err := dtf.tableNameExpr.Eval(dtf.ctx, nil)
+		if err != nil {
+			return nil, nil, nil, "", err
+		}
 
-	tableName, ok := tableNameVal.(string)
-	if !ok {
-		return nil, nil, nil, "", ErrInvalidTableName.New(dtf.tableNameExpr.String())
+		var ok bool
+		tableName, ok = tableNameVal.(string)
+		if !ok {
+			return nil, nil, nil, "", ErrInvalidTableName.New(dtf.tableNameExpr.String())
+		}
 	}
 
 	if dtf.dotCommitExpr != nil {
@@ -412,13 +880,19 @@ func (dtf *DiffTableFunction) generateSchema(ctx *sql.Context, fromCommitVal, to
 		return err
 	}
 
-	fromTable, fromTableExists := delta.FromTable, delta.FromTable != nil
-	toTable, toTableExists := delta.ToTable, delta.ToTable != nil
-
-	if !toTableExists && !fromTableExists {
+	if delta.FromTable == nil && delta.ToTable == nil {
 		return sql.ErrTableNotFound.New(tableName)
 	}
 
+	return dtf.generateDeltaSchema(delta)
+}
+
+// generateDeltaSchema generates the schema of the diff of the table |delta|, which must exist in at least one of the
+// revisions, and the projections applied to its rows by the options given
+func (dtf *DiffTableFunction) generateDeltaSchema(delta diff.TableDelta) error {
+	fromTable, fromTableExists := delta.FromTable, delta.FromTable != nil
+	toTable, toTableExists := delta.ToTable, delta.ToTable != nil
+
 	var toSchema, fromSchema schema.Schema
 	var format *types.NomsBinFormat
 
@@ -449,53 +923,945 @@ func (dtf *DiffTableFunction) generateSchema(ctx *sql.Context, fromCommitVal, to
 	}
 
 	dtf.sqlSch = sqlSchema.Schema
+	dtf.diffSch = sqlSchema.Schema
+	dtf.projection = nil
+	dtf.jsonPatches = nil
+	dtf.statements = nil
+
+	if dtf.context > 0 {
+		if !types.IsFormat_DOLT(format) {
+			return fmt.Errorf("--%s is only supported for databases in the %s format", diffContextFlag, types.Format_DOLT.VersionString())
+		}
+		if toTableExists && schema.IsKeyless(toSchema) {
+			return fmt.Errorf("--%s requires a table with a primary key, but %s has none", diffContextFlag, delta.ToName)
+		}
+	}
+
+	if !dtf.rawEnums {
+		dtf.sqlSch, dtf.projection = enumLabelProjection(dtf.sqlSch)
+	}
+
+	if !dtf.fullBlobs {
+		labels := dtf.projection
+		sch, summaries := blobSummaryProjection(dtf.sqlSch)
+		dtf.sqlSch = sch
+		if summaries != nil {
+			dtf.projection = summaries
+			if labels != nil {
+				dtf.projection = func(r sql.Row) sql.Row {
+					return summaries(labels(r))
+				}
+			}
+		}
+	}
+
+	var restrict func(sql.Schema, diff.TableDelta) (sql.Schema, func(sql.Row) sql.Row)
+	if dtf.keysOnly {
+		restrict = keysOnlyProjection
+	} else if dtf.toOnly {
+		restrict = toOnlyProjection
+	}
+
+	if restrict != nil {
+		labels := dtf.projection
+		sch, restricted := restrict(dtf.sqlSch, delta)
+		dtf.sqlSch, dtf.projection = sch, restricted
+		if labels != nil {
+			dtf.projection = func(r sql.Row) sql.Row {
+				return restricted(labels(r))
+			}
+		}
+	}
+
+	if dtf.jsonDiff {
+		dtf.sqlSch, dtf.jsonPatches = jsonPatchSchema(dtf.sqlSch, delta)
+	}
+
+	if dtf.asSql {
+		dtf.statements, err = newDiffStatements(dtf.diffSch, delta)
+		if err != nil {
+			return err
+		}
+		dtf.sqlSch = append(dtf.sqlSch.Copy(), &sql.Column{Name: diffStatementColName, Type: gmstypes.LongText, Nullable: true})
+	}
 
 	return nil
 }
 
-// cacheTableDelta caches and returns an appropriate table delta for the table name given, taking renames into
-// consideration. Returns a sql.ErrTableNotFound if the given table name cannot be found in either revision.
-func (dtf *DiffTableFunction) cacheTableDelta(ctx *sql.Context, fromCommitVal, toCommitVal, dotCommitVal interface{}, tableName string, db dsess.SqlDatabase) (diff.TableDelta, error) {
-	fromRefDetails, toRefDetails, err := loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, db)
-	if err != nil {
-		return diff.TableDelta{}, err
-	}
+// allTablesDiff is the diff of one of the tables changed in an --all-tables diff
+type allTablesDiff struct {
+	tableName string
+	// dtf diffs the table alone, with the options of the --all-tables diff
+	dtf *DiffTableFunction
+	// colIdxs maps each column of the table's diff schema to its column in the --all-tables schema
+	colIdxs []int
+}
 
-	fromTable, _, fromTableExists, err := fromRefDetails.root.GetTableInsensitive(ctx, tableName)
-	if err != nil {
-		return diff.TableDelta{}, err
+// generateAllTablesSchema generates the schema of an --all-tables diff: a table_name column, followed by the union of
+// the diff schemas of every table changed between the revisions. Columns are matched by name, and a column whose type
+// differs between tables is output as text. Like a diff of a single table, a table that was added or dropped has all
+// of its rows added or removed.
+func (dtf *DiffTableFunction) generateAllTablesSchema(ctx *sql.Context, fromCommitVal, toCommitVal, dotCommitVal interface{}) error {
+	if !dtf.Resolved() {
+		return nil
 	}
 
-	toTable, _, toTableExists, err := toRefDetails.root.GetTableInsensitive(ctx, tableName)
-	if err != nil {
-		return diff.TableDelta{}, err
+	sqledb, ok := dtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", dtf.database)
 	}
 
-	if !fromTableExists && !toTableExists {
-		return diff.TableDelta{}, sql.ErrTableNotFound.New(tableName)
+	fromRefDetails, toRefDetails, err := dtf.loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+	if err != nil {
+		return err
 	}
 
-	// TODO: it would be nice to limit this to just the table under consideration, not all tables with a diff
-	deltas, err := diff.GetTableDeltas(ctx, fromRefDetails.root, toRefDetails.root)
+	// only tables with changes have a delta
+	changed, err := diff.GetTableDeltas(ctx, fromRefDetails.root, toRefDetails.root)
 	if err != nil {
-		return diff.TableDelta{}, err
+		return err
 	}
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].CurName() < changed[j].CurName()
+	})
 
 	dtf.fromDate = fromRefDetails.commitTime
 	dtf.toDate = toRefDetails.commitTime
+	dtf.tableDelta = diff.TableDelta{}
+	dtf.projection = nil
+	dtf.jsonPatches = nil
+	dtf.statements = nil
+
+	var unionSch sql.Schema
+	var textCols []bool
+	dtf.tableDiffs = make([]*allTablesDiff, len(changed))
+	for i, delta := range changed {
+		tableDtf := *dtf
+		tableDtf.allTables = false
+		tableDtf.tableDiffs = nil
+		tableDtf.tableNameExpr = expression.NewLiteral(delta.CurName(), gmstypes.LongText)
+		tableDtf.tableDelta = delta
+		if err = tableDtf.generateDeltaSchema(delta); err != nil {
+			return err
+		}
 
-	delta := findMatchingDelta(deltas, tableName)
+		unionSch, textCols = unionDiffSchema(unionSch, textCols, tableDtf.sqlSch)
+		dtf.tableDiffs[i] = &allTablesDiff{tableName: delta.CurName(), dtf: &tableDtf}
+	}
 
-	// We only get a delta if there's a diff. When there isn't one, construct a delta here with table and schema info
-	if delta.FromTable == nil && delta.ToTable == nil {
-		delta.FromName = tableName
-		delta.ToName = tableName
-		delta.FromTable = fromTable
-		delta.ToTable = toTable
+	if len(unionSch) == 0 {
+		unionSch = sql.Schema{&sql.Column{Name: "diff_type", Type: gmstypes.LongText, Nullable: true}}
+		textCols = []bool{false}
+	}
 
-		if fromTable != nil {
-			sch, err := fromTable.GetSchema(ctx)
-			if err != nil {
+	for _, td := range dtf.tableDiffs {
+		td.colIdxs = make([]int, len(td.dtf.sqlSch))
+		for i, col := range td.dtf.sqlSch {
+			// the table_name column comes first
+			td.colIdxs[i] = 1 + unionSch.IndexOfColName(col.Name)
+		}
+	}
+
+	tableNameCol := &sql.Column{Name: diffTableNameColName, Type: gmstypes.LongText, Nullable: false}
+	dtf.sqlSch = append(sql.Schema{tableNameCol}, unionSch...)
+	dtf.diffSch = dtf.sqlSch
+	dtf.textCols = append([]bool{false}, textCols...)
+
+	return nil
+}
+
+// unionDiffSchema adds the columns of |sch| that |union| is missing to it, each placed before the next column of |sch|
+// that |union| has, so that the to and from columns of every table stay together. A column of |union| whose type
+// differs from that of |sch| becomes a text column, and is marked in |textCols| for its values to be converted.
+func unionDiffSchema(union sql.Schema, textCols []bool, sch sql.Schema) (sql.Schema, []bool) {
+	for i, col := range sch {
+		if idx := union.IndexOfColName(col.Name); idx >= 0 {
+			if !textCols[idx] && !union[idx].Type.Equals(col.Type) {
+				textCol := *union[idx]
+				textCol.Type = gmstypes.LongText
+				union[idx] = &textCol
+				textCols[idx] = true
+			}
+			continue
+		}
+
+		pos := len(union)
+		for _, next := range sch[i+1:] {
+			if idx := union.IndexOfColName(next.Name); idx >= 0 {
+				pos = idx
+				break
+			}
+		}
+
+		// rows of other tables don't have this column
+		unionCol := *col
+		unionCol.Nullable = true
+		unionCol.PrimaryKey = false
+		union = append(union[:pos], append(sql.Schema{&unionCol}, union[pos:]...)...)
+		textCols = append(textCols[:pos], append([]bool{false}, textCols[pos:]...)...)
+	}
+	return union, textCols
+}
+
+// diffOverviewSchema is the schema of a dolt_diff with --overview
+var diffOverviewSchema = sql.Schema{
+	&sql.Column{Name: diffTableNameColName, Type: gmstypes.LongText, Nullable: false},
+	&sql.Column{Name: "schema_changed", Type: gmstypes.Boolean, Nullable: false},
+	&sql.Column{Name: "data_changed", Type: gmstypes.Boolean, Nullable: false},
+}
+
+// generateOverviewSchema loads the deltas of the tables changed between the revisions for an --overview diff. The
+// deltas only hold the tables, so nothing is diffed until their hashes are compared by the row iter.
+func (dtf *DiffTableFunction) generateOverviewSchema(ctx *sql.Context, fromCommitVal, toCommitVal, dotCommitVal interface{}) error {
+	if !dtf.Resolved() {
+		return nil
+	}
+
+	sqledb, ok := dtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", dtf.database)
+	}
+
+	fromRefDetails, toRefDetails, err := dtf.loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+	if err != nil {
+		return err
+	}
+
+	// only tables whose root hash changed have a delta
+	deltas, err := diff.GetTableDeltas(ctx, fromRefDetails.root, toRefDetails.root)
+	if err != nil {
+		return err
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	dtf.overviewDeltas = deltas
+	dtf.sqlSch = diffOverviewSchema
+	dtf.diffSch = diffOverviewSchema
+	return nil
+}
+
+// overviewRowIter returns the rows of an --overview diff of the tables of |deltas|. A table's schema changed if it was
+// added, dropped or its schema hash differs, and its data changed if its row data hash differs, or it was added or
+// dropped with rows.
+func overviewRowIter(ctx *sql.Context, deltas []diff.TableDelta) (sql.RowIter, error) {
+	rows := make([]sql.Row, 0, len(deltas))
+	for _, delta := range deltas {
+		schemaChanged, err := delta.HasSchemaChanged(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dataChanged, err := delta.HasDataChanged(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, sql.Row{delta.CurName(), schemaChanged, dataChanged})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// allTablesDiffRowIter iterates the rows of the diff of each table of an --all-tables diff in turn, mapping them to
+// the columns of the --all-tables schema
+type allTablesDiffRowIter struct {
+	diffs    []*allTablesDiff
+	textCols []bool
+	width    int
+	current  sql.RowIter
+}
+
+var _ sql.RowIter = (*allTablesDiffRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (itr *allTablesDiffRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		if itr.current == nil {
+			if len(itr.diffs) == 0 {
+				return nil, io.EOF
+			}
+			iter, err := itr.diffs[0].dtf.RowIter(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			itr.current = iter
+		}
+
+		r, err := itr.current.Next(ctx)
+		if err == io.EOF {
+			err = itr.current.Close(ctx)
+			itr.current = nil
+			itr.diffs = itr.diffs[1:]
+			if err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		td := itr.diffs[0]
+		row := make(sql.Row, itr.width)
+		row[0] = td.tableName
+		for i, v := range r {
+			idx := td.colIdxs[i]
+			if itr.textCols[idx] && v != nil {
+				v, _, err = gmstypes.LongText.Convert(v)
+				if err != nil {
+					return nil, err
+				}
+			}
+			row[idx] = v
+		}
+		return row, nil
+	}
+}
+
+// Close implements the sql.RowIter interface
+func (itr *allTablesDiffRowIter) Close(ctx *sql.Context) error {
+	if itr.current != nil {
+		return itr.current.Close(ctx)
+	}
+	return nil
+}
+
+// diffStatements generates the SQL statement that applies each change of a table's diff, for the --as-sql option
+type diffStatements struct {
+	splitter    *diff.DiffSplitter
+	projections []sql.Expression
+	diffTypeIdx int
+	tableName   string
+	sch         schema.Schema
+}
+
+// newDiffStatements returns the diffStatements for the rows of |diffSch|, the diff schema of |delta|. Returns an error
+// if the table's schema changed, since the statements can only apply changes to rows of the same schema.
+func newDiffStatements(diffSch sql.Schema, delta diff.TableDelta) (*diffStatements, error) {
+	tableName, sch := delta.ToName, delta.ToSch
+	if sch == nil {
+		tableName, sch = delta.FromName, delta.FromSch
+	} else if delta.FromSch != nil && !schema.SchemasAreEqual(delta.FromSch, delta.ToSch) {
+		return nil, fmt.Errorf("--%s requires the schema of %s to be the same at both revisions", diffAsSqlFlag, tableName)
+	}
+
+	targetSch, err := sqlutil.FromDoltSchema(tableName, sch)
+	if err != nil {
+		return nil, err
+	}
+	querySch, projections := getDiffQuerySqlSchemaAndProjections(diffSch, getColumnNamesWithDiff(delta.FromSch, delta.ToSch))
+	splitter, err := diff.NewDiffSplitter(querySch, targetSch.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diffStatements{
+		splitter:    splitter,
+		projections: projections,
+		diffTypeIdx: diffSch.IndexOfColName("diff_type"),
+		tableName:   tableName,
+		sch:         sch,
+	}, nil
+}
+
+// statementDiffRowIter appends the SQL statement that applies each change to the rows of a diff, used for the --as-sql
+// option. Context rows have a NULL statement.
+type statementDiffRowIter struct {
+	child      sql.RowIter
+	statements *diffStatements
+}
+
+var _ sql.RowIter = (*statementDiffRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (itr *statementDiffRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.child.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r[itr.statements.diffTypeIdx] == diffTypeContext {
+		return append(r.Copy(), nil), nil
+	}
+
+	stmt, err := diffRowStatement(ctx, itr.statements.splitter, itr.statements.projections, r, itr.statements.tableName, itr.statements.sch)
+	if err != nil {
+		return nil, err
+	}
+	return append(r.Copy(), stmt), nil
+}
+
+// Close implements the sql.RowIter interface
+func (itr *statementDiffRowIter) Close(ctx *sql.Context) error {
+	return itr.child.Close(ctx)
+}
+
+// enumLabelProjection returns the schema and row projection used to output enum and set columns as their labels,
+// rather than the numeric values they're stored as. The projection is nil if there are no enum or set columns.
+func enumLabelProjection(diffSch sql.Schema) (sql.Schema, func(sql.Row) sql.Row) {
+	var idxs []int
+	projectedSch := make(sql.Schema, len(diffSch))
+	for i, col := range diffSch {
+		projectedSch[i] = col
+		switch t := col.Type.(type) {
+		case sql.EnumType:
+			labelCol := *col
+			labelCol.Type = gmstypes.CreateLongText(t.Collation())
+			projectedSch[i] = &labelCol
+			idxs = append(idxs, i)
+		case sql.SetType:
+			labelCol := *col
+			labelCol.Type = gmstypes.CreateLongText(t.Collation())
+			projectedSch[i] = &labelCol
+			idxs = append(idxs, i)
+		}
+	}
+
+	if len(idxs) == 0 {
+		return diffSch, nil
+	}
+
+	projection := func(r sql.Row) sql.Row {
+		projected := r.Copy()
+		for _, idx := range idxs {
+			projected[idx] = enumOrSetLabel(diffSch[idx].Type, r[idx])
+		}
+		return projected
+	}
+	return projectedSch, projection
+}
+
+// enumOrSetLabel returns the label of the enum or set value given, or the value itself if it has no label
+func enumOrSetLabel(typ sql.Type, val interface{}) interface{} {
+	if val == nil {
+		return nil
+	}
+
+	ordinal, _, err := gmstypes.Uint64.Convert(val)
+	if err != nil {
+		return val
+	}
+
+	switch t := typ.(type) {
+	case sql.EnumType:
+		if label, ok := t.At(int(ordinal.(uint64))); ok {
+			return label
+		}
+	case sql.SetType:
+		if label, err := t.BitsToString(ordinal.(uint64)); err == nil {
+			return label
+		}
+	}
+	return val
+}
+
+// blobSummaryProjection returns the schema and row projection that output the binary and blob columns of a diff as
+// a summary of their length and hash, so that large values aren't output in full. Returns a nil projection if the diff
+// has no binary columns.
+func blobSummaryProjection(diffSch sql.Schema) (sql.Schema, func(sql.Row) sql.Row) {
+	var idxs []int
+	projectedSch := make(sql.Schema, len(diffSch))
+	for i, col := range diffSch {
+		projectedSch[i] = col
+		if gmstypes.IsBinaryType(col.Type) {
+			summaryCol := *col
+			summaryCol.Type = gmstypes.LongText
+			projectedSch[i] = &summaryCol
+			idxs = append(idxs, i)
+		}
+	}
+
+	if len(idxs) == 0 {
+		return diffSch, nil
+	}
+
+	projection := func(r sql.Row) sql.Row {
+		projected := r.Copy()
+		for _, idx := range idxs {
+			projected[idx] = blobSummary(r[idx])
+		}
+		return projected
+	}
+	return projectedSch, projection
+}
+
+// blobSummary returns the length and hash of the binary value given, like "5 bytes, <hash>"
+func blobSummary(val interface{}) interface{} {
+	var b []byte
+	switch v := val.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return val
+	}
+	return fmt.Sprintf("%d bytes, %s", len(b), hash.Of(b).String())
+}
+
+// keysOnlyProjection returns the schema and row projection used for the --keys-only option. Rows of tables with a
+// primary key are projected to their to and from primary key columns and the diff type. Keyless tables have no key to
+// project, so their rows are projected to a hash of the row's values and the diff type instead.
+func keysOnlyProjection(diffSch sql.Schema, delta diff.TableDelta) (sql.Schema, func(sql.Row) sql.Row) {
+	sch := delta.ToSch
+	if sch == nil {
+		sch = delta.FromSch
+	}
+	diffTypeIdx := diffSch.IndexOfColName("diff_type")
+
+	if schema.IsKeyless(sch) {
+		toIdxs := diffColumnIndexes(diffSch, sch.GetAllCols().GetColumnNames(), diff.ToColNamer)
+		fromIdxs := diffColumnIndexes(diffSch, sch.GetAllCols().GetColumnNames(), diff.FromColNamer)
+
+		projectedSch := sql.Schema{
+			&sql.Column{Name: diffRowHashColName, Type: gmstypes.Text, Nullable: false},
+			diffSch[diffTypeIdx],
+		}
+		projection := func(r sql.Row) sql.Row {
+			// keyless rows are only ever added or removed, since a row's identity is its values
+			idxs := toIdxs
+			if r[diffTypeIdx] == "removed" {
+				idxs = fromIdxs
+			}
+			return sql.Row{keylessRowHash(r, idxs), r[diffTypeIdx]}
+		}
+		return projectedSch, projection
+	}
+
+	pkNames := sch.GetPKCols().GetColumnNames()
+	idxs := append(diffColumnIndexes(diffSch, pkNames, diff.ToColNamer), diffColumnIndexes(diffSch, pkNames, diff.FromColNamer)...)
+	idxs = append(idxs, diffTypeIdx)
+
+	projectedSch := make(sql.Schema, len(idxs))
+	for i, idx := range idxs {
+		projectedSch[i] = diffSch[idx]
+	}
+	projection := func(r sql.Row) sql.Row {
+		projected := make(sql.Row, len(idxs))
+		for i, idx := range idxs {
+			projected[i] = r[idx]
+		}
+		return projected
+	}
+	return projectedSch, projection
+}
+
+// toOnlyProjection returns the schema and row projection used for the --to-only option. Rows are projected to the
+// table's columns as of the to revision, named as in the table, and the diff type. Removed rows have no to values, so
+// they're projected to their from primary key columns, with every other column NULL. Keyless tables have no key to
+// identify removed rows by, so their removed rows keep all of their from values.
+func toOnlyProjection(diffSch sql.Schema, delta diff.TableDelta) (sql.Schema, func(sql.Row) sql.Row) {
+	sch := delta.ToSch
+	if sch == nil {
+		sch = delta.FromSch
+	}
+	diffTypeIdx := diffSch.IndexOfColName("diff_type")
+	keyless := schema.IsKeyless(sch)
+
+	cols := sch.GetAllCols().GetColumns()
+	toIdxs := make([]int, len(cols))
+	fromIdxs := make([]int, len(cols))
+	projectedSch := make(sql.Schema, 0, len(cols)+1)
+	for i, col := range cols {
+		toIdxs[i] = diffSch.IndexOfColName(diff.ToColNamer(col.Name))
+		fromIdxs[i] = -1
+		if keyless || col.IsPartOfPK {
+			fromIdxs[i] = diffSch.IndexOfColName(diff.FromColNamer(col.Name))
+		}
+
+		idx := toIdxs[i]
+		if idx < 0 {
+			idx = diffSch.IndexOfColName(diff.FromColNamer(col.Name))
+		}
+		projectedCol := *diffSch[idx]
+		projectedCol.Name = col.Name
+		projectedSch = append(projectedSch, &projectedCol)
+	}
+	projectedSch = append(projectedSch, diffSch[diffTypeIdx])
+
+	projection := func(r sql.Row) sql.Row {
+		idxs := toIdxs
+		if r[diffTypeIdx] == "removed" {
+			idxs = fromIdxs
+		}
+		projected := make(sql.Row, len(idxs)+1)
+		for i, idx := range idxs {
+			if idx >= 0 {
+				projected[i] = r[idx]
+			}
+		}
+		projected[len(idxs)] = r[diffTypeIdx]
+		return projected
+	}
+	return projectedSch, projection
+}
+
+// diffColumnIndexes returns the indexes in |diffSch| of the diff columns for the table columns named. Columns not
+// present in |diffSch| are skipped.
+func diffColumnIndexes(diffSch sql.Schema, colNames []string, namer func(string) string) []int {
+	var idxs []int
+	for _, name := range colNames {
+		if idx := diffSch.IndexOfColName(namer(name)); idx >= 0 {
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs
+}
+
+// keylessRowHash returns a hash of the values at |idxs| in the row given, identifying a keyless row by its contents
+func keylessRowHash(r sql.Row, idxs []int) string {
+	var sb strings.Builder
+	for _, idx := range idxs {
+		fmt.Fprintf(&sb, "%T:%v\x00", r[idx], r[idx])
+	}
+	return hash.Of([]byte(sb.String())).String()
+}
+
+// jsonPatchColumns are the JSON columns diffed as patches with the --json-diff option
+type jsonPatchColumns struct {
+	// toIdxs and fromIdxs are the indexes of the to and from columns of each JSON column
+	toIdxs, fromIdxs []int
+	diffTypeIdx      int
+}
+
+// jsonPatchSchema returns the schema used for the --json-diff option, which adds a <column>_patch column after the
+// diff type for each JSON column in both the from and to schemas of the table, along with those columns' indexes in
+// |diffSch|. The columns are nil if the table has no such JSON columns.
+func jsonPatchSchema(diffSch sql.Schema, delta diff.TableDelta) (sql.Schema, *jsonPatchColumns) {
+	if delta.FromSch == nil || delta.ToSch == nil {
+		return diffSch, nil
+	}
+
+	cols := &jsonPatchColumns{diffTypeIdx: diffSch.IndexOfColName("diff_type")}
+	var patchSch sql.Schema
+	for _, col := range delta.ToSch.GetAllCols().GetColumns() {
+		if _, ok := delta.FromSch.GetAllCols().GetByName(col.Name); !ok {
+			continue
+		}
+		toIdx := diffSch.IndexOfColName(diff.ToColNamer(col.Name))
+		fromIdx := diffSch.IndexOfColName(diff.FromColNamer(col.Name))
+		if toIdx < 0 || fromIdx < 0 || !gmstypes.IsJSON(diffSch[toIdx].Type) || !gmstypes.IsJSON(diffSch[fromIdx].Type) {
+			continue
+		}
+		cols.toIdxs = append(cols.toIdxs, toIdx)
+		cols.fromIdxs = append(cols.fromIdxs, fromIdx)
+		patchSch = append(patchSch, &sql.Column{Name: col.Name + diffPatchColSuffix, Type: gmstypes.JSON, Nullable: true})
+	}
+
+	if len(patchSch) == 0 {
+		return diffSch, nil
+	}
+	return append(diffSch.Copy(), patchSch...), cols
+}
+
+// jsonPatchDiffRowIter replaces the from and to documents of the JSON columns of modified rows with a JSON patch of
+// the changes between them, used for the --json-diff option. Added and removed rows, and modified rows where either
+// document is NULL, keep their documents and have a NULL patch.
+type jsonPatchDiffRowIter struct {
+	child sql.RowIter
+	cols  *jsonPatchColumns
+}
+
+var _ sql.RowIter = (*jsonPatchDiffRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (itr *jsonPatchDiffRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.child.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := make(sql.Row, len(r), len(r)+len(itr.cols.toIdxs))
+	copy(patched, r)
+	modified := r[itr.cols.diffTypeIdx] == "modified"
+	for i, toIdx := range itr.cols.toIdxs {
+		fromIdx := itr.cols.fromIdxs[i]
+		if !modified || r[toIdx] == nil || r[fromIdx] == nil {
+			patched = append(patched, nil)
+			continue
+		}
+
+		from, err := unmarshalJsonValue(ctx, r[fromIdx])
+		if err != nil {
+			return nil, err
+		}
+		to, err := unmarshalJsonValue(ctx, r[toIdx])
+		if err != nil {
+			return nil, err
+		}
+
+		patched[toIdx], patched[fromIdx] = nil, nil
+		patched = append(patched, gmstypes.JSONDocument{Val: diff.JsonPatch(from, to)})
+	}
+	return patched, nil
+}
+
+// Close implements the sql.RowIter interface
+func (itr *jsonPatchDiffRowIter) Close(ctx *sql.Context) error {
+	return itr.child.Close(ctx)
+}
+
+// unmarshalJsonValue returns the JSON value of a JSON column as a Go value
+func unmarshalJsonValue(ctx *sql.Context, v interface{}) (interface{}, error) {
+	converted, _, err := gmstypes.JSON.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	jsonVal, ok := converted.(gmstypes.JSONValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for JSON value: %T", converted)
+	}
+	doc, err := jsonVal.Unmarshall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Val, nil
+}
+
+// withContextRows returns the rows of |diffIter|, which must be in primary key order, along with up to |dtf.context|
+// unchanged rows of the to table before and after each changed row. Context rows have the same to and from values,
+// and a diff type of context. Only the rows around each change are read, by their ordinal position in the table.
+func (dtf *DiffTableFunction) withContextRows(ctx *sql.Context, diffIter sql.RowIter) (sql.RowIter, error) {
+	diffRows, err := sql.RowIterToRows(ctx, nil, diffIter)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffRows) == 0 || dtf.tableDelta.ToTable == nil {
+		return sql.RowsToRowIter(diffRows...), nil
+	}
+
+	rowData, err := dtf.tableDelta.ToTable.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.ProllyMapFromIndex(rowData)
+	ns := m.NodeStore()
+	kd, vd := m.Descriptors()
+	pkCols := dtf.tableDelta.ToSch.GetPKCols().GetColumns()
+	nonPkCols := dtf.tableDelta.ToSch.GetNonPKCols().GetColumns()
+	diffTypeIdx := dtf.diffSch.IndexOfColName("diff_type")
+
+	count, err := m.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	diffKeys := make([]val.Tuple, len(diffRows))
+	for i, r := range diffRows {
+		diffKeys[i], err = dtf.diffRowKey(ctx, r, r[diffTypeIdx] == "removed", pkCols, kd, ns)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Stable(diffRowsByKey{rows: diffRows, keys: diffKeys, kd: kd})
+
+	// Find the ordinals of the unchanged rows around each changed row
+	changed := make(map[uint64]struct{})
+	var ordinals []uint64
+	for i, r := range diffRows {
+		removed := r[diffTypeIdx] == "removed"
+
+		// The ordinal of the row's key, or of the row after it if it was removed
+		ord, err := m.GetOrdinalForKey(ctx, diffKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		after := ord
+		if !removed {
+			changed[ord] = struct{}{}
+			after++
+		}
+
+		start := uint64(0)
+		if ord > uint64(dtf.context) {
+			start = ord - uint64(dtf.context)
+		}
+		for o := start; o < ord; o++ {
+			ordinals = append(ordinals, o)
+		}
+		for o := after; o < after+uint64(dtf.context) && o < uint64(count); o++ {
+			ordinals = append(ordinals, o)
+		}
+	}
+
+	sort.Slice(ordinals, func(i, j int) bool {
+		return ordinals[i] < ordinals[j]
+	})
+
+	var contextRows []sql.Row
+	var contextKeys []val.Tuple
+	for i := 0; i < len(ordinals); {
+		// Read each run of consecutive ordinals with a single range iterator
+		j := i + 1
+		for j < len(ordinals) && ordinals[j] <= ordinals[j-1]+1 {
+			j++
+		}
+
+		iter, err := m.IterOrdinalRange(ctx, ordinals[i], ordinals[j-1]+1)
+		if err != nil {
+			return nil, err
+		}
+		for o := ordinals[i]; o <= ordinals[j-1]; o++ {
+			k, v, err := iter.Next(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := changed[o]; ok {
+				continue
+			}
+
+			r, err := dtf.contextRow(ctx, diffRows[0], pkCols, nonPkCols, kd, vd, k, v, ns)
+			if err != nil {
+				return nil, err
+			}
+			contextRows = append(contextRows, r)
+			contextKeys = append(contextKeys, k)
+		}
+		i = j
+	}
+
+	rows := make([]sql.Row, 0, len(diffRows)+len(contextRows))
+	for i, j := 0, 0; i < len(diffRows) || j < len(contextRows); {
+		if j == len(contextRows) || (i < len(diffRows) && kd.Compare(diffKeys[i], contextKeys[j]) < 0) {
+			rows = append(rows, diffRows[i])
+			i++
+		} else {
+			rows = append(rows, contextRows[j])
+			j++
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// diffRowsByKey sorts diff rows by their primary keys
+type diffRowsByKey struct {
+	rows []sql.Row
+	keys []val.Tuple
+	kd   val.TupleDesc
+}
+
+func (d diffRowsByKey) Len() int {
+	return len(d.rows)
+}
+
+func (d diffRowsByKey) Less(i, j int) bool {
+	return d.kd.Compare(d.keys[i], d.keys[j]) < 0
+}
+
+func (d diffRowsByKey) Swap(i, j int) {
+	d.rows[i], d.rows[j] = d.rows[j], d.rows[i]
+	d.keys[i], d.keys[j] = d.keys[j], d.keys[i]
+}
+
+// diffRowKey returns the primary key of the diff row given as a key of the to table: its to primary key columns, or
+// its from primary key columns if it was removed.
+func (dtf *DiffTableFunction) diffRowKey(ctx *sql.Context, r sql.Row, removed bool, pkCols []schema.Column, kd val.TupleDesc, ns tree.NodeStore) (val.Tuple, error) {
+	namer := diff.ToColNamer
+	if removed {
+		namer = diff.FromColNamer
+	}
+
+	tb := val.NewTupleBuilder(kd)
+	for i, col := range pkCols {
+		var v interface{}
+		if idx := dtf.diffSch.IndexOfColName(namer(col.Name)); idx >= 0 {
+			var err error
+			v, _, err = col.TypeInfo.ToSqlType().Convert(r[idx])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := index.PutField(ctx, ns, tb, i, v); err != nil {
+			return nil, err
+		}
+	}
+	return tb.Build(ns.Pool()), nil
+}
+
+// contextRow returns the diff row for the unchanged row of the to table with key |k| and value |v|. Its to and from
+// columns have the row's values, and its commit columns are taken from |diffRow|.
+func (dtf *DiffTableFunction) contextRow(ctx *sql.Context, diffRow sql.Row, pkCols, nonPkCols []schema.Column, kd, vd val.TupleDesc, k, v val.Tuple, ns tree.NodeStore) (sql.Row, error) {
+	r := make(sql.Row, len(dtf.diffSch))
+	for _, colName := range []string{"to_commit", "to_commit_date", "from_commit", "from_commit_date"} {
+		if idx := dtf.diffSch.IndexOfColName(colName); idx >= 0 {
+			r[idx] = diffRow[idx]
+		}
+	}
+	r[dtf.diffSch.IndexOfColName("diff_type")] = diffTypeContext
+
+	setField := func(colName string, desc val.TupleDesc, i int, tup val.Tuple) error {
+		field, err := index.GetField(ctx, desc, i, tup, ns)
+		if err != nil {
+			return err
+		}
+		for _, namer := range []func(string) string{diff.ToColNamer, diff.FromColNamer} {
+			idx := dtf.diffSch.IndexOfColName(namer(colName))
+			if idx < 0 {
+				continue
+			}
+			r[idx], _, err = dtf.diffSch[idx].Type.Convert(field)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, col := range pkCols {
+		if err := setField(col.Name, kd, i, k); err != nil {
+			return nil, err
+		}
+	}
+	for i, col := range nonPkCols {
+		if err := setField(col.Name, vd, i, v); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// cacheTableDelta caches and returns an appropriate table delta for the table name given, taking renames into
+// consideration. Returns a sql.ErrTableNotFound if the given table name cannot be found in either revision.
+func (dtf *DiffTableFunction) cacheTableDelta(ctx *sql.Context, fromCommitVal, toCommitVal, dotCommitVal interface{}, tableName string, db dsess.SqlDatabase) (diff.TableDelta, error) {
+	fromRefDetails, toRefDetails, err := dtf.loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, db)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+
+	fromTable, _, fromTableExists, err := fromRefDetails.root.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+
+	toTable, _, toTableExists, err := toRefDetails.root.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+
+	if !fromTableExists && !toTableExists {
+		return diff.TableDelta{}, sql.ErrTableNotFound.New(tableName)
+	}
+
+	// TODO: it would be nice to limit this to just the table under consideration, not all tables with a diff
+	deltas, err := diff.GetTableDeltas(ctx, fromRefDetails.root, toRefDetails.root)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+
+	dtf.fromDate = fromRefDetails.commitTime
+	dtf.toDate = toRefDetails.commitTime
+
+	delta := findMatchingDelta(deltas, tableName)
+
+	// We only get a delta if there's a diff. When there isn't one, construct a delta here with table and schema info
+	if delta.FromTable == nil && delta.ToTable == nil {
+		delta.FromName = tableName
+		delta.ToName = tableName
+		delta.FromTable = fromTable
+		delta.ToTable = toTable
+
+		if fromTable != nil {
+			sch, err := fromTable.GetSchema(ctx)
+			if err != nil {
 				return diff.TableDelta{}, err
 			}
 			delta.FromSch = sch
@@ -532,23 +1898,30 @@ func (dtf *DiffTableFunction) Schema() sql.Schema {
 
 // Resolved implements the sql.Resolvable interface
 func (dtf *DiffTableFunction) Resolved() bool {
+	if dtf.tableNameExpr != nil && !dtf.tableNameExpr.Resolved() {
+		return false
+	}
 	if dtf.dotCommitExpr != nil {
-		return dtf.tableNameExpr.Resolved() && dtf.dotCommitExpr.Resolved()
+		return dtf.dotCommitExpr.Resolved()
 	}
-	return dtf.tableNameExpr.Resolved() && dtf.fromCommitExpr.Resolved() && dtf.toCommitExpr.Resolved()
+	return dtf.fromCommitExpr.Resolved() && dtf.toCommitExpr.Resolved()
 }
 
 // String implements the Stringer interface
 func (dtf *DiffTableFunction) String() string {
+	args := make([]string, 0, 3+len(dtf.optionExprs))
 	if dtf.dotCommitExpr != nil {
-		return fmt.Sprintf("DOLT_DIFF(%s, %s)",
-			dtf.dotCommitExpr.String(),
-			dtf.tableNameExpr.String())
-	}
-	return fmt.Sprintf("DOLT_DIFF(%s, %s, %s)",
-		dtf.fromCommitExpr.String(),
-		dtf.toCommitExpr.String(),
-		dtf.tableNameExpr.String())
+		args = append(args, dtf.dotCommitExpr.String())
+	} else if !dtf.upstream && dtf.mergeParent == "" {
+		args = append(args, dtf.fromCommitExpr.String(), dtf.toCommitExpr.String())
+	}
+	if dtf.tableNameExpr != nil {
+		args = append(args, dtf.tableNameExpr.String())
+	}
+	for _, expr := range dtf.optionExprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_DIFF(%s)", strings.Join(args, ", "))
 }
 
 // Name implements the sql.TableFunction interface
@@ -0,0 +1,242 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+)
+
+var _ sql.TableFunction = (*IndexDiffTableFunction)(nil)
+var _ sql.ExecSourceRel = (*IndexDiffTableFunction)(nil)
+
+// IndexDiffTableFunction lists the indexes of a table that were added, dropped or modified between two refs, e.g.
+// dolt_index_diff('main~', 'main', 't'). Indexes are compared by their definitions as shown by SHOW CREATE TABLE,
+// including the table's primary key, which is named PRIMARY.
+type IndexDiffTableFunction struct {
+	ctx *sql.Context
+
+	fromRefExpr   sql.Expression
+	toRefExpr     sql.Expression
+	tableNameExpr sql.Expression
+	database      sql.Database
+}
+
+var indexDiffTableSchema = sql.Schema{
+	&sql.Column{Name: "index_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "change_type", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "from_definition", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "to_definition", Type: types.LongText, Nullable: true},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (idf *IndexDiffTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &IndexDiffTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (idf *IndexDiffTableFunction) Database() sql.Database {
+	return idf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (idf *IndexDiffTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nidf := *idf
+	nidf.database = database
+	return &nidf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (idf *IndexDiffTableFunction) Name() string {
+	return "dolt_index_diff"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (idf *IndexDiffTableFunction) Resolved() bool {
+	return idf.fromRefExpr.Resolved() && idf.toRefExpr.Resolved() && idf.tableNameExpr.Resolved()
+}
+
+// String implements the Stringer interface
+func (idf *IndexDiffTableFunction) String() string {
+	return fmt.Sprintf("DOLT_INDEX_DIFF(%s, %s, %s)", idf.fromRefExpr.String(), idf.toRefExpr.String(), idf.tableNameExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (idf *IndexDiffTableFunction) Schema() sql.Schema {
+	return indexDiffTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (idf *IndexDiffTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (idf *IndexDiffTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return idf, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (idf *IndexDiffTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tableName, err := expressionToString(ctx, idf.tableNameExpr)
+	if err != nil {
+		return false
+	}
+
+	return opChecker.UserHasPrivileges(ctx,
+		sql.NewPrivilegedOperation(idf.database.Name(), tableName, "", sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (idf *IndexDiffTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{idf.fromRefExpr, idf.toRefExpr, idf.tableNameExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (idf *IndexDiffTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(idf.Name(), 3, len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(idf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(idf.Name(), expr.String())
+		}
+		if !types.IsText(expr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(idf.Name(), expr.String())
+		}
+	}
+
+	nidf := *idf
+	nidf.fromRefExpr = expression[0]
+	nidf.toRefExpr = expression[1]
+	nidf.tableNameExpr = expression[2]
+
+	return &nidf, nil
+}
+
+// RowIter implements the sql.Node interface
+func (idf *IndexDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	fromRef, err := expressionToString(ctx, idf.fromRefExpr)
+	if err != nil {
+		return nil, err
+	}
+	toRef, err := expressionToString(ctx, idf.toRefExpr)
+	if err != nil {
+		return nil, err
+	}
+	tableName, err := expressionToString(ctx, idf.tableNameExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb, ok := idf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", idf.database)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	fromRoot, _, _, err := sess.ResolveRootForRef(ctx, sqledb.Name(), fromRef)
+	if err != nil {
+		return nil, err
+	}
+	toRoot, _, _, err := sess.ResolveRootForRef(ctx, sqledb.Name(), toRef)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDefs, fromOk, err := indexDefinitionsAtRoot(ctx, fromRoot, tableName)
+	if err != nil {
+		return nil, err
+	}
+	toDefs, toOk, err := indexDefinitionsAtRoot(ctx, toRoot, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if !fromOk && !toOk {
+		return nil, sql.ErrTableNotFound.New(tableName)
+	}
+
+	var rows []sql.Row
+	for name, fromDef := range fromDefs {
+		toDef, ok := toDefs[name]
+		if !ok {
+			rows = append(rows, sql.Row{name, "dropped", fromDef, nil})
+		} else if fromDef != toDef {
+			rows = append(rows, sql.Row{name, "modified", fromDef, toDef})
+		}
+	}
+	for name, toDef := range toDefs {
+		if _, ok := fromDefs[name]; !ok {
+			rows = append(rows, sql.Row{name, "added", nil, toDef})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0].(string) < rows[j][0].(string)
+	})
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// indexDefinitionsAtRoot returns the definitions of the primary key and indexes of the table named in |root|, keyed by
+// index name, and whether the table exists.
+func indexDefinitionsAtRoot(ctx *sql.Context, root *doltdb.RootValue, tableName string) (map[string]string, bool, error) {
+	table, _, ok, err := root.GetTableInsensitive(ctx, tableName)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defs := make(map[string]string)
+	if pkCols := sch.GetPKCols(); pkCols.Size() > 0 && !schema.IsKeyless(sch) {
+		defs["PRIMARY"] = fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(sql.QuoteIdentifiers(pkCols.GetColumnNames()), ","))
+	}
+	for _, idx := range sch.Indexes().AllIndexes() {
+		defs[idx.Name()] = strings.TrimSpace(sqlfmt.GenerateCreateTableIndexDefinition(idx))
+	}
+
+	return defs, true, nil
+}
@@ -15,34 +15,88 @@
 package sqle
 
 import (
+	goerrors "errors"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
+const (
+	logGroupByDayFlag           = "group-by-day"
+	logShowRootFlag             = "show-root"
+	logContainedInFlag          = "contained-in"
+	logAllFlag                  = "all"
+	logSimplifyByDecorationFlag = "simplify-by-decoration"
+	logAuthorFlag               = "author"
+	logCommitterFlag            = "committer"
+	logSinceFlag                = "since"
+	logUntilFlag                = "until"
+	logMaxCountFlag             = "max-count"
+	logGrepFlag                 = "grep"
+	logTablesFlag               = "tables"
+	logChangeTypeFlag           = "change-type"
+	logCherryMarkFlag           = "cherry-mark"
+	logCherryFlag               = "cherry"
+	logAbbrevFlag               = "abbrev"
+)
+
+// logChangeTypes are the values --change-type accepts, which are the same as the diff_type column of the diff tables
+var logChangeTypes = []string{"added", "modified", "removed"}
+
+// minLogAbbrev is the shortest abbreviation of commit hashes allowed by --abbrev, like git's
+const minLogAbbrev = 4
+
 var _ sql.TableFunction = (*LogTableFunction)(nil)
 var _ sql.ExecSourceRel = (*LogTableFunction)(nil)
 
 type LogTableFunction struct {
 	ctx *sql.Context
 
-	revisionExpr       sql.Expression
-	secondRevisionExpr sql.Expression
-
-	notRevision string
-	minParents  int
-	showParents bool
-	decoration  string
+	revisionExprs []sql.Expression
+	optionExprs   []sql.Expression
+
+	notRevisions         []string
+	minParents           int
+	maxParents           int
+	showParents          bool
+	decoration           string
+	dataOnly             bool
+	schemaOnly           bool
+	groupByDay           bool
+	showRoot             bool
+	containedIn          string
+	all                  bool
+	simplifyByDecoration bool
+	author               string
+	committer            string
+	since                string
+	until                string
+	sinceTime            time.Time
+	untilTime            time.Time
+	maxCount             int
+	grep                 string
+	tables               []string
+	changeType           string
+	cherryMark           bool
+	cherry               bool
+	abbrev               int
 
 	database sql.Database
 }
@@ -53,6 +107,13 @@ var logTableSchema = sql.Schema{
 	&sql.Column{Name: "email", Type: types.Text},
 	&sql.Column{Name: "date", Type: types.Datetime},
 	&sql.Column{Name: "message", Type: types.Text},
+	&sql.Column{Name: "commit_order", Type: types.Uint64},
+	&sql.Column{Name: "author_date", Type: types.Datetime},
+}
+
+var logGroupByDaySchema = sql.Schema{
+	&sql.Column{Name: "date", Type: types.Date},
+	&sql.Column{Name: "commit_count", Type: types.Int64},
 }
 
 // NewInstance creates a new instance of TableFunction interface
@@ -89,11 +150,10 @@ func (ltf *LogTableFunction) Name() string {
 
 // Resolved implements the sql.Resolvable interface
 func (ltf *LogTableFunction) Resolved() bool {
-	if ltf.revisionExpr != nil && ltf.secondRevisionExpr != nil {
-		return ltf.revisionExpr.Resolved() && ltf.secondRevisionExpr.Resolved()
-	}
-	if ltf.revisionExpr != nil {
-		return ltf.revisionExpr.Resolved()
+	for _, expr := range ltf.revisionExprs {
+		if !expr.Resolved() {
+			return false
+		}
 	}
 	return true
 }
@@ -106,22 +166,22 @@ func (ltf *LogTableFunction) String() string {
 func (ltf *LogTableFunction) getOptionsString() string {
 	var options []string
 
-	if ltf.revisionExpr != nil {
-		options = append(options, ltf.revisionExpr.String())
+	for _, expr := range ltf.revisionExprs {
+		options = append(options, expr.String())
 	}
 
-	if ltf.secondRevisionExpr != nil {
-		options = append(options, ltf.secondRevisionExpr.String())
-	}
-
-	if len(ltf.notRevision) > 0 {
-		options = append(options, fmt.Sprintf("--%s %s", cli.NotFlag, ltf.notRevision))
+	if len(ltf.notRevisions) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", cli.NotFlag, strings.Join(ltf.notRevisions, " ")))
 	}
 
 	if ltf.minParents > 0 {
 		options = append(options, fmt.Sprintf("--%s %d", cli.MinParentsFlag, ltf.minParents))
 	}
 
+	if ltf.maxParents >= 0 {
+		options = append(options, fmt.Sprintf("--%s %d", cli.MaxParentsFlag, ltf.maxParents))
+	}
+
 	if ltf.showParents {
 		options = append(options, fmt.Sprintf("--%s", cli.ParentsFlag))
 	}
@@ -130,19 +190,102 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, fmt.Sprintf("--%s %s", cli.DecorateFlag, ltf.decoration))
 	}
 
+	if ltf.dataOnly {
+		options = append(options, fmt.Sprintf("--%s", cli.DataOnlyFlag))
+	}
+
+	if ltf.schemaOnly {
+		options = append(options, fmt.Sprintf("--%s", cli.SchemaOnlyFlag))
+	}
+
+	if ltf.groupByDay {
+		options = append(options, fmt.Sprintf("--%s", logGroupByDayFlag))
+	}
+
+	if ltf.showRoot {
+		options = append(options, fmt.Sprintf("--%s", logShowRootFlag))
+	}
+
+	if len(ltf.containedIn) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logContainedInFlag, ltf.containedIn))
+	}
+
+	if ltf.all {
+		options = append(options, fmt.Sprintf("--%s", logAllFlag))
+	}
+
+	if ltf.simplifyByDecoration {
+		options = append(options, fmt.Sprintf("--%s", logSimplifyByDecorationFlag))
+	}
+
+	if len(ltf.author) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logAuthorFlag, ltf.author))
+	}
+
+	if len(ltf.committer) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logCommitterFlag, ltf.committer))
+	}
+
+	if len(ltf.since) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logSinceFlag, ltf.since))
+	}
+
+	if len(ltf.until) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logUntilFlag, ltf.until))
+	}
+
+	if ltf.maxCount >= 0 {
+		options = append(options, fmt.Sprintf("--%s %d", logMaxCountFlag, ltf.maxCount))
+	}
+
+	if len(ltf.grep) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logGrepFlag, ltf.grep))
+	}
+
+	if len(ltf.tables) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logTablesFlag, strings.Join(ltf.tables, ",")))
+	}
+
+	if len(ltf.changeType) > 0 {
+		options = append(options, fmt.Sprintf("--%s %s", logChangeTypeFlag, ltf.changeType))
+	}
+
+	if ltf.cherry {
+		options = append(options, fmt.Sprintf("--%s", logCherryFlag))
+	} else if ltf.cherryMark {
+		options = append(options, fmt.Sprintf("--%s", logCherryMarkFlag))
+	}
+
+	if ltf.abbrev > 0 {
+		options = append(options, fmt.Sprintf("--%s %d", logAbbrevFlag, ltf.abbrev))
+	}
+
 	return strings.Join(options, ", ")
 }
 
 // Schema implements the sql.Node interface.
 func (ltf *LogTableFunction) Schema() sql.Schema {
+	if ltf.groupByDay {
+		return logGroupByDaySchema
+	}
+
 	logSchema := logTableSchema
 
 	if ltf.showParents {
 		logSchema = append(logSchema, &sql.Column{Name: "parents", Type: types.Text})
 	}
+	if ltf.showRoot {
+		logSchema = append(logSchema, &sql.Column{Name: "root_hash", Type: types.Text})
+	}
 	if shouldDecorateWithRefs(ltf.decoration) {
 		logSchema = append(logSchema, &sql.Column{Name: "refs", Type: types.Text})
 	}
+	if len(ltf.containedIn) > 0 {
+		logSchema = append(logSchema, &sql.Column{Name: "in_" + ltf.containedIn, Type: types.Boolean})
+	}
+	if ltf.cherryMark {
+		logSchema = append(logSchema, &sql.Column{Name: "cherry", Type: types.Text})
+	}
 
 	return logSchema
 }
@@ -178,13 +321,8 @@ func (ltf *LogTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.Pri
 // Expressions implements the sql.Expressioner interface.
 func (ltf *LogTableFunction) Expressions() []sql.Expression {
 	exprs := []sql.Expression{}
-	if ltf.revisionExpr != nil {
-		exprs = append(exprs, ltf.revisionExpr)
-	}
-	if ltf.secondRevisionExpr != nil {
-		exprs = append(exprs, ltf.secondRevisionExpr)
-	}
-	return exprs
+	exprs = append(exprs, ltf.revisionExprs...)
+	return append(exprs, ltf.optionExprs...)
 }
 
 // getDoltArgs builds an argument string from sql expressions so that we can
@@ -215,19 +353,107 @@ func getDoltArgs(ctx *sql.Context, expressions []sql.Expression, name string) ([
 	return args, nil
 }
 
+// partitionOptionExpressions splits |exprs| into positional arguments and the options recognized by |ap|, along with
+// the values of any options that take them. Options are text literals beginning with "--", or with "-" followed by the
+// abbreviation of an option recognized by |ap|.
+func partitionOptionExpressions(ctx *sql.Context, ap *argparser.ArgParser, exprs []sql.Expression) (positional, options []sql.Expression, err error) {
+	optsByName := make(map[string]*argparser.Option)
+	for _, opt := range ap.Supported {
+		optsByName["--"+opt.Name] = opt
+		if len(opt.Abbrev) > 0 {
+			optsByName["-"+opt.Abbrev] = opt
+		}
+	}
+
+	for i := 0; i < len(exprs); i++ {
+		if !types.IsText(exprs[i].Type()) {
+			positional = append(positional, exprs[i])
+			continue
+		}
+
+		val, err := exprs[i].Eval(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		str, ok := val.(string)
+		if !ok {
+			positional = append(positional, exprs[i])
+			continue
+		}
+		opt, ok := optsByName[str]
+		if !ok && !strings.HasPrefix(str, "--") {
+			positional = append(positional, exprs[i])
+			continue
+		}
+
+		options = append(options, exprs[i])
+		if !ok || opt.OptType == argparser.OptionalFlag {
+			continue
+		}
+
+		for i+1 < len(exprs) {
+			i++
+			options = append(options, exprs[i])
+			// options allowing multiple values take every value up to the next option
+			if !opt.AllowMultipleOptions || startsNextOption(ctx, exprs[i+1:]) {
+				break
+			}
+		}
+	}
+
+	return positional, options, nil
+}
+
+// startsNextOption returns whether the first of |exprs|, if any, is an option rather than a value
+func startsNextOption(ctx *sql.Context, exprs []sql.Expression) bool {
+	if len(exprs) == 0 {
+		return true
+	}
+	if !types.IsText(exprs[0].Type()) {
+		return false
+	}
+	str, err := expressionToString(ctx, exprs[0])
+	return err == nil && strings.HasPrefix(str, "-")
+}
+
+// logTableFunctionArgParser returns the parser for the options accepted by dolt_log, which are those of dolt log along
+// with options only supported in SQL.
+func logTableFunctionArgParser() *argparser.ArgParser {
+	ap := cli.CreateLogArgParser()
+	ap.SupportsFlag(logGroupByDayFlag, "", "Return the number of commits on each day with at least one commit, instead of the commits.")
+	ap.SupportsFlag(logShowRootFlag, "", "Shows the hash of the root value of each commit. Commits with the same root hash as their parent changed nothing.")
+	ap.SupportsString(logContainedInFlag, "", "revision", "Adds a column, named in_ followed by the revision, that is true for each commit reachable from the revision given.")
+	ap.SupportsFlag(logAllFlag, "", "Includes the commits reachable from every branch, remote branch and tag, as if they were all given as revisions.")
+	ap.SupportsFlag(logSimplifyByDecorationFlag, "", "Limits the log to commits that a branch, remote branch or tag points to, for an outline of the history.")
+	ap.SupportsString(logAuthorFlag, "", "pattern", "Limits the log to commits whose committer name or email contains the text given, ignoring case.")
+	ap.SupportsString(logCommitterFlag, "", "pattern", "Same as --author, since a commit records a single name and email.")
+	ap.SupportsString(logSinceFlag, "", "date", "Limits the log to commits made at or after the date given.")
+	ap.SupportsString(logUntilFlag, "", "date", "Limits the log to commits made at or before the date given.")
+	ap.SupportsString(logGrepFlag, "", "pattern", "Limits the log to commits whose message contains the text given, ignoring case.")
+	ap.SupportsStringList(logTablesFlag, "", "table", "Limits the log to commits that changed at least one of the tables given, compared to their first parent.")
+	ap.SupportsString(logChangeTypeFlag, "", "type", "With --tables, limits the log to commits that added, modified or removed rows in at least one of the tables given, compared to their first parent. Must be one of added, modified or removed.")
+	ap.SupportsFlag(logCherryMarkFlag, "", "Adds a column, named cherry, that is = for each commit whose changes were also made by a commit on the other side of an a...b revision, and + for the others. Changes are compared by patch id, a hash of the rows and schemas a commit changed.")
+	ap.SupportsFlag(logCherryFlag, "", "Same as --cherry-mark, but limits the log to the commits on the right side of an a...b revision, excluding merge commits.")
+	ap.SupportsInt(logMaxCountFlag, "", "num_commits", "Limits the log to the first commits, stopping the walk of the commit graph once that many have been found. Same as --number or -n.")
+	ap.SupportsInt(logAbbrevFlag, "", "n", "Shows only the first n characters of the commit hashes in the commit_hash and parents columns, instead of the full hashes.")
+	return ap
+}
+
 func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 	args, err := getDoltArgs(ltf.ctx, expression, ltf.Name())
 	if err != nil {
 		return err
 	}
 
-	apr, err := cli.CreateLogArgParser().Parse(args)
+	apr, err := logTableFunctionArgParser().Parse(args)
 	if err != nil {
 		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), err.Error())
 	}
 
-	if notRevisionStr, ok := apr.GetValue(cli.NotFlag); ok {
-		ltf.notRevision = notRevisionStr
+	ltf.notRevisions = nil
+	if notRevisions, ok := apr.GetValueList(cli.NotFlag); ok {
+		ltf.notRevisions = notRevisions
 	}
 
 	minParents := apr.GetIntOrDefault(cli.MinParentsFlag, 0)
@@ -236,6 +462,7 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 	}
 
 	ltf.minParents = minParents
+	ltf.maxParents = apr.GetIntOrDefault(cli.MaxParentsFlag, -1)
 	ltf.showParents = apr.Contains(cli.ParentsFlag)
 
 	decorateOption := apr.GetValueOrDefault(cli.DecorateFlag, "auto")
@@ -246,6 +473,101 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 	}
 	ltf.decoration = decorateOption
 
+	ltf.dataOnly = apr.Contains(cli.DataOnlyFlag)
+	ltf.schemaOnly = apr.Contains(cli.SchemaOnlyFlag)
+	if ltf.dataOnly && ltf.schemaOnly {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("cannot use --%s with --%s", cli.DataOnlyFlag, cli.SchemaOnlyFlag))
+	}
+
+	ltf.groupByDay = apr.Contains(logGroupByDayFlag)
+	if ltf.groupByDay && ltf.showParents {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("cannot use --%s with --%s", logGroupByDayFlag, cli.ParentsFlag))
+	}
+	if ltf.groupByDay && shouldDecorateWithRefs(ltf.decoration) {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("cannot use --%s with --%s", logGroupByDayFlag, cli.DecorateFlag))
+	}
+
+	ltf.showRoot = apr.Contains(logShowRootFlag)
+	if ltf.groupByDay && ltf.showRoot {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("cannot use --%s with --%s", logGroupByDayFlag, logShowRootFlag))
+	}
+
+	ltf.containedIn = apr.GetValueOrDefault(logContainedInFlag, "")
+	if ltf.groupByDay && len(ltf.containedIn) > 0 {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("cannot use --%s with --%s", logGroupByDayFlag, logContainedInFlag))
+	}
+
+	ltf.all = apr.Contains(logAllFlag)
+	ltf.simplifyByDecoration = apr.Contains(logSimplifyByDecorationFlag)
+	ltf.author = apr.GetValueOrDefault(logAuthorFlag, "")
+	ltf.committer = apr.GetValueOrDefault(logCommitterFlag, "")
+
+	ltf.grep = apr.GetValueOrDefault(logGrepFlag, "")
+
+	ltf.tables = nil
+	if tables, ok := apr.GetValueList(logTablesFlag); ok {
+		ltf.tables = tables
+	}
+
+	ltf.changeType = strings.ToLower(apr.GetValueOrDefault(logChangeTypeFlag, ""))
+	if apr.Contains(logChangeTypeFlag) {
+		valid := false
+		for _, changeType := range logChangeTypes {
+			valid = valid || ltf.changeType == changeType
+		}
+		if !valid {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("invalid --%s value: %s, must be one of %s", logChangeTypeFlag, apr.MustGetValue(logChangeTypeFlag), strings.Join(logChangeTypes, ", ")))
+		}
+		if len(ltf.tables) == 0 {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("--%s requires --%s", logChangeTypeFlag, logTablesFlag))
+		}
+	}
+
+	ltf.since = apr.GetValueOrDefault(logSinceFlag, "")
+	ltf.sinceTime = time.Time{}
+	if len(ltf.since) > 0 {
+		ltf.sinceTime, err = cli.ParseDate(ltf.since)
+		if err != nil {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("invalid --%s date: %s", logSinceFlag, ltf.since))
+		}
+	}
+
+	ltf.until = apr.GetValueOrDefault(logUntilFlag, "")
+	ltf.untilTime = time.Time{}
+	if len(ltf.until) > 0 {
+		ltf.untilTime, err = cli.ParseDate(ltf.until)
+		if err != nil {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("invalid --%s date: %s", logUntilFlag, ltf.until))
+		}
+	}
+
+	ltf.cherry = apr.Contains(logCherryFlag)
+	ltf.cherryMark = ltf.cherry || apr.Contains(logCherryMarkFlag)
+	if ltf.groupByDay && ltf.cherryMark {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("cannot use --%s with --%s", logGroupByDayFlag, logCherryMarkFlag))
+	}
+
+	ltf.maxCount = -1
+	for _, flag := range []string{logMaxCountFlag, cli.NumberFlag} {
+		if !apr.Contains(flag) {
+			continue
+		}
+		n, ok := apr.GetInt(flag)
+		if !ok || n < 0 {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("invalid --%s value: %s", flag, apr.MustGetValue(flag)))
+		}
+		ltf.maxCount = n
+	}
+
+	ltf.abbrev = 0
+	if apr.Contains(logAbbrevFlag) {
+		n, ok := apr.GetInt(logAbbrevFlag)
+		if !ok || n < minLogAbbrev || n > hash.StringLen {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("invalid --%s value: %s, must be between %d and %d", logAbbrevFlag, apr.MustGetValue(logAbbrevFlag), minLogAbbrev, hash.StringLen))
+		}
+		ltf.abbrev = n
+	}
+
 	return nil
 }
 
@@ -261,30 +583,19 @@ func (ltf *LogTableFunction) WithExpressions(expression ...sql.Expression) (sql.
 		}
 	}
 
-	newLtf := *ltf
-	if err := newLtf.addOptions(expression); err != nil {
-		return nil, err
-	}
-
 	// Gets revisions, excluding any flag-related expression
-	var filteredExpressions []sql.Expression
-	for i, ex := range expression {
-		if !strings.Contains(ex.String(), "--") && !(i > 0 && strings.Contains(expression[i-1].String(), "--")) {
-			filteredExpressions = append(filteredExpressions, ex)
-		}
+	revisionExprs, optionExprs, err := partitionOptionExpressions(ltf.ctx, logTableFunctionArgParser(), expression)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(filteredExpressions) > 2 {
-		return nil, sql.ErrInvalidArgumentNumber.New(newLtf.Name(), "0 to 2", len(filteredExpressions))
+	newLtf := *ltf
+	if err := newLtf.addOptions(optionExprs); err != nil {
+		return nil, err
 	}
 
-	exLen := len(filteredExpressions)
-	if exLen > 0 {
-		newLtf.revisionExpr = filteredExpressions[0]
-	}
-	if exLen == 2 {
-		newLtf.secondRevisionExpr = filteredExpressions[1]
-	}
+	newLtf.revisionExprs = revisionExprs
+	newLtf.optionExprs = optionExprs
 
 	if err := newLtf.validateRevisionExpressions(); err != nil {
 		return nil, err
@@ -298,61 +609,50 @@ func (ltf *LogTableFunction) invalidArgDetailsErr(expr sql.Expression, reason st
 }
 
 func (ltf *LogTableFunction) validateRevisionExpressions() error {
-	// We must convert the expressions to strings before making string comparisons
-	// For dolt_log('^main'), ltf.revisionExpr.String() = "'^main'"" and revisionStr = "^main"
-	revisionStr := ""
-	secondRevisionStr := ""
-
-	if ltf.revisionExpr != nil {
-		revisionStr = mustExpressionToString(ltf.ctx, ltf.revisionExpr)
-		if !types.IsText(ltf.revisionExpr.Type()) {
-			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), ltf.revisionExpr.String())
+	includes := 0
+	for _, expr := range ltf.revisionExprs {
+		if !types.IsText(expr.Type()) {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), expr.String())
 		}
-		if ltf.secondRevisionExpr == nil && strings.HasPrefix(revisionStr, "^") {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "second revision must exist if first revision contains '^'")
+
+		// We must convert the expressions to strings before making string comparisons
+		// For dolt_log('^main'), expr.String() = "'^main'"" and revisionStr = "^main"
+		revisionStr := mustExpressionToString(ltf.ctx, expr)
+		if strings.Contains(revisionStr, "..") {
+			if strings.HasPrefix(revisionStr, "^") {
+				return ltf.invalidArgDetailsErr(expr, "revision cannot contain both '..' or '...' and '^'")
+			}
+			if len(ltf.revisionExprs) > 1 {
+				return ltf.invalidArgDetailsErr(expr, "revision cannot contain '..' or '...' if other revisions are given; to exclude revisions, prefix them with '^' or list them after --not instead")
+			}
+			if len(ltf.notRevisions) > 0 {
+				return ltf.invalidArgDetailsErr(expr, "cannot use --not with '..' or '...'; to exclude revisions, list the revisions to include and the revisions to exclude after --not instead")
+			}
 		}
-		if strings.Contains(revisionStr, "..") && strings.HasPrefix(revisionStr, "^") {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "revision cannot contain both '..' or '...' and '^'")
+
+		if !strings.HasPrefix(revisionStr, "^") {
+			includes++
 		}
 	}
 
-	if ltf.secondRevisionExpr != nil {
-		secondRevisionStr = mustExpressionToString(ltf.ctx, ltf.secondRevisionExpr)
-		if !types.IsText(ltf.secondRevisionExpr.Type()) {
-			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), ltf.secondRevisionExpr.String())
-		}
-		if strings.Contains(secondRevisionStr, "..") {
-			return ltf.invalidArgDetailsErr(ltf.secondRevisionExpr, "second revision cannot contain '..' or '...'")
-		}
-		if strings.Contains(revisionStr, "..") {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "revision cannot contain '..' or '...' if second revision exists")
-		}
+	if includes == 0 && len(ltf.revisionExprs) > 0 {
+		return ltf.invalidArgDetailsErr(ltf.revisionExprs[0], "at least one revision without '^' must be given to exclude revisions")
 	}
 
-	if ltf.revisionExpr != nil && ltf.secondRevisionExpr != nil {
-		if strings.HasPrefix(revisionStr, "^") && strings.HasPrefix(secondRevisionStr, "^") {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "both revisions cannot contain '^'")
-		}
-		if !strings.HasPrefix(revisionStr, "^") && !strings.HasPrefix(secondRevisionStr, "^") {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "one revision must contain '^' if two revisions provided")
-		}
+	if len(ltf.notRevisions) > 0 && includes == 0 {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), "must have revision in order to use --not")
 	}
 
-	if len(ltf.notRevision) > 0 {
-		if ltf.revisionExpr == nil && ltf.secondRevisionExpr == nil {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "must have revision in order to use --not")
-		}
-		if ltf.revisionExpr != nil && (strings.Contains(revisionStr, "..") || strings.HasPrefix(revisionStr, "^")) {
-			return ltf.invalidArgDetailsErr(ltf.revisionExpr, "cannot use --not if dots or '^' present in revision")
-		}
-		if ltf.secondRevisionExpr != nil && strings.HasPrefix(secondRevisionStr, "^") {
-			return ltf.invalidArgDetailsErr(ltf.secondRevisionExpr, "cannot use --not if '^' present in second revision")
-		}
-		if strings.Contains(ltf.notRevision, "..") {
-			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("%s - %s", ltf.notRevision, "--not revision cannot contain '..'"))
+	if ltf.cherryMark && (len(ltf.revisionExprs) != 1 || !strings.Contains(mustExpressionToString(ltf.ctx, ltf.revisionExprs[0]), "...")) {
+		return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("--%s requires a single revision of the form 'a...b'", logCherryMarkFlag))
+	}
+
+	for _, notRevision := range ltf.notRevisions {
+		if strings.Contains(notRevision, "..") {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("%s - %s", notRevision, "--not revision cannot contain '..'"))
 		}
-		if strings.HasPrefix(ltf.notRevision, "^") {
-			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("%s - %s", ltf.notRevision, "--not revision cannot contain '^'"))
+		if strings.HasPrefix(notRevision, "^") {
+			return sql.ErrInvalidArgumentDetails.New(ltf.Name(), fmt.Sprintf("%s - %s", notRevision, "--not revision cannot contain '^'"))
 		}
 	}
 
@@ -361,7 +661,7 @@ func (ltf *LogTableFunction) validateRevisionExpressions() error {
 
 // RowIter implements the sql.Node interface
 func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	revisionVal, secondRevisionVal, threeDot, err := ltf.evaluateArguments()
+	includeRevisions, excludeRevisions, threeDot, err := ltf.evaluateArguments()
 	if err != nil {
 		return nil, err
 	}
@@ -372,72 +672,448 @@ func (ltf *LogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 	}
 
 	sess := dsess.DSessFromSess(ctx.Session)
-	var commit *doltdb.Commit
+	ddb := sqledb.DbData().Ddb
+
+	// Revisions may be relative to HEAD, which revision databases for tags and commits don't have
+	headRef, err := sess.CWBHeadRef(ctx, sqledb.Name())
+	if err == doltdb.ErrOperationNotSupportedInDetachedHead {
+		headRef = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var commits []*doltdb.Commit
+	if len(includeRevisions) > 0 {
+		commits, err = resolveCommits(ctx, ddb, headRef, includeRevisions)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// If no revision is given, use session head
+		commit, err := sess.GetHeadCommit(ctx, sqledb.Name())
+		if err != nil {
+			return nil, err
+		}
+		commits = []*doltdb.Commit{commit}
+	}
+
+	excludingCommits, err := resolveCommits(ctx, ddb, headRef, excludeRevisions)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(revisionVal) > 0 {
-		cs, err := doltdb.NewCommitSpec(revisionVal)
+	var cherryMarks map[hash.Hash]string
+	var rightCommits map[hash.Hash]struct{}
+	if threeDot {
+		mergeBase, err := merge.MergeBase(ctx, commits[0], commits[1])
 		if err != nil {
 			return nil, err
 		}
 
-		commit, err = sqledb.DbData().Ddb.Resolve(ctx, cs, nil)
+		// Use merge base as excluding commit
+		mergeCommit, err := ddb.ReadCommit(ctx, mergeBase)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		// If revisionExpr not defined, use session head
-		commit, err = sess.GetHeadCommit(ctx, sqledb.Name())
+		excludingCommits = append(excludingCommits, mergeCommit)
+
+		if ltf.cherryMark {
+			cherryMarks, rightCommits, err = getCherryMarks(ctx, ddb, commits[0], commits[1], mergeCommit)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cHashToRefs, err := getCommitHashToRefs(ctx, ddb, ltf.decoration)
+	if err != nil {
+		return nil, err
+	}
+
+	if ltf.all {
+		refCommits, err := getDecoratedCommits(ctx, ddb, cHashToRefs)
 		if err != nil {
 			return nil, err
 		}
+		commits = append(commits, refCommits...)
 	}
 
 	matchFunc := func(commit *doltdb.Commit) (bool, error) {
-		return commit.NumParents() >= ltf.minParents, nil
+		if ltf.simplifyByDecoration {
+			h, err := commit.HashOf()
+			if err != nil {
+				return false, err
+			}
+			if _, ok := cHashToRefs[h]; !ok {
+				return false, nil
+			}
+		}
+		if ltf.cherry {
+			h, err := commit.HashOf()
+			if err != nil {
+				return false, err
+			}
+			if _, ok := rightCommits[h]; !ok || commit.NumParents() > 1 {
+				return false, nil
+			}
+		}
+		if commit.NumParents() < ltf.minParents {
+			return false, nil
+		}
+		if ltf.maxParents >= 0 && commit.NumParents() > ltf.maxParents {
+			return false, nil
+		}
+		if len(ltf.author) > 0 || len(ltf.committer) > 0 || len(ltf.grep) > 0 || len(ltf.since) > 0 || len(ltf.until) > 0 {
+			meta, err := commit.GetCommitMeta(ctx)
+			if err != nil {
+				return false, err
+			}
+			if !commitMetaMatchesPerson(meta, ltf.author) || !commitMetaMatchesPerson(meta, ltf.committer) {
+				return false, nil
+			}
+			if !strings.Contains(strings.ToLower(meta.Description), strings.ToLower(ltf.grep)) {
+				return false, nil
+			}
+			if len(ltf.since) > 0 && meta.Time().Before(ltf.sinceTime) {
+				return false, nil
+			}
+			if len(ltf.until) > 0 && meta.Time().After(ltf.untilTime) {
+				return false, nil
+			}
+		}
+		if len(ltf.changeType) > 0 {
+			changed, err := commitChangesTableRows(ctx, commit, ltf.tables, ltf.changeType)
+			if err != nil || !changed {
+				return false, err
+			}
+		} else if len(ltf.tables) > 0 {
+			changed, err := diff.CommitChangesTables(ctx, commit, ltf.tables)
+			if err != nil || !changed {
+				return false, err
+			}
+		}
+		if !ltf.dataOnly && !ltf.schemaOnly {
+			return true, nil
+		}
+
+		dataChanged, schemaChanged, err := diff.GetCommitChanges(ctx, commit)
+		if err != nil {
+			return false, err
+		}
+		if ltf.dataOnly {
+			return dataChanged, nil
+		}
+		return schemaChanged, nil
 	}
 
-	cHashToRefs, err := getCommitHashToRefs(ctx, sqledb.DbData().Ddb, ltf.decoration)
+	var itr *logTableFunctionRowIter
+	if len(excludingCommits) > 0 {
+		itr, err = ltf.NewDotDotLogTableFunctionRowIter(ctx, ddb, commits, excludingCommits, matchFunc, cHashToRefs)
+	} else {
+		itr, err = ltf.NewLogTableFunctionRowIter(ctx, ddb, commits, matchFunc, cHashToRefs)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Two and three dot log
-	if len(secondRevisionVal) > 0 {
-		secondCs, err := doltdb.NewCommitSpec(secondRevisionVal)
+	if ltf.maxCount >= 0 {
+		itr.child = doltdb.NewLimitingCommitItr(itr.child, ltf.maxCount)
+	}
+
+	if ltf.all && len(includeRevisions) <= 1 {
+		// The commits of every ref follow the log's head commit, which is still the one HEAD decorates
+		itr.headHash, err = commits[0].HashOf()
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		secondCommit, err := sqledb.DbData().Ddb.Resolve(ctx, secondCs, nil)
+	if shouldDecorateWithRefs(ltf.decoration) {
+		itr.headBranch, err = getHeadBranchRefName(ctx, ddb, headRef, includeRevisions, ltf.decoration)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		if threeDot {
-			mergeBase, err := merge.MergeBase(ctx, commit, secondCommit)
-			if err != nil {
-				return nil, err
-			}
+	if len(ltf.containedIn) > 0 {
+		itr.containedIn, err = getReachableCommits(ctx, ddb, headRef, ltf.containedIn)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			mergeCs, err := doltdb.NewCommitSpec(mergeBase.String())
-			if err != nil {
-				return nil, err
+	itr.cherryMarks = cherryMarks
+
+	if ltf.groupByDay {
+		return groupCommitsByDay(ctx, itr.child)
+	}
+	return itr, nil
+}
+
+// logSide is a bit set of the sides of an a...b revision that a commit is reachable from
+type logSide uint8
+
+const (
+	logSideLeft logSide = 1 << iota
+	logSideRight
+)
+
+// getCherryMarks returns the --cherry-mark column for the commits of an a...b log, along with the commits on its right
+// side. A commit is marked = if a commit on the other side has the same patch id, and + otherwise. Merge commits are
+// always marked +.
+func getCherryMarks(ctx *sql.Context, ddb *doltdb.DoltDB, left, right, mergeBase *doltdb.Commit) (map[hash.Hash]string, map[hash.Hash]struct{}, error) {
+	sides, patchIDs, err := getSymmetricDifference(ctx, ddb, left, right, mergeBase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leftIDs := make(map[hash.Hash]struct{})
+	rightIDs := make(map[hash.Hash]struct{})
+	for h, side := range sides {
+		if patchIDs[h].IsEmpty() {
+			continue
+		}
+		if side&logSideLeft != 0 {
+			leftIDs[patchIDs[h]] = struct{}{}
+		}
+		if side&logSideRight != 0 {
+			rightIDs[patchIDs[h]] = struct{}{}
+		}
+	}
+
+	marks := make(map[hash.Hash]string, len(sides))
+	rightCommits := make(map[hash.Hash]struct{})
+	for h, side := range sides {
+		marks[h] = "+"
+		if side&logSideLeft != 0 {
+			if _, ok := rightIDs[patchIDs[h]]; ok {
+				marks[h] = "="
 			}
+		}
+		if side&logSideRight != 0 {
+			rightCommits[h] = struct{}{}
+			if _, ok := leftIDs[patchIDs[h]]; ok {
+				marks[h] = "="
+			}
+		}
+	}
+	return marks, rightCommits, nil
+}
 
-			// Use merge base as excluding commit
-			mergeCommit, err := sqledb.DbData().Ddb.Resolve(ctx, mergeCs, nil)
-			if err != nil {
-				return nil, err
+// getSymmetricDifference walks the commits reachable from |left| or |right| but not from |mergeBase| once, returning
+// the sides each commit is reachable from and its patch id, or an empty hash for merge commits. The walk visits
+// children before their parents, so each commit's sides are known by the time it's visited and are passed on to its
+// parents.
+func getSymmetricDifference(ctx *sql.Context, ddb *doltdb.DoltDB, left, right, mergeBase *doltdb.Commit) (map[hash.Hash]logSide, map[hash.Hash]hash.Hash, error) {
+	hashes, err := commitHashes([]*doltdb.Commit{left, right})
+	if err != nil {
+		return nil, nil, err
+	}
+	exHashes, err := commitHashes([]*doltdb.Commit{mergeBase})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reachable := map[hash.Hash]logSide{hashes[0]: logSideLeft}
+	reachable[hashes[1]] |= logSideRight
+
+	itr, err := commitwalk.GetDotDotRevisionsIterator(ctx, ddb, hashes, ddb, exHashes, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sides := make(map[hash.Hash]logSide)
+	patchIDs := make(map[hash.Hash]hash.Hash)
+	for {
+		h, cm, err := itr.Next(ctx)
+		if err == io.EOF {
+			return sides, patchIDs, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		side := reachable[h]
+		sides[h] = side
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, parent := range parents {
+			reachable[parent] |= side
+		}
+
+		if cm.NumParents() > 1 {
+			patchIDs[h] = hash.Hash{}
+			continue
+		}
+		patchIDs[h], err = diff.GetCommitPatchID(ctx, cm)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// commitChangesTableRows returns whether |commit| made at least one row change of the type given, one of
+// logChangeTypes, to any of the tables named, compared to its first parent. Tables whose primary key changed can't be
+// diffed by row, and are skipped.
+func commitChangesTableRows(ctx *sql.Context, commit *doltdb.Commit, tableNames []string, changeType string) (bool, error) {
+	deltas, err := diff.GetCommitTableDeltas(ctx, commit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, delta := range deltas {
+		named := false
+		for _, name := range tableNames {
+			if strings.EqualFold(delta.FromName, name) || strings.EqualFold(delta.ToName, name) {
+				named = true
+				break
 			}
+		}
+		if !named {
+			continue
+		}
 
-			return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, []*doltdb.Commit{commit, secondCommit}, mergeCommit, matchFunc, cHashToRefs)
+		stat, _, _, err := getDiffStat(ctx, delta)
+		if goerrors.Is(err, diff.ErrPrimaryKeySetChanged) {
+			continue
+		} else if err != nil {
+			return false, err
 		}
 
-		return ltf.NewDotDotLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, []*doltdb.Commit{commit}, secondCommit, matchFunc, cHashToRefs)
+		var count uint64
+		switch changeType {
+		case "added":
+			count = stat.Adds
+		case "modified":
+			count = stat.Changes
+		case "removed":
+			count = stat.Removes
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
 
+	return false, nil
+}
+
+// commitMetaMatchesPerson returns whether the name or email of |meta| contains |pattern|, ignoring case. Dolt records
+// a single name and email for each commit, so this is the match for both --author and --committer.
+func commitMetaMatchesPerson(meta *datas.CommitMeta, pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	return strings.Contains(strings.ToLower(meta.Name), pattern) || strings.Contains(strings.ToLower(meta.Email), pattern)
+}
+
+// groupCommitsByDay returns a row with the date and number of commits for each day on which any of the commits
+// returned by |itr| were made, most recent first. Days are taken from the commit dates as they're shown in the date
+// column of dolt_log.
+func groupCommitsByDay(ctx *sql.Context, itr doltdb.CommitItr) (sql.RowIter, error) {
+	counts := make(map[time.Time]int64)
+	for {
+		_, cm, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		t := meta.Time()
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		counts[day]++
+	}
+
+	days := make([]time.Time, 0, len(counts))
+	for day := range counts {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].After(days[j])
+	})
+
+	rows := make([]sql.Row, len(days))
+	for i, day := range days {
+		rows[i] = sql.NewRow(day, counts[day])
 	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// resolveCommits resolves each of the revisions given to a commit
+func resolveCommits(ctx *sql.Context, ddb *doltdb.DoltDB, headRef ref.DoltRef, revisions []string) ([]*doltdb.Commit, error) {
+	commits := make([]*doltdb.Commit, len(revisions))
+	for i, revision := range revisions {
+		cs, err := doltdb.NewCommitSpec(revision)
+		if err != nil {
+			return nil, err
+		}
 
-	return ltf.NewLogTableFunctionRowIter(ctx, sqledb.DbData().Ddb, commit, matchFunc, cHashToRefs)
+		commits[i], err = ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commits, nil
+}
+
+// getReachableCommits returns the hashes of the commits reachable from |revision|, including the commit it resolves to
+func getReachableCommits(ctx *sql.Context, ddb *doltdb.DoltDB, headRef ref.DoltRef, revision string) (map[hash.Hash]struct{}, error) {
+	commits, err := resolveCommits(ctx, ddb, headRef, []string{revision})
+	if isUnknownCommitErr(err) {
+		return nil, ErrUnknownCommit.New(revision)
+	} else if err != nil {
+		return nil, err
+	}
+	hashes, err := commitHashes(commits)
+	if err != nil {
+		return nil, err
+	}
+
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, hashes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reachable := make(map[hash.Hash]struct{})
+	for {
+		h, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			return reachable, nil
+		} else if err != nil {
+			return nil, err
+		}
+		reachable[h] = struct{}{}
+	}
+}
+
+// getHeadBranchRefName returns the name of the branch that HEAD points to in the log's refs column, decorated the same
+// way as the other refs. That's the branch given as the log's only revision, or the session's branch when no revision
+// is given. An empty string means HEAD is detached, e.g. when the revision is a commit hash or a tag.
+func getHeadBranchRefName(ctx *sql.Context, ddb *doltdb.DoltDB, headRef ref.DoltRef, revisions []string, decoration string) (string, error) {
+	var branchRef ref.DoltRef
+	switch len(revisions) {
+	case 0:
+		branchRef = headRef
+	case 1:
+		branchName, ok, err := ddb.HasBranch(ctx, revisions[0])
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			branchRef = ref.NewBranchRef(branchName)
+		}
+	}
+
+	if branchRef == nil {
+		return "", nil
+	}
+	if decoration == "full" {
+		return branchRef.String(), nil
+	}
+	return branchRef.GetPath(), nil
 }
 
 func getCommitHashToRefs(ctx *sql.Context, ddb *doltdb.DoltDB, decoration string) (map[hash.Hash][]string, error) {
@@ -486,40 +1162,58 @@ func getCommitHashToRefs(ctx *sql.Context, ddb *doltdb.DoltDB, decoration string
 	return cHashToRefs, nil
 }
 
-// evaluateArguments returns revisionValStr, secondRevisionValStr, and three dot boolean.
-// It evaluates the argument expressions to turn them into values this LogTableFunction
-// can use. Note that this method only evals the expressions, and doesn't validate the values.
-func (ltf *LogTableFunction) evaluateArguments() (string, string, bool, error) {
-	var revisionValStr string
-	var secondRevisionValStr string
-	var err error
-	threeDot := false
+// getDecoratedCommits returns the commits that the refs in |cHashToRefs| point to, in hash order
+func getDecoratedCommits(ctx *sql.Context, ddb *doltdb.DoltDB, cHashToRefs map[hash.Hash][]string) ([]*doltdb.Commit, error) {
+	hashes := make([]hash.Hash, 0, len(cHashToRefs))
+	for h := range cHashToRefs {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i].Less(hashes[j])
+	})
 
-	if ltf.revisionExpr != nil {
-		revisionValStr, secondRevisionValStr, threeDot, err = getRevisionsFromExpr(ltf.ctx, ltf.revisionExpr, true)
+	commits := make([]*doltdb.Commit, len(hashes))
+	for i, h := range hashes {
+		cm, err := ddb.ReadCommit(ctx, h)
 		if err != nil {
-			return "", "", false, err
+			return nil, err
 		}
+		commits[i] = cm
 	}
+	return commits, nil
+}
+
+// evaluateArguments returns the revisions to include and exclude, and whether the revisions to include are the two
+// sides of a three dot log. It evaluates the argument expressions to turn them into values this LogTableFunction
+// can use. Note that this method only evals the expressions, and doesn't validate the values.
+func (ltf *LogTableFunction) evaluateArguments() ([]string, []string, bool, error) {
+	var includeRevisions, excludeRevisions []string
+	threeDot := false
 
-	if ltf.secondRevisionExpr != nil {
-		rvs, srvs, _, err := getRevisionsFromExpr(ltf.ctx, ltf.secondRevisionExpr, false)
+	for _, expr := range ltf.revisionExprs {
+		revisionValStr, err := expressionToString(ltf.ctx, expr)
 		if err != nil {
-			return "", "", false, err
-		}
-		if len(rvs) > 0 {
-			revisionValStr = rvs
+			return nil, nil, false, err
 		}
-		if len(srvs) > 0 {
-			secondRevisionValStr = srvs
+
+		if strings.Contains(revisionValStr, "...") {
+			refs := strings.Split(revisionValStr, "...")
+			includeRevisions = append(includeRevisions, refs[0], refs[1])
+			threeDot = true
+		} else if strings.Contains(revisionValStr, "..") {
+			refs := strings.Split(revisionValStr, "..")
+			includeRevisions = append(includeRevisions, refs[1])
+			excludeRevisions = append(excludeRevisions, refs[0])
+		} else if strings.HasPrefix(revisionValStr, "^") {
+			excludeRevisions = append(excludeRevisions, strings.TrimPrefix(revisionValStr, "^"))
+		} else {
+			includeRevisions = append(includeRevisions, revisionValStr)
 		}
 	}
 
-	if len(ltf.notRevision) > 0 {
-		secondRevisionValStr = ltf.notRevision
-	}
+	excludeRevisions = append(excludeRevisions, ltf.notRevisions...)
 
-	return revisionValStr, secondRevisionValStr, threeDot, nil
+	return includeRevisions, excludeRevisions, threeDot, nil
 }
 
 func mustExpressionToString(ctx *sql.Context, expr sql.Expression) string {
@@ -544,30 +1238,6 @@ func expressionToString(ctx *sql.Context, expr sql.Expression) (string, error) {
 	return valStr, nil
 }
 
-// getRevisionsFromExpr returns the revisionName and/or secondRevisionName, as
-// well as a threeDot boolean from sql expression
-func getRevisionsFromExpr(ctx *sql.Context, expr sql.Expression, canDot bool) (string, string, bool, error) {
-	revisionValStr, err := expressionToString(ctx, expr)
-	if err != nil {
-		return "", "", false, err
-	}
-
-	if canDot && strings.Contains(revisionValStr, "..") {
-		if strings.Contains(revisionValStr, "...") {
-			refs := strings.Split(revisionValStr, "...")
-			return refs[0], refs[1], true, nil
-		}
-		refs := strings.Split(revisionValStr, "..")
-		return refs[1], refs[0], false, nil
-	}
-
-	if strings.HasPrefix(revisionValStr, "^") {
-		return "", strings.TrimPrefix(revisionValStr, "^"), false, nil
-	}
-
-	return revisionValStr, "", false, nil
-}
-
 //------------------------------------
 // logTableFunctionRowIter
 //------------------------------------
@@ -578,48 +1248,59 @@ var _ sql.RowIter = (*logTableFunctionRowIter)(nil)
 type logTableFunctionRowIter struct {
 	child       doltdb.CommitItr
 	showParents bool
+	showRoot    bool
+	// abbrev is the number of characters of commit hashes shown, or 0 for the full hashes
+	abbrev      int
 	decoration  string
 	cHashToRefs map[hash.Hash][]string
 	headHash    hash.Hash
+	headBranch  string
+	// containedIn is the set of commits reachable from the --contained-in revision, or nil if it wasn't given
+	containedIn map[hash.Hash]struct{}
+	// cherryMarks is the --cherry-mark column for each commit, or nil if it wasn't given
+	cherryMarks map[hash.Hash]string
 }
 
-func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, commit *doltdb.Commit, matchFn func(*doltdb.Commit) (bool, error), cHashToRefs map[hash.Hash][]string) (*logTableFunctionRowIter, error) {
-	h, err := commit.HashOf()
+func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, commits []*doltdb.Commit, matchFn func(*doltdb.Commit) (bool, error), cHashToRefs map[hash.Hash][]string) (*logTableFunctionRowIter, error) {
+	hashes, err := commitHashes(commits)
 	if err != nil {
 		return nil, err
 	}
 
-	child, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{h}, matchFn)
+	child, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, hashes, matchFn)
 	if err != nil {
 		return nil, err
 	}
 
+	var headHash hash.Hash
+
+	if len(hashes) == 1 {
+		headHash = hashes[0]
+	}
+
 	return &logTableFunctionRowIter{
 		child:       child,
 		showParents: ltf.showParents,
+		showRoot:    ltf.showRoot,
+		abbrev:      ltf.abbrev,
 		decoration:  ltf.decoration,
 		cHashToRefs: cHashToRefs,
-		headHash:    h,
+		headHash:    headHash,
 	}, nil
 }
 
-func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, commits []*doltdb.Commit, excludingCommit *doltdb.Commit, matchFn func(*doltdb.Commit) (bool, error), cHashToRefs map[hash.Hash][]string) (*logTableFunctionRowIter, error) {
-	hashes := make([]hash.Hash, len(commits))
-
-	for i, commit := range commits {
-		h, err := commit.HashOf()
-		if err != nil {
-			return nil, err
-		}
-		hashes[i] = h
+func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, commits []*doltdb.Commit, excludingCommits []*doltdb.Commit, matchFn func(*doltdb.Commit) (bool, error), cHashToRefs map[hash.Hash][]string) (*logTableFunctionRowIter, error) {
+	hashes, err := commitHashes(commits)
+	if err != nil {
+		return nil, err
 	}
 
-	exHash, err := excludingCommit.HashOf()
+	exHashes, err := commitHashes(excludingCommits)
 	if err != nil {
 		return nil, err
 	}
 
-	child, err := commitwalk.GetDotDotRevisionsIterator(ctx, ddb, hashes, ddb, []hash.Hash{exHash}, matchFn)
+	child, err := commitwalk.GetDotDotRevisionsIterator(ctx, ddb, hashes, ddb, exHashes, matchFn)
 	if err != nil {
 		return nil, err
 	}
@@ -633,12 +1314,27 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 	return &logTableFunctionRowIter{
 		child:       child,
 		showParents: ltf.showParents,
+		showRoot:    ltf.showRoot,
+		abbrev:      ltf.abbrev,
 		decoration:  ltf.decoration,
 		cHashToRefs: cHashToRefs,
 		headHash:    headHash,
 	}, nil
 }
 
+// commitHashes returns the hashes of the commits given
+func commitHashes(commits []*doltdb.Commit) ([]hash.Hash, error) {
+	hashes := make([]hash.Hash, len(commits))
+	for i, commit := range commits {
+		h, err := commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = h
+	}
+	return hashes, nil
+}
+
 // Next retrieves the next row. It will return io.EOF if it's the last row.
 // After retrieving the last row, Close will be automatically closed.
 func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
@@ -652,20 +1348,46 @@ func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
 		return nil, err
 	}
 
-	row := sql.NewRow(h.String(), meta.Name, meta.Email, meta.Time(), meta.Description)
+	height, err := cm.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	row := sql.NewRow(abbrevHash(h, itr.abbrev), meta.Name, meta.Email, meta.Time(), meta.Description, height, meta.AuthorTime())
 
 	if itr.showParents {
-		prStr, err := getParentsString(ctx, cm)
+		prStr, err := getParentsString(ctx, cm, itr.abbrev)
 		if err != nil {
 			return nil, err
 		}
 		row = row.Append(sql.NewRow(prStr))
 	}
 
+	if itr.showRoot {
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rootHash, err := root.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		row = row.Append(sql.NewRow(rootHash.String()))
+	}
+
 	if shouldDecorateWithRefs(itr.decoration) {
-		branchNames := itr.cHashToRefs[h]
+		refNames := itr.cHashToRefs[h]
 		isHead := itr.headHash == h
-		row = row.Append(sql.NewRow(getRefsString(branchNames, isHead)))
+		row = row.Append(sql.NewRow(getRefsString(refNames, isHead, itr.headBranch)))
+	}
+
+	if itr.containedIn != nil {
+		_, ok := itr.containedIn[h]
+		row = row.Append(sql.NewRow(ok))
+	}
+
+	if itr.cherryMarks != nil {
+		row = row.Append(sql.NewRow(itr.cherryMarks[h]))
 	}
 
 	return row, nil
@@ -675,20 +1397,27 @@ func (itr *logTableFunctionRowIter) Close(_ *sql.Context) error {
 	return nil
 }
 
-func getRefsString(branchNames []string, isHead bool) string {
-	if len(branchNames) == 0 {
-		return ""
+// getRefsString returns the refs column for a commit with the |refNames| given. For the log's head commit, the branch
+// HEAD points to is listed first as "HEAD -> branch", or "HEAD" is listed first if HEAD is detached.
+func getRefsString(refNames []string, isHead bool, headBranch string) string {
+	if !isHead {
+		return strings.Join(refNames, ", ")
 	}
-	var refStr string
-	if isHead {
-		refStr += "HEAD -> "
+
+	head := "HEAD"
+	others := make([]string, 0, len(refNames))
+	for _, refName := range refNames {
+		if headBranch != "" && refName == headBranch {
+			head = "HEAD -> " + refName
+		} else {
+			others = append(others, refName)
+		}
 	}
-	refStr += strings.Join(branchNames, ", ")
 
-	return refStr
+	return strings.Join(append([]string{head}, others...), ", ")
 }
 
-func getParentsString(ctx *sql.Context, cm *doltdb.Commit) (string, error) {
+func getParentsString(ctx *sql.Context, cm *doltdb.Commit, abbrev int) (string, error) {
 	parents, err := cm.ParentHashes(ctx)
 	if err != nil {
 		return "", err
@@ -696,7 +1425,7 @@ func getParentsString(ctx *sql.Context, cm *doltdb.Commit) (string, error) {
 
 	var prStr string
 	for i, h := range parents {
-		prStr += h.String()
+		prStr += abbrevHash(h, abbrev)
 		if i < len(parents)-1 {
 			prStr += ", "
 		}
@@ -705,6 +1434,15 @@ func getParentsString(ctx *sql.Context, cm *doltdb.Commit) (string, error) {
 	return prStr, nil
 }
 
+// abbrevHash returns the first |abbrev| characters of |h|, or all of them if |abbrev| is 0
+func abbrevHash(h hash.Hash, abbrev int) string {
+	str := h.String()
+	if abbrev > 0 && abbrev < len(str) {
+		return str[:abbrev]
+	}
+	return str
+}
+
 // Default ("auto") for the dolt_log table function is "no"
 func shouldDecorateWithRefs(decoration string) bool {
 	return decoration == "full" || decoration == "short"
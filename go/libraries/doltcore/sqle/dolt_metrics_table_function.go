@@ -0,0 +1,132 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var _ sql.TableFunction = (*MetricsTableFunction)(nil)
+var _ sql.ExecSourceRel = (*MetricsTableFunction)(nil)
+
+// MetricsTableFunction reports the server's counters, e.g. dolt_metrics(). Counters count events across every
+// session and database of the server, such as transaction commits that had to merge with or were rolled back because
+// of a concurrent transaction. They start at zero when the server starts and aren't persisted, so monitoring should
+// sample them and look at how they change over time.
+type MetricsTableFunction struct {
+	ctx *sql.Context
+
+	database sql.Database
+}
+
+var metricsTableSchema = sql.Schema{
+	&sql.Column{Name: "name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "value", Type: types.Uint64, Nullable: false},
+	&sql.Column{Name: "description", Type: types.LongText, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (mtf *MetricsTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &MetricsTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (mtf *MetricsTableFunction) Database() sql.Database {
+	return mtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (mtf *MetricsTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nmtf := *mtf
+	nmtf.database = database
+	return &nmtf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (mtf *MetricsTableFunction) Name() string {
+	return "dolt_metrics"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (mtf *MetricsTableFunction) Resolved() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (mtf *MetricsTableFunction) String() string {
+	return "DOLT_METRICS()"
+}
+
+// Schema implements the sql.Node interface.
+func (mtf *MetricsTableFunction) Schema() sql.Schema {
+	return metricsTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (mtf *MetricsTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (mtf *MetricsTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return mtf, nil
+}
+
+// CheckPrivileges implements the interface sql.Node. Like SHOW GLOBAL STATUS, the counters don't reveal any data, so
+// no privileges are required to read them.
+func (mtf *MetricsTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (mtf *MetricsTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (mtf *MetricsTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(mtf.Name(), 0, len(expression))
+	}
+	return mtf, nil
+}
+
+// RowIter implements the sql.Node interface
+func (mtf *MetricsTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	counters := dsess.Counters()
+	rows := make([]sql.Row, len(counters))
+	for i, c := range counters {
+		rows[i] = sql.Row{c.Name(), c.Value(), c.Description()}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
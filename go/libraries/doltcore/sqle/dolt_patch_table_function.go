@@ -467,35 +467,46 @@ func getDataSqlPatchResults(ctx *sql.Context, diffQuerySch, targetSch sql.Schema
 			return nil, err
 		}
 
-		r, err = rowexec.ProjectRow(ctx, projections, r)
+		stmt, err := diffRowStatement(ctx, ds, projections, r, tn, tsch)
 		if err != nil {
 			return nil, err
 		}
 
-		oldRow, newRow, err := ds.SplitDiffResultRow(r)
-		if err != nil {
-			return nil, err
+		if stmt != "" {
+			res = append(res, stmt)
 		}
+	}
+}
 
-		var stmt string
-		if oldRow.Row != nil {
-			stmt, err = diff.GetDataDiffStatement(tn, tsch, oldRow.Row, oldRow.RowDiff, oldRow.ColDiffs)
-			if err != nil {
-				return nil, err
-			}
-		}
+// diffRowStatement returns the INSERT, UPDATE or DELETE statement that applies the change in the row |r| of a diff of
+// the table |tn| to the from side of the diff. |projections| select the columns of |r| that |ds| splits.
+func diffRowStatement(ctx *sql.Context, ds *diff.DiffSplitter, projections []sql.Expression, r sql.Row, tn string, tsch schema.Schema) (string, error) {
+	r, err := rowexec.ProjectRow(ctx, projections, r)
+	if err != nil {
+		return "", err
+	}
 
-		if newRow.Row != nil {
-			stmt, err = diff.GetDataDiffStatement(tn, tsch, newRow.Row, newRow.RowDiff, newRow.ColDiffs)
-			if err != nil {
-				return nil, err
-			}
+	oldRow, newRow, err := ds.SplitDiffResultRow(r)
+	if err != nil {
+		return "", err
+	}
+
+	var stmt string
+	if oldRow.Row != nil {
+		stmt, err = diff.GetDataDiffStatement(tn, tsch, oldRow.Row, oldRow.RowDiff, oldRow.ColDiffs)
+		if err != nil {
+			return "", err
 		}
+	}
 
-		if stmt != "" {
-			res = append(res, stmt)
+	if newRow.Row != nil {
+		stmt, err = diff.GetDataDiffStatement(tn, tsch, newRow.Row, newRow.RowDiff, newRow.ColDiffs)
+		if err != nil {
+			return "", err
 		}
 	}
+
+	return stmt, nil
 }
 
 // getDiffQuery returns diff schema for specified columns and array of sql.Expression as projection to be used
@@ -0,0 +1,642 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var _ sql.TableFunction = (*RowHistoryTableFunction)(nil)
+var _ sql.ExecSourceRel = (*RowHistoryTableFunction)(nil)
+
+// RowHistoryTableFunction returns the history of a single row, identified by its primary key, e.g.
+// dolt_row_history('orders', 123). There is a row for every commit reachable from HEAD that added, modified or removed
+// the row, compared to the commit's first parent, with the row's values as of that commit in to_ columns and as of the
+// parent in from_ columns.
+//
+// The primary key can also be given as a JSON object of key column values, e.g.
+// dolt_row_history('orders', '{"region": "us", "id": 123}'), whose fields must name exactly the table's key columns.
+//
+// Commits that didn't change the table are skipped without reading it, and each distinct version of the table is read
+// with a single point lookup on its primary index, so this is much cheaper than filtering dolt_history_<table> on
+// deep histories.
+type RowHistoryTableFunction struct {
+	ctx *sql.Context
+
+	tableNameExpr sql.Expression
+	pkExprs       []sql.Expression
+	database      sql.Database
+
+	tableName string
+	// pkVals are the primary key values given, converted to the types of the table's primary key columns at HEAD
+	pkVals []interface{}
+	// tableSch is the schema of the table at HEAD, which the to_ and from_ columns are taken from
+	tableSch sql.Schema
+	sqlSch   sql.Schema
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (rh *RowHistoryTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &RowHistoryTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (rh *RowHistoryTableFunction) Database() sql.Database {
+	return rh.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (rh *RowHistoryTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nrh := *rh
+	nrh.database = database
+	return &nrh, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (rh *RowHistoryTableFunction) Name() string {
+	return "dolt_row_history"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (rh *RowHistoryTableFunction) Resolved() bool {
+	for _, expr := range rh.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (rh *RowHistoryTableFunction) String() string {
+	args := make([]string, 0, len(rh.pkExprs)+1)
+	for _, expr := range rh.Expressions() {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_ROW_HISTORY(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (rh *RowHistoryTableFunction) Schema() sql.Schema {
+	return rh.sqlSch
+}
+
+// Children implements the sql.Node interface.
+func (rh *RowHistoryTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (rh *RowHistoryTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return rh, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (rh *RowHistoryTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return opChecker.UserHasPrivileges(ctx,
+		sql.NewPrivilegedOperation(rh.database.Name(), rh.tableName, "", sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (rh *RowHistoryTableFunction) Expressions() []sql.Expression {
+	if rh.tableNameExpr == nil {
+		return []sql.Expression{}
+	}
+	return append([]sql.Expression{rh.tableNameExpr}, rh.pkExprs...)
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (rh *RowHistoryTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(rh.Name(), "at least 2", len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(rh.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(rh.Name(), expr.String())
+		}
+	}
+
+	if !gmstypes.IsText(expression[0].Type()) {
+		return nil, sql.ErrInvalidArgumentDetails.New(rh.Name(), expression[0].String())
+	}
+
+	nrh := *rh
+	nrh.tableNameExpr = expression[0]
+	nrh.pkExprs = expression[1:]
+
+	if err := nrh.generateSchema(nrh.ctx); err != nil {
+		return nil, err
+	}
+
+	return &nrh, nil
+}
+
+// generateSchema resolves the table at the session's HEAD, converts the primary key arguments to the types of its
+// primary key columns, and builds the schema of the rows returned.
+func (rh *RowHistoryTableFunction) generateSchema(ctx *sql.Context) error {
+	sqledb, ok := rh.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", rh.database)
+	}
+
+	tableName, err := expressionToString(ctx, rh.tableNameExpr)
+	if err != nil {
+		return err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, sqledb.Name())
+	if err != nil {
+		return err
+	}
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+
+	table, resolvedName, ok, err := headRoot.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableNotFound.New(tableName)
+	}
+	rh.tableName = resolvedName
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if schema.IsKeyless(sch) {
+		return fmt.Errorf("%s requires a table with a primary key, but %s has none", rh.Name(), resolvedName)
+	}
+
+	pkCols := sch.GetPKCols().GetColumns()
+	pkArgs, err := rh.primaryKeyArgs(ctx, resolvedName, pkCols)
+	if err != nil {
+		return err
+	}
+
+	rh.pkVals = make([]interface{}, len(pkCols))
+	for i, col := range pkCols {
+		rh.pkVals[i], _, err = col.TypeInfo.ToSqlType().Convert(pkArgs[i])
+		if err != nil {
+			return sql.ErrInvalidArgumentDetails.New(rh.Name(), fmt.Sprintf("%s - %s", col.Name, err.Error()))
+		}
+	}
+
+	tableSch, err := sqlutil.FromDoltSchema("", sch)
+	if err != nil {
+		return err
+	}
+	rh.tableSch = tableSch.Schema
+
+	rh.sqlSch = sql.Schema{
+		&sql.Column{Name: "commit_hash", Type: gmstypes.Text, Nullable: false},
+		&sql.Column{Name: "committer", Type: gmstypes.Text, Nullable: false},
+		&sql.Column{Name: "date", Type: gmstypes.Datetime, Nullable: false},
+		&sql.Column{Name: "diff_type", Type: gmstypes.Text, Nullable: false},
+	}
+	for _, col := range rh.tableSch {
+		rh.sqlSch = append(rh.sqlSch, &sql.Column{
+			Name:     diff.ToColNamer(col.Name),
+			Type:     col.Type,
+			Nullable: true,
+		})
+	}
+	for _, col := range rh.tableSch {
+		rh.sqlSch = append(rh.sqlSch, &sql.Column{
+			Name:     diff.FromColNamer(col.Name),
+			Type:     col.Type,
+			Nullable: true,
+		})
+	}
+
+	return nil
+}
+
+// primaryKeyArgs returns the values of the primary key arguments, in the order of the table's primary key columns
+// |pkCols|. A single argument is read as a JSON object of key column values if it's a JSON value, or if it's a string
+// holding a JSON object and the table's primary key isn't a single string column.
+func (rh *RowHistoryTableFunction) primaryKeyArgs(ctx *sql.Context, tableName string, pkCols []schema.Column) ([]interface{}, error) {
+	args := make([]interface{}, len(rh.pkExprs))
+	for i, expr := range rh.pkExprs {
+		v, err := expr.Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	if len(args) == 1 {
+		obj, ok, err := rh.primaryKeyJSONObject(ctx, rh.pkExprs[0], args[0], pkCols)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return primaryKeyArgsFromJSON(tableName, obj, pkCols)
+		}
+	}
+
+	if len(args) != len(pkCols) {
+		return nil, sql.ErrInvalidArgumentNumber.New(rh.Name(), len(pkCols)+1, len(args)+1)
+	}
+	return args, nil
+}
+
+// primaryKeyJSONObject returns the JSON object given as the primary key argument |v|, and whether it is one.
+func (rh *RowHistoryTableFunction) primaryKeyJSONObject(ctx *sql.Context, expr sql.Expression, v interface{}, pkCols []schema.Column) (map[string]interface{}, bool, error) {
+	var doc gmstypes.JSONDocument
+	switch v := v.(type) {
+	case gmstypes.JSONValue:
+		var err error
+		doc, err = v.Unmarshall(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+	case string:
+		if len(pkCols) == 1 && gmstypes.IsText(pkCols[0].TypeInfo.ToSqlType()) {
+			return nil, false, nil
+		}
+		if !strings.HasPrefix(strings.TrimSpace(v), "{") {
+			return nil, false, nil
+		}
+		converted, _, err := gmstypes.JSON.Convert(v)
+		if err != nil {
+			return nil, false, sql.ErrInvalidArgumentDetails.New(rh.Name(), fmt.Sprintf("%s - %s", expr.String(), err.Error()))
+		}
+		doc = converted.(gmstypes.JSONDocument)
+	default:
+		return nil, false, nil
+	}
+
+	obj, ok := doc.Val.(map[string]interface{})
+	if !ok {
+		return nil, false, sql.ErrInvalidArgumentDetails.New(rh.Name(), fmt.Sprintf("%s - primary key must be a JSON object", expr.String()))
+	}
+	return obj, true, nil
+}
+
+// primaryKeyArgsFromJSON returns the values of the key columns |pkCols| in the JSON object |obj|, whose fields must name
+// exactly those columns, case-insensitive.
+func primaryKeyArgsFromJSON(tableName string, obj map[string]interface{}, pkCols []schema.Column) ([]interface{}, error) {
+	fields := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		fields[strings.ToLower(k)] = v
+	}
+
+	pkNames := make([]string, len(pkCols))
+	for i, col := range pkCols {
+		pkNames[i] = col.Name
+	}
+
+	args := make([]interface{}, len(pkCols))
+	for i, col := range pkCols {
+		v, ok := fields[strings.ToLower(col.Name)]
+		if !ok || len(fields) != len(pkCols) {
+			return nil, fmt.Errorf("primary key given does not match the primary key of table %s: (%s)", tableName, strings.Join(pkNames, ", "))
+		}
+		args[i] = v
+	}
+	return args, nil
+}
+
+// RowIter implements the sql.Node interface
+func (rh *RowHistoryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := rh.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", rh.database)
+	}
+
+	ddb := sqledb.DbData().Ddb
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, fmt.Errorf("%s is only supported for databases in the %s format", rh.Name(), types.Format_DOLT.VersionString())
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, sqledb.Name())
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{headHash}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := newRowVersionLookup(rh.tableName, rh.tableSch, rh.pkVals)
+	var rows []sql.Row
+	for {
+		h, cm, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		fromRow, toRow, changed, err := lookup.rowChangedInCommit(ctx, ddb, h, cm)
+		if err != nil {
+			return nil, err
+		}
+		if changed == "" {
+			continue
+		}
+
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		r := sql.NewRow(h.String(), meta.Name, meta.Time(), changed)
+		if toRow == nil {
+			toRow = make(sql.Row, len(rh.tableSch))
+		}
+		if fromRow == nil {
+			fromRow = make(sql.Row, len(rh.tableSch))
+		}
+		r = append(r, toRow...)
+		rows = append(rows, append(r, fromRow...))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// rowVersion is the value of a row in one version of a table, which is nil if the row or table doesn't exist
+type rowVersion struct {
+	row sql.Row
+}
+
+// rowVersionLookup looks up a row by primary key in versions of a table, reading each distinct version of the table
+// at most once.
+type rowVersionLookup struct {
+	tableName string
+	sch       sql.Schema
+	pkVals    []interface{}
+
+	// tableHashes are the addresses of the table at each commit looked at, or an empty hash if it doesn't exist
+	tableHashes map[hash.Hash]hash.Hash
+	// versions are the rows found in each version of the table
+	versions map[hash.Hash]rowVersion
+}
+
+func newRowVersionLookup(tableName string, sch sql.Schema, pkVals []interface{}) *rowVersionLookup {
+	return &rowVersionLookup{
+		tableName:   tableName,
+		sch:         sch,
+		pkVals:      pkVals,
+		tableHashes: make(map[hash.Hash]hash.Hash),
+		versions:    make(map[hash.Hash]rowVersion),
+	}
+}
+
+// rowChangedInCommit returns the row at the commit given's first parent and at the commit, and how it was changed:
+// "added", "modified" or "removed", or an empty string if it wasn't changed.
+func (l *rowVersionLookup) rowChangedInCommit(ctx *sql.Context, ddb *doltdb.DoltDB, h hash.Hash, cm *doltdb.Commit) (sql.Row, sql.Row, string, error) {
+	toHash, err := l.tableHashAt(ctx, h, cm)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	var fromHash hash.Hash
+	var parent *doltdb.Commit
+	if cm.NumParents() > 0 {
+		parent, err = ddb.ResolveParent(ctx, cm, 0)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		parentHash, err := parent.HashOf()
+		if err != nil {
+			return nil, nil, "", err
+		}
+		fromHash, err = l.tableHashAt(ctx, parentHash, parent)
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	if toHash == fromHash {
+		return nil, nil, "", nil
+	}
+
+	to, err := l.rowAt(ctx, toHash, cm)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	from, err := l.rowAt(ctx, fromHash, parent)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	switch {
+	case from.row == nil && to.row == nil:
+		return nil, nil, "", nil
+	case from.row == nil:
+		return nil, to.row, "added", nil
+	case to.row == nil:
+		return from.row, nil, "removed", nil
+	}
+
+	equal, err := l.rowsEqual(ctx, from.row, to.row)
+	if err != nil || equal {
+		return nil, nil, "", err
+	}
+	return from.row, to.row, "modified", nil
+}
+
+// tableHashAt returns the address of the table at the commit given, with hash |h|
+func (l *rowVersionLookup) tableHashAt(ctx *sql.Context, h hash.Hash, cm *doltdb.Commit) (hash.Hash, error) {
+	if tableHash, ok := l.tableHashes[h]; ok {
+		return tableHash, nil
+	}
+
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	tableHash, _, err := root.GetTableHash(ctx, l.tableName)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	l.tableHashes[h] = tableHash
+	return tableHash, nil
+}
+
+// rowAt returns the row in the version of the table with the address given, looking it up in the table at |cm| if
+// that version hasn't been read before.
+func (l *rowVersionLookup) rowAt(ctx *sql.Context, tableHash hash.Hash, cm *doltdb.Commit) (rowVersion, error) {
+	if tableHash.IsEmpty() {
+		return rowVersion{}, nil
+	}
+	if v, ok := l.versions[tableHash]; ok {
+		return v, nil
+	}
+
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return rowVersion{}, err
+	}
+	table, ok, err := root.GetTable(ctx, l.tableName)
+	if err != nil {
+		return rowVersion{}, err
+	} else if !ok {
+		return rowVersion{}, nil
+	}
+
+	row, err := l.lookupRow(ctx, table)
+	if err != nil {
+		return rowVersion{}, err
+	}
+
+	v := rowVersion{row: row}
+	l.versions[tableHash] = v
+	return v, nil
+}
+
+// lookupRow returns the row with the primary key being looked up in |table|, with the columns of the table's schema
+// at HEAD. Columns that don't exist in this version of the table are nil. Returns nil if there is no such row, or if
+// the primary key of this version of the table is different.
+func (l *rowVersionLookup) lookupRow(ctx *sql.Context, table *doltdb.Table) (sql.Row, error) {
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pkCols := sch.GetPKCols().GetColumns()
+	if schema.IsKeyless(sch) || len(pkCols) != len(l.pkVals) {
+		return nil, nil
+	}
+
+	rowData, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.ProllyMapFromIndex(rowData)
+	ns := m.NodeStore()
+	kd, vd := m.Descriptors()
+
+	tb := val.NewTupleBuilder(kd)
+	for i, col := range pkCols {
+		idx := l.sch.IndexOfColName(col.Name)
+		if idx < 0 || !l.sch[idx].PrimaryKey {
+			return nil, nil
+		}
+		v, _, err := col.TypeInfo.ToSqlType().Convert(l.pkVals[i])
+		if err != nil {
+			return nil, nil
+		}
+		if err = index.PutField(ctx, ns, tb, i, v); err != nil {
+			return nil, err
+		}
+	}
+	key := tb.Build(ns.Pool())
+
+	var row sql.Row
+	err = m.Get(ctx, key, func(k, v val.Tuple) error {
+		if k == nil {
+			return nil
+		}
+
+		row = make(sql.Row, len(l.sch))
+		for i, col := range pkCols {
+			if err := l.putField(ctx, row, col.Name, kd, i, k, ns); err != nil {
+				return err
+			}
+		}
+		for i, col := range sch.GetNonPKCols().GetColumns() {
+			if err := l.putField(ctx, row, col.Name, vd, i, v, ns); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// putField sets the value of the column named in |row| to the field of |tup| at index |i|, if the column exists in the
+// table's schema at HEAD.
+func (l *rowVersionLookup) putField(ctx *sql.Context, row sql.Row, colName string, desc val.TupleDesc, i int, tup val.Tuple, ns tree.NodeStore) error {
+	idx := l.sch.IndexOfColName(colName)
+	if idx < 0 {
+		return nil
+	}
+	v, err := index.GetField(ctx, desc, i, tup, ns)
+	if err != nil {
+		return err
+	}
+	row[idx], _, err = l.sch[idx].Type.Convert(v)
+	return err
+}
+
+// rowsEqual returns whether every column of the two rows given are equal
+func (l *rowVersionLookup) rowsEqual(ctx *sql.Context, from, to sql.Row) (bool, error) {
+	for i, col := range l.sch {
+		if from[i] == nil || to[i] == nil {
+			if from[i] != to[i] {
+				return false, nil
+			}
+			continue
+		}
+		cmp, err := col.Type.Compare(from[i], to[i])
+		if err != nil || cmp != 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
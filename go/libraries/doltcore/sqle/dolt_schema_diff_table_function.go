@@ -0,0 +1,330 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var _ sql.TableFunction = (*SchemaDiffTableFunction)(nil)
+var _ sql.ExecSourceRel = (*SchemaDiffTableFunction)(nil)
+
+// SchemaDiffTableFunction lists the columns that were added, dropped, modified or renamed between two refs, e.g.
+// dolt_schema_diff('main~', 'main') or dolt_schema_diff('main~..main', 't'). Columns are matched across the refs by
+// their tags, so a column whose name changed is renamed rather than dropped and added. A column is modified when its
+// type, default or nullability changed. Every column of a table added or dropped between the refs is listed as added
+// or dropped.
+type SchemaDiffTableFunction struct {
+	ctx *sql.Context
+
+	fromCommitExpr sql.Expression
+	toCommitExpr   sql.Expression
+	dotCommitExpr  sql.Expression
+	tableNameExpr  sql.Expression
+	database       sql.Database
+}
+
+var schemaDiffTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "column_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "change_type", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "from_type", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "to_type", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "from_default", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "to_default", Type: types.LongText, Nullable: true},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (sdf *SchemaDiffTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &SchemaDiffTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (sdf *SchemaDiffTableFunction) Database() sql.Database {
+	return sdf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (sdf *SchemaDiffTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nsdf := *sdf
+	nsdf.database = database
+	return &nsdf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (sdf *SchemaDiffTableFunction) Name() string {
+	return "dolt_schema_diff"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (sdf *SchemaDiffTableFunction) Resolved() bool {
+	for _, expr := range sdf.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (sdf *SchemaDiffTableFunction) String() string {
+	var args []string
+	for _, expr := range sdf.Expressions() {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_SCHEMA_DIFF(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (sdf *SchemaDiffTableFunction) Schema() sql.Schema {
+	return schemaDiffTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (sdf *SchemaDiffTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (sdf *SchemaDiffTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return sdf, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (sdf *SchemaDiffTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	if sdf.tableNameExpr != nil {
+		tableName, err := expressionToString(ctx, sdf.tableNameExpr)
+		if err != nil {
+			return false
+		}
+
+		return opChecker.UserHasPrivileges(ctx,
+			sql.NewPrivilegedOperation(sdf.database.Name(), tableName, "", sql.PrivilegeType_Select))
+	}
+
+	tblNames, err := sdf.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		operations = append(operations, sql.NewPrivilegedOperation(sdf.database.Name(), tblName, "", sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (sdf *SchemaDiffTableFunction) Expressions() []sql.Expression {
+	var exprs []sql.Expression
+	if sdf.dotCommitExpr != nil {
+		exprs = append(exprs, sdf.dotCommitExpr)
+	} else {
+		exprs = append(exprs, sdf.fromCommitExpr, sdf.toCommitExpr)
+	}
+	if sdf.tableNameExpr != nil {
+		exprs = append(exprs, sdf.tableNameExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (sdf *SchemaDiffTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(sdf.Name(), "1 to 3", len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(sdf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(sdf.Name(), expr.String())
+		}
+		if !types.IsText(expr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(sdf.Name(), expr.String())
+		}
+	}
+
+	nsdf := *sdf
+	nsdf.fromCommitExpr, nsdf.toCommitExpr, nsdf.dotCommitExpr, nsdf.tableNameExpr = nil, nil, nil, nil
+	if strings.Contains(expression[0].String(), "..") {
+		if len(expression) > 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(sdf.Name(), "1 or 2", len(expression))
+		}
+		nsdf.dotCommitExpr = expression[0]
+		if len(expression) == 2 {
+			nsdf.tableNameExpr = expression[1]
+		}
+	} else {
+		if len(expression) < 2 || len(expression) > 3 {
+			return nil, sql.ErrInvalidArgumentNumber.New(sdf.Name(), "2 or 3", len(expression))
+		}
+		nsdf.fromCommitExpr = expression[0]
+		nsdf.toCommitExpr = expression[1]
+		if len(expression) == 3 {
+			nsdf.tableNameExpr = expression[2]
+		}
+	}
+
+	return &nsdf, nil
+}
+
+// RowIter implements the sql.Node interface
+func (sdf *SchemaDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	var fromCommitVal, toCommitVal, dotCommitVal interface{}
+	var err error
+	if sdf.dotCommitExpr != nil {
+		dotCommitVal, err = sdf.dotCommitExpr.Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		fromCommitVal, err = sdf.fromCommitExpr.Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		toCommitVal, err = sdf.toCommitExpr.Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sqledb, ok := sdf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", sdf.database)
+	}
+
+	fromDetails, toDetails, err := loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromDetails.root, toDetails.root)
+	if err != nil {
+		return nil, err
+	}
+
+	if sdf.tableNameExpr != nil {
+		tableName, err := expressionToString(ctx, sdf.tableNameExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		delta := findMatchingDelta(deltas, tableName)
+		if delta.FromTable == nil && delta.ToTable == nil {
+			// an unchanged table has no delta, so only a table missing from both refs is an error
+			_, _, ok, err := toDetails.root.GetTableInsensitive(ctx, tableName)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, sql.ErrTableNotFound.New(tableName)
+			}
+			return sql.RowsToRowIter(), nil
+		}
+		deltas = []diff.TableDelta{delta}
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return strings.Compare(deltas[i].CurName(), deltas[j].CurName()) < 0
+	})
+
+	var rows []sql.Row
+	for _, delta := range deltas {
+		rows = append(rows, getColumnChangeRows(delta)...)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// getColumnChangeRows returns a row for each column changed in |delta|, in the order of the columns in the new schema
+// followed by the dropped columns in the order of the old schema.
+func getColumnChangeRows(delta diff.TableDelta) []sql.Row {
+	tableName := delta.CurName()
+	fromCols, toCols := schema.EmptyColColl, schema.EmptyColColl
+	if delta.FromSch != nil {
+		fromCols = delta.FromSch.GetAllCols()
+	}
+	if delta.ToSch != nil {
+		toCols = delta.ToSch.GetAllCols()
+	}
+
+	var rows []sql.Row
+	_ = toCols.Iter(func(tag uint64, toCol schema.Column) (stop bool, err error) {
+		fromCol, ok := fromCols.GetByTag(tag)
+		if !ok {
+			rows = append(rows, sql.Row{tableName, toCol.Name, "added", nil, columnTypeString(toCol), nil, columnDefault(toCol)})
+			return false, nil
+		}
+
+		changeType := ""
+		if fromCol.Name != toCol.Name {
+			changeType = "renamed"
+		} else if columnTypeString(fromCol) != columnTypeString(toCol) || fromCol.Default != toCol.Default || fromCol.IsNullable() != toCol.IsNullable() {
+			changeType = "modified"
+		}
+		if changeType != "" {
+			rows = append(rows, sql.Row{tableName, toCol.Name, changeType, columnTypeString(fromCol), columnTypeString(toCol), columnDefault(fromCol), columnDefault(toCol)})
+		}
+		return false, nil
+	})
+
+	_ = fromCols.Iter(func(tag uint64, fromCol schema.Column) (stop bool, err error) {
+		if _, ok := toCols.GetByTag(tag); !ok {
+			rows = append(rows, sql.Row{tableName, fromCol.Name, "dropped", columnTypeString(fromCol), nil, columnDefault(fromCol), nil})
+		}
+		return false, nil
+	})
+
+	return rows
+}
+
+// columnTypeString returns the SQL type of |col|, e.g. varchar(20)
+func columnTypeString(col schema.Column) string {
+	return col.TypeInfo.ToSqlType().String()
+}
+
+// columnDefault returns the default value expression of |col|, or nil if it has none
+func columnDefault(col schema.Column) interface{} {
+	if len(col.Default) == 0 {
+		return nil
+	}
+	return col.Default
+}
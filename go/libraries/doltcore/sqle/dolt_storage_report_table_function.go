@@ -0,0 +1,463 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.TableFunction = (*StorageReportTableFunction)(nil)
+var _ sql.ExecSourceRel = (*StorageReportTableFunction)(nil)
+
+// StorageReportTableFunction breaks down the storage used by each table, e.g. dolt_storage_report() or
+// dolt_storage_report('t'). Every chunk of table data reachable from any branch or tag is attributed to exactly one
+// table and one category, so the totals of all rows add up to the table data in the database:
+//
+//	live_data_bytes   chunks of the table's primary index at the session's HEAD
+//	index_bytes       chunks of the table's secondary indexes at HEAD, not counted above
+//	historical_bytes  chunks only reachable from other versions of the table, in history or on other branches
+//	shared_bytes      chunks also reachable from other tables. Shared chunks are attributed to the first table that
+//	                  references them, in table name order, and aren't counted by any other table
+//
+// Commits, root values, schemas and other metadata aren't attributed to any table, so the totals are a little less
+// than the size of the store. When the report is scoped to a single table, other tables aren't walked, so chunks that
+// table shares with others are reported in its other categories rather than as shared.
+//
+// Computing the report walks every version of every table, which is expensive for databases with long histories. The
+// walk can be cancelled by killing the query, and its progress by table is reported in the process list.
+type StorageReportTableFunction struct {
+	ctx *sql.Context
+
+	tableNameExpr sql.Expression
+	database      sql.Database
+}
+
+var storageReportTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: gmstypes.LongText, Nullable: false},
+	&sql.Column{Name: "live_data_bytes", Type: gmstypes.Uint64, Nullable: false},
+	&sql.Column{Name: "index_bytes", Type: gmstypes.Uint64, Nullable: false},
+	&sql.Column{Name: "historical_bytes", Type: gmstypes.Uint64, Nullable: false},
+	&sql.Column{Name: "shared_bytes", Type: gmstypes.Uint64, Nullable: false},
+	&sql.Column{Name: "chunk_count", Type: gmstypes.Uint64, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (sr *StorageReportTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &StorageReportTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (sr *StorageReportTableFunction) Database() sql.Database {
+	return sr.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (sr *StorageReportTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nsr := *sr
+	nsr.database = database
+	return &nsr, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (sr *StorageReportTableFunction) Name() string {
+	return "dolt_storage_report"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (sr *StorageReportTableFunction) Resolved() bool {
+	return sr.tableNameExpr == nil || sr.tableNameExpr.Resolved()
+}
+
+// String implements the Stringer interface
+func (sr *StorageReportTableFunction) String() string {
+	if sr.tableNameExpr != nil {
+		return fmt.Sprintf("DOLT_STORAGE_REPORT(%s)", sr.tableNameExpr.String())
+	}
+	return "DOLT_STORAGE_REPORT()"
+}
+
+// Schema implements the sql.Node interface.
+func (sr *StorageReportTableFunction) Schema() sql.Schema {
+	return storageReportTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (sr *StorageReportTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (sr *StorageReportTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return sr, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (sr *StorageReportTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := sr.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		operations = append(operations, sql.NewPrivilegedOperation(sr.database.Name(), tblName, "", sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (sr *StorageReportTableFunction) Expressions() []sql.Expression {
+	if sr.tableNameExpr != nil {
+		return []sql.Expression{sr.tableNameExpr}
+	}
+	return []sql.Expression{}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (sr *StorageReportTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) > 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(sr.Name(), "0 or 1", len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(sr.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(sr.Name(), expr.String())
+		}
+		if !gmstypes.IsText(expr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(sr.Name(), expr.String())
+		}
+	}
+
+	nsr := *sr
+	nsr.tableNameExpr = nil
+	if len(expression) == 1 {
+		nsr.tableNameExpr = expression[0]
+	}
+
+	return &nsr, nil
+}
+
+// RowIter implements the sql.Node interface
+func (sr *StorageReportTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := sr.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", sr.database)
+	}
+
+	ddb := sqledb.DbData().Ddb
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, fmt.Errorf("%s is only supported for databases in the %s format", sr.Name(), types.Format_DOLT.VersionString())
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, sqledb.Name())
+	if err != nil {
+		return nil, err
+	}
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	historicalRoots, err := storageReportHistoricalRoots(ctx, ddb, head)
+	if err != nil {
+		return nil, err
+	}
+
+	var tableNames []string
+	if sr.tableNameExpr != nil {
+		tableName, err := expressionToString(ctx, sr.tableNameExpr)
+		if err != nil {
+			return nil, err
+		}
+		tableNames = []string{tableName}
+	} else {
+		tableNames, err = storageReportTableNames(ctx, append(historicalRoots, headRoot))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := newStorageReport()
+	for _, tableName := range tableNames {
+		err = report.walkTable(ctx, tableName, headRoot, historicalRoots)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// chunks are only known to be shared once every table has been walked
+	stats := report.stats()
+	rows := make([]sql.Row, len(tableNames))
+	for i, tableName := range tableNames {
+		s, ok := stats[tableName]
+		if !ok {
+			s = &storageReportStats{}
+		}
+		rows[i] = sql.Row{tableName, s.liveBytes, s.indexBytes, s.historicalBytes, s.sharedBytes, s.chunkCount}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// storageReportHistoricalRoots returns the root values of every commit reachable from a branch or a tag, other than
+// |head|.
+func storageReportHistoricalRoots(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit) ([]*doltdb.RootValue, error) {
+	headHash, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	startHashes := []hash.Hash{headHash}
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		startHashes = append(startHashes, b.Hash)
+	}
+	tags, err := ddb.GetTagsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		startHashes = append(startHashes, t.Hash)
+	}
+
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, startHashes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []*doltdb.RootValue
+	for {
+		h, commit, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if h == headHash {
+			continue
+		}
+
+		root, err := commit.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
+
+// storageReportTableNames returns the sorted names of every table in the roots given
+func storageReportTableNames(ctx *sql.Context, roots []*doltdb.RootValue) ([]string, error) {
+	names := make(map[string]struct{})
+	for _, root := range roots {
+		tableNames, err := root.GetTableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range tableNames {
+			names[name] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// storageClass is the category a chunk is attributed to within a table, in order of precedence
+type storageClass int
+
+const (
+	storageClassLive storageClass = iota
+	storageClassIndex
+	storageClassHistorical
+)
+
+// chunkAttribution records the table and category a chunk is attributed to
+type chunkAttribution struct {
+	table  string
+	class  storageClass
+	size   uint64
+	shared bool
+	// lastTable is the last table whose walk visited this chunk
+	lastTable string
+}
+
+type storageReportStats struct {
+	liveBytes       uint64
+	indexBytes      uint64
+	historicalBytes uint64
+	sharedBytes     uint64
+	chunkCount      uint64
+}
+
+// storageReport attributes the chunks reachable from tables to a single table and category. Tables must be walked one
+// at a time, each in order of category precedence, so that each chunk is attributed to the first table and category
+// that reaches it.
+type storageReport struct {
+	chunks map[hash.Hash]*chunkAttribution
+}
+
+func newStorageReport() *storageReport {
+	return &storageReport{chunks: make(map[hash.Hash]*chunkAttribution)}
+}
+
+// walkTable attributes the chunks reachable from every version of the table named, first at |headRoot| and then in
+// |historicalRoots|. Progress is reported in the process list.
+func (r *storageReport) walkTable(ctx *sql.Context, tableName string, headRoot *doltdb.RootValue, historicalRoots []*doltdb.RootValue) error {
+	progressName := fmt.Sprintf("storage report: %s", tableName)
+	ctx.ProcessList.AddTableProgress(ctx.Pid(), progressName, int64(len(historicalRoots)+1))
+	defer ctx.ProcessList.RemoveTableProgress(ctx.Pid(), progressName)
+
+	err := r.walkTableAtRoot(ctx, tableName, headRoot, storageClassLive, storageClassIndex)
+	if err != nil {
+		return err
+	}
+	ctx.ProcessList.UpdateTableProgress(ctx.Pid(), progressName, 1)
+
+	for _, root := range historicalRoots {
+		err = r.walkTableAtRoot(ctx, tableName, root, storageClassHistorical, storageClassHistorical)
+		if err != nil {
+			return err
+		}
+		ctx.ProcessList.UpdateTableProgress(ctx.Pid(), progressName, 1)
+	}
+
+	return nil
+}
+
+func (r *storageReport) walkTableAtRoot(ctx *sql.Context, tableName string, root *doltdb.RootValue, primaryClass, indexClass storageClass) error {
+	table, ok, err := root.GetTable(ctx, tableName)
+	if err != nil || !ok {
+		return err
+	}
+
+	rowData, err := table.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.walkIndex(ctx, tableName, rowData, primaryClass)
+	if err != nil {
+		return err
+	}
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	indexSet, err := table.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	for _, idx := range sch.Indexes().AllIndexes() {
+		idxData, err := indexSet.GetIndex(ctx, sch, idx.Name())
+		if err != nil {
+			return err
+		}
+		err = r.walkIndex(ctx, tableName, idxData, indexClass)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *storageReport) walkIndex(ctx *sql.Context, tableName string, idx durable.Index, class storageClass) error {
+	m := durable.ProllyMapFromIndex(idx)
+	return tree.WalkReachableNodes(ctx, m.HashOf(), m.Node(), m.NodeStore(), func(_ context.Context, addr hash.Hash, nd tree.Node) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		if c, ok := r.chunks[addr]; ok {
+			// this table already visited this chunk, and everything reachable from it
+			if c.lastTable == tableName {
+				return false, nil
+			}
+			c.lastTable = tableName
+			c.shared = true
+			return true, nil
+		}
+
+		r.chunks[addr] = &chunkAttribution{table: tableName, class: class, size: uint64(nd.Size()), lastTable: tableName}
+		return true, nil
+	})
+}
+
+// stats returns the stats of the chunks attributed to each table
+func (r *storageReport) stats() map[string]*storageReportStats {
+	stats := make(map[string]*storageReportStats)
+	for _, c := range r.chunks {
+		s, ok := stats[c.table]
+		if !ok {
+			s = &storageReportStats{}
+			stats[c.table] = s
+		}
+
+		s.chunkCount++
+		switch {
+		case c.shared:
+			s.sharedBytes += c.size
+		case c.class == storageClassLive:
+			s.liveBytes += c.size
+		case c.class == storageClassIndex:
+			s.indexBytes += c.size
+		default:
+			s.historicalBytes += c.size
+		}
+	}
+	return stats
+}
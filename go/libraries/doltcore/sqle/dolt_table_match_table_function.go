@@ -0,0 +1,206 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var _ sql.TableFunction = (*TableMatchTableFunction)(nil)
+var _ sql.ExecSourceRel = (*TableMatchTableFunction)(nil)
+
+// TableMatchTableFunction compares the address of every table at two refs, e.g. dolt_table_match('main', 'feature').
+// Only the root values of the two refs are read, so this is cheap regardless of the size of the tables, and can be
+// used to confirm that two refs hold identical table contents.
+type TableMatchTableFunction struct {
+	ctx *sql.Context
+
+	fromRefExpr sql.Expression
+	toRefExpr   sql.Expression
+	database    sql.Database
+}
+
+var tableMatchTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "from_hash", Type: types.Text, Nullable: true},
+	&sql.Column{Name: "to_hash", Type: types.Text, Nullable: true},
+	&sql.Column{Name: "matches", Type: types.Boolean, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (tm *TableMatchTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &TableMatchTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Database implements the sql.Databaser interface
+func (tm *TableMatchTableFunction) Database() sql.Database {
+	return tm.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (tm *TableMatchTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ntm := *tm
+	ntm.database = database
+	return &ntm, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (tm *TableMatchTableFunction) Name() string {
+	return "dolt_table_match"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (tm *TableMatchTableFunction) Resolved() bool {
+	return tm.fromRefExpr.Resolved() && tm.toRefExpr.Resolved()
+}
+
+// String implements the Stringer interface
+func (tm *TableMatchTableFunction) String() string {
+	return fmt.Sprintf("DOLT_TABLE_MATCH(%s, %s)", tm.fromRefExpr.String(), tm.toRefExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (tm *TableMatchTableFunction) Schema() sql.Schema {
+	return tableMatchTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (tm *TableMatchTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (tm *TableMatchTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return tm, nil
+}
+
+// CheckPrivileges implements the interface sql.Node.
+func (tm *TableMatchTableFunction) CheckPrivileges(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := tm.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		operations = append(operations, sql.NewPrivilegedOperation(tm.database.Name(), tblName, "", sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (tm *TableMatchTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{tm.fromRefExpr, tm.toRefExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (tm *TableMatchTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(tm.Name(), 2, len(expression))
+	}
+
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(tm.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(tm.Name(), expr.String())
+		}
+		if !types.IsText(expr.Type()) {
+			return nil, sql.ErrInvalidArgumentDetails.New(tm.Name(), expr.String())
+		}
+	}
+
+	ntm := *tm
+	ntm.fromRefExpr = expression[0]
+	ntm.toRefExpr = expression[1]
+
+	return &ntm, nil
+}
+
+// RowIter implements the sql.Node interface
+func (tm *TableMatchTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	fromRef, err := expressionToString(ctx, tm.fromRefExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	toRef, err := expressionToString(ctx, tm.toRefExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb, ok := tm.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", tm.database)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	fromRoot, _, _, err := sess.ResolveRootForRef(ctx, sqledb.Name(), fromRef)
+	if err != nil {
+		return nil, err
+	}
+
+	toRoot, _, _, err := sess.ResolveRootForRef(ctx, sqledb.Name(), toRef)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := diff.GetTableHashMatches(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(matches))
+	for i, m := range matches {
+		rows[i] = tableHashMatchRow(m)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// tableHashMatchRow returns a table_name, from_hash, to_hash, matches row for the comparison given. The hash of a
+// table missing on either side is NULL.
+func tableHashMatchRow(m diff.TableHashMatch) sql.Row {
+	var fromHash, toHash interface{}
+	if !m.FromHash.IsEmpty() {
+		fromHash = m.FromHash.String()
+	}
+	if !m.ToHash.IsEmpty() {
+		toHash = m.ToHash.String()
+	}
+	return sql.Row{m.TableName, fromHash, toHash, m.Matches()}
+}
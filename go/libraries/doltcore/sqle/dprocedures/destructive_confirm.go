@@ -0,0 +1,160 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const confirmFlag = "confirm"
+
+var ErrDestructiveConfirmRequired = errors.NewKind("%s would discard changes to %d tables (%s), changing their row " +
+	"counts by %d rows; use --%s to discard them, or raise @@%s (currently %d)")
+
+// withConfirmFlag adds the --confirm flag to the argument parser of a procedure that can discard changes. The flag
+// allows the procedure to discard changes to more tables than @@dolt_destructive_confirm_threshold.
+func withConfirmFlag(ap *argparser.ArgParser) *argparser.ArgParser {
+	ap.SupportsFlag(confirmFlag, "", fmt.Sprintf("Discard changes even if they affect more tables than @@%s.", dsess.DestructiveConfirmThreshold))
+	return ap
+}
+
+// discardedChanges summarizes the changes a procedure would discard
+type discardedChanges struct {
+	// tables are the names of the tables whose changes would be discarded
+	tables []string
+	// rowCountDelta is the sum of the differences in the row counts of those tables
+	rowCountDelta uint64
+}
+
+// getDiscardedChanges returns the changes that would be lost by replacing each of the roots in |discarded| with the
+// root |kept|. Tables are compared by hash, and rows are estimated from the row counts of each table, so this doesn't
+// read any table data.
+func getDiscardedChanges(ctx *sql.Context, kept *doltdb.RootValue, discarded ...*doltdb.RootValue) (discardedChanges, error) {
+	keptHashes, err := kept.MapTableHashes(ctx)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+
+	deltas := make(map[string]uint64)
+	for _, root := range discarded {
+		hashes, err := root.MapTableHashes(ctx)
+		if err != nil {
+			return discardedChanges{}, err
+		}
+
+		names := make(map[string]struct{}, len(hashes)+len(keptHashes))
+		for name := range hashes {
+			names[name] = struct{}{}
+		}
+		for name := range keptHashes {
+			names[name] = struct{}{}
+		}
+
+		for name := range names {
+			if hashes[name] == keptHashes[name] {
+				continue
+			}
+			delta, err := rowCountDelta(ctx, name, root, kept)
+			if err != nil {
+				return discardedChanges{}, err
+			}
+			if delta >= deltas[name] {
+				// a table can differ in more than one discarded root, so keep the largest difference
+				deltas[name] = delta
+			}
+		}
+	}
+
+	var changes discardedChanges
+	for name, delta := range deltas {
+		changes.tables = append(changes.tables, name)
+		changes.rowCountDelta += delta
+	}
+	sort.Strings(changes.tables)
+
+	return changes, nil
+}
+
+// rowCountDelta returns the difference between the row counts of the table named in the two roots given. A table
+// that doesn't exist in a root has no rows.
+func rowCountDelta(ctx *sql.Context, tableName string, left, right *doltdb.RootValue) (uint64, error) {
+	leftCount, err := tableRowCount(ctx, tableName, left)
+	if err != nil {
+		return 0, err
+	}
+	rightCount, err := tableRowCount(ctx, tableName, right)
+	if err != nil {
+		return 0, err
+	}
+
+	if leftCount > rightCount {
+		return leftCount - rightCount, nil
+	}
+	return rightCount - leftCount, nil
+}
+
+func tableRowCount(ctx *sql.Context, tableName string, root *doltdb.RootValue) (uint64, error) {
+	tbl, ok, err := root.GetTable(ctx, tableName)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return rows.Count()
+}
+
+// checkDestructiveConfirm returns an error if @@dolt_destructive_confirm_threshold is above 0 and the changes returned
+// by |getChanges| affect more tables than it allows, unless the --confirm flag was given. The changes are only computed
+// when the threshold is set. |action| describes what would discard the changes.
+func checkDestructiveConfirm(ctx *sql.Context, apr *argparser.ArgParseResults, action string, getChanges func() (discardedChanges, error)) error {
+	if apr.Contains(confirmFlag) {
+		return nil
+	}
+
+	val, err := ctx.GetSessionVariable(ctx, dsess.DestructiveConfirmThreshold)
+	if err != nil {
+		return err
+	}
+	threshold, ok := val.(int64)
+	if !ok {
+		return fmt.Errorf("unexpected type for variable %s: %T", dsess.DestructiveConfirmThreshold, val)
+	}
+	if threshold <= 0 {
+		return nil
+	}
+
+	changes, err := getChanges()
+	if err != nil {
+		return err
+	}
+	if int64(len(changes.tables)) <= threshold {
+		return nil
+	}
+
+	return ErrDestructiveConfirmRequired.New(action, len(changes.tables), strings.Join(changes.tables, ", "),
+		changes.rowCountDelta, confirmFlag, dsess.DestructiveConfirmThreshold, threshold)
+}
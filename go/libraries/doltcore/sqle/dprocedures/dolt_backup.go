@@ -75,8 +75,7 @@ func doDoltBackup(ctx *sql.Context, args []string) (int, error) {
 	}
 
 	if apr.NArg() == 0 {
-		return statusErr, fmt.Errorf("listing existing backup endpoints in sql is not currently implemented. Let us know if you need this by opening a GitHub issue: https://github.com/dolthub/dolt/issues")
-
+		return statusErr, fmt.Errorf("error: invalid argument, use 'dolt_backups' system table to list backups")
 	}
 	switch apr.Arg(0) {
 	case cli.AddBackupId:
@@ -226,7 +225,7 @@ func syncBackupViaName(ctx *sql.Context, dbData env.DbData, sess *dsess.DoltSess
 
 	b, ok := backups[backupName]
 	if !ok {
-		return fmt.Errorf("error: unknown backup: '%s'; %v", backupName, backups)
+		return fmt.Errorf("error: unknown backup: '%s'", backupName)
 	}
 
 	return syncRoots(ctx, dbData, sess, b)
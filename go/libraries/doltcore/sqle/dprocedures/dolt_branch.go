@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
@@ -53,12 +54,15 @@ func doDoltBranch(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("Empty database name.")
 	}
 
-	apr, err := cli.CreateBranchArgParser().Parse(args)
+	apr, err := withConfirmFlag(cli.CreateBranchArgParser()).Parse(args)
 	if err != nil {
 		return 1, err
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, err
+	}
 	dbData, ok := dSess.GetDbData(ctx, dbName)
 	if !ok {
 		return 1, fmt.Errorf("Could not load database %s", dbName)
@@ -73,6 +77,10 @@ func doDoltBranch(ctx *sql.Context, args []string) (int, error) {
 		err = renameBranch(ctx, dbData, apr, dSess, dbName, &rsc)
 	case apr.Contains(cli.DeleteFlag), apr.Contains(cli.DeleteForceFlag):
 		err = deleteBranches(ctx, dbData, apr, dSess, dbName, &rsc)
+	case apr.Contains(cli.OrphanFlag):
+		err = createOrphanBranch(ctx, dbData, apr, dSess, &rsc)
+	case apr.Contains(cli.SetDescFlag):
+		err = setBranchDescription(ctx, dbData, apr)
 	default:
 		err = createNewBranch(ctx, dbData, apr, &rsc)
 	}
@@ -198,6 +206,15 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParse
 				"running `dolt checkout <another_branch> and restarting the sql-server", branchName, dbName)
 		}
 
+		if force {
+			err = checkDestructiveConfirm(ctx, apr, fmt.Sprintf("deleting branch '%s'", branchName), func() (discardedChanges, error) {
+				return getUnmergedBranchChanges(ctx, dSess, dbData.Ddb, dbName, branchName)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		err = actions.DeleteBranch(ctx, dbData, branchName, actions.DeleteOptions{
 			Force: force,
 		}, dSess.Provider(), rsc)
@@ -209,6 +226,55 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParse
 	return nil
 }
 
+// getUnmergedBranchChanges returns the changes on the branch named, committed or not, since it diverged from the
+// session's current branch, which are lost when the branch is force deleted.
+func getUnmergedBranchChanges(ctx *sql.Context, dSess *dsess.DoltSession, ddb *doltdb.DoltDB, dbName, branchName string) (discardedChanges, error) {
+	branchRef := ref.NewBranchRef(branchName)
+	if ok, err := ddb.HasRef(ctx, branchRef); err != nil || !ok {
+		// deleting a branch that doesn't exist fails without discarding anything
+		return discardedChanges{}, err
+	}
+
+	branchHead, err := ddb.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+	branchRoot, err := branchHead.GetRootValue(ctx)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+
+	wsRef, err := ref.WorkingSetRefForHead(branchRef)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+	ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+	if err == nil {
+		branchRoot = ws.WorkingRoot()
+	} else if !errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+		return discardedChanges{}, err
+	}
+
+	head, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+	mergeBase, err := merge.MergeBase(ctx, branchHead, head)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+	baseCommit, err := ddb.ReadCommit(ctx, mergeBase)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+	baseRoot, err := baseCommit.GetRootValue(ctx)
+	if err != nil {
+		return discardedChanges{}, err
+	}
+
+	return getDiscardedChanges(ctx, baseRoot, branchRoot)
+}
+
 // shouldAllowDefaultBranchDeletion returns true if the default branch deletion check should be
 // bypassed for testing. This should only ever be true for tests that need to invalidate a databases
 // default branch to test recovery from a bad state. We determine if the check should be bypassed by
@@ -354,6 +420,47 @@ func createNewBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgPars
 	return nil
 }
 
+// createOrphanBranch creates a new branch with no history, which starts from an empty commit with no parents
+func createOrphanBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseResults, sess *dsess.DoltSession, rsc *doltdb.ReplicationStatusController) error {
+	if apr.NArg() != 1 || apr.Contains(cli.TrackFlag) {
+		return InvalidArgErr
+	}
+
+	branchName := apr.Arg(0)
+	if len(branchName) == 0 {
+		return EmptyBranchNameErr
+	}
+
+	err := branch_control.CanCreateBranch(ctx, branchName)
+	if err != nil {
+		return err
+	}
+	if apr.Contains(cli.ForceFlag) {
+		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
+			return err
+		}
+	}
+
+	return actions.CreateOrphanBranch(ctx, dbData, branchName, sess.Username(), sess.Email(), apr.Contains(cli.ForceFlag), rsc)
+}
+
+// setBranchDescription sets the description of the branch given, which is shown in the dolt_branches table
+func setBranchDescription(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseResults) error {
+	if apr.NArg() != 2 {
+		return InvalidArgErr
+	}
+
+	branchName := apr.Arg(0)
+	if len(branchName) == 0 {
+		return EmptyBranchNameErr
+	}
+	if err := branch_control.CanDeleteBranch(ctx, branchName); err != nil {
+		return err
+	}
+
+	return actions.SetBranchDescription(ctx, dbData, branchName, apr.Arg(1))
+}
+
 func copyBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseResults, rsc *doltdb.ReplicationStatusController) error {
 	if apr.NArg() != 2 {
 		return InvalidArgErr
@@ -401,5 +508,5 @@ func copyABranch(ctx *sql.Context, dbData env.DbData, srcBr string, destBr strin
 		return err
 	}
 
-	return nil
+	return actions.RecordBranchCreation(ctx, dbData.Rsw, destBr)
 }
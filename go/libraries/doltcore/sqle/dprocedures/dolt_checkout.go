@@ -54,7 +54,7 @@ func doDoltCheckout(ctx *sql.Context, args []string) (int, error) {
 		return -1, err
 	}
 
-	apr, err := cli.CreateCheckoutArgParser().Parse(args)
+	apr, err := withConfirmFlag(cli.CreateCheckoutArgParser()).Parse(args)
 	if err != nil {
 		return 1, err
 	}
@@ -116,13 +116,15 @@ func doDoltCheckout(ctx *sql.Context, args []string) (int, error) {
 		return 0, nil
 	}
 
-	roots, ok := dSess.GetRoots(ctx, dbName)
+	// Tables are checked out in the current database, which is a different session database than the base database
+	// when it's a revision database.
+	roots, ok := dSess.GetRoots(ctx, currentDbName)
 	if !ok {
-		return 1, fmt.Errorf("Could not load database %s", dbName)
+		return 1, fmt.Errorf("Could not load database %s", currentDbName)
 	}
 
-	err = checkoutTables(ctx, roots, dbName, args)
-	if err != nil && apr.NArg() == 1 {
+	err = checkoutTables(ctx, apr, roots, currentDbName, apr.Args)
+	if err != nil && apr.NArg() == 1 && !ErrDestructiveConfirmRequired.Is(err) {
 		err = checkoutRemoteBranch(ctx, dbName, dbData, branchName, apr, &rsc)
 	}
 
@@ -209,6 +211,9 @@ func checkoutRemoteBranch(ctx *sql.Context, dbName string, dbData env.DbData, br
 	if len(remoteRefs) == 0 {
 		return fmt.Errorf("error: could not find %s", branchName)
 	} else if len(remoteRefs) == 1 {
+		if err = dsess.DSessFromSess(ctx.Session).CheckDatabaseWriteLock(ctx, dbName); err != nil {
+			return err
+		}
 		remoteRef := remoteRefs[0]
 		err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, remoteRef.String(), false, rsc)
 		if err != nil {
@@ -258,6 +263,9 @@ func checkoutNewBranch(ctx *sql.Context, dbName string, dbData env.DbData, apr *
 		if err != nil {
 			return err
 		}
+		if err = checkRemoteBranchExists(ctx, dbData, startPt, remoteName, remoteBranchName); err != nil {
+			return err
+		}
 		newBranchName = remoteBranchName
 	}
 
@@ -268,7 +276,13 @@ func checkoutNewBranch(ctx *sql.Context, dbName string, dbData env.DbData, apr *
 		newBranchName = newBranch
 	}
 
-	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, false, rsc)
+	if err = dsess.DSessFromSess(ctx.Session).CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return err
+	}
+
+	// like `git checkout -B`, -f resets an existing branch to the remote branch being tracked
+	force := setTrackUpstream && apr.Contains(cli.ForceFlag)
+	err = actions.CreateBranchWithStartPt(ctx, dbData, newBranchName, startPt, force, rsc)
 	if err != nil {
 		return err
 	}
@@ -278,7 +292,7 @@ func checkoutNewBranch(ctx *sql.Context, dbName string, dbData env.DbData, apr *
 	}
 
 	if setTrackUpstream {
-		err = env.SetRemoteUpstreamForRefSpec(dbData.Rsw, refSpec, remoteName, ref.NewBranchRef(remoteBranchName))
+		err = env.SetRemoteUpstreamForRefSpec(dbData.Rsw, refSpec, remoteName, ref.NewBranchRef(newBranchName))
 		if err != nil {
 			return err
 		}
@@ -288,7 +302,7 @@ func checkoutNewBranch(ctx *sql.Context, dbName string, dbData env.DbData, apr *
 		if err != nil {
 			return nil
 		}
-		err = env.SetRemoteUpstreamForRefSpec(dbData.Rsw, refSpec, remoteName, ref.NewBranchRef(remoteBranchName))
+		err = env.SetRemoteUpstreamForRefSpec(dbData.Rsw, refSpec, remoteName, ref.NewBranchRef(newBranchName))
 		if err != nil {
 			return err
 		}
@@ -297,6 +311,26 @@ func checkoutNewBranch(ctx *sql.Context, dbName string, dbData env.DbData, apr *
 	return nil
 }
 
+// checkRemoteBranchExists returns an error unless |startPt| names a remote-tracking branch of a configured remote
+func checkRemoteBranchExists(ctx *sql.Context, dbData env.DbData, startPt, remoteName, remoteBranchName string) error {
+	remotes, err := dbData.Rsr.GetRemotes()
+	if err != nil {
+		return err
+	}
+	if _, ok := remotes[remoteName]; !ok {
+		return fmt.Errorf("'%s' is not a valid remote ref and a branch '%s' cannot be created from it", startPt, remoteBranchName)
+	}
+
+	hasRef, err := dbData.Ddb.HasRef(ctx, ref.NewRemoteRef(remoteName, remoteBranchName))
+	if err != nil {
+		return err
+	}
+	if !hasRef {
+		return fmt.Errorf("error: remote branch '%s' not found", startPt)
+	}
+	return nil
+}
+
 func checkoutBranch(ctx *sql.Context, dbName string, branchName string) error {
 	wsRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef(branchName))
 	if err != nil {
@@ -311,8 +345,8 @@ func checkoutBranch(ctx *sql.Context, dbName string, branchName string) error {
 	return dSess.SwitchWorkingSet(ctx, dbName, wsRef)
 }
 
-func checkoutTables(ctx *sql.Context, roots doltdb.Roots, name string, tables []string) error {
-	roots, err := actions.MoveTablesFromHeadToWorking(ctx, roots, tables)
+func checkoutTables(ctx *sql.Context, apr *argparser.ArgParseResults, roots doltdb.Roots, name string, tables []string) error {
+	newRoots, err := actions.MoveTablesFromHeadToWorking(ctx, roots, tables)
 
 	if err != nil {
 		if doltdb.IsRootValUnreachable(err) {
@@ -325,6 +359,13 @@ func checkoutTables(ctx *sql.Context, roots doltdb.Roots, name string, tables []
 		}
 	}
 
+	err = checkDestructiveConfirm(ctx, apr, "checkout", func() (discardedChanges, error) {
+		return getDiscardedChanges(ctx, newRoots.Working, roots.Working)
+	})
+	if err != nil {
+		return err
+	}
+
 	dSess := dsess.DSessFromSess(ctx.Session)
-	return dSess.SetRoots(ctx, name, roots)
+	return dSess.SetRoots(ctx, name, newRoots)
 }
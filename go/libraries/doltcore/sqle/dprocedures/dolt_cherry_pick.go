@@ -17,6 +17,7 @@ package dprocedures
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -31,7 +32,9 @@ import (
 var ErrEmptyCherryPick = errors.New("cannot cherry-pick empty string")
 var ErrCherryPickUncommittedChanges = errors.New("cannot cherry-pick with uncommitted changes")
 
-// doltCherryPick is the stored procedure version for the CLI command `dolt cherry-pick`.
+// doltCherryPick is the stored procedure version for the CLI command `dolt cherry-pick`. The hash is empty when no
+// commit is created, i.e. with --no-commit or when the cherry-pick generated conflicts. Conflicts are left in the working
+// set for the dolt_conflicts tables, the same as dolt_merge.
 func doltCherryPick(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	res, err := doDoltCherryPick(ctx, args)
 	if err != nil {
@@ -40,6 +43,17 @@ func doltCherryPick(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	return rowToIter(res), nil
 }
 
+// doltCherryPickHashOut is the stored procedure version for the CLI command `dolt cherry-pick`. The first parameter is
+// the variable to set the hash of the new commit to.
+func doltCherryPickHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.RowIter, error) {
+	res, err := doDoltCherryPick(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	*outHash = res
+	return rowToIter(res), nil
+}
+
 func doDoltCherryPick(ctx *sql.Context, args []string) (string, error) {
 	// Get the information for the sql context.
 	dbName := ctx.GetCurrentDatabase()
@@ -75,11 +89,26 @@ func doDoltCherryPick(ctx *sql.Context, args []string) (string, error) {
 		return "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	newWorkingRoot, commitMsg, err := cherryPick(ctx, dSess, roots, dbName, cherryStr)
+	newWorkingRoot, commitMsg, tablesWithConflict, err := cherryPick(ctx, dSess, roots, dbName, cherryStr)
 	if err != nil {
 		return "", err
 	}
 
+	if len(tablesWithConflict) > 0 {
+		// like dolt_merge, the conflicts are written to the working set, and the transaction fails unless
+		// @@dolt_allow_commit_conflicts is set
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return "", err
+		}
+		err = dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(newWorkingRoot).WithStagedRoot(newWorkingRoot))
+		if err != nil {
+			return "", err
+		}
+		ctx.Warn(DoltMergeWarningCode, fmt.Sprintf("conflicts in table {'%s'}", strings.Join(tablesWithConflict, "', '")))
+		return "", nil
+	}
+
 	err = dSess.SetRoot(ctx, dbName, newWorkingRoot)
 	if err != nil {
 		return "", err
@@ -92,85 +121,89 @@ func doDoltCherryPick(ctx *sql.Context, args []string) (string, error) {
 	if res != 0 {
 		return "", fmt.Errorf("dolt add failed")
 	}
+	if apr.Contains(cli.NoCommitFlag) {
+		return "", nil
+	}
 
-	return doDoltCommit(ctx, []string{"-m", commitMsg})
+	return DoDoltCommit(ctx, []string{"-m", commitMsg})
 }
 
 // cherryPick checks that the current working set is clean, verifies the cherry-pick commit is not a merge commit
 // or a commit without parent commit, performs merge and returns the new working set root value and
 // the commit message of cherry-picked commit as the commit message of the new commit created during this command.
-func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots, dbName, cherryStr string) (*doltdb.RootValue, string, error) {
+// If the merge generated conflicts, the root value with the conflicts is returned along with the tables that have them.
+func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots, dbName, cherryStr string) (*doltdb.RootValue, string, []string, error) {
 	// check for clean working set
 	headRootHash, err := roots.Head.HashOf()
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	workingRootHash, err := roots.Working.HashOf()
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	if workingRootHash != headRootHash {
-		return nil, "", ErrCherryPickUncommittedChanges
+		return nil, "", nil, ErrCherryPickUncommittedChanges
 	}
 
 	stagedRootHash, err := roots.Staged.HashOf()
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	if stagedRootHash != headRootHash {
-		return nil, "", ErrCherryPickUncommittedChanges
+		return nil, "", nil, ErrCherryPickUncommittedChanges
 	}
 
 	doltDB, ok := dSess.GetDoltDB(ctx, dbName)
 	if !ok {
-		return nil, "", fmt.Errorf("failed to get DoltDB")
+		return nil, "", nil, fmt.Errorf("failed to get DoltDB")
 	}
 
 	dbData, ok := dSess.GetDbData(ctx, dbName)
 	if !ok {
-		return nil, "", fmt.Errorf("failed to get dbData")
+		return nil, "", nil, fmt.Errorf("failed to get dbData")
 	}
 
 	cherryCommitSpec, err := doltdb.NewCommitSpec(cherryStr)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	headRef, err := dbData.Rsr.CWBHeadRef()
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	cherryCommit, err := doltDB.Resolve(ctx, cherryCommitSpec, headRef)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	if len(cherryCommit.DatasParents()) > 1 {
-		return nil, "", fmt.Errorf("cherry-picking a merge commit is not supported")
+		return nil, "", nil, fmt.Errorf("cherry-picking a merge commit is not supported")
 	}
 	if len(cherryCommit.DatasParents()) == 0 {
-		return nil, "", fmt.Errorf("cherry-picking a commit without parents is not supported")
+		return nil, "", nil, fmt.Errorf("cherry-picking a commit without parents is not supported")
 	}
 
 	cherryRoot, err := cherryCommit.GetRootValue(ctx)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	parentCommit, err := doltDB.ResolveParent(ctx, cherryCommit, 0)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	parentRoot, err := parentCommit.GetRootValue(ctx)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	} else if !ok {
-		return nil, "", sql.ErrDatabaseNotFound.New(dbName)
+		return nil, "", nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
 	// use parent of cherry-pick as ancestor root to merge
@@ -181,7 +214,7 @@ func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots,
 	}
 	result, err := merge.MergeRoots(ctx, roots.Working, cherryRoot, parentRoot, cherryCommit, parentCommit, dbState.EditOpts(), mo)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	var tablesWithConflict []string
@@ -192,23 +225,23 @@ func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots,
 	}
 
 	if len(tablesWithConflict) > 0 {
-		tblNames := strings.Join(tablesWithConflict, "', '")
-		return nil, "", fmt.Errorf("conflicts in table {'%s'}", tblNames)
+		sort.Strings(tablesWithConflict)
+		return result.Root, "", tablesWithConflict, nil
 	}
 
 	workingRootHash, err = result.Root.HashOf()
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	if headRootHash.Equal(workingRootHash) {
-		return nil, "", fmt.Errorf("no changes were made, nothing to commit")
+		return nil, "", nil, fmt.Errorf("no changes were made, nothing to commit")
 	}
 
 	cherryCommitMeta, err := cherryCommit.GetCommitMeta(ctx)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
-	return result.Root, cherryCommitMeta.Description, nil
+	return result.Root, cherryCommitMeta.Description, nil, nil
 }
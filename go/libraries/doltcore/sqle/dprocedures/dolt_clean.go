@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
@@ -25,46 +26,74 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
+// cleanSchema is the schema of dolt_clean's result. The first row has the status. With --dry-run, it's followed by a
+// row for each table that would be removed, and the working set is left unchanged.
+var cleanSchema = append(int64Schema("status"), &sql.Column{Name: "table_name", Type: types.LongText, Nullable: true})
+
 // doltClean is the stored procedure version for the CLI command `dolt clean`.
 func doltClean(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	res, err := doDoltClean(ctx, args)
+	res, untracked, err := doDoltClean(ctx, args)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(int64(res)), nil
+
+	rows := make([]sql.Row, 0, len(untracked)+1)
+	rows = append(rows, sql.NewRow(int64(res), nil))
+	for _, tableName := range untracked {
+		rows = append(rows, sql.NewRow(int64(res), tableName))
+	}
+	return sql.RowsToRowIter(rows...), nil
 }
 
-func doDoltClean(ctx *sql.Context, args []string) (int, error) {
+// doDoltClean cleans the untracked tables named in |args|, or all untracked tables. With --dry-run, it returns the
+// tables that would be removed instead.
+func doDoltClean(ctx *sql.Context, args []string) (int, []string, error) {
 	dbName := ctx.GetCurrentDatabase()
 
 	if len(dbName) == 0 {
-		return 1, fmt.Errorf("Empty database name.")
+		return 1, nil, fmt.Errorf("Empty database name.")
 	}
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
-		return statusErr, err
+		return statusErr, nil, err
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
 
 	apr, err := cli.CreateCleanArgParser().Parse(args)
 	if err != nil {
-		return 1, err
+		return 1, nil, err
+	}
+
+	db, err := dSess.Provider().Database(ctx, dbName)
+	if err != nil {
+		return 1, nil, err
+	}
+	if rodb, ok := db.(sql.ReadOnlyDatabase); ok && rodb.IsReadOnly() {
+		return 1, nil, fmt.Errorf("unable to clean untracked tables in read-only databases")
 	}
 
 	// Get all the needed roots.
 	roots, ok := dSess.GetRoots(ctx, dbName)
 	if !ok {
-		return 1, fmt.Errorf("Could not load database %s", dbName)
+		return 1, nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	if apr.Contains(cli.DryRunFlag) {
+		untracked, err := actions.UntrackedTables(ctx, roots, apr.Args)
+		if err != nil {
+			return 1, nil, fmt.Errorf("failed to clean; %w", err)
+		}
+		return 0, untracked, nil
 	}
 
-	roots, err = actions.CleanUntracked(ctx, roots, apr.Args, apr.ContainsAll(cli.DryRunFlag), false)
+	roots, err = actions.CleanUntracked(ctx, roots, apr.Args, false, false)
 	if err != nil {
-		return 1, fmt.Errorf("failed to clean; %w", err)
+		return 1, nil, fmt.Errorf("failed to clean; %w", err)
 	}
 
 	err = dSess.SetRoots(ctx, dbName, roots)
 	if err != nil {
-		return 1, err
+		return 1, nil, err
 	}
-	return 0, nil
+	return 0, nil, nil
 }
@@ -17,22 +17,22 @@ package dprocedures
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
-	"github.com/dolthub/vitess/go/vt/proto/query"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
 )
 
-var hashType = types.MustCreateString(query.Type_TEXT, 32, sql.Collation_ascii_bin)
-
 // doltCommit is the stored procedure version for the CLI command `dolt commit`.
 func doltCommit(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	res, err := doDoltCommit(ctx, args)
+	res, err := DoDoltCommit(ctx, args)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func doltCommit(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 // doltCommitHashOut is the stored procedure version for the CLI function `commit`. The first parameter is the variable
 // to set the hash of.
 func doltCommitHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.RowIter, error) {
-	res, err := doDoltCommit(ctx, args)
+	res, err := DoDoltCommit(ctx, args)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +50,8 @@ func doltCommitHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.R
 	return rowToIter(res), nil
 }
 
-func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
+// DoDoltCommit creates a commit with the arguments of `dolt commit` and returns its hash.
+func DoDoltCommit(ctx *sql.Context, args []string) (string, error) {
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
 		return "", err
 	}
@@ -93,18 +94,22 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 
 	amend := apr.Contains(cli.AmendFlag)
 
+	var amendedMeta *datas.CommitMeta
+	if amend {
+		commit, err := dSess.GetHeadCommit(ctx, dbName)
+		if err != nil {
+			return "", err
+		}
+		amendedMeta, err = commit.GetCommitMeta(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	msg, msgOk := apr.GetValue(cli.MessageArg)
 	if !msgOk {
 		if amend {
-			commit, err := dSess.GetHeadCommit(ctx, dbName)
-			if err != nil {
-				return "", err
-			}
-			commitMeta, err := commit.GetCommitMeta(ctx)
-			if err != nil {
-				return "", err
-			}
-			msg = commitMeta.Description
+			msg = amendedMeta.Description
 		} else {
 			return "", fmt.Errorf("Must provide commit message.")
 		}
@@ -120,9 +125,37 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 		}
 	}
 
+	// --date sets both the commit and author dates, and --amend keeps the author date of the amended commit unless
+	// either is given
+	var authorDate time.Time
+	if authorDateStr, ok := apr.GetValue(cli.AuthorDateParam); ok {
+		authorDate, err = cli.ParseDate(authorDateStr)
+		if err != nil {
+			return "", err
+		}
+	} else if amend && !apr.Contains(cli.DateParam) {
+		authorDate = amendedMeta.AuthorTime()
+	}
+
+	// the tag is checked before committing, so that a commit is never made without the tag that was asked for
+	tagName, tagOk := apr.GetValue(cli.TagParam)
+	if tagOk {
+		dbData, ok := dSess.GetDbData(ctx, dbName)
+		if !ok {
+			return "", fmt.Errorf("Could not load database %s", dbName)
+		}
+		err = actions.CheckNewTagName(ctx, dbData.Ddb, tagName)
+		if err == actions.ErrAlreadyExists {
+			return "", fmt.Errorf("tag '%s' already exists", tagName)
+		} else if err != nil {
+			return "", err
+		}
+	}
+
 	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, actions.CommitStagedProps{
 		Message:    msg,
 		Date:       t,
+		AuthorDate: authorDate,
 		AllowEmpty: apr.Contains(cli.AllowEmptyFlag),
 		Amend:      amend,
 		Force:      apr.Contains(cli.ForceFlag),
@@ -143,6 +176,18 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 		return "", err
 	}
 
+	if tagOk {
+		dbData, _ := dSess.GetDbData(ctx, dbName)
+		err = dbData.Ddb.NewTagAtCommit(ctx, ref.NewTagRef(tagName), newCommit, datas.NewTagMeta(name, email, ""))
+		if err != nil {
+			return "", err
+		}
+		err = actions.RecordTagCreation(ctx, dbData.Rsw, tagName)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	h, err := newCommit.HashOf()
 	if err != nil {
 		return "", err
@@ -151,7 +196,8 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, error) {
 	return h.String(), nil
 }
 
-func getDoltArgs(ctx *sql.Context, row sql.Row, children []sql.Expression) ([]string, error) {
+// GetDoltArgs evaluates |children| against |row| and converts the results to the string arguments of a dolt command.
+func GetDoltArgs(ctx *sql.Context, row sql.Row, children []sql.Expression) ([]string, error) {
 	args := make([]string, len(children))
 	for i := range children {
 		childVal, err := children[i].Eval(ctx, row)
@@ -15,9 +15,12 @@
 package dprocedures
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
@@ -48,7 +52,7 @@ func doltConflictsResolve(ctx *sql.Context, args ...string) (sql.RowIter, error)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(res), nil
+	return rowToIter(int64(res)), nil
 }
 
 // DoltConflictsCatFunc runs a `dolt commit` in the SQL context, committing staged changes to head.
@@ -381,34 +385,44 @@ func ResolveSchemaConflicts(ctx *sql.Context, ddb *doltdb.DoltDB, ws *doltdb.Wor
 }
 
 func ResolveDataConflicts(ctx *sql.Context, dSess *dsess.DoltSession, root *doltdb.RootValue, dbName string, ours bool, tblNames []string) error {
+	root, err := resolveDataConflictsInRoot(ctx, dSess, root, dbName, ours, tblNames)
+	if err != nil {
+		return err
+	}
+	return dSess.SetRoot(ctx, dbName, root)
+}
+
+// resolveDataConflictsInRoot resolves the data conflicts of the tables named in |root| to our or their side, and
+// returns the resulting root.
+func resolveDataConflictsInRoot(ctx *sql.Context, dSess *dsess.DoltSession, root *doltdb.RootValue, dbName string, ours bool, tblNames []string) (*doltdb.RootValue, error) {
 	for _, tblName := range tblNames {
 		tbl, ok, err := root.GetTable(ctx, tblName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if !ok {
-			return doltdb.ErrTableNotFound
+			return nil, doltdb.ErrTableNotFound
 		}
 
 		if has, err := tbl.HasConflicts(ctx); err != nil {
-			return err
+			return nil, err
 		} else if !has {
 			continue
 		}
 
 		sch, err := tbl.GetSchema(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		_, ourSch, theirSch, err := tbl.GetConflictSchemas(ctx, tblName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if ours && !schema.ColCollsAreEqual(sch.GetAllCols(), ourSch.GetAllCols()) {
-			return ErrConfSchIncompatible
+			return nil, ErrConfSchIncompatible
 		} else if !ours && !schema.ColCollsAreEqual(sch.GetAllCols(), theirSch.GetAllCols()) {
-			return ErrConfSchIncompatible
+			return nil, ErrConfSchIncompatible
 		}
 
 		if !ours {
@@ -417,29 +431,297 @@ func ResolveDataConflicts(ctx *sql.Context, dSess *dsess.DoltSession, root *dolt
 			} else {
 				state, _, err := dSess.LookupDbState(ctx, dbName)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				opts := state.WriteSession.GetOptions()
 				tbl, err = resolveNomsConflicts(ctx, opts, tbl, tblName, sch)
 			}
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 
 		newRoot, err := clearTableAndUpdateRoot(ctx, root, tbl, tblName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = validateConstraintViolations(ctx, root, newRoot, tblName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		root = newRoot
 	}
-	return dSess.SetRoot(ctx, dbName, root)
+	return root, nil
+}
+
+// conflictRowFromJSON returns the row of |sch| given by the JSON object |rowJson|, validated against the columns of
+// |sch|. Primary key columns missing from |rowJson| are taken from the JSON object |pkJson|, which must name every
+// primary key column of |sch|.
+func conflictRowFromJSON(sch schema.Schema, pkJson, rowJson string) (sql.Row, error) {
+	pkVals, err := parseJSONObject(pkJson)
+	if err != nil {
+		return nil, fmt.Errorf("invalid primary key %s: %w", pkJson, err)
+	}
+	rowVals, err := parseJSONObject(rowJson)
+	if err != nil {
+		return nil, fmt.Errorf("invalid row %s: %w", rowJson, err)
+	}
+
+	allCols := sch.GetAllCols()
+	for name := range pkVals {
+		if _, ok := sch.GetPKCols().GetByNameCaseInsensitive(name); !ok {
+			return nil, fmt.Errorf("invalid primary key %s: %s is not a primary key column", pkJson, name)
+		}
+	}
+	for name := range rowVals {
+		if _, ok := allCols.GetByNameCaseInsensitive(name); !ok {
+			return nil, fmt.Errorf("invalid row %s: unknown column %s", rowJson, name)
+		}
+	}
+
+	r := make(sql.Row, allCols.Size())
+	err = allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		sqlType := col.TypeInfo.ToSqlType()
+		v, inRow := rowVals[strings.ToLower(col.Name)]
+		if inRow {
+			if v, _, err = sqlType.Convert(v); err != nil {
+				return true, fmt.Errorf("invalid value for column %s: %w", col.Name, err)
+			}
+		}
+
+		if col.IsPartOfPK {
+			pkv, ok := pkVals[strings.ToLower(col.Name)]
+			if !ok {
+				return true, fmt.Errorf("invalid primary key %s: missing primary key column %s", pkJson, col.Name)
+			}
+			if pkv, _, err = sqlType.Convert(pkv); err != nil {
+				return true, fmt.Errorf("invalid value for column %s: %w", col.Name, err)
+			}
+			if !inRow {
+				v = pkv
+			} else if cmp, err := sqlType.Compare(v, pkv); err != nil {
+				return true, err
+			} else if cmp != 0 {
+				return true, fmt.Errorf("the row's value for column %s does not match the primary key %s", col.Name, pkJson)
+			}
+		}
+
+		if v == nil && !col.IsNullable() {
+			return true, sql.ErrInsertIntoNonNullableProvidedNull.New(col.Name)
+		}
+		r[allCols.TagToIdx[tag]] = v
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// parseJSONObject returns the values of the JSON object |s| by their lower case keys
+func parseJSONObject(s string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	vals := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if n, ok := v.(json.Number); ok {
+			v = n.String()
+		}
+		vals[strings.ToLower(k)] = v
+	}
+	return vals, nil
+}
+
+// resolveProllyConflictWithValues resolves the conflicts of |tbl| for the row |r| by setting the row to the values of
+// |r|, and returns whether there were any conflicts for the row.
+func resolveProllyConflictWithValues(ctx *sql.Context, tbl *doltdb.Table, sch schema.Schema, r sql.Row) (*doltdb.Table, bool, error) {
+	ns := tbl.NodeStore()
+	allCols := sch.GetAllCols()
+
+	kb := val.NewTupleBuilder(sch.GetKeyDescriptor())
+	for i, col := range sch.GetPKCols().GetColumns() {
+		if err := index.PutField(ctx, ns, kb, i, r[allCols.TagToIdx[col.Tag]]); err != nil {
+			return nil, false, err
+		}
+	}
+	key := kb.Build(ns.Pool())
+
+	vb := val.NewTupleBuilder(sch.GetValueDescriptor())
+	for i, col := range sch.GetNonPKCols().GetColumns() {
+		if err := index.PutField(ctx, ns, vb, i, r[allCols.TagToIdx[col.Tag]]); err != nil {
+			return nil, false, err
+		}
+	}
+	value := vb.Build(ns.Pool())
+
+	// remove the conflicts for the key, of which there is one for each merge that conflicted on it
+	artifactIdx, err := tbl.GetArtifacts(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	artifactMap := durable.ProllyMapFromArtifactIndex(artifactIdx)
+	iter, err := artifactMap.IterAllConflicts(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	artKD, _ := artifactMap.Descriptors()
+	artKB := val.NewTupleBuilder(artKD)
+	artEditor := artifactMap.Editor()
+	found := false
+	for {
+		cnfArt, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if !bytes.Equal(cnfArt.Key, key) {
+			continue
+		}
+
+		found = true
+		for i := 0; i < key.Count(); i++ {
+			artKB.PutRaw(i, key.GetField(i))
+		}
+		artKB.PutCommitAddr(key.Count(), cnfArt.TheirRootIsh)
+		artKB.PutUint8(key.Count()+1, uint8(prolly.ArtifactTypeConflict))
+		if err = artEditor.Delete(ctx, artKB.Build(artifactMap.Pool())); err != nil {
+			return nil, false, err
+		}
+	}
+	if !found {
+		return tbl, false, nil
+	}
+
+	artifactMap, err = artEditor.Flush(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	tbl, err = tbl.SetArtifacts(ctx, durable.ArtifactIndexFromProllyMap(artifactMap))
+	if err != nil {
+		return nil, false, err
+	}
+
+	// update the row and secondary indexes
+	ourIdx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	ourMap := durable.ProllyMapFromIndex(ourIdx)
+	var ourRow val.Tuple
+	err = ourMap.Get(ctx, key, func(_, v val.Tuple) error {
+		ourRow = v
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	mutMap := ourMap.Mutate()
+	if err = mutMap.Put(ctx, key, value); err != nil {
+		return nil, false, err
+	}
+	newMap, err := mutMap.Map(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(newMap))
+	if err != nil {
+		return nil, false, err
+	}
+
+	idxSet, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	mutIdxs, err := merge.GetMutableSecondaryIdxs(ctx, sch, idxSet)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, mutIdx := range mutIdxs {
+		if len(ourRow) == 0 {
+			err = mutIdx.InsertEntry(ctx, key, value)
+		} else {
+			err = mutIdx.UpdateEntry(ctx, key, ourRow, value)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		m, err := mutIdx.Map(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		idxSet, err = idxSet.PutIndex(ctx, mutIdx.Name, durable.IndexFromProllyMap(m))
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	tbl, err = tbl.SetIndexSet(ctx, idxSet)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return tbl, true, nil
+}
+
+// ResolveDataConflictWithValues resolves the conflict of the row of table |tblName| with the primary key given by the
+// JSON object |pkJson| by setting the row to the values of the JSON object |rowJson|.
+func ResolveDataConflictWithValues(ctx *sql.Context, dSess *dsess.DoltSession, root *doltdb.RootValue, dbName, tblName, pkJson, rowJson string) error {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return doltdb.ErrTableNotFound
+	}
+	if tbl.Format() != types.Format_DOLT {
+		return fmt.Errorf("--%s is not supported for the old storage format", cli.InteractiveDataFlag)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if schema.IsKeyless(sch) {
+		return fmt.Errorf("--%s requires a table with a primary key, but %s is keyless", cli.InteractiveDataFlag, tblName)
+	}
+
+	r, err := conflictRowFromJSON(sch, pkJson, rowJson)
+	if err != nil {
+		return err
+	}
+
+	newTbl, found, err := resolveProllyConflictWithValues(ctx, tbl, sch, r)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no conflict in table %s for primary key %s", tblName, pkJson)
+	}
+
+	newRoot, err := root.PutTable(ctx, tblName, newTbl)
+	if err != nil {
+		return err
+	}
+	if err = validateConstraintViolations(ctx, root, newRoot, tblName); err != nil {
+		return err
+	}
+
+	return dSess.SetRoot(ctx, dbName, newRoot)
 }
 
 func DoDoltConflictsResolve(ctx *sql.Context, args []string) (int, error) {
@@ -466,6 +748,20 @@ func DoDoltConflictsResolve(ctx *sql.Context, args []string) (int, error) {
 
 	ours := apr.Contains(cli.OursFlag)
 	theirs := apr.Contains(cli.TheirsFlag)
+	if apr.Contains(cli.InteractiveDataFlag) {
+		if ours || theirs {
+			return 1, fmt.Errorf("--%s cannot be used with --ours or --theirs", cli.InteractiveDataFlag)
+		}
+		if apr.NArg() != 3 {
+			return 1, fmt.Errorf("--%s takes a table, the primary key of the conflicting row as JSON, and the resolved row as JSON", cli.InteractiveDataFlag)
+		}
+		err = ResolveDataConflictWithValues(ctx, dSess, ws.WorkingRoot(), dbName, apr.Arg(0), apr.Arg(1), apr.Arg(2))
+		if err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+
 	if ours && theirs {
 		return 1, fmt.Errorf("specify only either --ours or --theirs")
 	} else if !ours && !theirs {
@@ -0,0 +1,62 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltCreateDatabase creates a new, empty database whose tables default to the character set and collation given, e.g.
+// CALL DOLT_CREATE_DATABASE('foo', '--charset', 'utf8mb4', '--collate', 'utf8mb4_0900_ai_ci'). Tables created in the
+// new database without a character set or collation of their own inherit them.
+func doltCreateDatabase(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltCreateDatabase(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltCreateDatabase(ctx *sql.Context, args []string) (int, error) {
+	apr, err := cli.CreateCreateDatabaseArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	if apr.NArg() != 1 {
+		return 1, fmt.Errorf("error: invalid number of arguments: database name must be specified")
+	}
+	dbName := apr.Arg(0)
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	provider := sess.Provider()
+	if provider.HasDatabase(ctx, dbName) {
+		return 1, sql.ErrDatabaseExists.New(dbName)
+	}
+
+	charset, _ := apr.GetValue(cli.CharsetParam)
+	collation, _ := apr.GetValue(cli.CollateParam)
+	err = provider.CreateDatabaseWithCharset(ctx, dbName, charset, collation)
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
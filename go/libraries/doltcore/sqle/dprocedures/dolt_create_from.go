@@ -0,0 +1,77 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltCreateFrom creates a new database from the current HEAD of a template database, e.g.
+// CALL DOLT_CREATE_FROM('templatedb', 'tenant_acme'). The new database shares the template's table data, but not its
+// history unless --preserve-history is given. The template database is not modified. With --prefix, the prefix given
+// is prepended to the name of the new database, e.g. CALL DOLT_CREATE_FROM('--prefix', 'tenant_', 'templatedb', 'acme').
+func doltCreateFrom(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltCreateFrom(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltCreateFrom(ctx *sql.Context, args []string) (int, error) {
+	apr, err := cli.CreateCreateFromArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	if apr.NArg() != 2 {
+		return 1, fmt.Errorf("error: invalid number of arguments: template database and new database name must be specified")
+	}
+	templateName, dbName := apr.Arg(0), apr.Arg(1)
+	if prefix, ok := apr.GetValue(cli.PrefixParam); ok {
+		dbName = prefix + dbName
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	provider := sess.Provider()
+	if provider.HasDatabase(ctx, dbName) {
+		return 1, sql.ErrDatabaseExists.New(dbName)
+	}
+	if !provider.HasDatabase(ctx, templateName) {
+		return 1, sql.ErrDatabaseNotFound.New(templateName)
+	}
+
+	ddb, ok := sess.GetDoltDB(ctx, templateName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(templateName)
+	}
+
+	headCommit, err := sess.GetHeadCommit(ctx, templateName)
+	if err != nil {
+		return 1, err
+	}
+
+	err = provider.CreateDatabaseFromCommit(ctx, dbName, ddb, headCommit, apr.Contains(cli.PreserveHistory))
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
@@ -15,9 +15,12 @@
 package dprocedures
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
@@ -25,51 +28,121 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
+// fetchResultSchema is the schema of the rows dolt_fetch and dolt_pull return after their status columns. The first
+// row totals the chunks and bytes fetched, and is followed by a row for each remote tracking ref fetched, saying how
+// it moved. Callers that only want the status can read the first row, as before.
+var fetchResultSchema = sql.Schema{
+	&sql.Column{Name: "remote_ref", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "old_hash", Type: hashType, Nullable: true},
+	&sql.Column{Name: "new_hash", Type: hashType, Nullable: true},
+	&sql.Column{Name: "update_type", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "chunks", Type: types.Int64, Nullable: true},
+	&sql.Column{Name: "bytes", Type: types.Int64, Nullable: true},
+}
+
+// fetchSchema is the schema of dolt_fetch's result
+var fetchSchema = append(int64Schema("success"), fetchResultSchema...)
+
 // doltFetch is the stored procedure version for the CLI command `dolt fetch`.
 func doltFetch(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	res, err := doDoltFetch(ctx, args)
+	res, stats, updates, err := doDoltFetch(ctx, args)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(int64(res)), nil
+	return fetchResultIter(stats, updates, int64(res)), nil
 }
 
-func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
+func doDoltFetch(ctx *sql.Context, args []string) (int, *fetchStats, []actions.RefUpdate, error) {
 	dbName := ctx.GetCurrentDatabase()
 
 	if len(dbName) == 0 {
-		return cmdFailure, fmt.Errorf("empty database name")
+		return cmdFailure, nil, nil, fmt.Errorf("empty database name")
 	}
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
-		return cmdFailure, err
+		return cmdFailure, nil, nil, err
 	}
 
 	sess := dsess.DSessFromSess(ctx.Session)
+	if err := sess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return cmdFailure, nil, nil, err
+	}
 	dbData, ok := sess.GetDbData(ctx, dbName)
 	if !ok {
-		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
+		return cmdFailure, nil, nil, fmt.Errorf("Could not load database %s", dbName)
 	}
 
 	apr, err := cli.CreateFetchArgParser().Parse(args)
 	if err != nil {
-		return cmdFailure, err
+		return cmdFailure, nil, nil, err
 	}
 
 	remote, refSpecs, err := env.NewFetchOpts(apr.Args, dbData.Rsr)
 	if err != nil {
-		return cmdFailure, err
+		return cmdFailure, nil, nil, err
 	}
 
 	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, false)
 	if err != nil {
-		return 1, err
+		return 1, nil, nil, err
 	}
 
-	err = actions.FetchRefSpecs(ctx, dbData, srcDB, refSpecs, remote, ref.UpdateMode{Force: true}, runProgFuncs, stopProgFuncs)
+	stats := &fetchStats{}
+	updates, err := actions.FetchRefSpecs(ctx, dbData, srcDB, refSpecs, remote, ref.UpdateMode{Force: true}, stats.progStarter, stopProgFuncs)
 	if err != nil {
-		return cmdFailure, fmt.Errorf("fetch failed: %w", err)
+		return cmdFailure, nil, nil, fmt.Errorf("fetch failed: %w", err)
 	}
-	return cmdSuccess, nil
+	return cmdSuccess, stats, updates, nil
+}
+
+// fetchStats totals the chunks and bytes fetched by every pull of a fetch, from the stats that the puller reports
+type fetchStats struct {
+	mu     sync.Mutex
+	chunks uint64
+	bytes  uint64
+}
+
+// progStarter is an actions.ProgStarter that adds the last stats reported by a pull to the totals once it's stopped
+// with stopProgFuncs. The puller always reports its stats when it finishes, so the last ones are complete.
+func (s *fetchStats) progStarter(ctx context.Context) (*sync.WaitGroup, chan pull.Stats) {
+	statsCh := make(chan pull.Stats)
+	wg := &sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var last pull.Stats
+		for stats := range statsCh {
+			last = stats
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.chunks += last.FetchedSourceChunks
+		s.bytes += last.FetchedSourceBytes
+	}()
+
+	return wg, statsCh
+}
+
+// fetchResultIter returns the rows of a dolt_fetch or dolt_pull result, which start with the |status| columns given
+// and continue with the columns of fetchResultSchema
+func fetchResultIter(stats *fetchStats, updates []actions.RefUpdate, status ...interface{}) sql.RowIter {
+	rows := make([]sql.Row, 0, len(updates)+1)
+
+	stats.mu.Lock()
+	rows = append(rows, append(sql.NewRow(status...), nil, nil, nil, nil, int64(stats.chunks), int64(stats.bytes)))
+	stats.mu.Unlock()
+
+	for _, update := range updates {
+		var oldHash interface{}
+		if !update.OldHash.IsEmpty() {
+			oldHash = update.OldHash.String()
+		}
+		rows = append(rows, append(sql.NewRow(status...), update.Ref.String(), oldHash, update.NewHash.String(), string(update.Type), nil, nil))
+	}
+
+	return sql.RowsToRowIter(rows...)
 }
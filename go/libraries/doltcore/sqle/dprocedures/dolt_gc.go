@@ -75,6 +75,9 @@ func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return cmdFailure, err
+	}
 	ddb, ok := dSess.GetDoltDB(ctx, dbName)
 	if !ok {
 		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
@@ -108,7 +111,12 @@ func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 		// TODO: If we got a callback at the beginning and an
 		// (allowed-to-block) callback at the end, we could more
 		// gracefully tear things down.
-		err = ddb.GC(ctx, func() error {
+		concurrency, err := gcConcurrency(ctx)
+		if err != nil {
+			return cmdFailure, err
+		}
+
+		err = ddb.GC(ctx, concurrency, func() error {
 			if origepoch != -1 {
 				// Here we need to sanity check role and epoch.
 				if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
@@ -165,5 +173,23 @@ func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 		}
 	}
 
+	dsess.GCRunsTotal.Inc()
 	return cmdSuccess, nil
 }
+
+// gcConcurrency returns the number of goroutines a full GC may use to read the chunks it keeps, from
+// @@dolt_gc_concurrency.
+func gcConcurrency(ctx *sql.Context) (int, error) {
+	val, err := ctx.GetSessionVariable(ctx, dsess.GCConcurrency)
+	if err != nil {
+		return 0, err
+	}
+	concurrency, ok := val.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected type for variable %s: %T", dsess.GCConcurrency, val)
+	}
+	if concurrency < 1 {
+		return 0, fmt.Errorf("%s must be at least 1, but was %d", dsess.GCConcurrency, concurrency)
+	}
+	return int(concurrency), nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltLockDatabase takes an advisory write lock on the current database for this session. While the lock is held,
+// writes to the database from all other sessions fail. The lock is released by dolt_unlock_database, or when the
+// session's connection closes. The single argument is the reason for the lock, reported to blocked sessions.
+func doltLockDatabase(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltLockDatabase(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltLockDatabase(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	// Database administrators may lock the database, as may branch administrators
+	if session := branch_control.GetBranchAwareSession(ctx); !branch_control.HasDatabasePrivileges(session, dbName) {
+		if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Admin); err != nil {
+			return 1, err
+		}
+	}
+	if len(args) != 1 || len(args[0]) == 0 {
+		return 1, fmt.Errorf("error: dolt_lock_database requires a reason for the lock")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	locks := dSess.Provider().DatabaseWriteLocks()
+	if locks == nil {
+		return 1, fmt.Errorf("database locking is not supported for database %s", dbName)
+	}
+
+	if err := locks.Lock(ctx, dbName, args[0]); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// doltUnlockDatabase releases the advisory write lock this session holds on the current database.
+func doltUnlockDatabase(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltUnlockDatabase(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltUnlockDatabase(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if len(args) != 0 {
+		return 1, InvalidArgErr
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	locks := dSess.Provider().DatabaseWriteLocks()
+	if locks == nil {
+		return 1, fmt.Errorf("database locking is not supported for database %s", dbName)
+	}
+
+	if err := locks.Unlock(ctx, dbName); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
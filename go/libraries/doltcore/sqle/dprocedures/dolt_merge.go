@@ -27,7 +27,10 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
@@ -44,6 +47,15 @@ const (
 	fastForwardMerge = 1
 )
 
+// mergeStrategyParam picks the side, ours or theirs, that conflicts are resolved to while merging, rather than being
+// left in the dolt_conflicts tables.
+const mergeStrategyParam = "strategy"
+
+const (
+	mergeStrategyOurs   = "ours"
+	mergeStrategyTheirs = "theirs"
+)
+
 var ErrUncommittedChanges = goerrors.NewKind("cannot merge with uncommitted changes")
 
 // doltMerge is the stored procedure version for the CLI command `dolt merge`.
@@ -55,6 +67,36 @@ func doltMerge(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	return rowToIter(int64(ff), int64(hasConflicts)), nil
 }
 
+// doltMergeHashOut is the stored procedure version for the CLI command `dolt merge`. The first parameter is the
+// variable to set the hash of the HEAD commit after the merge to. When the merge isn't committed, because it has
+// conflicts or --no-commit was given, this is the unchanged HEAD commit.
+func doltMergeHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.RowIter, error) {
+	hasConflicts, ff, err := doDoltMerge(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := headCommitHash(ctx, ctx.GetCurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	*outHash = h
+	return rowToIter(int64(ff), int64(hasConflicts)), nil
+}
+
+// headCommitHash returns the hash of the session's HEAD commit for the database named
+func headCommitHash(ctx *sql.Context, dbName string) (string, error) {
+	head, err := dsess.DSessFromSess(ctx.Session).GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return "", err
+	}
+	h, err := head.HashOf()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
+
 // doDoltMerge returns has_conflicts and fast_forward status
 func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 	dbName := ctx.GetCurrentDatabase()
@@ -67,8 +109,13 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 	}
 
 	sess := dsess.DSessFromSess(ctx.Session)
+	if err := sess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return noConflictsOrViolations, threeWayMerge, err
+	}
 
-	apr, err := cli.CreateMergeArgParser().Parse(args)
+	ap := cli.CreateMergeArgParser()
+	ap.SupportsString(mergeStrategyParam, "s", "strategy", "Resolve conflicts to our side (ours) or their side (theirs) of the merge while merging, including schema conflicts, instead of leaving them to be resolved.")
+	apr, err := ap.Parse(args)
 	if err != nil {
 		return noConflictsOrViolations, threeWayMerge, err
 	}
@@ -76,6 +123,13 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 	if apr.ContainsAll(cli.SquashParam, cli.NoFFParam) {
 		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("error: Flags '--%s' and '--%s' cannot be used together.\n", cli.SquashParam, cli.NoFFParam)
 	}
+	if apr.ContainsAll(cli.FFOnlyParam, cli.NoFFParam) {
+		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("error: Flags '--%s' and '--%s' cannot be used together.\n", cli.FFOnlyParam, cli.NoFFParam)
+	}
+	strategy := apr.GetValueOrDefault(mergeStrategyParam, "")
+	if strategy != "" && strategy != mergeStrategyOurs && strategy != mergeStrategyTheirs {
+		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("error: invalid merge strategy '%s', expected '%s' or '%s'", strategy, mergeStrategyOurs, mergeStrategyTheirs)
+	}
 
 	ws, err := sess.WorkingSet(ctx, dbName)
 	if err != nil {
@@ -91,6 +145,7 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 			return noConflictsOrViolations, threeWayMerge, fmt.Errorf("fatal: There is no merge to abort")
 		}
 
+		mergeWorking := roots.Working
 		ws, err = abortMerge(ctx, ws, roots)
 		if err != nil {
 			return noConflictsOrViolations, threeWayMerge, err
@@ -106,6 +161,11 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 			return noConflictsOrViolations, threeWayMerge, err
 		}
 
+		err = resetAutoIncrementTracker(ctx, sess, dbName, ws, mergeWorking)
+		if err != nil {
+			return noConflictsOrViolations, threeWayMerge, err
+		}
+
 		return noConflictsOrViolations, threeWayMerge, nil
 	}
 
@@ -130,7 +190,7 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 		msg = userMsg
 	}
 
-	ws, conflicts, fastForward, err := performMerge(ctx, sess, roots, ws, dbName, mergeSpec, apr.Contains(cli.NoCommitFlag), msg)
+	ws, conflicts, fastForward, err := performMerge(ctx, sess, roots, ws, dbName, mergeSpec, apr.Contains(cli.NoCommitFlag), msg, strategy)
 	if err != nil || conflicts != 0 || fastForward != 0 {
 		return conflicts, fastForward, err
 	}
@@ -142,9 +202,10 @@ func doDoltMerge(ctx *sql.Context, args []string) (int, int, error) {
 // fast-forward, no fast-forward, merge commit, and merging into working set.
 // Returns a new WorkingSet, whether there were merge conflicts, and whether a
 // fast-forward was performed. This commits the working set if merge is successful and
-// 'no-commit' flag is not defined.
+// 'no-commit' flag is not defined. A non-empty |strategy| resolves any conflicts to that
+// side of the merge.
 // TODO FF merging commit with constraint violations requires `constraint verify`
-func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots, ws *doltdb.WorkingSet, dbName string, spec *merge.MergeSpec, noCommit bool, msg string) (*doltdb.WorkingSet, int, int, error) {
+func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots, ws *doltdb.WorkingSet, dbName string, spec *merge.MergeSpec, noCommit bool, msg string, strategy string) (*doltdb.WorkingSet, int, int, error) {
 	// todo: allow merges even when an existing merge is uncommitted
 	if ws.MergeActive() {
 		return ws, noConflictsOrViolations, threeWayMerge, doltdb.ErrMergeActive
@@ -168,6 +229,9 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 		default:
 			return ws, noConflictsOrViolations, threeWayMerge, err
 		}
+	} else if !canFF && spec.FFOnly {
+		// --ff-only never creates a merge commit, so the merge fails before the working set is touched
+		return ws, noConflictsOrViolations, threeWayMerge, merge.ErrNotFastForward
 	}
 
 	if canFF {
@@ -176,6 +240,7 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 			if err == doltdb.ErrUnresolvedConflictsOrViolations {
 				// if there are unresolved conflicts, write the resulting working set back to the session and return an
 				// error message
+				countMergeConflictTables(ctx, ws)
 				wsErr := sess.SetWorkingSet(ctx, dbName, ws)
 				if wsErr != nil {
 					return ws, hasConflictsOrViolations, threeWayMerge, wsErr
@@ -185,7 +250,8 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 
 				return ws, hasConflictsOrViolations, threeWayMerge, nil
 			}
-			return ws, noConflictsOrViolations, fastForwardMerge, err
+			// --no-ff always records a merge commit, so this isn't reported as a fast-forward
+			return ws, noConflictsOrViolations, threeWayMerge, err
 		}
 
 		ws, err = executeFFMerge(ctx, dbName, spec.Squash, ws, dbData, spec.MergeC)
@@ -200,9 +266,13 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 	}
 
 	ws, err = executeMerge(ctx, spec.Squash, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts())
+	if err == doltdb.ErrUnresolvedConflictsOrViolations && strategy != "" {
+		ws, err = resolveMergeConflicts(ctx, sess, dbName, ws, strategy == mergeStrategyOurs)
+	}
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
+		countMergeConflictTables(ctx, ws)
 		wsErr := sess.SetWorkingSet(ctx, dbName, ws)
 		if wsErr != nil {
 			return ws, hasConflictsOrViolations, threeWayMerge, wsErr
@@ -220,9 +290,10 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 		return ws, noConflictsOrViolations, threeWayMerge, err
 	}
 
-	if !noCommit {
+	// --squash leaves the merged changes staged for the next commit, rather than recording a merge commit
+	if !noCommit && !spec.Squash {
 		author := fmt.Sprintf("%s <%s>", spec.Name, spec.Email)
-		_, err = doDoltCommit(ctx, []string{"-m", msg, "--author", author})
+		_, err = DoDoltCommit(ctx, []string{"-m", msg, "--author", author})
 		if err != nil {
 			return ws, noConflictsOrViolations, threeWayMerge, fmt.Errorf("dolt_commit failed")
 		}
@@ -231,6 +302,58 @@ func performMerge(ctx *sql.Context, sess *dsess.DoltSession, roots doltdb.Roots,
 	return ws, noConflictsOrViolations, threeWayMerge, nil
 }
 
+// resolveMergeConflicts resolves the schema and data conflicts of the merge in progress in |ws| to our or their side.
+// It returns doltdb.ErrUnresolvedConflictsOrViolations along with the working set if any conflicts or constraint
+// violations are left.
+func resolveMergeConflicts(ctx *sql.Context, sess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, ours bool) (*doltdb.WorkingSet, error) {
+	ddb, ok := sess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	tbls, err := ws.WorkingRoot().GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err = ResolveSchemaConflicts(ctx, ddb, ws, ours, tbls)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := resolveDataConflictsInRoot(ctx, sess, ws.WorkingRoot(), dbName, ours, tbls)
+	if err != nil {
+		return nil, err
+	}
+	ws = ws.WithWorkingRoot(root).WithStagedRoot(root)
+
+	if ws.MergeActive() && ws.MergeState().HasSchemaConflicts() {
+		return ws, doltdb.ErrUnresolvedConflictsOrViolations
+	}
+	if has, err := root.HasConflicts(ctx); err != nil {
+		return nil, err
+	} else if has {
+		return ws, doltdb.ErrUnresolvedConflictsOrViolations
+	}
+	if has, err := root.HasConstraintViolations(ctx); err != nil {
+		return nil, err
+	} else if has {
+		return ws, doltdb.ErrUnresolvedConflictsOrViolations
+	}
+
+	return ws, nil
+}
+
+// countMergeConflictTables adds the number of tables with conflicts in the working set given to the
+// dolt_merge_conflict_tables_total metric. Failing to count them doesn't fail the merge.
+func countMergeConflictTables(ctx *sql.Context, ws *doltdb.WorkingSet) {
+	tbls, err := ws.WorkingRoot().TablesWithDataConflicts(ctx)
+	if err != nil {
+		return
+	}
+	dsess.MergeConflictTablesTotal.Add(uint64(len(tbls)))
+}
+
 func abortMerge(ctx *sql.Context, workingSet *doltdb.WorkingSet, roots doltdb.Roots) (*doltdb.WorkingSet, error) {
 	tbls, err := doltdb.UnionTableNames(ctx, roots.Working, roots.Staged, roots.Head)
 	if err != nil {
@@ -247,6 +370,60 @@ func abortMerge(ctx *sql.Context, workingSet *doltdb.WorkingSet, roots doltdb.Ro
 	return workingSet, nil
 }
 
+// resetAutoIncrementTracker resets the global auto increment values of the auto increment tables in |mergeWorking|, the
+// working root of a merge that's been aborted, to the highest ones on any branch, so that values given out while the
+// merge was in progress are given out again. |ws| is the working set of the current branch after the abort.
+func resetAutoIncrementTracker(ctx *sql.Context, sess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, mergeWorking *doltdb.RootValue) error {
+	db, err := sess.Provider().Database(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	stateProvider, ok := db.(globalstate.StateProvider)
+	if !ok {
+		return nil
+	}
+	ait, err := stateProvider.GetGlobalState().GetAutoIncrementTracker(ctx)
+	if err != nil {
+		return err
+	}
+
+	ddb, ok := sess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+
+	wses := []*doltdb.WorkingSet{ws}
+	for _, b := range branches {
+		wsRef, err := ref.WorkingSetRefForHead(b)
+		if err != nil {
+			return err
+		}
+		if wsRef == ws.Ref() {
+			// the working set given may not have been persisted yet if we're in a transaction
+			continue
+		}
+
+		branchWs, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		wses = append(wses, branchWs)
+	}
+
+	return mergeWorking.IterTables(ctx, func(name string, _ *doltdb.Table, sch schema.Schema) (bool, error) {
+		if !schema.HasAutoIncrement(sch) {
+			return false, nil
+		}
+		return false, ait.ResetTable(ctx, name, wses...)
+	})
+}
+
 func executeMerge(ctx *sql.Context, squash bool, head, cm *doltdb.Commit, cmSpec string, ws *doltdb.WorkingSet, opts editor.Options) (*doltdb.WorkingSet, error) {
 	result, err := merge.MergeCommits(ctx, head, cm, opts)
 	if err != nil {
@@ -396,7 +573,7 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 	if apr.Contains(cli.NoCommitFlag) && apr.Contains(cli.CommitFlag) {
 		return nil, errors.New("cannot define both 'commit' and 'no-commit' flags at the same time")
 	}
-	mergeSpec, err := merge.NewMergeSpec(ctx, dbData.Rsr, ddb, roots, name, email, msg, commitSpecStr, apr.Contains(cli.SquashParam), apr.Contains(cli.NoFFParam), apr.Contains(cli.ForceFlag), apr.Contains(cli.NoCommitFlag), apr.Contains(cli.NoEditFlag), t)
+	mergeSpec, err := merge.NewMergeSpec(ctx, dbData.Rsr, ddb, roots, name, email, msg, commitSpecStr, apr.Contains(cli.SquashParam), apr.Contains(cli.NoFFParam), apr.Contains(cli.FFOnlyParam), apr.Contains(cli.ForceFlag), apr.Contains(cli.NoCommitFlag), apr.Contains(cli.NoEditFlag), t)
 	if err != nil {
 		return nil, err
 	}
@@ -32,39 +32,47 @@ import (
 	"github.com/dolthub/dolt/go/store/datas/pull"
 )
 
+// pullSchema is the schema of dolt_pull's result
+var pullSchema = append(int64Schema("fast_forward", "conflicts"), fetchResultSchema...)
+
 // doltPull is the stored procedure version for the CLI command `dolt pull`.
 func doltPull(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	conflicts, ff, err := doDoltPull(ctx, args)
+	stats := &fetchStats{}
+	conflicts, ff, updates, err := doDoltPull(ctx, args, stats)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(int64(ff), int64(conflicts)), nil
+	return fetchResultIter(stats, updates, int64(ff), int64(conflicts)), nil
 }
 
-// doDoltPull returns conflicts, fast_forward statuses
-func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
+// doDoltPull returns conflicts, fast_forward statuses, and the change made to each remote tracking ref. The chunks and
+// bytes fetched are added to |stats|.
+func doDoltPull(ctx *sql.Context, args []string, stats *fetchStats) (int, int, []actions.RefUpdate, error) {
 	dbName := ctx.GetCurrentDatabase()
 
 	if len(dbName) == 0 {
-		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("empty database name.")
+		return noConflictsOrViolations, threeWayMerge, nil, fmt.Errorf("empty database name.")
 	}
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
-		return noConflictsOrViolations, threeWayMerge, err
+		return noConflictsOrViolations, threeWayMerge, nil, err
 	}
 
 	sess := dsess.DSessFromSess(ctx.Session)
+	if err := sess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return noConflictsOrViolations, threeWayMerge, nil, err
+	}
 	dbData, ok := sess.GetDbData(ctx, dbName)
 	if !ok {
-		return noConflictsOrViolations, threeWayMerge, sql.ErrDatabaseNotFound.New(dbName)
+		return noConflictsOrViolations, threeWayMerge, nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
 	apr, err := cli.CreatePullArgParser().Parse(args)
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, err
+		return noConflictsOrViolations, threeWayMerge, nil, err
 	}
 
 	if apr.NArg() > 2 {
-		return noConflictsOrViolations, threeWayMerge, actions.ErrInvalidPullArgs
+		return noConflictsOrViolations, threeWayMerge, nil, actions.ErrInvalidPullArgs
 	}
 
 	var remoteName, remoteRefName string
@@ -77,36 +85,37 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 
 	pullSpec, err := env.NewPullSpec(ctx, dbData.Rsr, remoteName, remoteRefName, apr.Contains(cli.SquashParam), apr.Contains(cli.NoFFParam), apr.Contains(cli.NoCommitFlag), apr.Contains(cli.NoEditFlag), apr.Contains(cli.ForceFlag), apr.NArg() == 1)
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, err
+		return noConflictsOrViolations, threeWayMerge, nil, err
 	}
 
 	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), pullSpec.Remote, false)
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("failed to get remote db; %w", err)
+		return noConflictsOrViolations, threeWayMerge, nil, fmt.Errorf("failed to get remote db; %w", err)
 	}
 
 	ws, err := sess.WorkingSet(ctx, dbName)
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, err
+		return noConflictsOrViolations, threeWayMerge, nil, err
 	}
 
 	// Fetch all references
 	branchRefs, err := srcDB.GetHeadRefs(ctx)
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
+		return noConflictsOrViolations, threeWayMerge, nil, fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
 	}
 
 	_, hasBranch, err := srcDB.HasBranch(ctx, pullSpec.Branch.GetPath())
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, err
+		return noConflictsOrViolations, threeWayMerge, nil, err
 	}
 	if !hasBranch {
-		return noConflictsOrViolations, threeWayMerge,
+		return noConflictsOrViolations, threeWayMerge, nil,
 			fmt.Errorf("branch %q not found on remote", pullSpec.Branch.GetPath())
 	}
 
 	var conflicts int
 	var fastForward int
+	var updates []actions.RefUpdate
 	for _, refSpec := range pullSpec.RefSpecs {
 		rsSeen := false // track invalid refSpecs
 		for _, branchRef := range branchRefs {
@@ -119,19 +128,25 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 			rsSeen = true
 			tmpDir, err := dbData.Rsw.TempTableFilesDir()
 			if err != nil {
-				return noConflictsOrViolations, threeWayMerge, err
+				return noConflictsOrViolations, threeWayMerge, nil, err
 			}
 			// todo: can we pass nil for either of the channels?
-			srcDBCommit, err := actions.FetchRemoteBranch(ctx, tmpDir, pullSpec.Remote, srcDB, dbData.Ddb, branchRef, runProgFuncs, stopProgFuncs)
+			srcDBCommit, err := actions.FetchRemoteBranch(ctx, tmpDir, pullSpec.Remote, srcDB, dbData.Ddb, branchRef, stats.progStarter, stopProgFuncs)
+			if err != nil {
+				return noConflictsOrViolations, threeWayMerge, nil, err
+			}
+
+			update, err := actions.GetRefUpdate(ctx, dbData.Ddb, remoteTrackRef, srcDBCommit)
 			if err != nil {
-				return noConflictsOrViolations, threeWayMerge, err
+				return noConflictsOrViolations, threeWayMerge, nil, err
 			}
 
 			// TODO: this could be replaced with a canFF check to test for error
 			err = dbData.Ddb.FastForward(ctx, remoteTrackRef, srcDBCommit)
 			if err != nil {
-				return noConflictsOrViolations, threeWayMerge, fmt.Errorf("fetch failed; %w", err)
+				return noConflictsOrViolations, threeWayMerge, nil, fmt.Errorf("fetch failed; %w", err)
 			}
+			updates = append(updates, update)
 
 			// Only merge iff branch is current branch and there is an upstream set (pullSpec.Branch is set to nil if there is no upstream)
 			if branchRef != pullSpec.Branch {
@@ -140,44 +155,44 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, error) {
 
 			roots, ok := sess.GetRoots(ctx, dbName)
 			if !ok {
-				return noConflictsOrViolations, threeWayMerge, sql.ErrDatabaseNotFound.New(dbName)
+				return noConflictsOrViolations, threeWayMerge, nil, sql.ErrDatabaseNotFound.New(dbName)
 			}
 
 			mergeSpec, err := createMergeSpec(ctx, sess, dbName, apr, remoteTrackRef.String())
 			if err != nil {
-				return noConflictsOrViolations, threeWayMerge, err
+				return noConflictsOrViolations, threeWayMerge, nil, err
 			}
 
 			headRef, err := dbData.Rsr.CWBHeadRef()
 			if err != nil {
-				return noConflictsOrViolations, threeWayMerge, err
+				return noConflictsOrViolations, threeWayMerge, nil, err
 			}
 			msg := fmt.Sprintf("Merge branch '%s' of %s into %s", pullSpec.Branch.GetPath(), pullSpec.Remote.Url, headRef.GetPath())
-			ws, conflicts, fastForward, err = performMerge(ctx, sess, roots, ws, dbName, mergeSpec, apr.Contains(cli.NoCommitFlag), msg)
+			ws, conflicts, fastForward, err = performMerge(ctx, sess, roots, ws, dbName, mergeSpec, apr.Contains(cli.NoCommitFlag), msg, "")
 			if err != nil && !errors.Is(doltdb.ErrUpToDate, err) {
-				return conflicts, fastForward, err
+				return conflicts, fastForward, nil, err
 			}
 
 			err = sess.SetWorkingSet(ctx, dbName, ws)
 			if err != nil {
-				return conflicts, fastForward, err
+				return conflicts, fastForward, nil, err
 			}
 		}
 		if !rsSeen {
-			return noConflictsOrViolations, threeWayMerge, fmt.Errorf("%w: '%s'", ref.ErrInvalidRefSpec, refSpec.GetRemRefToLocal())
+			return noConflictsOrViolations, threeWayMerge, nil, fmt.Errorf("%w: '%s'", ref.ErrInvalidRefSpec, refSpec.GetRemRefToLocal())
 		}
 	}
 
 	tmpDir, err := dbData.Rsw.TempTableFilesDir()
 	if err != nil {
-		return noConflictsOrViolations, threeWayMerge, err
+		return noConflictsOrViolations, threeWayMerge, nil, err
 	}
-	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, runProgFuncs, stopProgFuncs)
+	err = actions.FetchFollowTags(ctx, tmpDir, srcDB, dbData.Ddb, stats.progStarter, stopProgFuncs)
 	if err != nil {
-		return conflicts, fastForward, err
+		return conflicts, fastForward, nil, err
 	}
 
-	return conflicts, fastForward, nil
+	return conflicts, fastForward, updates, nil
 }
 
 // TODO: remove this as it does not do anything useful
@@ -0,0 +1,513 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrRebaseUncommittedChanges = errors.New("cannot rebase with uncommitted changes")
+var ErrRebaseNotInProgress = errors.New("no rebase in progress")
+var ErrRebaseInProgress = errors.New("a rebase is already in progress, use --continue or --abort")
+
+// doltRebase is the stored procedure to replay the commits of the current branch on top of another branch or commit,
+// like the CLI command `git rebase`. Merge commits are not replayed. If a commit doesn't replay cleanly, the rebase
+// stops with status 1 and the conflicts are left in the working set for the dolt_conflicts tables, the same as
+// dolt_merge. Once they are resolved, --continue commits the result and replays the rest of the commits, and --abort
+// resets the branch to where it was before the rebase. The state of a stopped rebase is stored in the database, so any
+// session on the branch can continue or abort it. Conflicts are only left in the working set if the session could
+// commit them, otherwise the rebase is aborted with an error.
+func doltRebase(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltRebase(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltRebase(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+
+	apr, err := cli.CreateRebaseArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+	if apr.ContainsAll(cli.AbortParam, cli.ContinueFlag) {
+		return 1, fmt.Errorf("error: --%s and --%s are mutually exclusive options.", cli.AbortParam, cli.ContinueFlag)
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, err
+	}
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	state, err := loadRebaseState(ctx, ddb, headRef)
+	if err != nil {
+		return 1, err
+	}
+	if apr.Contains(cli.AbortParam) || apr.Contains(cli.ContinueFlag) {
+		if apr.NArg() > 0 {
+			return 1, fmt.Errorf("error: --%s and --%s don't take a commit", cli.AbortParam, cli.ContinueFlag)
+		}
+		if state == nil {
+			return 1, ErrRebaseNotInProgress
+		}
+
+		if apr.Contains(cli.AbortParam) {
+			err = abortRebase(ctx, dSess, dbName, state)
+			if err != nil {
+				return 1, err
+			}
+			return 0, nil
+		}
+		return continueRebase(ctx, dSess, dbName, state)
+	}
+
+	if state != nil {
+		return 1, ErrRebaseInProgress
+	}
+	if apr.NArg() == 0 {
+		return 1, fmt.Errorf("error: specify the branch or commit to rebase onto")
+	}
+	return startRebase(ctx, dSess, dbName, headRef, apr.Arg(0))
+}
+
+// rebaseState is a rebase in progress. Only what's needed to continue it is stored when it stops with conflicts, and the
+// commits left to replay are found again from that when it's continued.
+type rebaseState struct {
+	// branch is the branch being rebased
+	branch ref.DoltRef
+	// origHead is the head of the branch before the rebase started, which it is reset to if the rebase is aborted
+	origHead *doltdb.Commit
+	// onto is the commit the branch is rebased onto
+	onto *doltdb.Commit
+	// current is the commit whose changes are in the working set, to be committed once its conflicts are resolved
+	current *doltdb.Commit
+	// remaining are the commits still to be replayed after current, oldest first
+	remaining []*doltdb.Commit
+}
+
+// loadRebaseState returns the rebase stopped on |branch|, or nil if there is none
+func loadRebaseState(ctx *sql.Context, ddb *doltdb.DoltDB, branch ref.DoltRef) (*rebaseState, error) {
+	stored, err := ddb.GetRebaseState(ctx, branch)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+
+	commits, err := rebaseCommits(ctx, ddb, stored.OrigHead, stored.Onto)
+	if err != nil {
+		return nil, err
+	}
+	stoppedHash, err := stored.Stopped.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	var remaining []*doltdb.Commit
+	for i, cm := range commits {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if h == stoppedHash {
+			remaining = commits[i+1:]
+			break
+		}
+	}
+
+	return &rebaseState{
+		branch:    branch,
+		origHead:  stored.OrigHead,
+		onto:      stored.Onto,
+		current:   stored.Stopped,
+		remaining: remaining,
+	}, nil
+}
+
+// rebaseCommits returns the commits of |head| that aren't in |onto|, oldest first, without merge commits
+func rebaseCommits(ctx *sql.Context, ddb *doltdb.DoltDB, head, onto *doltdb.Commit) ([]*doltdb.Commit, error) {
+	headHash, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	ontoHash, err := onto.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	commits, err := commitwalk.GetDotDotRevisions(ctx, ddb, []hash.Hash{headHash}, ddb, []hash.Hash{ontoHash}, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var replayed []*doltdb.Commit
+	for i := len(commits) - 1; i >= 0; i-- {
+		if commits[i].NumParents() == 1 {
+			replayed = append(replayed, commits[i])
+		}
+	}
+	return replayed, nil
+}
+
+// startRebase replays the commits of the branch |headRef| that aren't in |upstreamSpec| on top of it
+func startRebase(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, headRef ref.DoltRef, upstreamSpec string) (int, error) {
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	headRootHash, err := roots.Head.HashOf()
+	if err != nil {
+		return 1, err
+	}
+	workingRootHash, err := roots.Working.HashOf()
+	if err != nil {
+		return 1, err
+	}
+	stagedRootHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return 1, err
+	}
+	if workingRootHash != headRootHash || stagedRootHash != headRootHash {
+		return 1, ErrRebaseUncommittedChanges
+	}
+
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	upstreamCommitSpec, err := doltdb.NewCommitSpec(upstreamSpec)
+	if err != nil {
+		return 1, err
+	}
+	upstream, err := ddb.Resolve(ctx, upstreamCommitSpec, headRef)
+	if err != nil {
+		return 1, err
+	}
+	headCommit, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	upstreamHash, err := upstream.HashOf()
+	if err != nil {
+		return 1, err
+	}
+	mergeBase, err := merge.MergeBase(ctx, headCommit, upstream)
+	if err != nil {
+		return 1, err
+	}
+	if mergeBase == upstreamHash {
+		// the branch already contains upstream
+		return 0, nil
+	}
+
+	remaining, err := rebaseCommits(ctx, ddb, headCommit, upstream)
+	if err != nil {
+		return 1, err
+	}
+
+	state := &rebaseState{
+		branch:    headRef,
+		origHead:  headCommit,
+		onto:      upstream,
+		remaining: remaining,
+	}
+	return replayRebaseCommits(ctx, dSess, dbName, state, upstream)
+}
+
+// continueRebase commits the resolved conflicts of the rebase stopped at |state| and replays the rest of its commits
+func continueRebase(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, state *rebaseState) (int, error) {
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	tablesWithConflict, err := roots.Working.TablesWithDataConflicts(ctx)
+	if err != nil {
+		return 1, err
+	}
+	if len(tablesWithConflict) > 0 {
+		sort.Strings(tablesWithConflict)
+		return 1, fmt.Errorf("error: cannot continue the rebase with unresolved conflicts in table {'%s'}", strings.Join(tablesWithConflict, "', '"))
+	}
+
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	head, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	// a resolution that leaves no changes drops the commit
+	head, err = commitReplayedRoot(ctx, ddb, head, roots.Working, state.current)
+	if err != nil {
+		return 1, err
+	}
+	state.current = nil
+
+	return replayRebaseCommits(ctx, dSess, dbName, state, head)
+}
+
+// abortRebase resets the branch of the rebase stopped at |state| to where it was before the rebase started
+func abortRebase(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, state *rebaseState) error {
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	err := ddb.ClearRebaseState(ctx, state.branch)
+	if err != nil {
+		return err
+	}
+	return resetRebaseBranch(ctx, dSess, dbName, state.branch, state.origHead, nil)
+}
+
+// replayRebaseCommits replays the remaining commits of |state| on top of |head|, and moves the branch of the rebase to
+// the last commit replayed. If a commit produces conflicts, the rebase stops and returns status 1 with the conflicts
+// in the working set.
+func replayRebaseCommits(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, state *rebaseState, head *doltdb.Commit) (int, error) {
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return 1, err
+	} else if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	for len(state.remaining) > 0 {
+		commit := state.remaining[0]
+		state.remaining = state.remaining[1:]
+
+		headRoot, err := head.GetRootValue(ctx)
+		if err != nil {
+			return 1, err
+		}
+		newRoot, tablesWithConflict, err := replayCommit(ctx, ddb, headRoot, commit, dbState.EditOpts())
+		if err != nil {
+			return 1, err
+		}
+
+		if len(tablesWithConflict) > 0 {
+			return stopRebase(ctx, dSess, dbName, state, head, commit, newRoot, tablesWithConflict)
+		}
+
+		head, err = commitReplayedRoot(ctx, ddb, head, newRoot, commit)
+		if err != nil {
+			return 1, err
+		}
+	}
+
+	err = ddb.ClearRebaseState(ctx, state.branch)
+	if err != nil {
+		return 1, err
+	}
+	err = resetRebaseBranch(ctx, dSess, dbName, state.branch, head, nil)
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+// stopRebase moves the branch of the rebase to |head|, with the conflicts produced by replaying |commit| in the
+// working set, and stores |state| in the database to continue or abort the rebase later. If the session can't commit
+// conflicts, the rebase is aborted instead.
+func stopRebase(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, state *rebaseState, head, commit *doltdb.Commit, conflictRoot *doltdb.RootValue, tablesWithConflict []string) (int, error) {
+	canCommit, err := canCommitConflicts(ctx)
+	if err != nil {
+		return 1, err
+	}
+	if !canCommit {
+		err = abortRebase(ctx, dSess, dbName, state)
+		if err != nil {
+			return 1, err
+		}
+		return 1, fmt.Errorf("conflicts in table {'%s'}, the rebase was aborted. "+
+			"To resolve the conflicts of a rebase using the dolt_conflicts tables, set @@dolt_allow_commit_conflicts = 1",
+			strings.Join(tablesWithConflict, "', '"))
+	}
+
+	commitHash, err := commit.HashOf()
+	if err != nil {
+		return 1, err
+	}
+
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	// the state is stored before the branch moves, so that the rebase can always be aborted
+	state.current = commit
+	err = ddb.SetRebaseState(ctx, state.branch, &doltdb.RebaseState{OrigHead: state.origHead, Onto: state.onto, Stopped: commit})
+	if err != nil {
+		return 1, err
+	}
+	err = resetRebaseBranch(ctx, dSess, dbName, state.branch, head, conflictRoot)
+	if err != nil {
+		return 1, err
+	}
+
+	ctx.Warn(DoltMergeWarningCode, fmt.Sprintf("conflicts in table {'%s'} replaying commit %s, resolve them and call dolt_rebase('--continue')",
+		strings.Join(tablesWithConflict, "', '"), commitHash.String()))
+	return 1, nil
+}
+
+// replayCommit applies the changes of |commit| to |root|, the same as a cherry-pick. If that produces conflicts, the
+// root value with the conflicts is returned along with the tables that have them.
+func replayCommit(ctx *sql.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, commit *doltdb.Commit, opts editor.Options) (*doltdb.RootValue, []string, error) {
+	commitRoot, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := ddb.ResolveParent(ctx, commit, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentRoot, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := merge.MergeRoots(ctx, root, commitRoot, parentRoot, commit, parent, opts, merge.MergeOpts{IsCherryPick: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tablesWithConflict []string
+	for tbl, stats := range result.Stats {
+		if stats.HasConflicts() {
+			tablesWithConflict = append(tablesWithConflict, tbl)
+		}
+	}
+	sort.Strings(tablesWithConflict)
+
+	return result.Root, tablesWithConflict, nil
+}
+
+// commitReplayedRoot commits |root| on top of |head| with the metadata of the replayed commit |commit|, and returns the
+// new commit. If |root| has no changes from |head|, e.g. because upstream already has the changes of |commit|, no
+// commit is made and |head| is returned.
+func commitReplayedRoot(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, root *doltdb.RootValue, commit *doltdb.Commit) (*doltdb.Commit, error) {
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headRootHash, err := headRoot.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	rootHash, err := root.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if rootHash == headRootHash {
+		return head, nil
+	}
+
+	_, valueHash, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := commit.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ddb.CommitDanglingWithParentCommits(ctx, valueHash, []*doltdb.Commit{head}, meta)
+}
+
+// resetRebaseBranch moves |branch| to |head| and resets the working set to it. If |conflictRoot| is not nil, it is
+// used as the working and staged root instead, the same as a cherry-pick that produced conflicts.
+func resetRebaseBranch(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, branch ref.DoltRef, head *doltdb.Commit, conflictRoot *doltdb.RootValue) error {
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	// TODO: like dolt_reset --hard, this moves the branch outside the transaction
+	err := ddb.SetHeadToCommit(ctx, branch, head)
+	if err != nil {
+		return err
+	}
+
+	root := conflictRoot
+	if root == nil {
+		root, err = head.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	return dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(root).WithStagedRoot(root).ClearMerge())
+}
+
+// canCommitConflicts returns whether the session can leave conflicts in the working set at the end of the statement,
+// i.e. when conflicts can be committed or the transaction is not committed with the statement.
+func canCommitConflicts(ctx *sql.Context) (bool, error) {
+	for _, name := range []string{dsess.AllowCommitConflicts, dsess.ForceTransactionCommit} {
+		val, err := ctx.GetSessionVariable(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		if val.(int8) == 1 {
+			return true, nil
+		}
+	}
+
+	autocommit, err := ctx.GetSessionVariable(ctx, sql.AutoCommitSessionVar)
+	if err != nil {
+		return false, err
+	}
+	isAutocommit, err := types.ConvertToBool(autocommit)
+	if err != nil {
+		return false, err
+	}
+	return !isAutocommit, nil
+}
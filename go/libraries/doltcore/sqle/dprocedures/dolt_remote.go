@@ -15,6 +15,7 @@
 package dprocedures
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -38,7 +39,7 @@ func doltRemote(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(res), nil
+	return rowToIter(int64(res)), nil
 }
 
 // doDoltRemote is used as sql dolt_remote command for only creating or deleting remotes, not listing.
@@ -52,6 +53,9 @@ func doDoltRemote(ctx *sql.Context, args []string) (int, error) {
 		return 1, err
 	}
 	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, err
+	}
 	dbData, ok := dSess.GetDbData(ctx, dbName)
 	if !ok {
 		return 1, fmt.Errorf("Could not load database %s", dbName)
@@ -86,7 +90,7 @@ func doDoltRemote(ctx *sql.Context, args []string) (int, error) {
 	return 0, nil
 }
 
-func addRemote(_ *sql.Context, dbName string, dbd env.DbData, apr *argparser.ArgParseResults, sess *dsess.DoltSession) error {
+func addRemote(ctx *sql.Context, dbName string, dbd env.DbData, apr *argparser.ArgParseResults, sess *dsess.DoltSession) error {
 	if apr.NArg() != 3 {
 		return fmt.Errorf("error: invalid argument")
 	}
@@ -109,6 +113,16 @@ func addRemote(_ *sql.Context, dbName string, dbd env.DbData, apr *argparser.Arg
 		return err
 	}
 	r := env.NewRemote(remoteName, absRemoteUrl, params)
+
+	if apr.Contains(cli.VerifyFlag) {
+		err = env.VerifyRemote(ctx, r, func(ctx context.Context, r env.Remote) (*doltdb.DoltDB, error) {
+			return sess.Provider().GetRemoteDB(ctx, dbd.Ddb.ValueReadWriter().Format(), r, false)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return dbd.Rsw.AddRemote(r)
 }
 
@@ -162,6 +176,9 @@ func remoteParams(apr *argparser.ArgParseResults, scheme, remoteUrl string) (map
 	default:
 		err = cli.VerifyNoAwsParams(apr)
 	}
+	if err == nil {
+		err = cli.AddRemoteParams(scheme, apr, params)
+	}
 
 	if err != nil {
 		return nil, errhand.VerboseErrorFromError(err)
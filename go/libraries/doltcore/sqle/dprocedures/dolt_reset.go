@@ -22,50 +22,75 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
+// mixedResetFlag resets the staged tables to the commit given, but keeps the working tables. This is what dolt_reset
+// does when given a commit without --hard or --soft; the flag is accepted so that scripts can say so explicitly.
+const mixedResetFlag = "mixed"
+
+// keepResetFlag names tables whose working changes a hard reset keeps, while resetting everything else
+const keepResetFlag = "keep"
+
 // doltReset is the stored procedure version for the CLI command `dolt reset`.
 func doltReset(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	res, err := doDoltReset(ctx, args)
+	res, oldHead, newHead, err := doDoltReset(ctx, args)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(int64(res)), nil
+	return rowToIter(int64(res), oldHead, newHead), nil
 }
 
-func doDoltReset(ctx *sql.Context, args []string) (int, error) {
+// doDoltReset runs dolt_reset with the arguments given, returning its status and the hashes of the head of the
+// current branch before and after the reset.
+func doDoltReset(ctx *sql.Context, args []string) (int, string, string, error) {
 	dbName := ctx.GetCurrentDatabase()
 
 	if len(dbName) == 0 {
-		return 1, fmt.Errorf("Empty database name.")
+		return 1, "", "", fmt.Errorf("Empty database name.")
 	}
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
-		return 1, err
+		return 1, "", "", err
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, "", "", err
+	}
 	dbData, ok := dSess.GetDbData(ctx, dbName)
 
 	if !ok {
-		return 1, fmt.Errorf("Could not load database %s", dbName)
+		return 1, "", "", fmt.Errorf("Could not load database %s", dbName)
 	}
 
-	apr, err := cli.CreateResetArgParser().Parse(args)
+	ap := withConfirmFlag(cli.CreateResetArgParser())
+	ap.SupportsFlag(mixedResetFlag, "", "Resets the staged tables to the commit given, but not the working tables. This is the default when a commit is given.")
+	ap.SupportsStringList(keepResetFlag, "", "tables", "With --hard, keeps the working changes to the comma-separated list of tables given, instead of resetting them.")
+	apr, err := ap.Parse(args)
 	if err != nil {
-		return 1, err
+		return 1, "", "", err
 	}
 
 	// Check if problems with args first.
-	if apr.ContainsAll(cli.HardResetParam, cli.SoftResetParam) {
-		return 1, fmt.Errorf("error: --%s and --%s are mutually exclusive options.", cli.HardResetParam, cli.SoftResetParam)
+	modes := []string{cli.HardResetParam, cli.SoftResetParam, mixedResetFlag}
+	for i := range modes {
+		for _, other := range modes[i+1:] {
+			if apr.ContainsAll(modes[i], other) {
+				return 1, "", "", fmt.Errorf("error: --%s and --%s are mutually exclusive options.", modes[i], other)
+			}
+		}
+	}
+	if apr.Contains(keepResetFlag) && !apr.Contains(cli.HardResetParam) {
+		return 1, "", "", fmt.Errorf("error: --%s can only be used with --%s", keepResetFlag, cli.HardResetParam)
 	}
 
 	provider := dSess.Provider()
 	db, err := provider.Database(ctx, dbName)
 	if err != nil {
-		return 1, err
+		return 1, "", "", err
 	}
 
 	// Disallow manipulating any roots for read-only databases – changing the branch
@@ -73,61 +98,148 @@ func doDoltReset(ctx *sql.Context, args []string) (int, error) {
 	// any contents for a read-only database.
 	if rodb, ok := db.(sql.ReadOnlyDatabase); ok {
 		if rodb.IsReadOnly() {
-			return 1, fmt.Errorf("unable to reset HEAD in read-only databases")
+			return 1, "", "", fmt.Errorf("unable to reset HEAD in read-only databases")
 		}
 	}
 
 	// Get all the needed roots.
 	roots, ok := dSess.GetRoots(ctx, dbName)
 	if !ok {
-		return 1, fmt.Errorf("Could not load database %s", dbName)
+		return 1, "", "", fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	oldHead, err := headHash(ctx, dSess, dbName)
+	if err != nil {
+		return 1, "", "", err
 	}
 
 	if apr.Contains(cli.HardResetParam) {
 		// Get the commitSpec for the branch if it exists
 		arg := ""
 		if apr.NArg() > 1 {
-			return 1, fmt.Errorf("--hard supports at most one additional param")
+			return 1, "", "", fmt.Errorf("--hard supports at most one additional param")
 		} else if apr.NArg() == 1 {
 			arg = apr.Arg(0)
 		}
 
 		var newHead *doltdb.Commit
-		newHead, roots, err = actions.ResetHardTables(ctx, dbData, arg, roots)
+		var newRoots doltdb.Roots
+		newHead, newRoots, err = actions.ResetHardTables(ctx, dbData, arg, roots)
 		if err != nil {
-			return 1, err
+			return 1, "", "", err
 		}
 
+		if keepTables, ok := apr.GetValueList(keepResetFlag); ok {
+			newRoots.Working, err = keepWorkingTables(ctx, newRoots.Working, roots.Working, keepTables)
+			if err != nil {
+				return 1, "", "", err
+			}
+		}
+
+		err = checkDestructiveConfirm(ctx, apr, "reset --hard", func() (discardedChanges, error) {
+			return getDiscardedChanges(ctx, newRoots.Working, roots.Working, roots.Staged)
+		})
+		if err != nil {
+			return 1, "", "", err
+		}
+		roots = newRoots
+
 		// TODO: this overrides the transaction setting, needs to happen at commit, not here
 		if newHead != nil {
 			headRef, err := dbData.Rsr.CWBHeadRef()
 			if err != nil {
-				return 1, err
+				return 1, "", "", err
 			}
 			if err := dbData.Ddb.SetHeadToCommit(ctx, headRef, newHead); err != nil {
-				return 1, err
+				return 1, "", "", err
 			}
 		}
 
 		ws, err := dSess.WorkingSet(ctx, dbName)
 		if err != nil {
-			return 1, err
+			return 1, "", "", err
 		}
 		err = dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged).ClearMerge())
 		if err != nil {
-			return 1, err
+			return 1, "", "", err
+		}
+	} else if isRef, err := isResetToRef(ctx, apr, dbData); err != nil {
+		return 1, "", "", err
+	} else if isRef {
+		newRoots, err := actions.ResetSoftToRef(ctx, dbData, apr.Arg(0))
+		if err != nil {
+			return 1, "", "", err
+		}
+
+		// --soft keeps the staged tables, so the changes between the new head and the old one are left staged
+		if !apr.Contains(cli.SoftResetParam) {
+			roots.Staged = newRoots.Staged
+		}
+
+		err = dSess.SetRoots(ctx, dbName, roots)
+		if err != nil {
+			return 1, "", "", err
 		}
 	} else {
+		// Tables given with or without --soft are unstaged, like `git reset <path>`. Tables that aren't named are left
+		// staged, and every table named must exist in either the staged or head root.
 		roots, err = actions.ResetSoftTables(ctx, dbData, apr, roots)
 		if err != nil {
-			return 1, err
+			return 1, "", "", err
 		}
 
 		err = dSess.SetRoots(ctx, dbName, roots)
 		if err != nil {
-			return 1, err
+			return 1, "", "", err
+		}
+	}
+
+	newHead, err := headHash(ctx, dSess, dbName)
+	if err != nil {
+		return 1, "", "", err
+	}
+
+	return 0, oldHead, newHead, nil
+}
+
+// keepWorkingTables returns |newWorking| with the tables named copied from |oldWorking|, so that a hard reset keeps
+// their working changes. Returns an error if a table doesn't exist in |oldWorking|.
+func keepWorkingTables(ctx *sql.Context, newWorking, oldWorking *doltdb.RootValue, tableNames []string) (*doltdb.RootValue, error) {
+	for _, name := range tableNames {
+		tbl, tblName, ok, err := oldWorking.GetTableInsensitive(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, sql.ErrTableNotFound.New(name)
+		}
+
+		newWorking, err = newWorking.PutTable(ctx, tblName, tbl)
+		if err != nil {
+			return nil, err
 		}
 	}
+	return newWorking, nil
+}
 
-	return 0, nil
+// isResetToRef returns whether the arguments given to dolt_reset are a single commit to move the head of the current
+// branch to, rather than tables to unstage. A name that's both a table and a commit is treated as a commit.
+func isResetToRef(ctx *sql.Context, apr *argparser.ArgParseResults, dbData env.DbData) (bool, error) {
+	if apr.NArg() != 1 {
+		return false, nil
+	}
+	return actions.IsValidRef(ctx, apr.Arg(0), dbData.Ddb, dbData.Rsr)
+}
+
+// headHash returns the hash of the session's head commit for the database named
+func headHash(ctx *sql.Context, dSess *dsess.DoltSession, dbName string) (string, error) {
+	head, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return "", err
+	}
+	h, err := head.HashOf()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
 }
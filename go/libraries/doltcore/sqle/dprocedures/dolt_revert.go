@@ -28,7 +28,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
-// doltRevert is the stored procedure version for the CLI command `dolt revert`.
+// doltRevert is the stored procedure version for the CLI command `dolt revert`. Its status is 1 when reverting a commit
+// generated conflicts, which are left in the working set for the dolt_conflicts tables instead of being committed.
 func doltRevert(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	res, err := doDoltRevert(ctx, args)
 	if err != nil {
@@ -103,7 +104,17 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 	}
 
 	workingRoot, revertMessage, err := merge.Revert(ctx, ddb, workingRoot, headCommit, commits, dbState.EditOpts())
-	if err != nil {
+	if err == doltdb.ErrUnresolvedConflictsOrViolations {
+		// like dolt_merge, write the conflicts to the working set so they can be resolved with the dolt_conflicts tables,
+		// and leave it to the transaction to fail unless @@dolt_allow_commit_conflicts is set
+		workingSet = workingSet.WithWorkingRoot(workingRoot).WithStagedRoot(workingRoot)
+		err = dSess.SetWorkingSet(ctx, dbName, workingSet)
+		if err != nil {
+			return 1, err
+		}
+		ctx.Warn(DoltMergeWarningCode, doltdb.ErrUnresolvedConflictsOrViolations.Error())
+		return 1, nil
+	} else if err != nil {
 		return 1, err
 	}
 	workingHash, err = workingRoot.HashOf()
@@ -117,18 +128,18 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 		}
 		stringType := typeinfo.StringDefaultType.ToSqlType()
 
-		expressions := []sql.Expression{expression.NewLiteral("-a", stringType), expression.NewLiteral("-m", stringType), expression.NewLiteral(revertMessage, stringType)}
+		expressions := []sql.Expression{expression.NewLiteral("-a", stringType), expression.NewLiteral("-m", stringType), expression.NewLiteral(apr.GetValueOrDefault(cli.MessageArg, revertMessage), stringType)}
 
 		author, hasAuthor := apr.GetValue(cli.AuthorParam)
 		if hasAuthor {
 			expressions = append(expressions, expression.NewLiteral("--author", stringType), expression.NewLiteral(author, stringType))
 		}
 
-		commitArgs, err := getDoltArgs(ctx, nil, expressions)
+		commitArgs, err := GetDoltArgs(ctx, nil, expressions)
 		if err != nil {
 			return 1, err
 		}
-		_, err = doDoltCommit(ctx, commitArgs)
+		_, err = DoDoltCommit(ctx, commitArgs)
 		if err != nil {
 			return 1, err
 		}
@@ -0,0 +1,192 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltStash is the stored procedure version for the CLI command `dolt stash`. It saves the uncommitted changes of the
+// current branch as a new stash entry, which are listed by the dolt_stashes table, and resets them to HEAD.
+func doltStash(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltStash(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+// doltStashPop is the stored procedure version for the CLI command `dolt stash pop`. If applying the stash entry
+// produces conflicts, they are written to the working set for the dolt_conflicts tables, the same as dolt_merge, and
+// the stash entry is kept.
+func doltStashPop(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltStashPop(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltStash(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+
+	apr, err := cli.CreateStashArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, err
+	}
+	ddb, err := getStashDoltDB(ctx, dSess, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	headCommit, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	roots, stashed, err := actions.StashChanges(ctx, ddb, roots, headRef, headCommit, apr.Contains(cli.IncludeUntrackedFlag), apr.Contains(cli.AllFlag))
+	if err != nil {
+		return 1, err
+	}
+	if !stashed {
+		return 1, fmt.Errorf("no local changes to save")
+	}
+
+	err = dSess.SetRoots(ctx, dbName, roots)
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+func doDoltStashPop(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+
+	apr, err := cli.CreateStashPopArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	idx := 0
+	if apr.NArg() == 1 {
+		stashName := strings.TrimSuffix(strings.TrimPrefix(apr.Arg(0), "stash@{"), "}")
+		idx, err = strconv.Atoi(stashName)
+		if err != nil {
+			return 1, fmt.Errorf("error: %s is not a valid reference", apr.Arg(0))
+		}
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, err
+	}
+	ddb, err := getStashDoltDB(ctx, dSess, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return 1, err
+	} else if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	roots, conflictRoot, tablesWithConflict, err := actions.ApplyStash(ctx, ddb, roots, idx, dbState.EditOpts())
+	if err != nil {
+		return 1, err
+	}
+
+	if len(tablesWithConflict) > 0 {
+		// like dolt_merge, the conflicts are written to the working set, and the transaction fails unless
+		// @@dolt_allow_commit_conflicts is set
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return 1, err
+		}
+		err = dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(conflictRoot))
+		if err != nil {
+			return 1, err
+		}
+		sort.Strings(tablesWithConflict)
+		ctx.Warn(DoltMergeWarningCode, fmt.Sprintf("conflicts in table {'%s'}, the stash entry is kept in case you need it again", strings.Join(tablesWithConflict, "', '")))
+		return 1, nil
+	}
+
+	err = dSess.SetRoots(ctx, dbName, roots)
+	if err != nil {
+		return 1, err
+	}
+
+	err = ddb.RemoveStashAtIdx(ctx, idx)
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+// getStashDoltDB returns the DoltDB of the database named, whose storage format must support stashes
+func getStashDoltDB(ctx *sql.Context, dSess *dsess.DoltSession, dbName string) (*doltdb.DoltDB, error) {
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	if !ddb.Format().UsesFlatbuffers() {
+		return nil, actions.ErrStashNotSupportedForOldFormat
+	}
+	return ddb, nil
+}
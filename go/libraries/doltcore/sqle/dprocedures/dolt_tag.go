@@ -21,63 +21,82 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
 // doltTag is the stored procedure version for the CLI command `dolt tag`.
 func doltTag(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	res, err := doDoltTag(ctx, args)
+	res, _, err := doDoltTag(ctx, args)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(res), nil
+	return rowToIter(int64(res)), nil
 }
 
-// doDoltTag is used as sql dolt_tag command for only creating or deleting tags, not listing.
-// To read/select tags, dolt_tags system table is used.
-func doDoltTag(ctx *sql.Context, args []string) (int, error) {
+// doltTagHashOut is the stored procedure version for the CLI command `dolt tag`. The first parameter is the variable
+// to set the hash of the tagged commit to, or an empty string when tags are deleted.
+func doltTagHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.RowIter, error) {
+	res, h, err := doDoltTag(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	*outHash = h
+	return rowToIter(int64(res)), nil
+}
+
+// doDoltTag is used as sql dolt_tag command for only creating or deleting tags, not listing. It returns the status and
+// the hash of the commit tagged, if a tag was created. To read/select tags, dolt_tags system table is used.
+func doDoltTag(ctx *sql.Context, args []string) (int, string, error) {
 	dbName := ctx.GetCurrentDatabase()
 	if len(dbName) == 0 {
-		return 1, fmt.Errorf("Empty database name.")
+		return 1, "", fmt.Errorf("Empty database name.")
 	}
 	dSess := dsess.DSessFromSess(ctx.Session)
+	if err := dSess.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return 1, "", err
+	}
 	dbData, ok := dSess.GetDbData(ctx, dbName)
 	if !ok {
-		return 1, fmt.Errorf("Could not load database %s", dbName)
+		return 1, "", fmt.Errorf("Could not load database %s", dbName)
 	}
 
 	apr, err := cli.CreateTagArgParser().Parse(args)
 	if err != nil {
-		return 1, err
+		return 1, "", err
 	}
 
 	// list tags
 	if len(apr.Args) == 0 || apr.Contains(cli.VerboseFlag) {
-		return 1, fmt.Errorf("error: invalid argument, use 'dolt_tags' system table to list tags")
+		return 1, "", fmt.Errorf("error: invalid argument, use 'dolt_tags' system table to list tags")
 	}
 
 	// delete tag
 	if apr.Contains(cli.DeleteFlag) {
 		if apr.Contains(cli.MessageArg) {
-			return 1, fmt.Errorf("delete and tag message options are incompatible")
+			return 1, "", fmt.Errorf("delete and tag message options are incompatible")
 		}
 		err = actions.DeleteTagsOnDB(ctx, dbData.Ddb, apr.Args...)
 		if err != nil {
-			return 1, err
+			return 1, "", err
+		}
+		err = actions.RecordTagDeletion(dbData.Rsw, apr.Args...)
+		if err != nil {
+			return 1, "", err
 		}
-		return 0, nil
+		return 0, "", nil
 	}
 
 	// create tag
 	if len(apr.Args) > 2 {
-		return 1, fmt.Errorf("create tag takes at most two args")
+		return 1, "", fmt.Errorf("create tag takes at most two args")
 	}
 
 	var name, email string
 	if authorStr, ok := apr.GetValue(cli.AuthorParam); ok {
 		name, email, err = cli.ParseAuthor(authorStr)
 		if err != nil {
-			return 1, err
+			return 1, "", err
 		}
 	} else {
 		name = dSess.Username()
@@ -99,12 +118,25 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 	}
 	headRef, err := dbData.Rsr.CWBHeadRef()
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	err = actions.CreateTagOnDB(ctx, dbData.Ddb, tagName, startPoint, props, headRef)
 	if err != nil {
-		return 1, err
+		return 1, "", err
+	}
+	err = actions.RecordTagCreation(ctx, dbData.Rsw, tagName)
+	if err != nil {
+		return 1, "", err
+	}
+
+	tag, err := dbData.Ddb.ResolveTag(ctx, ref.NewTagRef(tagName))
+	if err != nil {
+		return 1, "", err
+	}
+	h, err := tag.Commit.HashOf()
+	if err != nil {
+		return 1, "", err
 	}
 
-	return 0, nil
+	return 0, h.String(), nil
 }
@@ -0,0 +1,112 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// verifySyncSchema is the schema of the rows returned by dolt_verify_sync, one for each table on either side.
+var verifySyncSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "local_hash", Type: types.Text, Nullable: true},
+	&sql.Column{Name: "remote_hash", Type: types.Text, Nullable: true},
+	&sql.Column{Name: "matches", Type: types.Boolean, Nullable: false},
+}
+
+// doltVerifySync compares the address of every table at the head of a local branch with the same branch on a remote,
+// e.g. CALL DOLT_VERIFY_SYNC('origin', 'main'). Only the remote's commit and root value are read, no table data is
+// fetched, so this is a cheap way to confirm that a push or replication completed.
+func doltVerifySync(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+
+	apr, err := cli.CreateVerifySyncArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() != 2 {
+		return nil, fmt.Errorf("error: invalid number of arguments: remote and branch must be specified")
+	}
+	remoteName, branchName := apr.Arg(0), apr.Arg(1)
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	remotes, err := dbData.Rsr.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+	remote, ok := remotes[remoteName]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", env.ErrUnknownRemote, remoteName)
+	}
+
+	branchRef := ref.NewBranchRef(branchName)
+	localCommit, err := dbData.Ddb.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return nil, err
+	}
+	localRoot, err := localCommit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, false)
+	if err != nil {
+		return nil, err
+	}
+	remoteCommit, err := remoteDB.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve branch '%s' on remote '%s': %w", branchName, remoteName, err)
+	}
+	remoteRoot, err := remoteCommit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := diff.GetTableHashMatches(ctx, localRoot, remoteRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(matches))
+	for i, m := range matches {
+		var localHash, remoteHash interface{}
+		if !m.FromHash.IsEmpty() {
+			localHash = m.FromHash.String()
+		}
+		if !m.ToHash.IsEmpty() {
+			remoteHash = m.ToHash.String()
+		}
+		rows[i] = sql.Row{m.TableName, localHash, remoteHash, m.Matches()}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
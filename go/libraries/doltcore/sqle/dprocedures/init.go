@@ -17,63 +17,107 @@ package dprocedures
 import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
 )
 
+// DoltProcedures are the stored procedures provided by Dolt. Every procedure except dolt_verify_sync, which returns a
+// row for each table compared, dolt_fetch and dolt_pull, which return a row for each remote ref fetched after the
+// first (see fetchResultSchema), and dolt_clean, which returns a row for each table it would remove after the first
+// with --dry-run (see cleanSchema), returns a single row with named, non-nullable columns:
+//
+//	status BIGINT         0 for success, 1 for failure (dolt_add, dolt_branch, dolt_checkout, dolt_clean, dolt_clone,
+//	                      dolt_conflicts_resolve, dolt_create_database, dolt_create_from, dolt_lock_database,
+//	                      dolt_rebase, dolt_remote, dolt_reset, dolt_revert, dolt_stash, dolt_stash_pop,
+//	                      dolt_tag, dolt_tag_hash_out, dolt_unlock_database)
+//	old_head CHAR(32),    the hashes of the head of the current branch before and after the reset, which are the same
+//	new_head CHAR(32)     unless a commit was given (dolt_reset, after status)
+//	success BIGINT        1 for success, 0 for failure (dolt_backup, dolt_fetch, dolt_gc, dolt_push)
+//	hash CHAR(32)         the hash of the commit created (dolt_cherry_pick, dolt_cherry_pick_hash_out, dolt_commit,
+//	                      dolt_commit_hash_out)
+//	fast_forward BIGINT,  whether the merge was a fast-forward, and whether it produced conflicts or constraint
+//	conflicts BIGINT      violations (dolt_merge, dolt_merge_hash_out, dolt_pull)
+//	violations BIGINT     whether any constraint violations were found (dolt_verify_constraints)
+//
+// The *_hash_out variants take the variable to set to the resulting commit hash as their first parameter, so that
+// scripts can use the hash in later statements.
+//
+// Errors are returned as errors, not as a failure status, so there is no message column: it would always be empty,
+// and adding it would change the width of every procedure's result.
+//
+// No procedure reports an affected-rows count. The engine only reports one for statements with an OkResult schema,
+// which these result sets can't have, and it sets ROW_COUNT() to -1 for a CALL after the procedure's rows are read.
 var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_add", Schema: int64Schema("status"), Function: doltAdd},
 	{Name: "dolt_backup", Schema: int64Schema("success"), Function: doltBackup},
 	{Name: "dolt_branch", Schema: int64Schema("status"), Function: doltBranch},
 	{Name: "dolt_checkout", Schema: int64Schema("status"), Function: doltCheckout},
-	{Name: "dolt_cherry_pick", Schema: stringSchema("hash"), Function: doltCherryPick},
-	{Name: "dolt_clean", Schema: int64Schema("status"), Function: doltClean},
+	{Name: "dolt_cherry_pick", Schema: hashSchema("hash"), Function: doltCherryPick},
+	{Name: "dolt_cherry_pick_hash_out", Schema: hashSchema("hash"), Function: doltCherryPickHashOut},
+	{Name: "dolt_clean", Schema: cleanSchema, Function: doltClean},
 	{Name: "dolt_clone", Schema: int64Schema("status"), Function: doltClone},
-	{Name: "dolt_commit", Schema: stringSchema("hash"), Function: doltCommit},
-	{Name: "dolt_commit_hash_out", Schema: stringSchema("hash"), Function: doltCommitHashOut},
+	{Name: "dolt_commit", Schema: hashSchema("hash"), Function: doltCommit},
+	{Name: "dolt_commit_hash_out", Schema: hashSchema("hash"), Function: doltCommitHashOut},
 	{Name: "dolt_conflicts_resolve", Schema: int64Schema("status"), Function: doltConflictsResolve},
-	{Name: "dolt_fetch", Schema: int64Schema("success"), Function: doltFetch},
+	{Name: "dolt_create_database", Schema: int64Schema("status"), Function: doltCreateDatabase},
+	{Name: "dolt_create_from", Schema: int64Schema("status"), Function: doltCreateFrom},
+	{Name: "dolt_fetch", Schema: fetchSchema, Function: doltFetch},
 
 	// dolt_gc is enabled behind a feature flag for now, see dolt_gc.go
 	{Name: "dolt_gc", Schema: int64Schema("success"), Function: doltGC},
 
+	{Name: "dolt_lock_database", Schema: int64Schema("status"), Function: doltLockDatabase},
 	{Name: "dolt_merge", Schema: int64Schema("fast_forward", "conflicts"), Function: doltMerge},
-	{Name: "dolt_pull", Schema: int64Schema("fast_forward", "conflicts"), Function: doltPull},
+	{Name: "dolt_merge_hash_out", Schema: int64Schema("fast_forward", "conflicts"), Function: doltMergeHashOut},
+	{Name: "dolt_pull", Schema: pullSchema, Function: doltPull},
 	{Name: "dolt_push", Schema: int64Schema("success"), Function: doltPush},
+	{Name: "dolt_rebase", Schema: int64Schema("status"), Function: doltRebase},
 	{Name: "dolt_remote", Schema: int64Schema("status"), Function: doltRemote},
-	{Name: "dolt_reset", Schema: int64Schema("status"), Function: doltReset},
+	{Name: "dolt_reset", Schema: resetSchema, Function: doltReset},
 	{Name: "dolt_revert", Schema: int64Schema("status"), Function: doltRevert},
+	{Name: "dolt_stash", Schema: int64Schema("status"), Function: doltStash},
+	{Name: "dolt_stash_pop", Schema: int64Schema("status"), Function: doltStashPop},
 	{Name: "dolt_tag", Schema: int64Schema("status"), Function: doltTag},
+	{Name: "dolt_tag_hash_out", Schema: int64Schema("status"), Function: doltTagHashOut},
+	{Name: "dolt_unlock_database", Schema: int64Schema("status"), Function: doltUnlockDatabase},
 	{Name: "dolt_verify_constraints", Schema: int64Schema("violations"), Function: doltVerifyConstraints},
+	{Name: "dolt_verify_sync", Schema: verifySyncSchema, Function: doltVerifySync},
 
 	// Dolt stored procedure aliases
 	// TODO: Add new procedure aliases in doltProcedureAliasSet in go-mysql-server/sql/information_schema/routines.go file
 	{Name: "dadd", Schema: int64Schema("status"), Function: doltAdd},
 	{Name: "dbranch", Schema: int64Schema("status"), Function: doltBranch},
 	{Name: "dcheckout", Schema: int64Schema("status"), Function: doltCheckout},
-	{Name: "dcherry_pick", Schema: stringSchema("hash"), Function: doltCherryPick},
-	{Name: "dclean", Schema: int64Schema("status"), Function: doltClean},
+	{Name: "dcherry_pick", Schema: hashSchema("hash"), Function: doltCherryPick},
+	{Name: "dclean", Schema: cleanSchema, Function: doltClean},
 	{Name: "dclone", Schema: int64Schema("status"), Function: doltClone},
-	{Name: "dcommit", Schema: stringSchema("hash"), Function: doltCommit},
-	{Name: "dfetch", Schema: int64Schema("success"), Function: doltFetch},
+	{Name: "dcommit", Schema: hashSchema("hash"), Function: doltCommit},
+	{Name: "dfetch", Schema: fetchSchema, Function: doltFetch},
 
 	//	{Name: "dgc", Schema: int64Schema("status"), Function: doltGC},
 
 	{Name: "dmerge", Schema: int64Schema("fast_forward", "conflicts"), Function: doltMerge},
-	{Name: "dpull", Schema: int64Schema("fast_forward", "conflicts"), Function: doltPull},
+	{Name: "dpull", Schema: pullSchema, Function: doltPull},
 	{Name: "dpush", Schema: int64Schema("success"), Function: doltPush},
 	{Name: "dremote", Schema: int64Schema("status"), Function: doltRemote},
-	{Name: "dreset", Schema: int64Schema("status"), Function: doltReset},
+	{Name: "dreset", Schema: resetSchema, Function: doltReset},
 	{Name: "drevert", Schema: int64Schema("status"), Function: doltRevert},
 	{Name: "dtag", Schema: int64Schema("status"), Function: doltTag},
 	{Name: "dverify_constraints", Schema: int64Schema("violations"), Function: doltVerifyConstraints},
 }
 
-// stringSchema returns a non-nullable schema with all columns as LONGTEXT.
-func stringSchema(columnNames ...string) sql.Schema {
+// resetSchema is the schema of dolt_reset's result
+var resetSchema = append(int64Schema("status"), hashSchema("old_head", "new_head")...)
+
+// hashType is the type of result columns containing a commit hash
+var hashType = types.MustCreateString(query.Type_CHAR, 32, sql.Collation_ascii_bin)
+
+// hashSchema returns a non-nullable schema with all columns as CHAR(32), suitable for commit hashes.
+func hashSchema(columnNames ...string) sql.Schema {
 	sch := make(sql.Schema, len(columnNames))
 	for i, colName := range columnNames {
 		sch[i] = &sql.Column{
 			Name:     colName,
-			Type:     types.LongText,
+			Type:     hashType,
 			Nullable: false,
 		}
 	}
@@ -0,0 +1,176 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrDatabaseWriteLocked is returned when a session attempts to write to a database that is write-locked by another
+// session.
+var ErrDatabaseWriteLocked = goerrors.NewKind("database locked: %s (held by connection %d)")
+
+// ErrDatabaseWriteLockNotHeld is returned when a session attempts to release a write lock it does not hold.
+var ErrDatabaseWriteLockNotHeld = goerrors.NewKind("database %s is not locked by this connection")
+
+// DatabaseWriteLock describes an advisory write lock held on a database by a single session.
+type DatabaseWriteLock struct {
+	// Reason is the user supplied reason the lock was taken
+	Reason string
+	// ConnectionID is the connection id of the session holding the lock
+	ConnectionID uint32
+
+	holder *DoltSession
+}
+
+// String returns a human-readable description of the lock.
+func (l DatabaseWriteLock) String() string {
+	return fmt.Sprintf("%s (held by connection %d)", l.Reason, l.ConnectionID)
+}
+
+// DatabaseWriteLocks tracks advisory write locks on databases. While a database is locked, only the session holding
+// the lock may write to it. Locks are held in memory only and are never persisted. A DatabaseWriteLocks is shared by
+// all sessions of a single database provider.
+type DatabaseWriteLocks struct {
+	locks map[string]DatabaseWriteLock
+	mu    *sync.Mutex
+}
+
+// NewDatabaseWriteLocks returns a new, empty DatabaseWriteLocks.
+func NewDatabaseWriteLocks() *DatabaseWriteLocks {
+	return &DatabaseWriteLocks{
+		locks: make(map[string]DatabaseWriteLock),
+		mu:    &sync.Mutex{},
+	}
+}
+
+// Lock write-locks the database named for the session in |ctx|, with the reason given. If the database is already
+// locked by another session, ErrDatabaseWriteLocked is returned. Locking a database the session already holds updates
+// the reason.
+func (l *DatabaseWriteLocks) Lock(ctx *sql.Context, dbName, reason string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := lockKey(dbName)
+	sess := DSessFromSess(ctx.Session)
+	if existing, ok := l.lockedByOther(key, sess); ok {
+		return ErrDatabaseWriteLocked.New(existing.Reason, existing.ConnectionID)
+	}
+
+	l.locks[key] = DatabaseWriteLock{
+		Reason:       reason,
+		ConnectionID: sess.ID(),
+		holder:       sess,
+	}
+	return nil
+}
+
+// Unlock releases the write lock on the database named held by the session in |ctx|. Returns
+// ErrDatabaseWriteLockNotHeld if this session does not hold the lock.
+func (l *DatabaseWriteLocks) Unlock(ctx *sql.Context, dbName string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := lockKey(dbName)
+	existing, ok := l.locks[key]
+	if !ok || existing.holder != DSessFromSess(ctx.Session) {
+		return ErrDatabaseWriteLockNotHeld.New(dbName)
+	}
+
+	delete(l.locks, key)
+	return nil
+}
+
+// Status returns the write lock currently held on the database named, if any.
+func (l *DatabaseWriteLocks) Status(dbName string) (DatabaseWriteLock, bool) {
+	if l == nil {
+		return DatabaseWriteLock{}, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	existing, ok := l.locks[lockKey(dbName)]
+	return existing, ok
+}
+
+// CheckWrite returns ErrDatabaseWriteLocked if the database named is write-locked by a session other than the one in
+// |ctx|.
+func (l *DatabaseWriteLocks) CheckWrite(ctx *sql.Context, dbName string) error {
+	// Not every provider has a lock registry, e.g. in tests
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if existing, ok := l.lockedByOther(lockKey(dbName), DSessFromSess(ctx.Session)); ok {
+		return ErrDatabaseWriteLocked.New(existing.Reason, existing.ConnectionID)
+	}
+	return nil
+}
+
+// ReleaseConnection releases every write lock held by the connection with the id given. It's called when the
+// connection closes, so that its locks don't outlive it.
+func (l *DatabaseWriteLocks) ReleaseConnection(connID uint32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, lock := range l.locks {
+		if lock.ConnectionID == connID {
+			delete(l.locks, key)
+		}
+	}
+}
+
+// ReleasingProcessList returns a sql.ProcessList wrapping |pl| that releases the write locks of each connection
+// removed from it. The server removes a connection from its process list when the connection closes, which is the
+// only notification of it a session gets.
+func (l *DatabaseWriteLocks) ReleasingProcessList(pl sql.ProcessList) sql.ProcessList {
+	return writeLockReleasingProcessList{ProcessList: pl, locks: l}
+}
+
+type writeLockReleasingProcessList struct {
+	sql.ProcessList
+	locks *DatabaseWriteLocks
+}
+
+// RemoveConnection implements sql.ProcessList
+func (pl writeLockReleasingProcessList) RemoveConnection(connID uint32) {
+	pl.ProcessList.RemoveConnection(connID)
+	pl.locks.ReleaseConnection(connID)
+}
+
+// lockedByOther returns the lock on |key| if it's held by a session other than |sess|. Callers must hold |l.mu|.
+func (l *DatabaseWriteLocks) lockedByOther(key string, sess *DoltSession) (DatabaseWriteLock, bool) {
+	existing, ok := l.locks[key]
+	if !ok || existing.holder == sess {
+		return DatabaseWriteLock{}, false
+	}
+	return existing, true
+}
+
+// lockKey returns the key used to track the lock for the database named. Revision databases share the lock of their
+// base database.
+func lockKey(dbName string) string {
+	baseName := strings.SplitN(dbName, DbRevisionDelimiter, 2)[0]
+	return strings.ToLower(baseName)
+}
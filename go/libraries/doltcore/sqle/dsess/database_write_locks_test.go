@@ -0,0 +1,54 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+func TestDatabaseWriteLocksReleasedOnConnectionClose(t *testing.T) {
+	locks := NewDatabaseWriteLocks()
+	pl := locks.ReleasingProcessList(sql.EmptyProcessList{})
+
+	ctxA := newWriteLockTestContext(t, 1)
+	ctxB := newWriteLockTestContext(t, 2)
+
+	require.NoError(t, locks.Lock(ctxA, "mydb", "maintenance"))
+	require.NoError(t, locks.Lock(ctxA, "otherdb", "maintenance"))
+	assert.True(t, ErrDatabaseWriteLocked.Is(locks.CheckWrite(ctxB, "mydb")))
+
+	// closing another connection leaves the locks alone
+	pl.RemoveConnection(2)
+	assert.True(t, ErrDatabaseWriteLocked.Is(locks.CheckWrite(ctxB, "mydb")))
+
+	pl.RemoveConnection(1)
+	assert.NoError(t, locks.CheckWrite(ctxB, "mydb"))
+	assert.NoError(t, locks.CheckWrite(ctxB, "otherdb"))
+	_, ok := locks.Status("mydb")
+	assert.False(t, ok)
+}
+
+func newWriteLockTestContext(t *testing.T, connID uint32) *sql.Context {
+	sess, err := NewDoltSession(sql.NewBaseSessionWithClientServer("", sql.Client{}, connID), emptyDatabaseProvider(), config.NewMapConfig(make(map[string]string)), nil)
+	require.NoError(t, err)
+	return sql.NewContext(context.Background(), sql.WithSession(sess))
+}
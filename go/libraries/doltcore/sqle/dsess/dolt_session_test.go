@@ -291,6 +291,22 @@ func (e emptyRevisionDatabaseProvider) CloneDatabaseFromRemote(ctx *sql.Context,
 	return nil
 }
 
+func (e emptyRevisionDatabaseProvider) CreateDatabaseFromCommit(ctx *sql.Context, dbName string, srcDB *doltdb.DoltDB, commit *doltdb.Commit, preserveHistory bool) error {
+	return nil
+}
+
+func (e emptyRevisionDatabaseProvider) CreateDatabaseWithCharset(ctx *sql.Context, dbName string, charset string, collation string) error {
+	return nil
+}
+
+func (e emptyRevisionDatabaseProvider) DatabaseWriteLocks() *DatabaseWriteLocks {
+	return nil
+}
+
+func (e emptyRevisionDatabaseProvider) IsReadOnlyDatabase(dbName string) bool {
+	return false
+}
+
 func (e emptyRevisionDatabaseProvider) CreateDatabase(ctx *sql.Context, dbName string) error {
 	return nil
 }
@@ -0,0 +1,90 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import "sync/atomic"
+
+// Counter is a count of events across every session of the server, reported by the dolt_metrics() table function.
+// Counters start at zero when the process starts and aren't persisted.
+type Counter struct {
+	name        string
+	description string
+	value       atomic.Uint64
+}
+
+var counters []*Counter
+
+func newCounter(name, description string) *Counter {
+	c := &Counter{name: name, description: description}
+	counters = append(counters, c)
+	return c
+}
+
+var (
+	// TransactionCommitsTotal counts SQL transactions committed, including those that create a Dolt commit.
+	TransactionCommitsTotal = newCounter("dolt_transaction_commits_total",
+		"SQL transactions committed")
+	// TransactionMergesTotal counts transaction commits that had to merge their working set with one committed by
+	// another transaction since they began.
+	TransactionMergesTotal = newCounter("dolt_transaction_merges_total",
+		"Transaction commits merged with changes committed concurrently by another transaction")
+	// TransactionMergeConflictsTotal counts transaction commits rolled back because merging with another
+	// transaction's changes produced conflicts. Clients see these as serialization errors.
+	TransactionMergeConflictsTotal = newCounter("dolt_transaction_merge_conflicts_total",
+		"Transaction commits rolled back because merging with a concurrent transaction produced conflicts")
+	// TransactionRetriesTotal counts attempts to write a transaction commit that were retried because another
+	// transaction updated the working set first.
+	TransactionRetriesTotal = newCounter("dolt_transaction_retries_total",
+		"Transaction commit writes retried because a concurrent transaction updated the working set first")
+	// CommitsTotal counts Dolt commits created by SQL sessions.
+	CommitsTotal = newCounter("dolt_commit_total",
+		"Dolt commits created")
+	// MergeConflictTablesTotal counts the tables left with conflicts by dolt_merge and dolt_pull.
+	MergeConflictTablesTotal = newCounter("dolt_merge_conflict_tables_total",
+		"Tables left with conflicts by dolt_merge and dolt_pull")
+	// GCRunsTotal counts completed dolt_gc runs.
+	GCRunsTotal = newCounter("dolt_gc_runs_total",
+		"Completed dolt_gc runs")
+)
+
+// Counters returns every counter, in a stable order.
+func Counters() []*Counter {
+	return counters
+}
+
+// Name returns the name of this counter
+func (c *Counter) Name() string {
+	return c.name
+}
+
+// Description returns what this counter counts
+func (c *Counter) Description() string {
+	return c.description
+}
+
+// Value returns the current value of this counter
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+// Inc increments this counter by one
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Add increments this counter by |n|
+func (c *Counter) Add(n uint64) {
+	c.value.Add(n)
+}
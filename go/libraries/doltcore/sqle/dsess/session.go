@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer/analyzererrors"
 	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
@@ -524,6 +525,10 @@ func (d *DoltSession) doCommit(ctx *sql.Context, dbName string, tx sql.Transacti
 		return nil, nil
 	}
 
+	if err = d.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return nil, err
+	}
+
 	// TODO: validate that the transaction belongs to the DB named
 	dtx, ok := tx.(*DoltTransaction)
 	if !ok {
@@ -777,8 +782,11 @@ func (d *DoltSession) ResolveRootForRef(ctx *sql.Context, dbName, refStr string)
 	if refStr == doltdb.Working || refStr == doltdb.Staged {
 		// TODO: get from working set / staged update time
 		now := types.Timestamp(time.Now())
-		// TODO: no current database
-		roots, _ := d.GetRoots(ctx, ctx.GetCurrentDatabase())
+		// These are the session's own roots, which include changes not yet committed in its transaction
+		roots, ok := d.GetRoots(ctx, dbName)
+		if !ok {
+			return nil, nil, "", sql.ErrDatabaseNotFound.New(dbName)
+		}
 		if refStr == doltdb.Working {
 			return roots.Working, &now, refStr, nil
 		} else if refStr == doltdb.Staged {
@@ -788,45 +796,64 @@ func (d *DoltSession) ResolveRootForRef(ctx *sql.Context, dbName, refStr string)
 
 	var root *doltdb.RootValue
 	var commitTime *types.Timestamp
-	cs, err := doltdb.NewCommitSpec(refStr)
+	cm, err := d.ResolveCommit(ctx, dbName, refStr)
 	if err != nil {
 		return nil, nil, "", err
 	}
 
-	dbData, ok := d.GetDbData(ctx, dbName)
-	if !ok {
-		return nil, nil, "", sql.ErrDatabaseNotFound.New(dbName)
+	root, err = cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	headRef, err := d.CWBHeadRef(ctx, dbName)
+	meta, err := cm.GetCommitMeta(ctx)
 	if err != nil {
 		return nil, nil, "", err
 	}
 
-	cm, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+	t := meta.Time()
+	commitTime = (*types.Timestamp)(&t)
+
+	commitHash, err := cm.HashOf()
 	if err != nil {
 		return nil, nil, "", err
 	}
 
-	root, err = cm.GetRootValue(ctx)
+	return root, commitTime, commitHash.String(), nil
+}
+
+// ResolveCommit returns the commit named by the revision |spec|, like main~ or HEAD, in the database named. In revision
+// databases for commits and tags, HEAD is the commit of the revision database, and other revisions resolve against the
+// branch checked out in the database they are a revision of, see RevisionHeadRef.
+func (d *DoltSession) ResolveCommit(ctx *sql.Context, dbName, spec string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(spec)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, err
 	}
 
-	meta, err := cm.GetCommitMeta(ctx)
+	dbState, ok, err := d.LookupDbState(ctx, dbName)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	t := meta.Time()
-	commitTime = (*types.Timestamp)(&t)
+	if dbState.WorkingSet == nil {
+		name, as, err := doltdb.SplitAncestorSpec(strings.TrimSpace(spec))
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(name, "HEAD") {
+			return dbState.headCommit.GetAncestor(ctx, as)
+		}
+	}
 
-	commitHash, err := cm.HashOf()
+	headRef, err := d.RevisionHeadRef(ctx, dbName)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, err
 	}
 
-	return root, commitTime, commitHash.String(), nil
+	return dbState.dbData.Ddb.Resolve(ctx, cs, headRef)
 }
 
 // SetRoot sets a new root value for the session for the database named. This is the primary mechanism by which data
@@ -853,6 +880,9 @@ func (d *DoltSession) SetRoot(ctx *sql.Context, dbName string, newRoot *doltdb.R
 		// TODO: Return an error here?
 		return nil
 	}
+	if err = d.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return err
+	}
 	sessionState.WorkingSet = sessionState.WorkingSet.WithWorkingRoot(newRoot)
 
 	return d.SetWorkingSet(ctx, dbName, sessionState.WorkingSet)
@@ -871,6 +901,9 @@ func (d *DoltSession) SetRoots(ctx *sql.Context, dbName string, roots doltdb.Roo
 	if sessionState.WorkingSet == nil {
 		return doltdb.ErrOperationNotSupportedInDetachedHead
 	}
+	if err = d.CheckDatabaseWriteLock(ctx, dbName); err != nil {
+		return err
+	}
 
 	workingSet := sessionState.WorkingSet.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged)
 	return d.SetWorkingSet(ctx, dbName, workingSet)
@@ -930,6 +963,17 @@ func (d *DoltSession) SetWorkingSet(ctx *sql.Context, dbName string, ws *doltdb.
 	return nil
 }
 
+// CheckDatabaseWriteLock returns an error if the database named is read-only or is write-locked by another session.
+// Writes made via SetRoot, SetRoots and transaction commit are checked automatically, but operations that set the
+// working set directly or write to the database without going through the session, such as creating branches, must
+// check explicitly.
+func (d *DoltSession) CheckDatabaseWriteLock(ctx *sql.Context, dbName string) error {
+	if d.provider.IsReadOnlyDatabase(dbName) {
+		return analyzererrors.ErrReadOnlyDatabase.New(dbName)
+	}
+	return d.provider.DatabaseWriteLocks().CheckWrite(ctx, dbName)
+}
+
 // SwitchWorkingSet switches to a new working set for this session. Unlike SetWorkingSet, this method expresses no
 // intention to eventually persist any uncommitted changes. Rather, this method only changes the in memory state of
 // this session. It's equivalent to starting a new session with the working set reference provided. If the current
@@ -1283,6 +1327,26 @@ func (d *DoltSession) CWBHeadRef(ctx *sql.Context, dbName string) (ref.DoltRef,
 	return dbState.WorkingSet.Ref().ToHeadRef()
 }
 
+// RevisionHeadRef returns the branch ref that revisions, like main~ or HEAD, resolve against for the database named.
+// This is the session HEAD, except in read-only revision databases for commits and tags, which have no branch of their
+// own and resolve revisions against the branch checked out in the database they are a revision of.
+func (d *DoltSession) RevisionHeadRef(ctx *sql.Context, dbName string) (ref.DoltRef, error) {
+	dbState, ok, err := d.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	if dbState.WorkingSet == nil {
+		// the session's repo state reader for the database is its own, so use the one the revision database was
+		// created with, which is that of the database it is a revision of
+		return dbState.db.DbData().Rsr.CWBHeadRef()
+	}
+
+	return dbState.WorkingSet.Ref().ToHeadRef()
+}
+
 func (d *DoltSession) Username() string {
 	return d.username
 }
@@ -80,11 +80,23 @@ type DoltDatabaseProvider interface {
 	// (otherwise all branches are cloned), remoteName is the name for the remote created in the new database, and
 	// remoteUrl is a URL (e.g. "file:///dbs/db1") or an <org>/<database> path indicating a database hosted on DoltHub.
 	CloneDatabaseFromRemote(ctx *sql.Context, dbName, branch, remoteName, remoteUrl string, remoteParams map[string]string) error
+	// CreateDatabaseFromCommit creates a new database named dbName whose default branch starts at the root value of
+	// commit, which belongs to srcDB. Chunks are copied from srcDB as-is rather than rewritten. If preserveHistory is
+	// true the new branch points at commit itself, otherwise it points at a single new commit of commit's root value.
+	CreateDatabaseFromCommit(ctx *sql.Context, dbName string, srcDB *doltdb.DoltDB, commit *doltdb.Commit, preserveHistory bool) error
+	// CreateDatabaseWithCharset creates a new database named dbName whose tables default to the character set and
+	// collation named. Either name may be empty, in which case it's implied by the other, or is the server default.
+	CreateDatabaseWithCharset(ctx *sql.Context, dbName string, charset string, collation string) error
 	// SessionDatabase returns the SessionDatabase for the specified database, which may name a revision of a base
 	// database.
 	SessionDatabase(ctx *sql.Context, dbName string) (SqlDatabase, bool, error)
 	// DoltDatabases returns all databases known to this provider.
 	DoltDatabases() []SqlDatabase
+	// DatabaseWriteLocks returns the advisory write locks shared by all sessions of this provider.
+	DatabaseWriteLocks() *DatabaseWriteLocks
+	// IsReadOnlyDatabase returns whether the database named, which may name a revision of a base database, is served
+	// read-only by this provider.
+	IsReadOnlyDatabase(dbName string) bool
 }
 
 type SqlDatabase interface {
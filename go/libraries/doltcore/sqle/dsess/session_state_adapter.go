@@ -24,6 +24,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
 
 // SessionStateAdapter is an adapter for env.RepoStateReader in SQL contexts, getting information about the repo state
@@ -102,7 +103,7 @@ func (s SessionStateAdapter) GetBranches() (map[string]env.BranchConfig, error)
 func (s SessionStateAdapter) UpdateBranch(name string, new env.BranchConfig) error {
 	s.branches[name] = new
 
-	fs, err := s.session.Provider().FileSystemForDatabase(s.dbName)
+	fs, err := s.fileSystem()
 	if err != nil {
 		return err
 	}
@@ -116,6 +117,83 @@ func (s SessionStateAdapter) UpdateBranch(name string, new env.BranchConfig) err
 	return repoState.Save(fs)
 }
 
+// GetBranchMeta reads the branch metadata from the repo state on disk, so that branches created by other sessions are
+// included. Databases without a file system have no branch metadata.
+func (s SessionStateAdapter) GetBranchMeta() (map[string]env.RefMeta, error) {
+	repoState, err := s.loadRepoState()
+	if err != nil || repoState == nil {
+		return nil, err
+	}
+
+	return repoState.BranchMeta, nil
+}
+
+func (s SessionStateAdapter) UpdateBranchMeta(name string, meta *env.RefMeta) error {
+	fs, err := s.fileSystem()
+	if err != nil {
+		return err
+	}
+
+	repoState, err := env.LoadRepoState(fs)
+	if err != nil {
+		return err
+	}
+	repoState.UpdateBranchMeta(name, meta)
+
+	return repoState.Save(fs)
+}
+
+// GetTagMeta reads the tag metadata from the repo state on disk, like GetBranchMeta.
+func (s SessionStateAdapter) GetTagMeta() (map[string]env.RefMeta, error) {
+	repoState, err := s.loadRepoState()
+	if err != nil || repoState == nil {
+		return nil, err
+	}
+
+	return repoState.TagMeta, nil
+}
+
+func (s SessionStateAdapter) UpdateTagMeta(name string, meta *env.RefMeta) error {
+	fs, err := s.fileSystem()
+	if err != nil {
+		return err
+	}
+
+	repoState, err := env.LoadRepoState(fs)
+	if err != nil {
+		return err
+	}
+	repoState.UpdateTagMeta(name, meta)
+
+	return repoState.Save(fs)
+}
+
+// GetBranchDescriptions reads the branch descriptions from the repo state on disk, so that descriptions set by other
+// sessions are included. Databases without a file system have no branch descriptions.
+func (s SessionStateAdapter) GetBranchDescriptions() (map[string]string, error) {
+	repoState, err := s.loadRepoState()
+	if err != nil || repoState == nil {
+		return nil, err
+	}
+
+	return repoState.BranchDescriptions, nil
+}
+
+func (s SessionStateAdapter) UpdateBranchDescription(name string, description string) error {
+	fs, err := s.fileSystem()
+	if err != nil {
+		return err
+	}
+
+	repoState, err := env.LoadRepoState(fs)
+	if err != nil {
+		return err
+	}
+	repoState.UpdateBranchDescription(name, description)
+
+	return repoState.Save(fs)
+}
+
 func (s SessionStateAdapter) AddRemote(remote env.Remote) error {
 	if _, ok := s.remotes[remote.Name]; ok {
 		return env.ErrRemoteAlreadyExists
@@ -125,7 +203,7 @@ func (s SessionStateAdapter) AddRemote(remote env.Remote) error {
 		return env.ErrInvalidBackupName
 	}
 
-	fs, err := s.session.Provider().FileSystemForDatabase(s.dbName)
+	fs, err := s.fileSystem()
 	if err != nil {
 		return err
 	}
@@ -154,7 +232,7 @@ func (s SessionStateAdapter) AddBackup(backup env.Remote) error {
 		return env.ErrInvalidBackupName
 	}
 
-	fs, err := s.session.Provider().FileSystemForDatabase(s.dbName)
+	fs, err := s.fileSystem()
 	if err != nil {
 		return err
 	}
@@ -181,7 +259,7 @@ func (s SessionStateAdapter) RemoveRemote(_ context.Context, name string) error
 	}
 	delete(s.remotes, remote.Name)
 
-	fs, err := s.session.Provider().FileSystemForDatabase(s.dbName)
+	fs, err := s.fileSystem()
 	if err != nil {
 		return err
 	}
@@ -207,7 +285,7 @@ func (s SessionStateAdapter) RemoveBackup(_ context.Context, name string) error
 	}
 	delete(s.backups, backup.Name)
 
-	fs, err := s.session.Provider().FileSystemForDatabase(s.dbName)
+	fs, err := s.fileSystem()
 	if err != nil {
 		return err
 	}
@@ -234,3 +312,20 @@ func (s SessionStateAdapter) TempTableFilesDir() (string, error) {
 
 	return state.tmpFileDir, nil
 }
+
+// loadRepoState reads the repo state of this database from disk, returning nil for databases without a file system
+func (s SessionStateAdapter) loadRepoState() (*env.RepoState, error) {
+	fs, err := s.fileSystem()
+	if sql.ErrDatabaseNotFound.Is(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return env.LoadRepoState(fs)
+}
+
+// fileSystem returns the file system of this database, which a revision database shares with its base database
+func (s SessionStateAdapter) fileSystem() (filesys.Filesys, error) {
+	baseName := strings.SplitN(s.dbName, DbRevisionDelimiter, 2)[0]
+	return s.session.Provider().FileSystemForDatabase(baseName)
+}
@@ -316,6 +316,10 @@ func (tx *DoltTransaction) doCommit(
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
 
 	for i := 0; i < maxTxCommitRetries; i++ {
+		if i > 0 {
+			TransactionRetriesTotal.Inc()
+		}
+
 		updatedWs, newCommit, err := func() (*doltdb.WorkingSet, *doltdb.Commit, error) {
 			// Serialize commits, since only one can possibly succeed at a time anyway
 			txLock.Lock()
@@ -364,6 +368,7 @@ func (tx *DoltTransaction) doCommit(
 				return nil, nil, err
 			}
 			logrus.Tracef("working set merge took %s", time.Since(start))
+			TransactionMergesTotal.Inc()
 
 			err = tx.validateWorkingSetForCommit(ctx, mergedWorkingSet, notFfMerge)
 			if err != nil {
@@ -385,6 +390,10 @@ func (tx *DoltTransaction) doCommit(
 		if err != nil {
 			return nil, nil, err
 		} else if updatedWs != nil {
+			TransactionCommitsTotal.Inc()
+			if newCommit != nil {
+				CommitsTotal.Inc()
+			}
 			return updatedWs, newCommit, nil
 		}
 	}
@@ -515,6 +524,7 @@ func (tx *DoltTransaction) validateWorkingSetForCommit(ctx *sql.Context, working
 		// Conflicts are never acceptable when they resulted from a merge with the existing working set -- it's equivalent
 		// to hitting a write lock (which we didn't take). Always roll back and return an error in this case.
 		if !isFf {
+			TransactionMergeConflictsTotal.Inc()
 			rollbackErr := tx.rollback(ctx)
 			if rollbackErr != nil {
 				return rollbackErr
@@ -51,6 +51,8 @@ const (
 	AwsCredsRegion                = "aws_credentials_region"
 	ShowBranchDatabases           = "dolt_show_branch_databases"
 	DoltLogLevel                  = "dolt_log_level"
+	DestructiveConfirmThreshold   = "dolt_destructive_confirm_threshold"
+	GCConcurrency                 = "dolt_gc_concurrency"
 
 	DoltClusterRoleVariable         = "dolt_cluster_role"
 	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
@@ -0,0 +1,207 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*BackupsTable)(nil)
+var _ sql.UpdatableTable = (*BackupsTable)(nil)
+var _ sql.DeletableTable = (*BackupsTable)(nil)
+var _ sql.InsertableTable = (*BackupsTable)(nil)
+var _ sql.ReplaceableTable = (*BackupsTable)(nil)
+
+// BackupsTable is a sql.Table implementation that implements a system table which shows the dolt backups
+type BackupsTable struct {
+	dbName string
+	ddb    *doltdb.DoltDB
+}
+
+// NewBackupsTable creates a BackupsTable
+func NewBackupsTable(_ *sql.Context, dbName string, ddb *doltdb.DoltDB) sql.Table {
+	return &BackupsTable{dbName: dbName, ddb: ddb}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// BackupsTableName
+func (bt *BackupsTable) Name() string {
+	return doltdb.BackupsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// BackupsTableName
+func (bt *BackupsTable) String() string {
+	return doltdb.BackupsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the backups system table
+func (bt *BackupsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: types.Text, Source: doltdb.BackupsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "url", Type: types.Text, Source: doltdb.BackupsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "params", Type: types.JSON, Source: doltdb.BackupsTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (bt *BackupsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (bt *BackupsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (bt *BackupsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	return NewBackupItr(ctx, bt.dbName, bt.ddb)
+}
+
+// BackupItr is a sql.RowItr implementation which iterates over each backup as if it's a row in the table.
+type BackupItr struct {
+	backups []env.Remote
+	idx     int
+}
+
+// NewBackupItr creates a BackupItr for the backups of the database named.
+func NewBackupItr(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB) (*BackupItr, error) {
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	backupMap, err := dbData.Rsr.GetBackups()
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]env.Remote, 0, len(backupMap))
+	for _, b := range backupMap {
+		backups = append(backups, b)
+	}
+
+	return &BackupItr{backups, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *BackupItr) Next(*sql.Context) (sql.Row, error) {
+	if itr.idx >= len(itr.backups) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	backup := itr.backups[itr.idx]
+
+	params, _, err := types.JSON.Convert(backup.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.NewRow(backup.Name, backup.Url, params), nil
+}
+
+// Close closes the iterator.
+func (itr *BackupItr) Close(*sql.Context) error {
+	return nil
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (bt *BackupsTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return backupWriter{bt}
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (bt *BackupsTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return backupWriter{bt}
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (bt *BackupsTable) Inserter(*sql.Context) sql.RowInserter {
+	return backupWriter{bt}
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (bt *BackupsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return backupWriter{bt}
+}
+
+var _ sql.RowReplacer = backupWriter{nil}
+var _ sql.RowUpdater = backupWriter{nil}
+var _ sql.RowInserter = backupWriter{nil}
+var _ sql.RowDeleter = backupWriter{nil}
+
+type backupWriter struct {
+	bt *BackupsTable
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (bWr backupWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	return fmt.Errorf("the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups")
+}
+
+// Update the given row. Provides both the old and new rows.
+func (bWr backupWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	return fmt.Errorf("the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups")
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (bWr backupWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	return fmt.Errorf("the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups")
+}
+
+// StatementBegin implements the interface sql.TableEditor. Currently a no-op.
+func (bWr backupWriter) StatementBegin(ctx *sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor. Currently a no-op.
+func (bWr backupWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor. Currently a no-op.
+func (bWr backupWriter) StatementComplete(ctx *sql.Context) error {
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (bWr backupWriter) Close(*sql.Context) error {
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
@@ -81,6 +82,9 @@ func (bt *BranchesTable) Schema() sql.Schema {
 		{Name: "latest_committer_email", Type: types.Text, Source: tableName, PrimaryKey: false, Nullable: true},
 		{Name: "latest_commit_date", Type: types.Datetime, Source: tableName, PrimaryKey: false, Nullable: true},
 		{Name: "latest_commit_message", Type: types.Text, Source: tableName, PrimaryKey: false, Nullable: true},
+		{Name: "creator", Type: types.Text, Source: tableName, PrimaryKey: false, Nullable: true},
+		{Name: "created_at", Type: types.Datetime, Source: tableName, PrimaryKey: false, Nullable: true},
+		{Name: "description", Type: types.Text, Source: tableName, PrimaryKey: false, Nullable: true},
 	}
 }
 
@@ -101,9 +105,11 @@ func (bt *BranchesTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition)
 
 // BranchItr is a sql.RowItr implementation which iterates over each commit as if it's a row in the table.
 type BranchItr struct {
-	branches []string
-	commits  []*doltdb.Commit
-	idx      int
+	branches     []string
+	commits      []*doltdb.Commit
+	branchMeta   map[string]env.RefMeta
+	descriptions map[string]string
+	idx          int
 }
 
 // NewBranchItr creates a BranchItr from the current environment.
@@ -130,6 +136,25 @@ func NewBranchItr(ctx *sql.Context, db dsess.SqlDatabase, remote bool) (*BranchI
 		}
 	}
 
+	// Only local branches have creation metadata and descriptions. They're read through the session, so that changes
+	// made since the database was loaded are included.
+	var branchMeta map[string]env.RefMeta
+	var descriptions map[string]string
+	if !remote {
+		rsr := db.DbData().Rsr
+		if dbData, ok := dsess.DSessFromSess(ctx.Session).GetDbData(ctx, db.Name()); ok {
+			rsr = dbData.Rsr
+		}
+		branchMeta, err = rsr.GetBranchMeta()
+		if err != nil {
+			return nil, err
+		}
+		descriptions, err = rsr.GetBranchDescriptions()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	branchNames := make([]string, len(branchRefs))
 	commits := make([]*doltdb.Commit, len(branchRefs))
 	for i, branch := range branchRefs {
@@ -148,7 +173,7 @@ func NewBranchItr(ctx *sql.Context, db dsess.SqlDatabase, remote bool) (*BranchI
 		commits[i] = commit
 	}
 
-	return &BranchItr{branchNames, commits, 0}, nil
+	return &BranchItr{branchNames, commits, branchMeta, descriptions, 0}, nil
 }
 
 // Next retrieves the next row. It will return io.EOF if it's the last row.
@@ -176,7 +201,20 @@ func (itr *BranchItr) Next(ctx *sql.Context) (sql.Row, error) {
 		return nil, err
 	}
 
-	return sql.NewRow(name, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description), nil
+	var creator, createdAt interface{}
+	if bm, ok := itr.branchMeta[name]; ok {
+		if bm.Creator != "" {
+			creator = bm.Creator
+		}
+		createdAt = bm.Time()
+	}
+
+	var description interface{}
+	if d, ok := itr.descriptions[name]; ok {
+		description = d
+	}
+
+	return sql.NewRow(name, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description, creator, createdAt, description), nil
 }
 
 // Close closes the iterator.
@@ -119,7 +119,7 @@ func (dt *ColumnDiffTable) PartitionRows(ctx *sql.Context, partition sql.Partiti
 		if bytes.Equal(partition.Key(), workingSetPartitionKey) {
 			return dt.newWorkingSetRowItr(ctx)
 		} else if bytes.Equal(partition.Key(), commitHistoryPartitionKey) {
-			cms, hasCommitHashEquality := getCommitsFromCommitHashEquality(ctx, dt.ddb, dt.partitionFilters)
+			cms, hasCommitHashEquality := getCommitsFromCommitHashEquality(ctx, dt.dbName, dt.ddb, dt.partitionFilters)
 			if hasCommitHashEquality {
 				return dt.newCommitHistoryRowItrFromCommits(ctx, cms)
 			}
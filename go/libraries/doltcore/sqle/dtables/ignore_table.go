@@ -38,6 +38,7 @@ var _ sql.ReplaceableTable = (*IgnoreTable)(nil)
 
 // IgnoreTable is the system table that stores patterns for table names that should not be committed.
 type IgnoreTable struct {
+	dbName       string
 	ddb          *doltdb.DoltDB
 	backingTable sql.Table
 }
@@ -81,8 +82,8 @@ func (i *IgnoreTable) PartitionRows(context *sql.Context, partition sql.Partitio
 }
 
 // NewIgnoreTable creates an IgnoreTable
-func NewIgnoreTable(_ *sql.Context, ddb *doltdb.DoltDB, backingTable sql.Table) sql.Table {
-	return &IgnoreTable{ddb: ddb, backingTable: backingTable}
+func NewIgnoreTable(_ *sql.Context, dbName string, ddb *doltdb.DoltDB, backingTable sql.Table) sql.Table {
+	return &IgnoreTable{dbName: dbName, ddb: ddb, backingTable: backingTable}
 }
 
 // Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
@@ -156,7 +157,7 @@ func (iw *ignoreWriter) Delete(ctx *sql.Context, r sql.Row) error {
 // StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
 // in some way that it may be returned to in the case of an error.
 func (iw *ignoreWriter) StatementBegin(ctx *sql.Context) {
-	dbName := ctx.GetCurrentDatabase()
+	dbName := iw.it.dbName
 	dSess := dsess.DSessFromSess(ctx.Session)
 
 	roots, _ := dSess.GetRoots(ctx, dbName)
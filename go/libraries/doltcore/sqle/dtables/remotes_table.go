@@ -35,12 +35,13 @@ var _ sql.ReplaceableTable = (*RemotesTable)(nil)
 
 // RemotesTable is a sql.Table implementation that implements a system table which shows the dolt remotes
 type RemotesTable struct {
-	ddb *doltdb.DoltDB
+	dbName string
+	ddb    *doltdb.DoltDB
 }
 
 // NewRemotesTable creates a RemotesTable
-func NewRemotesTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
-	return &RemotesTable{ddb}
+func NewRemotesTable(_ *sql.Context, dbName string, ddb *doltdb.DoltDB) sql.Table {
+	return &RemotesTable{dbName: dbName, ddb: ddb}
 }
 
 // Name is a sql.Table interface function which returns the name of the table which is defined by the constant
@@ -77,7 +78,7 @@ func (bt *RemotesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
 
 // PartitionRows is a sql.Table interface function that gets a row iterator for a partition
 func (bt *RemotesTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
-	return NewRemoteItr(ctx, bt.ddb)
+	return NewRemoteItr(ctx, bt.dbName, bt.ddb)
 }
 
 // RemoteItr is a sql.RowItr implementation which iterates over each commit as if it's a row in the table.
@@ -86,10 +87,8 @@ type RemoteItr struct {
 	idx     int
 }
 
-// NewRemoteItr creates a RemoteItr from the current environment.
-func NewRemoteItr(ctx *sql.Context, ddb *doltdb.DoltDB) (*RemoteItr, error) {
-	dbName := ctx.GetCurrentDatabase()
-
+// NewRemoteItr creates a RemoteItr for the remotes of the database named.
+func NewRemoteItr(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB) (*RemoteItr, error) {
 	if len(dbName) == 0 {
 		return nil, fmt.Errorf("Empty database name.")
 	}
@@ -21,6 +21,8 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 )
 
@@ -28,12 +30,12 @@ var _ sql.Table = (*TagsTable)(nil)
 
 // TagsTable is a sql.Table implementation that implements a system table which shows the dolt tags
 type TagsTable struct {
-	ddb *doltdb.DoltDB
+	db dsess.SqlDatabase
 }
 
 // NewTagsTable creates a TagsTable
-func NewTagsTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
-	return &TagsTable{ddb: ddb}
+func NewTagsTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &TagsTable{db: db}
 }
 
 // Name is a sql.Table interface function which returns the name of the table which is defined by the constant
@@ -57,6 +59,8 @@ func (dt *TagsTable) Schema() sql.Schema {
 		{Name: "email", Type: types.Text, Source: doltdb.TagsTableName, PrimaryKey: false},
 		{Name: "date", Type: types.Datetime, Source: doltdb.TagsTableName, PrimaryKey: false},
 		{Name: "message", Type: types.Text, Source: doltdb.TagsTableName, PrimaryKey: false},
+		{Name: "creator", Type: types.Text, Source: doltdb.TagsTableName, PrimaryKey: false},
+		{Name: "created_at", Type: types.Datetime, Source: doltdb.TagsTableName, PrimaryKey: false},
 	}
 }
 
@@ -72,23 +76,34 @@ func (dt *TagsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
 
 // PartitionRows is a sql.Table interface function that gets a row iterator for a partition
 func (dt *TagsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
-	return NewTagsItr(ctx, dt.ddb)
+	return NewTagsItr(ctx, dt.db)
 }
 
 // TagsItr is a sql.RowItr implementation which iterates over each commit as if it's a row in the table.
 type TagsItr struct {
 	tagsWithHash []doltdb.TagWithHash
+	tagMeta      map[string]env.RefMeta
 	idx          int
 }
 
 // NewTagsItr creates a TagsItr from the current environment.
-func NewTagsItr(ctx *sql.Context, ddb *doltdb.DoltDB) (*TagsItr, error) {
-	tagsWithHash, err := ddb.GetTagsWithHashes(ctx)
+func NewTagsItr(ctx *sql.Context, db dsess.SqlDatabase) (*TagsItr, error) {
+	tagsWithHash, err := db.DbData().Ddb.GetTagsWithHashes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TagsItr{tagsWithHash, 0}, nil
+	// the creation metadata is read through the session, like that of branches
+	rsr := db.DbData().Rsr
+	if dbData, ok := dsess.DSessFromSess(ctx.Session).GetDbData(ctx, db.Name()); ok {
+		rsr = dbData.Rsr
+	}
+	tagMeta, err := rsr.GetTagMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagsItr{tagsWithHash, tagMeta, 0}, nil
 }
 
 // Next retrieves the next row. It will return io.EOF if it's the last row.
@@ -103,7 +118,16 @@ func (itr *TagsItr) Next(ctx *sql.Context) (sql.Row, error) {
 	}()
 
 	twh := itr.tagsWithHash[itr.idx]
-	return sql.NewRow(twh.Tag.Name, twh.Hash.String(), twh.Tag.Meta.Name, twh.Tag.Meta.Email, twh.Tag.Meta.Time(), twh.Tag.Meta.Description), nil
+
+	var creator, createdAt interface{}
+	if tm, ok := itr.tagMeta[twh.Tag.Name]; ok {
+		if tm.Creator != "" {
+			creator = tm.Creator
+		}
+		createdAt = tm.Time()
+	}
+
+	return sql.NewRow(twh.Tag.Name, twh.Hash.String(), twh.Tag.Meta.Name, twh.Tag.Meta.Email, twh.Tag.Meta.Time(), twh.Tag.Meta.Description, creator, createdAt), nil
 }
 
 // Close closes the iterator.
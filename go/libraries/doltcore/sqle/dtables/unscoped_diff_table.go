@@ -129,7 +129,7 @@ func (dt *UnscopedDiffTable) PartitionRows(ctx *sql.Context, partition sql.Parti
 		if bytes.Equal(partition.Key(), workingSetPartitionKey) {
 			return dt.newWorkingSetRowItr(ctx)
 		} else if bytes.Equal(partition.Key(), commitHistoryPartitionKey) {
-			cms, hasCommitHashEquality := getCommitsFromCommitHashEquality(ctx, dt.ddb, dt.partitionFilters)
+			cms, hasCommitHashEquality := getCommitsFromCommitHashEquality(ctx, dt.dbName, dt.ddb, dt.partitionFilters)
 			if hasCommitHashEquality {
 				return dt.newCommitHistoryRowItrFromCommits(ctx, cms)
 			}
@@ -493,7 +493,7 @@ func transformFilters(filters ...sql.Expression) []sql.Expression {
 	return filters
 }
 
-func getCommitsFromCommitHashEquality(ctx *sql.Context, ddb *doltdb.DoltDB, filters []sql.Expression) ([]*doltdb.Commit, bool) {
+func getCommitsFromCommitHashEquality(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, filters []sql.Expression) ([]*doltdb.Commit, bool) {
 	var commits []*doltdb.Commit
 	var isCommitHashEquality bool
 	for i := range filters {
@@ -502,7 +502,7 @@ func getCommitsFromCommitHashEquality(ctx *sql.Context, ddb *doltdb.DoltDB, filt
 			v, err := f.Right().Eval(ctx, nil)
 			if err == nil {
 				isCommitHashEquality = true
-				cm := getCommitFromHash(ctx, ddb, v.(string))
+				cm := getCommitFromHash(ctx, dbName, ddb, v.(string))
 				if cm != nil {
 					commits = append(commits, cm)
 				}
@@ -514,13 +514,13 @@ func getCommitsFromCommitHashEquality(ctx *sql.Context, ddb *doltdb.DoltDB, filt
 				if err == nil && right != nil {
 					isCommitHashEquality = true
 					if len(r) == 1 {
-						cm := getCommitFromHash(ctx, ddb, right.(string))
+						cm := getCommitFromHash(ctx, dbName, ddb, right.(string))
 						if cm != nil {
 							commits = append(commits, cm)
 						}
 					} else {
 						for _, el := range right.([]interface{}) {
-							cm := getCommitFromHash(ctx, ddb, el.(string))
+							cm := getCommitFromHash(ctx, dbName, ddb, el.(string))
 							if cm != nil {
 								commits = append(commits, cm)
 							}
@@ -533,12 +533,12 @@ func getCommitsFromCommitHashEquality(ctx *sql.Context, ddb *doltdb.DoltDB, filt
 	return commits, isCommitHashEquality
 }
 
-func getCommitFromHash(ctx *sql.Context, ddb *doltdb.DoltDB, val string) *doltdb.Commit {
+func getCommitFromHash(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB, val string) *doltdb.Commit {
 	cmSpec, err := doltdb.NewCommitSpec(val)
 	if err != nil {
 		return nil
 	}
-	headRef, err := dsess.DSessFromSess(ctx.Session).CWBHeadRef(ctx, ctx.GetCurrentDatabase())
+	headRef, err := dsess.DSessFromSess(ctx.Session).CWBHeadRef(ctx, dbName)
 	if err != nil {
 		return nil
 	}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -82,9 +83,9 @@ func TestSingleQuery(t *testing.T) {
 		// "create table t1 (pk int primary key, c int);",
 		// "insert into t1 values (1,2), (3,4)",
 		// "call dolt_add('.')",
-		// "set @Commit1 = dolt_commit('-am', 'initial table');",
+		// "set @Commit1 = (select dolt_commit('-am', 'initial table'));",
 		// "insert into t1 values (5,6), (7,8)",
-		// "set @Commit2 = dolt_commit('-am', 'two more rows');",
+		// "set @Commit2 = (select dolt_commit('-am', 'two more rows'));",
 	}
 
 	for _, q := range setupQueries {
@@ -1021,6 +1022,16 @@ func TestEvents(t *testing.T) {
 	enginetest.TestEvents(t, doltHarness)
 }
 
+func TestStoredProceduresOnBranches(t *testing.T) {
+	for _, script := range DoltProcedureBranchScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 func TestCallAsOf(t *testing.T) {
 	for _, script := range DoltCallAsOf {
 		func() {
@@ -1084,6 +1095,16 @@ func TestTransactions(t *testing.T) {
 	}
 }
 
+func TestDatabaseWriteLocks(t *testing.T) {
+	for _, script := range DatabaseWriteLockTests {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestTransactionScript(t, h, script)
+		}()
+	}
+}
+
 func TestBranchTransactions(t *testing.T) {
 	for _, script := range BranchIsolationTests {
 		func() {
@@ -1092,6 +1113,13 @@ func TestBranchTransactions(t *testing.T) {
 			enginetest.TestTransactionScript(t, h, script)
 		}()
 	}
+	for _, script := range DoltMetricsTransactionTests {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestTransactionScript(t, h, script)
+		}()
+	}
 }
 
 func TestMultiDbTransactions(t *testing.T) {
@@ -1213,6 +1241,30 @@ func TestDoltRevisionDbScripts(t *testing.T) {
 				Query:    "show databases;",
 				Expected: []sql.Row{{"mydb"}, {"information_schema"}, {"mysql"}},
 			},
+			{
+				Query:    "use mydb/" + commithash[:8],
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select database();",
+				Expected: []sql.Row{{"mydb/" + commithash[:8]}},
+			},
+			{
+				Query:    "select * from t01",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "use mydb;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select message from `mydb/" + commithash[:12] + "~`.dolt_log limit 1;",
+				Expected: []sql.Row{{"checkpoint enginetest database mydb"}},
+			},
+			{
+				Query:          "use mydb/" + commithash[:7],
+				ExpectedErrStr: "database not found: mydb/" + commithash[:7],
+			},
 		},
 	}
 
@@ -1324,6 +1376,46 @@ func TestDoltMerge(t *testing.T) {
 	}
 }
 
+func TestDoltRevert(t *testing.T) {
+	for _, script := range DoltRevertScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltRevertPrepared(t *testing.T) {
+	for _, script := range DoltRevertScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
+func TestDoltCherryPick(t *testing.T) {
+	for _, script := range DoltCherryPickScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltCherryPickPrepared(t *testing.T) {
+	for _, script := range DoltCherryPickScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
 func TestDoltAutoIncrement(t *testing.T) {
 	for _, script := range DoltAutoIncrementTests {
 		// doing commits on different branches is antagonistic to engine reuse, use a new engine on each script
@@ -1333,6 +1425,13 @@ func TestDoltAutoIncrement(t *testing.T) {
 			enginetest.TestScript(t, h, script)
 		}()
 	}
+	for _, script := range DoltAutoIncrementTransactionTests {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestTransactionScript(t, h, script)
+		}()
+	}
 
 	for _, script := range BrokenAutoIncrementTests {
 		t.Run(script.Name, func(t *testing.T) {
@@ -1441,6 +1540,89 @@ func TestDoltReset(t *testing.T) {
 			enginetest.TestScript(t, h, script)
 		}()
 	}
+
+	// Testing the heads reported by dolt_reset needs the generated commit hashes
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	e, err := harness.NewEngine(t)
+	require.NoError(t, err)
+	defer e.Close()
+	ctx := harness.NewContext()
+
+	setupScripts := []setup.SetupScript{
+		{"create table t (pk int primary key)"},
+		{"call dolt_commit('-Am', 'creating table t');"},
+		{"insert into t values (1);"},
+		{"call dolt_commit('-am', 'inserting 1');"},
+		{"insert into t values (2);"},
+		{"call dolt_commit('-am', 'inserting 2');"},
+	}
+	_, err = enginetest.RunSetupScripts(ctx, harness.engine, setupScripts, true)
+	require.NoError(t, err)
+
+	sch, iter, err := harness.engine.Query(ctx, "select hashof('HEAD'), hashof('HEAD~2');")
+	require.NoError(t, err)
+	rows, err := sql.RowIterToRows(ctx, sch, iter)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(rows))
+	head, first := rows[0][0].(string), rows[0][1].(string)
+	require.NotEqual(t, head, first)
+
+	scriptTest := queries.ScriptTest{
+		Name: "CALL DOLT_RESET reports the old and new heads",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_reset('--hard');",
+				Expected: []sql.Row{{0, head, head}},
+			},
+			{
+				Query:    "call dolt_reset('--soft', 'HEAD~2');",
+				Expected: []sql.Row{{0, head, first}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{{"t", true, "modified"}},
+			},
+			{
+				Query:    "call dolt_reset('t');",
+				Expected: []sql.Row{{0, first, first}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{{"t", false, "modified"}},
+			},
+			{
+				Query:    "call dolt_reset('--hard', '" + head + "');",
+				Expected: []sql.Row{{0, first, head}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	}
+
+	enginetest.TestScript(t, harness, scriptTest)
+}
+
+func TestDoltClean(t *testing.T) {
+	for _, script := range DoltCleanTestScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltDestructiveConfirm(t *testing.T) {
+	for _, script := range DoltDestructiveConfirmScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
 }
 
 func TestDoltGC(t *testing.T) {
@@ -1484,6 +1666,168 @@ func TestDoltRemote(t *testing.T) {
 	}
 }
 
+func TestDoltBackup(t *testing.T) {
+	// Adding a file backup creates its directory, so the backups live in a temp dir
+	dir := t.TempDir()
+	backupUrl := func(name string) string {
+		return "file://" + filepath.ToSlash(filepath.Join(dir, name))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), nil, 0644))
+
+	scripts := []queries.ScriptTest{
+		{
+			Name: "dolt-backup: add, list and remove backups",
+			Assertions: []queries.ScriptTestAssertion{
+				{
+					Query:    "select count(*) from dolt_backups;",
+					Expected: []sql.Row{{0}},
+				},
+				{
+					Query:    fmt.Sprintf("CALL DOLT_BACKUP('add', 'backup1', '%s')", backupUrl("backup1")),
+					Expected: []sql.Row{{1}},
+				},
+				{
+					Query:    fmt.Sprintf("CALL DOLT_BACKUP('add', 'backup2', '%s')", backupUrl("backup2")),
+					Expected: []sql.Row{{1}},
+				},
+				{
+					Query: "select name, url, params from dolt_backups order by name;",
+					Expected: []sql.Row{
+						{"backup1", backupUrl("backup1"), gmstypes.MustJSON(`{}`)},
+						{"backup2", backupUrl("backup2"), gmstypes.MustJSON(`{}`)},
+					},
+				},
+				{
+					Query:          fmt.Sprintf("CALL DOLT_BACKUP('add', 'backup1', '%s')", backupUrl("backup3")),
+					ExpectedErrStr: "error adding backup: error: a backup named 'backup1' already exists, remove it before running this command again",
+				},
+				{
+					Query:    "CALL DOLT_BACKUP('remove', 'backup1')",
+					Expected: []sql.Row{{1}},
+				},
+				{
+					Query:    "select name from dolt_backups;",
+					Expected: []sql.Row{{"backup2"}},
+				},
+				{
+					Query:          "CALL DOLT_BACKUP('remove', 'backup1')",
+					ExpectedErrStr: "error removing backup: error: unknown backup: 'backup1' ",
+				},
+				{
+					Query:          "CALL DOLT_BACKUP()",
+					ExpectedErrStr: "error: invalid argument, use 'dolt_backups' system table to list backups",
+				},
+			},
+		},
+		{
+			Name: "dolt-backup: dolt_backups is read-only",
+			SetUpScript: []string{
+				fmt.Sprintf("CALL DOLT_BACKUP('add', 'backup1', '%s')", backupUrl("backup1")),
+			},
+			Assertions: []queries.ScriptTestAssertion{
+				{
+					Query:          fmt.Sprintf("INSERT INTO dolt_backups (name, url) VALUES ('backup2', '%s')", backupUrl("backup2")),
+					ExpectedErrStr: "the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups",
+				},
+				{
+					Query:          "DELETE FROM dolt_backups WHERE name = 'backup1'",
+					ExpectedErrStr: "the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups",
+				},
+				{
+					Query:    "select name from dolt_backups;",
+					Expected: []sql.Row{{"backup1"}},
+				},
+			},
+		},
+		{
+			Name: "dolt-backup: backups are per database",
+			SetUpScript: []string{
+				"create database one",
+			},
+			Assertions: []queries.ScriptTestAssertion{
+				{
+					Query:    "use one;",
+					Expected: []sql.Row{},
+				},
+				{
+					Query:    fmt.Sprintf("CALL DOLT_BACKUP('add', 'backup1', '%s')", backupUrl("backup1")),
+					Expected: []sql.Row{{1}},
+				},
+				{
+					Query:    "select name from dolt_backups;",
+					Expected: []sql.Row{{"backup1"}},
+				},
+				{
+					Query:    "select count(*) from mydb.dolt_backups;",
+					Expected: []sql.Row{{0}},
+				},
+			},
+		},
+		{
+			Name: "dolt-backup: sync errors",
+			Assertions: []queries.ScriptTestAssertion{
+				{
+					Query:          "CALL DOLT_BACKUP('sync', 'backup1')",
+					ExpectedErrStr: "error syncing backup: error: unknown backup: 'backup1'",
+				},
+				{
+					// a file is in the way of the backup directory
+					Query:          fmt.Sprintf("CALL DOLT_BACKUP('sync-url', '%s')", backupUrl("file/backup1")),
+					ExpectedErrStr: fmt.Sprintf("error syncing backup url: error: '%s' is not valid.", backupUrl("file/backup1")),
+				},
+			},
+		},
+	}
+
+	for _, script := range scripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltStash(t *testing.T) {
+	if !types.IsFormat_DOLT(types.Format_Default) {
+		t.Skip("stash is not supported for old storage format")
+	}
+	for _, script := range DoltStashTestScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltRebase(t *testing.T) {
+	for _, script := range DoltRebaseTestScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+	for _, script := range DoltRebaseTransactionTests {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestTransactionScript(t, h, script)
+		}()
+	}
+}
+
+func TestDoltSystemTableCrossDatabase(t *testing.T) {
+	for _, script := range DoltSystemTableCrossDatabaseScripts {
+		func() {
+			h := newDoltHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 type testCommitClock struct {
 	unixNano int64
 }
@@ -1814,6 +2158,174 @@ func TestPatchTableFunctionPrepared(t *testing.T) {
 	}
 }
 
+func TestTableMatchTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range TableMatchTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestTableMatchTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range TableMatchTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func TestStorageReportTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range StorageReportTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestStorageReportTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range StorageReportTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func TestBranchesContainingTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range BranchesContainingTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestBranchesContainingTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range BranchesContainingTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func TestBranchesTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range BranchesTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestBranchesTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range BranchesTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func TestRowHistoryTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range RowHistoryTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestRowHistoryTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range RowHistoryTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func TestIndexDiffTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range IndexDiffTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestIndexDiffTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range IndexDiffTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
+func TestSchemaDiffTableFunction(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range SchemaDiffTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func TestSchemaDiffTableFunctionPrepared(t *testing.T) {
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	harness.Setup(setup.MydbData)
+	for _, test := range SchemaDiffTableFunctionScriptTests {
+		harness.engine = nil
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
 func TestLogTableFunction(t *testing.T) {
 	harness := newDoltHarness(t)
 	defer harness.Close()
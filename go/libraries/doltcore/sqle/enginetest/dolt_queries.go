@@ -17,6 +17,7 @@ package enginetest
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/enginetest/queries"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -364,6 +365,118 @@ var DoltRevisionDbScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "database revision specs: merge commit parents",
+		SetUpScript: []string{
+			"create table t01 (pk int primary key, c1 int)",
+			"call dolt_add('.');",
+			"call dolt_commit('-am', 'creating table t01 on main');",
+			"call dolt_branch('branch1');",
+			"insert into t01 values (1, 1);",
+			"call dolt_commit('-am', 'adding a row to table t01 on main');",
+			"call dolt_checkout('branch1');",
+			"insert into t01 values (100, 100);",
+			"call dolt_commit('-am', 'adding a row to table t01 on branch1');",
+			"call dolt_checkout('main');",
+			"call dolt_merge('branch1', '--no-ff', '-m', 'merging branch1 into main');",
+			"call dolt_tag('mergecommit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from `mydb/mergecommit`.t01;",
+				Expected: []sql.Row{{1, 1}, {100, 100}},
+			},
+			{
+				Query:    "select * from `mydb/mergecommit^`.t01;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select * from `mydb/mergecommit^1`.t01;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select * from `mydb/mergecommit^2`.t01;",
+				Expected: []sql.Row{{100, 100}},
+			},
+			{
+				Query:    "select * from `mydb/main^2`.t01;",
+				Expected: []sql.Row{{100, 100}},
+			},
+			{
+				Query:    "select * from `mydb/mergecommit^2~1`.t01;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "select * from `mydb/mergecommit^3`.t01;",
+				ExpectedErrStr: "invalid ancestor spec",
+			},
+			{
+				Query:    "use `mydb/mergecommit^2`;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from t01;",
+				Expected: []sql.Row{{100, 100}},
+			},
+		},
+	},
+	{
+		Name: "database revision specs: table functions resolve refs in tag revision databases",
+		SetUpScript: []string{
+			"create table t01 (pk int primary key, c1 int)",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'creating table t01 on main');",
+			"insert into t01 values (1, 1), (2, 2);",
+			"call dolt_commit('-am', 'adding rows to table t01 on main');",
+			"call dolt_tag('tag1');",
+			"insert into t01 values (3, 3);",
+			"call dolt_commit('-am', 'adding another row to table t01 on main');",
+			"set @Commit1 = hashof('main~');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "use `mydb/tag1`;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select to_pk, diff_type from dolt_diff('main~', 'main', 't01');",
+				Expected: []sql.Row{{3, "added"}},
+			},
+			{
+				Query:    "select to_pk, diff_type from dolt_diff('main~~...main', 't01') order by to_pk;",
+				Expected: []sql.Row{{1, "added"}, {2, "added"}, {3, "added"}},
+			},
+			{
+				Query:    "select count(*) from dolt_patch('main~', 'main', 't01');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select rows_added from dolt_diff_stat('main~', 'main', 't01');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select diff_type from dolt_diff_summary('main~', 'main', 't01');",
+				Expected: []sql.Row{{"modified"}},
+			},
+			{
+				// HEAD is the commit of the revision database
+				Query:    "select to_pk, diff_type from dolt_diff('HEAD~', 'HEAD', 't01') order by to_pk;",
+				Expected: []sql.Row{{1, "added"}, {2, "added"}},
+			},
+			{
+				Query:    "select pk, message from dolt_blame('main', 't01') where pk = 3;",
+				Expected: []sql.Row{{3, "adding another row to table t01 on main"}},
+			},
+			{
+				Query:    "select name from dolt_branches_containing('main~');",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "select hashof('HEAD') = @Commit1, hashof('main~') = @Commit1;",
+				Expected: []sql.Row{{true, true}},
+			},
+		},
+	},
 	{
 		Name: "database revision specs: tag-qualified revision spec",
 		SetUpScript: []string{
@@ -473,8 +586,16 @@ var DoltRevisionDbScripts = []queries.ScriptTest{
 				Expected: []sql.Row{},
 			},
 			{
-				Query:    "call dolt_reset();",
-				Expected: []sql.Row{{0}},
+				Query:    "set @head = hashof('HEAD');",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:            "call dolt_reset();",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select hashof('HEAD') = @head;",
+				Expected: []sql.Row{{true}},
 			},
 			{
 				Query:    "select database();",
@@ -584,9 +705,65 @@ var DoltRevisionDbScripts = []queries.ScriptTest{
 				Expected: []sql.Row{{0}},
 			},
 			{
-				Query: "select * from dolt_status",
-				// Expected: []sql.Row{},
-				SkipResultsCheck: true, // TODO: https://github.com/dolthub/dolt/issues/5816
+				Query:    "select * from dolt_status",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from t01",
+				Expected: []sql.Row{},
+			},
+			{
+				// only unstaged changes are discarded when the table has staged changes
+				Query:    "insert into t01 values (1, 1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_add('t01')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "insert into t01 values (2, 2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from dolt_status",
+				Expected: []sql.Row{{"t01", true, "modified"}, {"t01", false, "modified"}},
+			},
+			{
+				Query:    "call dolt_checkout('t01')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from dolt_status",
+				Expected: []sql.Row{{"t01", true, "modified"}},
+			},
+			{
+				Query:    "select * from t01",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "use mydb;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "call dolt_checkout('new-branch')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "insert into t01 values (3, 3);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from dolt_status",
+				Expected: []sql.Row{{"t01", false, "modified"}},
+			},
+			{
+				Query:    "call dolt_checkout('t01')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from dolt_status",
+				Expected: []sql.Row{},
 			},
 		},
 	},
@@ -823,6 +1000,8 @@ var DoltScripts = []queries.ScriptTest{
 			"CALL dcommit('-am', 'add rows');",
 			"INSERT INTO t VALUES ('dolt',0),('alt',12),('del',8),('ctl',3)",
 			"CALL dcommit('-am', 'add more rows');",
+			"SET @Commit1 = hashof('HEAD~');",
+			"SET @Commit2 = hashof('HEAD');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
@@ -838,6 +1017,96 @@ var DoltScripts = []queries.ScriptTest{
 					{"zzz", 4, "add rows"},
 				},
 			},
+			{
+				// each row has the hash, date, committer and email of the commit that last touched it
+				Query: "SELECT pk, val, `commit` = @Commit1, `commit` = @Commit2, committer, email FROM dolt_blame_t",
+				Expected: []sql.Row{
+					{"add", 5, true, false, "billy bob", "bigbillieb@fake.horse"},
+					{"alt", 12, false, true, "billy bob", "bigbillieb@fake.horse"},
+					{"ctl", 3, false, true, "billy bob", "bigbillieb@fake.horse"},
+					{"del", 8, false, true, "billy bob", "bigbillieb@fake.horse"},
+					{"dolt", 0, false, true, "billy bob", "bigbillieb@fake.horse"},
+					{"mult", 1, true, false, "billy bob", "bigbillieb@fake.horse"},
+					{"sub", 2, true, false, "billy bob", "bigbillieb@fake.horse"},
+					{"zzz", 4, true, false, "billy bob", "bigbillieb@fake.horse"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_blame_t b JOIN dolt_log l ON b.`commit` = l.commit_hash WHERE b.commit_date = l.date",
+				Expected: []sql.Row{{8}},
+			},
+		},
+	},
+	{
+		Name: "blame table function: composite pk ordered output at a revision",
+		SetUpScript: []string{
+			"CREATE TABLE blamed(pk varchar(20), val int, c int)",
+			"ALTER TABLE blamed ADD PRIMARY KEY (pk, val)",
+			"INSERT INTO blamed VALUES ('zzz',4,0),('mult',1,0),('sub',2,0),('add',5,0)",
+			"CALL dadd('.');",
+			"CALL dcommit('-am', 'add rows');",
+			"INSERT INTO blamed VALUES ('dolt',0,0),('alt',12,0),('del',8,0),('ctl',3,0)",
+			"CALL dcommit('-am', 'add more rows');",
+			"UPDATE blamed SET c = 1 WHERE pk = 'sub'",
+			"DELETE FROM blamed WHERE pk = 'del'",
+			"CALL dcommit('-am', 'update and delete rows');",
+			"CREATE TABLE keyless(c int)",
+			"CALL dcommit('-Am', 'add keyless table');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT pk, val, message FROM dolt_blame('HEAD', 'blamed')",
+				Expected: []sql.Row{
+					{"add", 5, "add rows"},
+					{"alt", 12, "add more rows"},
+					{"ctl", 3, "add more rows"},
+					{"dolt", 0, "add more rows"},
+					{"mult", 1, "add rows"},
+					{"sub", 2, "update and delete rows"},
+					{"zzz", 4, "add rows"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_blame_blamed WHERE (pk, val, `commit`, commit_date, committer, email, message) IN (SELECT pk, val, `commit`, commit_date, committer, email, message FROM dolt_blame('HEAD', 'blamed'))",
+				Expected: []sql.Row{{7}},
+			},
+			{
+				Query: "SELECT pk, val, message FROM dolt_blame('HEAD~2', 'blamed')",
+				Expected: []sql.Row{
+					{"add", 5, "add rows"},
+					{"alt", 12, "add more rows"},
+					{"ctl", 3, "add more rows"},
+					{"del", 8, "add more rows"},
+					{"dolt", 0, "add more rows"},
+					{"mult", 1, "add rows"},
+					{"sub", 2, "add rows"},
+					{"zzz", 4, "add rows"},
+				},
+			},
+			{
+				Query:    "SELECT pk, val, message FROM dolt_blame('HEAD~3', 'blamed') WHERE pk = 'zzz'",
+				Expected: []sql.Row{{"zzz", 4, "add rows"}},
+			},
+			{
+				Query:       "SELECT * FROM dolt_blame('HEAD~3', 'keyless')",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:          "SELECT * FROM dolt_blame('HEAD', 'keyless')",
+				ExpectedErrStr: "dolt_blame requires a table with a primary key, but keyless has none",
+			},
+			{
+				Query:       "SELECT * FROM dolt_blame('HEAD', 'doesnotexist')",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * FROM dolt_blame('blamed')",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:          "SELECT * FROM dolt_blame('doesnotexist', 'blamed')",
+				ExpectedErrStr: "branch not found: doesnotexist",
+			},
 		},
 	},
 	{
@@ -936,6 +1205,7 @@ var DoltScripts = []queries.ScriptTest{
 			"INSERT INTO hashof_test values (4,4), (5,5), (6,6)",
 			"CALL DOLT_COMMIT('-a', '-m', 'second commit')",
 			"SET @Commit2 = (SELECT commit_hash from DOLT_LOG() LIMIT 1)",
+			"SET @ShortCommit1 = left(@Commit1, 8)",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
@@ -965,94 +1235,372 @@ var DoltScripts = []queries.ScriptTest{
 				ExpectedErrStr: "invalid ref spec",
 			},
 			{
-				// Test that a short commit is invalid. This may change in the future.
-				Query:          "SELECT hashof(left(@Commit2,30))",
+				Query:    "SELECT hashof(left(@Commit2,30)) = @Commit2",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT hashof(left(@Commit2,8)) = @Commit2",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT hashof(concat(left(@Commit2,8), '~1')) = @Commit1",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log(@ShortCommit1) LIMIT 1",
+				Expected: []sql.Row{{"first commit"}},
+			},
+			{
+				// Abbreviated hashes must be at least 8 characters
+				Query:          "SELECT hashof(left(@Commit2,7))",
 				ExpectedErrStr: "invalid ref spec",
 			},
 		},
 	},
-}
-
-func makeLargeInsert(sz int) string {
-	var sb strings.Builder
-	sb.WriteString("insert into bigTable values (0,0)")
-	for i := 1; i < sz; i++ {
-		sb.WriteString(fmt.Sprintf(",(%d,%d)", i, i))
-	}
-	sb.WriteString(";")
-	return sb.String()
-}
-
-// DoltUserPrivTests are tests for Dolt-specific functionality that includes privilege checking logic.
-var DoltUserPrivTests = []queries.UserPrivilegeTest{
 	{
-		Name: "table function privilege checking",
+		Name: "test dolt_merge_base",
 		SetUpScript: []string{
-			"CREATE TABLE mydb.test (pk BIGINT PRIMARY KEY);",
-			"CREATE TABLE mydb.test2 (pk BIGINT PRIMARY KEY);",
-			"CALL DOLT_ADD('.')",
-			"CALL DOLT_COMMIT('-am', 'creating tables test and test2');",
-			"INSERT INTO mydb.test VALUES (1);",
-			"CALL DOLT_COMMIT('-am', 'inserting into test');",
-			"CREATE USER tester@localhost;",
+			"CREATE TABLE merge_base_test (pk int primary key)",
+			"CALL DOLT_COMMIT('-Am', 'create table')",
+			"CALL DOLT_BRANCH('merge_base_other')",
+			"INSERT INTO merge_base_test VALUES (1)",
+			"CALL DOLT_COMMIT('-am', 'insert 1 on main')",
+			"CALL DOLT_CHECKOUT('merge_base_other')",
+			"INSERT INTO merge_base_test VALUES (2)",
+			"CALL DOLT_COMMIT('-am', 'insert 2 on other')",
+			"SET @Merged = hashof('HEAD')",
+			"CALL DOLT_CHECKOUT('main')",
+			"CALL DOLT_MERGE('--no-ff', '-m', 'merge other', 'merge_base_other')",
+			"CALL DOLT_TAG('merged', 'HEAD')",
+			"INSERT INTO merge_base_test VALUES (3)",
+			"CALL DOLT_COMMIT('-am', 'insert 3 on main')",
+			"CALL DOLT_CHECKOUT('merge_base_other')",
+			"INSERT INTO merge_base_test VALUES (4)",
+			"CALL DOLT_COMMIT('-am', 'insert 4 on other')",
+			"CALL DOLT_CHECKOUT('main')",
 		},
-		Assertions: []queries.UserPrivilegeTestAssertion{
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				// Without access to the database, dolt_diff should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_diff('main~', 'main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:    "SELECT dolt_merge_base('main', 'merge_base_other') = @Merged",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				// Without access to the database, dolt_diff with dots should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_diff('main~..main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:    "SELECT dolt_merge_base('main', 'merge_base_other') IN (hashof('main'), hashof('merge_base_other'))",
+				Expected: []sql.Row{{false}},
 			},
 			{
-				// Without access to the database, dolt_diff_stat should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_diff_stat('main~', 'main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:    "SELECT message FROM dolt_log WHERE commit_hash = dolt_merge_base('merge_base_other', 'MAIN')",
+				Expected: []sql.Row{{"insert 2 on other"}},
 			},
 			{
-				// Without access to the database, dolt_diff_stat with dots should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_diff_stat('main~..main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:    "SELECT dolt_merge_base('HEAD', 'merge_base_other~1') = hashof('merge_base_other~1')",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				// Without access to the database, dolt_diff_summary should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_diff_summary('main~', 'main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:    "SELECT message FROM dolt_log WHERE commit_hash = dolt_merge_base('merged~1', 'merge_base_other')",
+				Expected: []sql.Row{{"create table"}},
 			},
 			{
-				// Without access to the database, dolt_diff_summary with dots should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_diff_summary('main~..main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:    "SELECT dolt_merge_base(hashof('main'), hashof('merge_base_other')) = @Merged",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				// Without access to the database, dolt_patch should fail with a database access error
-				User:        "tester",
-				Host:        "localhost",
-				Query:       "SELECT * FROM dolt_patch('main~', 'main', 'test');",
-				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+				Query:          "SELECT dolt_merge_base('main', 'non_branch')",
+				ExpectedErrStr: "invalid ref spec",
 			},
-			{
+		},
+	},
+	{
+		Name: "test dolt_count_commits",
+		SetUpScript: []string{
+			"CREATE TABLE count_commits_test (pk int primary key)",
+			"CALL DOLT_COMMIT('-Am', 'create table')",
+			"CALL DOLT_BRANCH('count_commits_other')",
+			"INSERT INTO count_commits_test VALUES (1)",
+			"CALL DOLT_COMMIT('-am', 'insert 1 on main')",
+			"CALL DOLT_CHECKOUT('count_commits_other')",
+			"INSERT INTO count_commits_test VALUES (2)",
+			"CALL DOLT_COMMIT('-am', 'insert 2 on other')",
+			"INSERT INTO count_commits_test VALUES (3)",
+			"CALL DOLT_COMMIT('-am', 'insert 3 on other')",
+			"CALL DOLT_CHECKOUT('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT dolt_count_commits('main', 'count_commits_other')",
+				Expected: []sql.Row{{types.MustJSON(`{"ahead": 1, "behind": 2}`)}},
+			},
+			{
+				Query:    "SELECT JSON_EXTRACT(dolt_count_commits('count_commits_other', 'main'), '$.ahead'), JSON_EXTRACT(dolt_count_commits('count_commits_other', 'main'), '$.behind')",
+				Expected: []sql.Row{{types.MustJSON("2"), types.MustJSON("1")}},
+			},
+			{
+				Query:    "SELECT dolt_count_commits('main', 'main~1')",
+				Expected: []sql.Row{{types.MustJSON(`{"ahead": 1, "behind": 0}`)}},
+			},
+			{
+				Query:    "SELECT dolt_count_commits('HEAD', 'main')",
+				Expected: []sql.Row{{types.MustJSON(`{"ahead": 0, "behind": 0}`)}},
+			},
+			{
+				Query:          "SELECT dolt_count_commits('main', 'non_branch')",
+				ExpectedErrStr: "invalid ref spec",
+			},
+		},
+	},
+	{
+		Name: "dolt procedures return named, typed result columns",
+		SetUpScript: []string{
+			"CREATE TABLE result_columns_t (pk int primary key);",
+			"CALL dolt_commit('-Am', 'create table');",
+			"CALL dolt_checkout('-b', 'other');",
+			"INSERT INTO result_columns_t VALUES (1);",
+			"CALL dolt_commit('-am', 'insert on other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL dolt_add('.')",
+				Expected: []sql.Row{{0}},
+				ExpectedColumns: sql.Schema{
+					{Name: "status", Type: types.Int64},
+				},
+			},
+			{
+				Query:    "CALL dolt_merge('other')",
+				Expected: []sql.Row{{1, 0}},
+				ExpectedColumns: sql.Schema{
+					{Name: "fast_forward", Type: types.Int64},
+					{Name: "conflicts", Type: types.Int64},
+				},
+			},
+			{
+				Query:    "CALL dolt_tag('result_columns_v1')",
+				Expected: []sql.Row{{0}},
+				ExpectedColumns: sql.Schema{
+					{Name: "status", Type: types.Int64},
+				},
+			},
+		},
+	},
+	{
+		Name: "procedure hash out parameters",
+		SetUpScript: []string{
+			"create table hash_out_t (pk int primary key, c1 int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table hash_out_t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into hash_out_t values (1, 1);",
+			"call dolt_commit_hash_out(@featureCommit, '-am', 'inserting into hash_out_t');",
+			"call dolt_tag_hash_out(@tagged, 'v1', 'feature');",
+			"call dolt_checkout('main');",
+			"insert into hash_out_t values (2, 2);",
+			"call dolt_commit('-am', 'inserting into hash_out_t on main');",
+			"call dolt_merge_hash_out(@mergeCommit, 'feature');",
+			"call dolt_checkout('-b', 'cherry_picked', 'HEAD~2');",
+			"call dolt_cherry_pick_hash_out(@cherryPicked, @featureCommit);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select @tagged = @featureCommit, tag_hash is not null from dolt_tags where tag_name = 'v1';",
+				Expected: []sql.Row{{true, true}},
+			},
+			{
+				Query:    "select commit_hash = @featureCommit from dolt_log('v1') limit 1;",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select count(*) from dolt_log('main') where commit_hash = @mergeCommit;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select @mergeCommit = hashof('main'), @mergeCommit = @featureCommit;",
+				Expected: []sql.Row{{true, false}},
+			},
+			{
+				Query:    "select @cherryPicked = hashof('cherry_picked'), @cherryPicked = @featureCommit;",
+				Expected: []sql.Row{{true, false}},
+			},
+			{
+				Query:    "select * from hash_out_t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				// fast-forward merges set the hash of the commit merged
+				Query:    "call dolt_checkout('-b', 'ff', @featureCommit);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_checkout('main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_branch('behind', 'HEAD~2');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_checkout('behind');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_merge_hash_out(@ffCommit, 'ff');",
+				Expected: []sql.Row{{1, 0}},
+			},
+			{
+				Query:    "select @ffCommit = @featureCommit;",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "call dolt_tag_hash_out(@deleted, '-d', 'v1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select @deleted;",
+				Expected: []sql.Row{{""}},
+			},
+		},
+	},
+	{
+		Name: "dolt_commit function returns the new commit hash",
+		SetUpScript: []string{
+			"CREATE TABLE commit_func_t (pk int primary key);",
+			"CALL dolt_add('.');",
+			"SET @Commit1 = (SELECT dolt_commit('-m', 'create table'));",
+			"INSERT INTO commit_func_t VALUES (1);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT @Commit1 = hashof('HEAD');",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log WHERE commit_hash = @Commit1;",
+				Expected: []sql.Row{{"create table"}},
+			},
+			{
+				Query:    "SELECT length(dolt_commit('-am', 'insert a row'));",
+				Expected: []sql.Row{{32}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log WHERE commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{"insert a row"}},
+			},
+			{
+				Query:          "SELECT dolt_commit('-am', 'nothing changed');",
+				ExpectedErrStr: "nothing to commit",
+			},
+		},
+	},
+}
+
+func makeLargeInsert(sz int) string {
+	var sb strings.Builder
+	sb.WriteString("insert into bigTable values (0,0)")
+	for i := 1; i < sz; i++ {
+		sb.WriteString(fmt.Sprintf(",(%d,%d)", i, i))
+	}
+	sb.WriteString(";")
+	return sb.String()
+}
+
+// DoltUserPrivTests are tests for Dolt-specific functionality that includes privilege checking logic.
+var DoltUserPrivTests = []queries.UserPrivilegeTest{
+	{
+		Name: "table function privilege checking",
+		SetUpScript: []string{
+			"CREATE TABLE mydb.test (pk BIGINT PRIMARY KEY);",
+			"CREATE TABLE mydb.test2 (pk BIGINT PRIMARY KEY);",
+			"CALL DOLT_ADD('.')",
+			"CALL DOLT_COMMIT('-am', 'creating tables test and test2');",
+			"INSERT INTO mydb.test VALUES (1);",
+			"CALL DOLT_COMMIT('-am', 'inserting into test');",
+			"CREATE USER tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				// Without access to the database, dolt_diff should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main~', 'main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_diff with dots should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main~..main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_diff with --working should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main', 'mydb/main', 'test', '--working');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_blame should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_blame('main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_diff_stat should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff_stat('main~', 'main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_diff_stat with dots should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff_stat('main~..main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_diff_summary should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff_summary('main~', 'main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_diff_summary with dots should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff_summary('main~..main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_patch should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_patch('main~', 'main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
 				// Without access to the database, dolt_patch with dots should fail with a database access error
 				User:        "tester",
 				Host:        "localhost",
 				Query:       "SELECT * FROM dolt_patch('main~..main', 'test');",
 				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
 			},
+			{
+				// Without access to the database, dolt_schema_diff should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_schema_diff('main~', 'main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				// Without access to the database, dolt_schema_diff with dots should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_schema_diff('main~..main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
 			{
 				// Without access to the database, dolt_log should fail with a database access error
 				User:        "tester",
@@ -1060,6 +1608,13 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:       "SELECT * FROM dolt_log('main');",
 				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
 			},
+			{
+				// Without access to the database, dolt_branches should fail with a database access error
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_branches('--merged', 'main');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
 			{
 				// Grant single-table access to the underlying user table
 				User:     "root",
@@ -1081,6 +1636,27 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:    "SELECT COUNT(*) FROM dolt_diff('main~..main', 'test');",
 				Expected: []sql.Row{{1}},
 			},
+			{
+				// After granting access to mydb.test, dolt_diff with --working should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_diff('main', 'mydb/main', 'test', '--working');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// After granting access to mydb.test, dolt_blame should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT pk, message FROM dolt_blame('main', 'test');",
+				Expected: []sql.Row{{1, "inserting into test"}},
+			},
+			{
+				// After granting access to mydb.test, dolt_schema_diff should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_schema_diff('main~', 'main', 'test');",
+				Expected: []sql.Row{{0}},
+			},
 			{
 				// With access to the db, but not the table, dolt_diff should fail
 				User:        "tester",
@@ -1095,6 +1671,48 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:       "SELECT * FROM dolt_diff('main~..main', 'test2');",
 				ExpectedErr: sql.ErrPrivilegeCheckFailed,
 			},
+			{
+				// With access to the db, but not the table, dolt_diff with --working should fail
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main', 'mydb/main', 'test2', '--working');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				// With access to the db, but not the table, dolt_blame should fail
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_blame('main', 'test2');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				// With access to every table changed, dolt_diff with --all-tables should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_diff('main~', 'main', '--all-tables');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				// With access to the db, but not every table changed, dolt_diff with --all-tables should fail
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main~~', 'main', '--all-tables');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				// With access to every table changed, dolt_diff with --overview should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT table_name, schema_changed, data_changed FROM dolt_diff('main~', 'main', '--overview');",
+				Expected: []sql.Row{{"test", false, true}},
+			},
+			{
+				// With access to the db, but not every table changed, dolt_diff with --overview should fail
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_diff('main~~', 'main', '--overview');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
 			{
 				// With access to the db, but not the table, dolt_diff_stat should fail
 				User:        "tester",
@@ -1151,6 +1769,20 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:       "SELECT * FROM dolt_diff_summary('main~...main');",
 				ExpectedErr: sql.ErrPrivilegeCheckFailed,
 			},
+			{
+				// With access to the db, dolt_diff_summary including unchanged tables omits the unchanged tables the user can't read
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT * FROM dolt_diff_summary('main~', 'main', '--include-unchanged');",
+				Expected: []sql.Row{{"test", "test", "modified", true, false}},
+			},
+			{
+				// With access to the db, dolt_diff_summary including unchanged tables with dots omits the unchanged tables the user can't read
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT * FROM dolt_diff_summary('main~..main', '--include-unchanged');",
+				Expected: []sql.Row{{"test", "test", "modified", true, false}},
+			},
 			{
 				// With access to the db, but not the table, dolt_patch should fail
 				User:        "tester",
@@ -1180,13 +1812,34 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				ExpectedErr: sql.ErrPrivilegeCheckFailed,
 			},
 			{
-				// Revoke select on mydb.test
-				User:     "root",
-				Host:     "localhost",
-				Query:    "REVOKE SELECT ON mydb.test from tester@localhost;",
-				Expected: []sql.Row{{types.NewOkResult(0)}},
-			},
-			{
+				// With access to the db, but not the table, dolt_schema_diff should fail
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_schema_diff('main~', 'main', 'test2');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				// With access to the db, dolt_schema_diff should fail for all tables if no access any of tables
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_schema_diff('main~', 'main');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				// With access to the db, dolt_schema_diff with dots should fail for all tables if no access any of tables
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_schema_diff('main~...main');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				// Revoke select on mydb.test
+				User:     "root",
+				Host:     "localhost",
+				Query:    "REVOKE SELECT ON mydb.test from tester@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
 				// After revoking access, dolt_diff should fail
 				User:        "tester",
 				Host:        "localhost",
@@ -1263,6 +1916,20 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:    "SELECT COUNT(*) FROM dolt_patch('main~...main');",
 				Expected: []sql.Row{{1}},
 			},
+			{
+				// After granting access to the entire db, dolt_schema_diff should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_schema_diff('main~', 'main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// After granting access to the entire db, dolt_schema_diff with dots should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM dolt_schema_diff('main~...main');",
+				Expected: []sql.Row{{0}},
+			},
 			{
 				// After granting access to the entire db, dolt_log should work
 				User:     "tester",
@@ -1270,6 +1937,13 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:    "SELECT COUNT(*) FROM dolt_log('main');",
 				Expected: []sql.Row{{4}},
 			},
+			{
+				// After granting access to the entire db, dolt_branches should work
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT name FROM dolt_branches('--merged', 'main');",
+				Expected: []sql.Row{{"main"}},
+			},
 			{
 				// Revoke multi-table access
 				User:     "root",
@@ -1312,6 +1986,13 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 				Query:       "SELECT * FROM dolt_patch('main~', 'main', 'test');",
 				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
 			},
+			{
+				// After revoking access, dolt_schema_diff should fail
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_schema_diff('main~', 'main', 'test');",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
 			{
 				// After revoking access, dolt_log should fail
 				User:        "tester",
@@ -1363,6 +2044,59 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 			},
 		},
 	},
+	{
+		Name: "database qualified system table privilege checking",
+		SetUpScript: []string{
+			"CREATE TABLE mydb.test (pk BIGINT PRIMARY KEY);",
+			"CALL DOLT_ADD('.')",
+			"CALL DOLT_COMMIT('-am', 'creating table test');",
+			"CREATE DATABASE otherdb;",
+			"CREATE TABLE otherdb.test (pk BIGINT PRIMARY KEY);",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT ON mydb.* TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				// With access to mydb only, the system tables of mydb can be read
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM mydb.dolt_branches;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				// Without access to otherdb, its system tables can't be read from mydb
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM otherdb.dolt_log;",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM otherdb.dolt_status;",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "SELECT * FROM otherdb.dolt_history_test;",
+				ExpectedErr: sql.ErrDatabaseAccessDeniedForUser,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT SELECT ON otherdb.* TO tester@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				// After granting access to otherdb, its system tables can be read
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT COUNT(*) FROM otherdb.dolt_status;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
 }
 
 // HistorySystemTableScriptTests contains working tests for both prepared and non-prepared
@@ -1450,6 +2184,15 @@ var HistorySystemTableScriptTests = []queries.ScriptTest{
 				Query:    "select count(*) from Dolt_History_t1;",
 				Expected: []sql.Row{{18}},
 			},
+			{
+				// the deletion of n=2 at @Commit5 is recorded by a row of its own, which is only read with the deleted column
+				Query:    "select count(*) from Dolt_History_t1 where deleted is not null;",
+				Expected: []sql.Row{{19}},
+			},
+			{
+				Query:    "select count(*) from Dolt_History_t1 where not deleted;",
+				Expected: []sql.Row{{18}},
+			},
 			{
 				Query:    "select n, de, fr from dolt_history_T1 where commit_hash = @Commit1;",
 				Expected: []sql.Row{{1, "Eins", nil}, {2, "Zwei", nil}, {3, "Drei", nil}},
@@ -1479,6 +2222,19 @@ var HistorySystemTableScriptTests = []queries.ScriptTest{
 					{4, "Vier, meine herren", "Quatre"},
 				},
 			},
+			{
+				Query: "select n, de, fr, deleted from dolt_history_T1 where commit_hash = @Commit5;",
+				Expected: []sql.Row{
+					{1, "Eins", "Un", false},
+					{3, "Drei, meine herren", nil, false},
+					{4, "Vier, meine herren", "Quatre", false},
+					{2, nil, nil, true},
+				},
+			},
+			{
+				Query:    "select n, commit_hash = @Commit5 from dolt_history_t1 where deleted;",
+				Expected: []sql.Row{{2, true}},
+			},
 			{
 				Query: "select de, fr, commit_hash=@commit1, commit_hash=@commit2, commit_hash=@commit3, commit_hash=@commit4" +
 					" from dolt_history_T1 where n=2 order by commit_date",
@@ -1491,6 +2247,98 @@ var HistorySystemTableScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "primary key table: deleted rows",
+		SetUpScript: []string{
+			"create table lifecycle (id int, region varchar(10), v int, primary key (region, id));",
+			"insert into lifecycle values (1, 'us', 1), (2, 'us', 2), (1, 'eu', 3);",
+			"call dolt_add('.');",
+			"call dolt_commit_hash_out(@Commit1, '-m', 'creating lifecycle');",
+			"delete from lifecycle where region = 'us' and id = 2;",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'deleting us 2');",
+			"insert into lifecycle values (2, 'us', 20);",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'reinserting us 2');",
+			"call dolt_checkout('-b', 'b1');",
+			"delete from lifecycle where region = 'eu';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'deleting eu on b1');",
+			"call dolt_checkout('main');",
+			"update lifecycle set v = 10 where region = 'us' and id = 1;",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'updating us 1');",
+			"call dolt_merge('b1', '--no-commit');",
+			"call dolt_commit_hash_out(@Merge, '-am', 'merging b1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// eu 1 was deleted on b1, so its deletion is recorded at the commit on b1 rather than at the merge
+				Query:    "select region, id, v, commit_hash = @Commit2, commit_hash = @Commit4 from dolt_history_lifecycle where deleted order by commit_order;",
+				Expected: []sql.Row{{"us", 2, nil, true, false}, {"eu", 1, nil, false, true}},
+			},
+			{
+				Query:    "select id, region, v, deleted from dolt_history_lifecycle where commit_hash = @Commit2 order by region, id;",
+				Expected: []sql.Row{{1, "eu", 3, false}, {1, "us", 1, false}, {2, "us", nil, true}},
+			},
+			{
+				Query:    "select count(*) from dolt_history_lifecycle where commit_hash = @Merge and deleted;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_history_lifecycle where region = 'us' and id = 2;",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				// the deletion of a key is found with a lookup on the primary key, like its other rows
+				Query:    "select count(*) from dolt_history_lifecycle where region = 'us' and id = 2 and deleted is not null;",
+				Expected: []sql.Row{{6}},
+			},
+			{
+				Query:    "select commit_hash = @Commit2 from dolt_history_lifecycle where region = 'us' and id = 2 and deleted;",
+				Expected: []sql.Row{{true}},
+			},
+		},
+	},
+	{
+		Name: "primary key table: columns named deleted and commit_order",
+		SetUpScript: []string{
+			"create table own_meta_cols (pk int primary key, deleted int, commit_order int);",
+			"insert into own_meta_cols values (1, 10, 100), (2, 20, 200);",
+			"call dolt_add('.');",
+			"call dolt_commit_hash_out(@Commit1, '-m', 'creating own_meta_cols');",
+			"update own_meta_cols set deleted = 11 where pk = 1;",
+			"delete from own_meta_cols where pk = 2;",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'updating and deleting');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// the table's own columns take the place of the history columns of the same names
+				Query: "describe dolt_history_own_meta_cols;",
+				Expected: []sql.Row{
+					{"pk", "int", "NO", "PRI", "NULL", ""},
+					{"deleted", "int", "YES", "", "NULL", ""},
+					{"commit_order", "int", "YES", "", "NULL", ""},
+					{"commit_hash", "char(32) CHARACTER SET ascii COLLATE ascii_bin", "NO", "MUL", "NULL", ""},
+					{"committer", "varchar(1024) CHARACTER SET ascii COLLATE ascii_bin", "NO", "", "NULL", ""},
+					{"commit_date", "datetime(6)", "NO", "", "NULL", ""},
+				},
+			},
+			{
+				// without a deleted column to mark them, no rows are added for deleted keys
+				Query:    "select pk, deleted, commit_order, commit_hash = @Commit2 from dolt_history_own_meta_cols order by commit_hash = @Commit2, pk;",
+				Expected: []sql.Row{{1, 10, 100, false}, {2, 20, 200, false}, {1, 11, 100, true}},
+			},
+			{
+				Query:    "select deleted, commit_order from dolt_history_own_meta_cols where pk = 1 order by deleted;",
+				Expected: []sql.Row{{10, 100}, {11, 100}},
+			},
+			{
+				Query:    "select pk from dolt_history_own_meta_cols where commit_order = 200;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk, deleted from dolt_history_own_meta_cols where deleted > 10 and pk = 1;",
+				Expected: []sql.Row{{1, 11}},
+			},
+		},
+	},
 	{
 		Name: "index by primary key",
 		SetUpScript: []string{
@@ -1721,6 +2569,32 @@ var HistorySystemTableScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "primary key table: non-pk column widening type changes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20), c3 int);",
+			"call dolt_add('.')",
+			"insert into t values (1, 2, '3', 4), (5, 6, '7', 8);",
+			"set @Commit1 = '';",
+			"CALL DOLT_COMMIT_HASH_OUT(@Commit1, '-am', 'creating table t');",
+			"alter table t modify column c1 bigint;",
+			"alter table t modify column c2 varchar(100);",
+			"alter table t modify column c3 tinyint;",
+			"set @Commit2 = '';",
+			"CALL DOLT_COMMIT_HASH_OUT(@Commit2, '-am', 'changed column types');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// widening changes keep their old values, narrowing changes are nil valued
+				Query:    "select pk, c1, c2, c3 from dolt_history_t where commit_hash=@Commit1 order by pk;",
+				Expected: []sql.Row{{1, 2, "3", nil}, {5, 6, "7", nil}},
+			},
+			{
+				Query:    "select pk, c1, c2, c3 from dolt_history_t where commit_hash=@Commit2 order by pk;",
+				Expected: []sql.Row{{1, 2, "3", 4}, {5, 6, "7", 8}},
+			},
+		},
+	},
 	{
 		Name: "primary key table: rename table",
 		SetUpScript: []string{
@@ -1770,15 +2644,38 @@ var HistorySystemTableScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				// TODO: The history system table processes history in parallel and pulls the rows for the
-				//       user table at all commits. This means we can't currently detect when a table was dropped
-				//       and if a different table with the same name exists at earlier commits, those results will
-				//       be included in the history table. It may make more sense to have history scoped only
-				//       to the current instance of the table, which would require changing the history system table
-				//       to use something like an iterator approach where it goes back sequentially until it detects
-				//       the current table doesn't exist any more and then stop.
+				// the history of the recreated table stops at the commit that dropped the old one, so none of the old
+				// table's rows are included
 				Query:    "select count(*) from dolt_history_t;",
-				Expected: []sql.Row{{2}},
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "insert into t values (7, 8);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:            "call dolt_commit('-am', 'inserting into t');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select pk, c1 from dolt_history_t;",
+				Expected: []sql.Row{{7, 8}},
+			},
+			{
+				Query:    "select count(*) from dolt_history_t where commit_hash = @Commit1;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_history_t where commit_hash = @Commit2;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "set @Commit4 = hashof('HEAD');",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select pk, c1 from dolt_history_t where commit_hash = @Commit4;",
+				Expected: []sql.Row{{7, 8}},
 			},
 		},
 	},
@@ -1900,127 +2797,314 @@ var HistorySystemTableScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
-}
-
-// BrokenHistorySystemTableScriptTests contains tests that work for non-prepared, but don't work
-// for prepared queries.
-var BrokenHistorySystemTableScriptTests = []queries.ScriptTest{
 	{
-		Name: "dolt_history table with AS OF",
-		SetUpScript: []string{
-			"create table t (pk int primary key, c1 int, c2 varchar(20));",
-			"call dolt_add('-A');",
-			"call dolt_commit('-m', 'creating table t');",
-			"insert into t values (1, 2, '3'), (4, 5, '6');",
-			"call dolt_commit('-am', 'added values');",
-			"insert into t values (11, 22, '3'), (44, 55, '6');",
-			"call dolt_commit('-am', 'added values again');",
-		},
+		Name:        "dolt_history table with a limit over a deep history",
+		SetUpScript: deepHistorySetup(),
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "select message from dolt_log AS OF 'head^';",
-				Expected: []sql.Row{
-					{"added values"},
-					{"creating table t"},
-					{"checkpoint enginetest database mydb"},
-					{"Initialize data repository"},
-				},
+				Query:    "select count(*) from (select * from dolt_history_deep limit 5) sq;",
+				Expected: []sql.Row{{5}},
 			},
-		},
-	},
-}
-
-var DoltBranchScripts = []queries.ScriptTest{
-	{
-		Name: "Create branches from HEAD with dolt_branch procedure",
-		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "CALL DOLT_BRANCH('myNewBranch1')",
-				Expected: []sql.Row{{0}},
+				Query:    "select count(*) from (select pk from dolt_history_deep where pk = 0 limit 1) sq;",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:    "SELECT COUNT(*) FROM DOLT_BRANCHES WHERE NAME='myNewBranch1';",
-				Expected: []sql.Row{{1}},
+				// ORDER BY has to read every commit to find the rows from the oldest ones
+				Query:    "select v from dolt_history_deep order by v limit 3;",
+				Expected: []sql.Row{{0}, {1}, {2}},
 			},
 			{
-				// Trying to recreate that branch fails without the force flag
-				Query:          "CALL DOLT_BRANCH('myNewBranch1')",
-				ExpectedErrStr: "fatal: A branch named 'myNewBranch1' already exists.",
+				Query:    "select v from dolt_history_deep order by v desc limit 2;",
+				Expected: []sql.Row{{200}, {199}},
 			},
 			{
-				Query:    "CALL DOLT_BRANCH('-f', 'myNewBranch1')",
-				Expected: []sql.Row{{0}},
+				Query:    "select count(*) from dolt_history_deep;",
+				Expected: []sql.Row{{201}},
 			},
 		},
 	},
 	{
-		Name: "Rename branches with dolt_branch procedure",
+		Name: "dolt_history table commit_order follows the commit graph when dates don't",
+		SetUpScript: []string{
+			"create table ordered (pk int primary key, v int);",
+			"call dolt_add('.');",
+			"call dolt_commit_hash_out(@Create, '-m', 'create ordered', '--date', '2022-01-01T12:00:00');",
+			"call dolt_checkout('-b', 'b1');",
+			"insert into ordered values (2, 2);",
+			"call dolt_commit_hash_out(@Insert2, '-am', 'insert 2', '--date', '2022-01-04T12:00:00');",
+			"update ordered set v = 20 where pk = 2;",
+			"call dolt_commit_hash_out(@Update2, '-am', 'update 2', '--date', '2022-01-02T12:00:00');",
+			"call dolt_checkout('main');",
+			"insert into ordered values (1, 1);",
+			"call dolt_commit_hash_out(@Insert1, '-am', 'insert 1', '--date', '2022-01-05T12:00:00');",
+			"call dolt_merge('b1', '--no-commit');",
+			"call dolt_commit_hash_out(@Merge, '-am', 'merge b1', '--date', '2022-01-03T12:00:00');",
+		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "CALL DOLT_BRANCH('myNewBranch1')",
-				Expected: []sql.Row{{0}},
+				Query:    "select pk, v from dolt_history_ordered order by commit_date desc, pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {1, 1}, {2, 20}, {2, 20}},
 			},
 			{
-				Query:    "CALL DOLT_BRANCH('myNewBranch2')",
-				Expected: []sql.Row{{0}},
+				Query:    "select pk, v from dolt_history_ordered order by commit_order desc, pk;",
+				Expected: []sql.Row{{1, 1}, {2, 20}, {2, 20}, {1, 1}, {2, 2}},
 			},
 			{
-				// Renaming to an existing name fails without the force flag
-				Query:          "CALL DOLT_BRANCH('-m', 'myNewBranch1', 'myNewBranch2')",
-				ExpectedErrStr: "already exists",
+				Query:    "select pk, v, commit_hash = @Update2 from dolt_history_ordered where commit_order = 5;",
+				Expected: []sql.Row{{2, 20, true}},
 			},
 			{
-				Query:    "CALL DOLT_BRANCH('-mf', 'myNewBranch1', 'myNewBranch2')",
-				Expected: []sql.Row{{0}},
+				Query:    "select count(*) from dolt_history_ordered where commit_order > 4;",
+				Expected: []sql.Row{{3}},
 			},
 			{
-				Query:    "CALL DOLT_BRANCH('-m', 'myNewBranch2', 'myNewBranch3')",
-				Expected: []sql.Row{{0}},
+				Query:    "select v, commit_order from dolt_history_ordered where pk = 2 and commit_hash = @Update2;",
+				Expected: []sql.Row{{20, uint64(5)}},
+			},
+			{
+				Query:    "select v, commit_order from dolt_history_ordered where pk = 2 and commit_hash in (@Insert2, @Merge) order by commit_order;",
+				Expected: []sql.Row{{2, uint64(4)}, {20, uint64(6)}},
 			},
 		},
 	},
 	{
-		Name: "Copy branches from other branches using dolt_branch procedure",
+		Name: "commit_date and committer filters are pushed down to the commit walk",
 		SetUpScript: []string{
-			"CALL DOLT_BRANCH('myNewBranch1')",
+			"create table dated (pk int primary key, v int);",
+			"insert into dated values (1, 1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create dated', '--date', '2023-01-01T12:00:00');",
+			"insert into dated values (2, 2);",
+			"call dolt_commit('-am', 'insert 2', '--date', '2023-01-02T12:00:00', '--author', 'John Doe <johndoe@example.com>');",
+			"update dated set v = 10 where pk = 1;",
+			"call dolt_commit('-am', 'update 1', '--date', '2023-01-02T12:00:00');",
+			"insert into dated values (3, 3);",
+			"call dolt_commit('-am', 'insert 3', '--date', '2023-01-03T12:00:00', '--author', 'John Doe <johndoe@example.com>');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "CALL DOLT_BRANCH('-c')",
-				ExpectedErrStr: "error: invalid usage",
+				Query:    "select pk, v from dolt_history_dated where commit_date > '2023-01-01T12:00:00' order by commit_order, pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {1, 10}, {2, 2}, {1, 10}, {2, 2}, {3, 3}},
 			},
 			{
-				Query:          "CALL DOLT_BRANCH('-c', 'myNewBranch1')",
-				ExpectedErrStr: "error: invalid usage",
+				// both commits with this timestamp are included
+				Query:    "select pk, v from dolt_history_dated where commit_date = '2023-01-02T12:00:00' order by commit_order, pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {1, 10}, {2, 2}},
 			},
 			{
-				Query:          "CALL DOLT_BRANCH('-c', 'myNewBranch2')",
-				ExpectedErrStr: "error: invalid usage",
+				Query:    "select count(*) from dolt_history_dated where commit_date >= '2023-01-02T12:00:00' and commit_date < '2023-01-03T12:00:00';",
+				Expected: []sql.Row{{4}},
 			},
 			{
-				Query:          "CALL DOLT_BRANCH('-c', '', '')",
-				ExpectedErrStr: "error: cannot branch empty string",
+				Query:    "select count(*) from dolt_history_dated where commit_date between '2023-01-01T12:00:00' and '2023-01-02T12:00:00';",
+				Expected: []sql.Row{{5}},
 			},
 			{
-				Query:    "CALL DOLT_BRANCH('-c', 'myNewBranch1', 'myNewBranch2')",
-				Expected: []sql.Row{{0}},
+				Query:    "select count(*) from dolt_history_dated where committer = 'John Doe';",
+				Expected: []sql.Row{{5}},
 			},
 			{
-				Query:    "SELECT COUNT(*) FROM DOLT_BRANCHES WHERE NAME='myNewBranch2';",
-				Expected: []sql.Row{{1}},
+				Query:    "select count(*) from dolt_history_dated where committer = 'John Doe' and commit_date < '2023-01-03T12:00:00';",
+				Expected: []sql.Row{{2}},
 			},
 			{
-				Query:          "CALL DOLT_BRANCH('-c', 'myNewBranch1', 'myNewBranch2')",
-				ExpectedErrStr: "fatal: A branch named 'myNewBranch2' already exists.",
+				// a filter on a column of the table isn't pushed down to the commit walk
+				Query:    "select count(*) from dolt_history_dated where committer = 'John Doe' or pk = 1;",
+				Expected: []sql.Row{{7}},
 			},
 			{
-				Query:    "CALL DOLT_BRANCH('-cf', 'myNewBranch1', 'myNewBranch2')",
+				// a NULL comparison filters out every commit, as it would every row
+				Query:    "select count(*) from dolt_history_dated where commit_date > null;",
 				Expected: []sql.Row{{0}},
 			},
+			{
+				Query:    "select count(*) from dolt_history_dated where committer = null or commit_date > '2023-01-02T12:00:00';",
+				Expected: []sql.Row{{3}},
+			},
 		},
 	},
 	{
-		Name: "Delete branches with dolt_branch procedure",
+		// prepared statements are planned without an exchange
+		SkipPrepared: true,
+		Name:         "commit_date and committer filters are pushed down to the commit walk: plans",
+		SetUpScript: []string{
+			"create table dated (pk int primary key, v int);",
+			"insert into dated values (1, 1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create dated', '--date', '2023-01-01T12:00:00');",
+			"insert into dated values (2, 2);",
+			"call dolt_commit('-am', 'insert 2', '--date', '2023-01-02T12:00:00', '--author', 'John Doe <johndoe@example.com>');",
+			"update dated set v = 10 where pk = 1;",
+			"call dolt_commit('-am', 'update 1', '--date', '2023-01-02T12:00:00');",
+			"insert into dated values (3, 3);",
+			"call dolt_commit('-am', 'insert 3', '--date', '2023-01-03T12:00:00', '--author', 'John Doe <johndoe@example.com>');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// the filter is evaluated against each commit as it's walked, so no rows are read at other commits
+				Query: "explain select pk, v from dolt_history_dated where commit_date > '2023-01-01T12:00:00';",
+				Expected: []sql.Row{
+					{"Exchange"},
+					{" └─ Project"},
+					{"     ├─ columns: [dolt_history_dated.pk, dolt_history_dated.v]"},
+					{"     └─ Table"},
+					{"         ├─ name: dolt_history_dated"},
+					{"         ├─ columns: [pk v commit_date]"},
+					{"         └─ filters: [(dolt_history_dated.commit_date > '2023-01-01T12:00:00')]"},
+				},
+			},
+			{
+				Query: "explain select pk, v from dolt_history_dated where committer = 'John Doe' and commit_date < '2023-01-03T12:00:00';",
+				Expected: []sql.Row{
+					{"Exchange"},
+					{" └─ Project"},
+					{"     ├─ columns: [dolt_history_dated.pk, dolt_history_dated.v]"},
+					{"     └─ Table"},
+					{"         ├─ name: dolt_history_dated"},
+					{"         ├─ columns: [pk v committer commit_date]"},
+					{"         └─ filters: [(dolt_history_dated.committer = 'John Doe') (dolt_history_dated.commit_date < '2023-01-03T12:00:00')]"},
+				},
+			},
+		},
+	},
+}
+
+func deepHistorySetup() []string {
+	queries := []string{
+		"create table deep (pk int primary key, v int);",
+		"insert into deep values (0, 0);",
+		"call dolt_commit('-Am', 'create table');",
+	}
+	for i := 1; i <= 200; i++ {
+		queries = append(
+			queries,
+			fmt.Sprintf("update deep set v = %d;", i),
+			fmt.Sprintf("call dolt_commit('-am', 'set v to %d');", i),
+		)
+	}
+	return queries
+}
+
+// BrokenHistorySystemTableScriptTests contains tests that work for non-prepared, but don't work
+// for prepared queries.
+var BrokenHistorySystemTableScriptTests = []queries.ScriptTest{
+	{
+		Name: "dolt_history table with AS OF",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20));",
+			"call dolt_add('-A');",
+			"call dolt_commit('-m', 'creating table t');",
+			"insert into t values (1, 2, '3'), (4, 5, '6');",
+			"call dolt_commit('-am', 'added values');",
+			"insert into t values (11, 22, '3'), (44, 55, '6');",
+			"call dolt_commit('-am', 'added values again');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select message from dolt_log AS OF 'head^';",
+				Expected: []sql.Row{
+					{"added values"},
+					{"creating table t"},
+					{"checkpoint enginetest database mydb"},
+					{"Initialize data repository"},
+				},
+			},
+		},
+	},
+}
+
+var DoltBranchScripts = []queries.ScriptTest{
+	{
+		Name: "Create branches from HEAD with dolt_branch procedure",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_BRANCH('myNewBranch1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM DOLT_BRANCHES WHERE NAME='myNewBranch1';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				// Trying to recreate that branch fails without the force flag
+				Query:          "CALL DOLT_BRANCH('myNewBranch1')",
+				ExpectedErrStr: "fatal: A branch named 'myNewBranch1' already exists.",
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-f', 'myNewBranch1')",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "Rename branches with dolt_branch procedure",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_BRANCH('myNewBranch1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('myNewBranch2')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// Renaming to an existing name fails without the force flag
+				Query:          "CALL DOLT_BRANCH('-m', 'myNewBranch1', 'myNewBranch2')",
+				ExpectedErrStr: "already exists",
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-mf', 'myNewBranch1', 'myNewBranch2')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-m', 'myNewBranch2', 'myNewBranch3')",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "Copy branches from other branches using dolt_branch procedure",
+		SetUpScript: []string{
+			"CALL DOLT_BRANCH('myNewBranch1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_BRANCH('-c')",
+				ExpectedErrStr: "error: invalid usage",
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('-c', 'myNewBranch1')",
+				ExpectedErrStr: "error: invalid usage",
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('-c', 'myNewBranch2')",
+				ExpectedErrStr: "error: invalid usage",
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('-c', '', '')",
+				ExpectedErrStr: "error: cannot branch empty string",
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-c', 'myNewBranch1', 'myNewBranch2')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM DOLT_BRANCHES WHERE NAME='myNewBranch2';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('-c', 'myNewBranch1', 'myNewBranch2')",
+				ExpectedErrStr: "fatal: A branch named 'myNewBranch2' already exists.",
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-cf', 'myNewBranch1', 'myNewBranch2')",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "Delete branches with dolt_branch procedure",
 		SetUpScript: []string{
 			"CALL DOLT_BRANCH('myNewBranch1')",
 			"CALL DOLT_BRANCH('myNewBranch2')",
@@ -2101,6 +3185,164 @@ var DoltBranchScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_branches creator and created_at",
+		SetUpScript: []string{
+			"call dolt_branch('b1');",
+			"call dolt_checkout('-b', 'b2');",
+			"call dolt_checkout('main');",
+			"call dolt_branch('-c', 'b1', 'b3');",
+			"set @b1_created = (select created_at from dolt_branches where name = 'b1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select name, creator, created_at is not null from dolt_branches order by name;",
+				Expected: []sql.Row{
+					{"b1", "root", true},
+					{"b2", "root", true},
+					{"b3", "root", true},
+					{"main", nil, false},
+				},
+			},
+			{
+				Query:    "call dolt_branch('-m', 'b1', 'b1_renamed');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select creator, created_at = @b1_created from dolt_branches where name = 'b1_renamed';",
+				Expected: []sql.Row{{"root", true}},
+			},
+			{
+				Query:    "call dolt_branch('-d', 'b1_renamed');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_branches where name in ('b1', 'b1_renamed');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_remote_branches where creator is not null or created_at is not null;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "Set branch descriptions with dolt_branch procedure",
+		SetUpScript: []string{
+			"CALL DOLT_BRANCH('branch1')",
+			"CALL DOLT_BRANCH('branch2')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT name, description FROM dolt_branches ORDER BY name",
+				Expected: []sql.Row{{"branch1", nil}, {"branch2", nil}, {"main", nil}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('--set-description', 'branch1', 'text here')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT name, description FROM dolt_branches ORDER BY name",
+				Expected: []sql.Row{{"branch1", "text here"}, {"branch2", nil}, {"main", nil}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('--set-description', 'branch2', 'other text')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('--set-description', 'branch2', '')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT description FROM dolt_branches WHERE name = 'branch2'",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-m', 'branch1', 'branch3')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT name, description FROM dolt_branches ORDER BY name",
+				Expected: []sql.Row{{"branch2", nil}, {"branch3", "text here"}, {"main", nil}},
+			},
+			{
+				Query:    "CALL DOLT_BRANCH('-d', 'branch3')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// a new branch with the name of a deleted one doesn't get its description
+				Query:    "CALL DOLT_BRANCH('branch3')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT description FROM dolt_branches WHERE name = 'branch3'",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('--set-description', 'nonexistent', 'text')",
+				ExpectedErrStr: "branch not found",
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('--set-description', 'branch2')",
+				ExpectedErrStr: "error: invalid usage",
+			},
+		},
+	},
+	{
+		Name: "Create an orphan branch with dolt_branch procedure",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_BRANCH('--orphan', 'clean')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log('clean')",
+				Expected: []sql.Row{{"Initialize branch clean"}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('clean') AND parent_hash IS NOT NULL",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('clean')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "SELECT * FROM t",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:    "CREATE TABLE t2 (pk int primary key)",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:            "CALL DOLT_COMMIT('-Am', 'creating table t2')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT message FROM dolt_log",
+				Expected: []sql.Row{{"creating table t2"}, {"Initialize branch clean"}},
+			},
+			{
+				// the orphan branch shares no history with main
+				Query:    "SELECT COUNT(*) FROM dolt_log('main') WHERE commit_hash IN (SELECT commit_hash FROM dolt_log('clean'))",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('--orphan', 'main')",
+				ExpectedErrStr: "fatal: A branch named 'main' already exists.",
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('--orphan', 'other', 'main')",
+				ExpectedErrStr: "error: invalid usage",
+			},
+		},
+	},
 }
 
 var DoltReset = []queries.ScriptTest{
@@ -2158,826 +3400,2852 @@ var DoltReset = []queries.ScriptTest{
 			},
 		},
 	},
-}
-
-func gcSetup() []string {
-	queries := []string{
-		"create table t (pk int primary key);",
-		"call dolt_commit('-Am', 'create table');",
-	}
-	for i := 0; i < 250; i++ {
-		queries = append(
-			queries,
-			fmt.Sprintf("INSERT INTO t VALUES (%d);", i),
-			fmt.Sprintf("CALL DOLT_COMMIT('-am', 'added pk %d')", i),
-		)
-	}
-	return queries
-}
-
-var DoltGC = []queries.ScriptTest{
 	{
-		Name:        "base case: gc",
-		SetUpScript: gcSetup(),
+		Name: "CALL DOLT_RESET('--soft') to a commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY);",
+			"CALL DOLT_COMMIT('-Am', 'creating table t');",
+			"SET @first = HASHOF('HEAD');",
+			"INSERT INTO t VALUES (1);",
+			"CALL DOLT_COMMIT('-am', 'inserting 1');",
+			"INSERT INTO t VALUES (2);",
+			"CALL DOLT_COMMIT('-am', 'inserting 2');",
+		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "CALL DOLT_GC(null);",
-				ExpectedErrStr: "error: invalid usage",
+				Query:            "CALL DOLT_RESET('--soft', 'HEAD~2');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:          "CALL DOLT_GC('bad', '--shallow');",
-				ExpectedErrStr: "error: invalid usage",
+				Query:    "SELECT HASHOF('HEAD') = @first;",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				Query:    "CALL DOLT_GC('--shallow');",
-				Expected: []sql.Row{{1}},
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{{"t", true, "modified"}},
 			},
 			{
-				Query:    "CALL DOLT_GC();",
-				Expected: []sql.Row{{1}},
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1}, {2}},
 			},
 			{
-				Query:          "CALL DOLT_GC();",
-				ExpectedErrStr: "no changes since last gc",
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"creating table t"}},
 			},
 		},
 	},
-}
-
-var LogTableFunctionScriptTests = []queries.ScriptTest{
 	{
-		Name: "invalid arguments",
+		Name: "CALL DOLT_RESET('--soft') with tables",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+			"CREATE TABLE t1 (pk int PRIMARY KEY);",
+			"CREATE TABLE t2 (pk int PRIMARY KEY);",
+			"CREATE TABLE t3 (pk int PRIMARY KEY);",
+			"CALL DOLT_COMMIT('-Am', 'creating tables');",
+			"INSERT INTO t1 VALUES (1);",
+			"INSERT INTO t2 VALUES (1);",
+			"INSERT INTO t3 VALUES (1);",
+			"CALL DOLT_ADD('.');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, @Commit2, 't');",
-				ExpectedErr: sql.ErrInvalidArgumentNumber,
+				Query:    "SELECT * FROM dolt_status ORDER BY table_name;",
+				Expected: []sql.Row{{"t1", true, "modified"}, {"t2", true, "modified"}, {"t3", true, "modified"}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:            "CALL DOLT_RESET('--soft', 't1');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log(null, null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM dolt_status ORDER BY table_name, staged;",
+				Expected: []sql.Row{{"t1", false, "modified"}, {"t2", true, "modified"}, {"t3", true, "modified"}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(null, '--not', null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:            "CALL DOLT_RESET('--soft', 't2', 't3');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, '--not', null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM dolt_status ORDER BY table_name, staged;",
+				Expected: []sql.Row{{"t1", false, "modified"}, {"t2", false, "modified"}, {"t3", false, "modified"}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, '--min-parents', null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM t1;",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, '--min-parents', 123);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:          "CALL DOLT_RESET('--soft', 't1', 'nonexistent');",
+				ExpectedErrStr: "error: the table(s) nonexistent do not exist",
 			},
 			{
-				Query:       "SELECT * from dolt_log(123, @Commit1);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "CREATE TABLE t4 (pk int PRIMARY KEY);",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, 123);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:          "CALL DOLT_RESET('--soft', 't4');",
+				ExpectedErrStr: "error: the table(s) t4 do not exist",
 			},
+		},
+	},
+	{
+		Name: "CALL DOLT_RESET('--mixed') to a commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY);",
+			"CALL DOLT_COMMIT('-Am', 'creating table t');",
+			"SET @first = HASHOF('HEAD');",
+			"INSERT INTO t VALUES (1);",
+			"CALL DOLT_COMMIT('-am', 'inserting 1');",
+			"SET @second = HASHOF('HEAD');",
+			"INSERT INTO t VALUES (2);",
+			"CALL DOLT_COMMIT('-am', 'inserting 2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, '--not', 123);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:            "CALL DOLT_RESET('--mixed', 'HEAD~1');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log('main..branch1', @Commit1);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT HASHOF('HEAD') = @second;",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				Query:       "SELECT * from dolt_log('^main..branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{{"t", false, "modified"}},
 			},
 			{
-				Query:       "SELECT * from dolt_log('^main...branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1}, {2}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, 'main..branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				// a commit without --soft or --mixed is a mixed reset
+				Query:            "CALL DOLT_RESET('HEAD~1');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, 'main...branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT HASHOF('HEAD') = @first;",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				Query:       "SELECT * from dolt_log('main..branch1', '--not', @Commit1);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{{"t", false, "modified"}},
 			},
 			{
-				Query:       "SELECT * from dolt_log('main...branch1', '--not', @Commit1);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1}, {2}},
 			},
 			{
-				Query:       "SELECT * from dolt_log('^main', '--not', @Commit1);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "CALL DOLT_ADD('t');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:       "SELECT * from dolt_log('main', '--not', '^branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				// a table name still unstages the table
+				Query:            "CALL DOLT_RESET('t');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log('main', '--not', 'main..branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{{"t", false, "modified"}},
 			},
+		},
+	},
+	{
+		Name: "CALL DOLT_RESET with more than one mode",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY KEY);",
+			"CALL DOLT_COMMIT('-Am', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_log('^main', @Commit2, '--not', @Commit1);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:          "CALL DOLT_RESET('--soft', '--mixed', 'HEAD~1');",
+				ExpectedErrStr: "error: --soft and --mixed are mutually exclusive options.",
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, @Commit2);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:          "CALL DOLT_RESET('--hard', '--mixed');",
+				ExpectedErrStr: "error: --hard and --mixed are mutually exclusive options.",
 			},
 			{
-				Query:       "SELECT * from dolt_log('^main', '^branch1');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:          "CALL DOLT_RESET('--hard', '--soft');",
+				ExpectedErrStr: "error: --hard and --soft are mutually exclusive options.",
 			},
+		},
+	},
+	{
+		Name: "CALL DOLT_RESET('--hard', '--keep') keeps the working changes to the tables given",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk int PRIMARY KEY);",
+			"CREATE TABLE t2 (pk int PRIMARY KEY);",
+			"CREATE TABLE t3 (pk int PRIMARY KEY);",
+			"CALL DOLT_COMMIT('-Am', 'creating tables');",
+			"INSERT INTO t1 VALUES (1);",
+			"INSERT INTO t2 VALUES (2);",
+			"INSERT INTO t3 VALUES (3);",
+			"CALL DOLT_ADD('t2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_log('^main');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:            "CALL DOLT_RESET('--hard', '--keep', 't2');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:          "SELECT * from dolt_log('fake-branch');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{{"t2", false, "modified"}},
 			},
 			{
-				Query:          "SELECT * from dolt_log('^fake-branch', 'main');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:    "SELECT * FROM t2;",
+				Expected: []sql.Row{{2}},
 			},
 			{
-				Query:          "SELECT * from dolt_log('fake-branch', '^main');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:    "SELECT count(*) FROM t1;",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:          "SELECT * from dolt_log('main..fake-branch');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:            "INSERT INTO t1 VALUES (1);",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:          "SELECT * from dolt_log('main', '--not', 'fake-branch');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:            "INSERT INTO t3 VALUES (3);",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log(concat('fake', '-', 'branch'));",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query:            "CALL DOLT_RESET('--hard', '--keep', 't1,t3');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log(hashof('main'));",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query:    "SELECT * FROM dolt_status ORDER BY table_name;",
+				Expected: []sql.Row{{"t1", false, "modified"}, {"t3", false, "modified"}},
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit3, '--not', hashof('main'));",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query:          "CALL DOLT_RESET('--hard', '--keep', 'nonexistent');",
+				ExpectedErrStr: "table not found: nonexistent",
 			},
 			{
-				Query:       "SELECT * from dolt_log(@Commit1, LOWER(@Commit2));",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				// a failed reset changes nothing
+				Query:    "SELECT * FROM dolt_status ORDER BY table_name;",
+				Expected: []sql.Row{{"t1", false, "modified"}, {"t3", false, "modified"}},
 			},
 			{
-				Query:          "SELECT parents from dolt_log();",
-				ExpectedErrStr: `column "parents" could not be found in any table in scope`,
+				Query:          "CALL DOLT_RESET('--soft', '--keep', 't1');",
+				ExpectedErrStr: "error: --keep can only be used with --hard",
 			},
 			{
-				Query:       "SELECT * from dolt_log('--decorate', 'invalid');",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:            "CALL DOLT_RESET('--hard');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:       "SELECT * from dolt_log('--decorate', 123);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
 			},
+		},
+	},
+}
+
+var DoltCleanTestScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_clean removes untracked tables",
+		SetUpScript: []string{
+			"create table committed (pk int primary key);",
+			"call dolt_commit('-Am', 'create committed');",
+			"insert into committed values (1);",
+			"create table staged (pk int primary key);",
+			"call dolt_add('staged');",
+			"create table new1 (pk int primary key);",
+			"create table new2 (pk int primary key);",
+			"create table new3 (pk int primary key);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_log('--decorate', null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:    "call dolt_clean('--dry-run');",
+				Expected: []sql.Row{{0, nil}, {0, "new1"}, {0, "new2"}, {0, "new3"}},
 			},
 			{
-				Query:          "SELECT refs from dolt_log();",
-				ExpectedErrStr: `column "refs" could not be found in any table in scope`,
+				Query:    "call dolt_clean('--dry-run', 'new2', 'staged');",
+				Expected: []sql.Row{{0, nil}, {0, "new2"}},
 			},
 			{
-				Query:          "SELECT refs from dolt_log('--decorate', 'auto');",
-				ExpectedErrStr: `column "refs" could not be found in any table in scope`,
+				Query: "select table_name, staged, status from dolt_status order by table_name;",
+				Expected: []sql.Row{
+					{"committed", false, "modified"},
+					{"new1", false, "new table"},
+					{"new2", false, "new table"},
+					{"new3", false, "new table"},
+					{"staged", true, "new table"},
+				},
 			},
 			{
-				Query:          "SELECT refs from dolt_log('--decorate', 'no');",
-				ExpectedErrStr: `column "refs" could not be found in any table in scope`,
+				Query:          "call dolt_clean('nonexistent');",
+				ExpectedErrStr: "failed to clean; table not found: 'nonexistent'",
 			},
-		},
-	},
-	{
-		Name: "basic case with one revision",
-		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
-
-			"call dolt_checkout('-b', 'new-branch')",
-			"insert into t values (3, 'three', 'four');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t again');",
-			"call dolt_checkout('main')",
-		},
-		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT count(*) from dolt_log();",
-				Expected: []sql.Row{{4}},
+				Query:    "call dolt_clean('new1', 'staged');",
+				Expected: []sql.Row{{0, nil}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main');",
-				Expected: []sql.Row{{4}},
+				Query: "select table_name, staged, status from dolt_status order by table_name;",
+				Expected: []sql.Row{
+					{"committed", false, "modified"},
+					{"new2", false, "new table"},
+					{"new3", false, "new table"},
+					{"staged", true, "new table"},
+				},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log(@Commit1);",
-				Expected: []sql.Row{{3}},
+				Query:    "call dolt_clean();",
+				Expected: []sql.Row{{0, nil}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log(@Commit2);",
-				Expected: []sql.Row{{4}},
+				Query: "select table_name, staged, status from dolt_status order by table_name;",
+				Expected: []sql.Row{
+					{"committed", false, "modified"},
+					{"staged", true, "new table"},
+				},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log(@Commit3);",
-				Expected: []sql.Row{{5}},
+				Query:    "select * from committed;",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('new-branch');",
-				Expected: []sql.Row{{5}},
+				Query:            "call dolt_commit('-am', 'commit the rest');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main^');",
-				Expected: []sql.Row{{3}},
+				Query:    "call dolt_clean();",
+				Expected: []sql.Row{{0, nil}},
 			},
 			{
-				Query:    "SELECT count(*)	 from dolt_log('main') join dolt_diff(@Commit1, @Commit2, 't') where commit_hash = to_commit;",
-				Expected: []sql.Row{{2}},
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "select * from new2;",
+				ExpectedErr: sql.ErrTableNotFound,
 			},
 		},
 	},
 	{
-		Name: "basic case with more than one revision or revision range",
+		Name: "dolt_clean in a read-only database",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.');",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t 2');",
-
-			"call dolt_checkout('-b', 'new-branch');",
-			"insert into t values (3, 'three', 'four');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t 3');",
-			"insert into t values (4, 'four', 'five');",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into t 4');",
-
-			"call dolt_checkout('main');",
-			"insert into t values (5, 'five', 'six');",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting into t 5');",
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_tag('tag1');",
 		},
-		/* Commit graph:
-		          3 - 4 (new-branch)
-		         /
-		0 - 1 - 2 - 5 (main)
-		*/
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT count(*) from dolt_log('^main', 'new-branch');",
-				Expected: []sql.Row{{2}}, // 4, 3
+				Query:    "use mydb/tag1;",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main..new-branch');",
-				Expected: []sql.Row{{2}}, // 4, 3
+				Query:          "call dolt_clean();",
+				ExpectedErrStr: "unable to clean untracked tables in read-only databases",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main...new-branch');",
-				Expected: []sql.Row{{3}}, // 5, 4, 3
+				Query:          "call dolt_clean('--dry-run');",
+				ExpectedErrStr: "unable to clean untracked tables in read-only databases",
 			},
+		},
+	},
+}
+
+var DoltDestructiveConfirmScripts = []queries.ScriptTest{
+	{
+		Name: "reset --hard with a destructive confirm threshold",
+		SetUpScript: []string{
+			"create table t1 (pk int primary key);",
+			"create table t2 (pk int primary key);",
+			"create table t3 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"insert into t1 values (1);",
+			"insert into t2 values (1), (2);",
+			"call dolt_add('t2');",
+			"insert into t3 values (1);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT count(*) from dolt_log('new-branch', '--not', 'main');",
-				Expected: []sql.Row{{2}}, // 4, 3
+				Query:    "select @@dolt_destructive_confirm_threshold;",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('new-branch', '^main');",
-				Expected: []sql.Row{{2}}, // 4, 3
+				Query:    "set @@dolt_destructive_confirm_threshold = 2;",
+				Expected: []sql.Row{{}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^new-branch', 'main');",
-				Expected: []sql.Row{{1}}, // 5
+				Query: "call dolt_reset('--hard');",
+				ExpectedErrStr: "reset --hard would discard changes to 3 tables (t1, t2, t3), changing their row counts by 4 rows; " +
+					"use --confirm to discard them, or raise @@dolt_destructive_confirm_threshold (currently 2)",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main', '--not', 'new-branch');",
-				Expected: []sql.Row{{1}}, // 5
+				Query:    "select (select count(*) from t1), (select count(*) from t2), (select count(*) from t3);",
+				Expected: []sql.Row{{1, 2, 1}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^main', 'main');",
-				Expected: []sql.Row{{0}},
+				Query:    "set @head = hashof('HEAD');",
+				Expected: []sql.Row{{}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main..main');",
-				Expected: []sql.Row{{0}},
+				Query:            "call dolt_reset('--hard', '--confirm');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main...main');",
-				Expected: []sql.Row{{0}},
+				Query:    "select hashof('HEAD') = @head;",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main', '--not', 'main');",
-				Expected: []sql.Row{{0}},
+				Query:    "select (select count(*) from t1), (select count(*) from t2), (select count(*) from t3);",
+				Expected: []sql.Row{{0, 0, 0}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^main~', 'main');",
-				Expected: []sql.Row{{1}},
+				Query:    "insert into t1 values (1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^main^', 'main');",
-				Expected: []sql.Row{{1}}, // 5
+				Query:    "set @head = hashof('HEAD');",
+				Expected: []sql.Row{{}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^main', 'main^');",
-				Expected: []sql.Row{{0}},
+				Query:            "call dolt_reset('--hard');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^main', @Commit3);",
-				Expected: []sql.Row{{1}},
+				Query:    "select hashof('HEAD') = @head;",
+				Expected: []sql.Row{{true}},
+			},
+		},
+	},
+	{
+		Name: "checkout tables with a destructive confirm threshold",
+		SetUpScript: []string{
+			"create table t1 (pk int primary key);",
+			"create table t2 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"insert into t1 values (1);",
+			"insert into t2 values (1), (2);",
+			"set @@dolt_destructive_confirm_threshold = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_checkout('t1', 't2');",
+				ExpectedErrStr: "checkout would discard changes to 2 tables (t1, t2), changing their row counts by 3 rows; " +
+					"use --confirm to discard them, or raise @@dolt_destructive_confirm_threshold (currently 1)",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('^new-branch', @Commit5);",
-				Expected: []sql.Row{{1}}, // 5
+				Query:    "call dolt_checkout('t1');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log(@Commit3, '--not', @Commit2);",
-				Expected: []sql.Row{{1}}, // 3
+				Query:    "insert into t1 values (1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log(@Commit4, '--not', @Commit2);",
-				Expected: []sql.Row{{2}}, // 4, 3
+				Query:    "call dolt_checkout('--confirm', 't1', 't2');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select (select count(*) from t1), (select count(*) from t2);",
+				Expected: []sql.Row{{0, 0}},
 			},
 		},
 	},
 	{
-		Name: "basic case with one revision, row content",
+		Name: "force deleting a branch with a destructive confirm threshold",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+			"create table t1 (pk int primary key);",
+			"create table t2 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"call dolt_branch('merged');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t1 values (1);",
+			"insert into t2 values (1);",
+			"call dolt_commit('-am', 'changing tables on feature');",
+			"call dolt_checkout('main');",
+			"set @@dolt_destructive_confirm_threshold = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_branch('-D', 'feature');",
+				ExpectedErrStr: "deleting branch 'feature' would discard changes to 2 tables (t1, t2), changing their row counts by 2 rows; " +
+					"use --confirm to discard them, or raise @@dolt_destructive_confirm_threshold (currently 1)",
+			},
+			{
+				Query:    "call dolt_branch('-D', 'merged');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_branch('-D', '--confirm', 'feature');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name from dolt_branches;",
+				Expected: []sql.Row{{"main"}},
+			},
+		},
+	},
+}
 
-			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+func gcSetup() []string {
+	queries := []string{
+		"create table t (pk int primary key);",
+		"call dolt_commit('-Am', 'create table');",
+	}
+	for i := 0; i < 250; i++ {
+		queries = append(
+			queries,
+			fmt.Sprintf("INSERT INTO t VALUES (%d);", i),
+			fmt.Sprintf("CALL DOLT_COMMIT('-am', 'added pk %d')", i),
+		)
+	}
+	return queries
+}
 
-			"call dolt_checkout('-b', 'new-branch')",
-			"insert into t values (3, 'three', 'four');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t again', '--author', 'John Doe <johndoe@example.com>');",
-			"call dolt_checkout('main')",
+var DoltGC = []queries.ScriptTest{
+	{
+		Name:        "base case: gc",
+		SetUpScript: gcSetup(),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_GC(null);",
+				ExpectedErrStr: "error: invalid usage",
+			},
+			{
+				Query:          "CALL DOLT_GC('bad', '--shallow');",
+				ExpectedErrStr: "error: invalid usage",
+			},
+			{
+				Query:    "CALL DOLT_GC('--shallow');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "CALL DOLT_GC();",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:          "CALL DOLT_GC();",
+				ExpectedErrStr: "no changes since last gc",
+			},
 		},
+	},
+	{
+		Name:        "gc with a concurrency limit",
+		SetUpScript: gcSetup(),
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT commit_hash = @Commit2, commit_hash = @Commit1, committer, email, message from dolt_log();",
-				Expected: []sql.Row{
-					{true, false, "billy bob", "bigbillieb@fake.horse", "inserting into t"},
-					{false, true, "billy bob", "bigbillieb@fake.horse", "creating table t"},
-					{false, false, "billy bob", "bigbillieb@fake.horse", "checkpoint enginetest database mydb"},
-					{false, false, "billy bob", "bigbillieb@fake.horse", "Initialize data repository"},
-				},
+				Query:       "SET @@dolt_gc_concurrency = 0;",
+				ExpectedErr: sql.ErrInvalidSystemVariableValue,
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit2, committer, email, message from dolt_log('main') limit 1;",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t"}},
+				Query:       "SET @@dolt_gc_concurrency = -1;",
+				ExpectedErr: sql.ErrInvalidSystemVariableValue,
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit3, committer, email, message from dolt_log('new-branch') limit 1;",
-				Expected: []sql.Row{{true, "John Doe", "johndoe@example.com", "inserting into t again"}},
+				Query:    "SET @@dolt_gc_concurrency = 1;",
+				Expected: []sql.Row{{}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit1, committer, email, message from dolt_log(@Commit1) limit 1;",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "creating table t"}},
+				Query:    "SELECT @@dolt_gc_concurrency;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "CALL DOLT_GC();",
+				Expected: []sql.Row{{1}},
 			},
 		},
 	},
+}
+
+var LogTableFunctionScriptTests = []queries.ScriptTest{
 	{
-		Name: "basic case with more than one revision or revision range, row content",
+		Name: "invalid arguments",
 		SetUpScript: []string{
 			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.');",
+			"call dolt_add('.')",
 			"set @Commit1 = '';",
 			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
 
 			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
 			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t 2');",
-
-			"call dolt_checkout('-b', 'new-branch');",
-			"insert into t values (3, 'three', 'four');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t 3', '--author', 'John Doe <johndoe@example.com>');",
-			"insert into t values (4, 'four', 'five');",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into t 4', '--author', 'John Doe <johndoe@example.com>');",
-
-			"call dolt_checkout('main');",
-			"insert into t values (5, 'five', 'six');",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting into t 5');",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
 		},
-		/* Commit graph:
-		          3 - 4 (new-branch)
-		         /
-		0 - 1 - 2 - 5 (main)
-		*/
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('^main', 'new-branch');",
-				Expected: []sql.Row{
-					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
-					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
-				},
+				Query:          "SELECT * from dolt_log(@Commit1, @Commit2, 't');",
+				ExpectedErrStr: "branch not found: t",
+			},
+			{
+				Query:       "SELECT * from dolt_log(null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(null, null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(null, '--not', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--not', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--min-parents', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--min-parents', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--max-parents', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--max-parents', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(123, @Commit1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--not', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main..branch1', @Commit1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('^main..branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('^main...branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, 'main..branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, 'main...branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main..branch1', '--not', @Commit1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main...branch1', '--not', @Commit1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('^main', '--not', @Commit1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--not', '^branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--not', 'main..branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main..branch1', '^main');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('^main', '^branch1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('^main');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "SELECT * from dolt_log('fake-branch');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_log('^fake-branch', 'main');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_log('fake-branch', '^main');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_log('main..fake-branch');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_log('main', '--not', 'fake-branch');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:       "SELECT * from dolt_log(concat('fake', '-', 'branch'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log(hashof('main'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit3, '--not', hashof('main'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, LOWER(@Commit2));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:          "SELECT parents from dolt_log();",
+				ExpectedErrStr: `column "parents" could not be found in any table in scope`,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--decorate', 'invalid');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--decorate', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--decorate', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--author', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--author', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--author');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--author', concat('John', ' ', 'Doe'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--committer', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--committer', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--committer');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--committer', concat('John', ' ', 'Doe'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--since', 'yesterday');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--until', '2022-13-45');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--since', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--until');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--max-count', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--max-count', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--max-count', 'ten');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--max-count', '-1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('-n', 'ten');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--max-count');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--abbrev', 'ten');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--abbrev', '3');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--abbrev', '33');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--abbrev');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--grep', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--grep', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--grep');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--grep', concat('a', 'b'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--tables', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log(@Commit1, '--tables');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--tables', concat('t', '1'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--cherry-mark');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main..new-branch', '--cherry');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main...new-branch', '--cherry-mark', '--group-by-day');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "SELECT refs from dolt_log();",
+				ExpectedErrStr: `column "refs" could not be found in any table in scope`,
+			},
+			{
+				Query:          "SELECT refs from dolt_log('--decorate', 'auto');",
+				ExpectedErrStr: `column "refs" could not be found in any table in scope`,
+			},
+			{
+				Query:          "SELECT refs from dolt_log('--decorate', 'no');",
+				ExpectedErrStr: `column "refs" could not be found in any table in scope`,
+			},
+		},
+	},
+	{
+		Name: "basic case with one revision",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+
+			"call dolt_checkout('-b', 'new-branch')",
+			"insert into t values (3, 'three', 'four');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t again');",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT count(*) from dolt_log();",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main');",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log(@Commit1);",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log(@Commit2);",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log(@Commit3);",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('new-branch');",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main^');",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT count(*)	 from dolt_log('main') join dolt_diff(@Commit1, @Commit2, 't') where commit_hash = to_commit;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "basic case with more than one revision or revision range",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t 2');",
+
+			"call dolt_checkout('-b', 'new-branch');",
+			"insert into t values (3, 'three', 'four');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t 3');",
+			"insert into t values (4, 'four', 'five');",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into t 4');",
+
+			"call dolt_checkout('main');",
+			"insert into t values (5, 'five', 'six');",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting into t 5');",
+		},
+		/* Commit graph:
+		          3 - 4 (new-branch)
+		         /
+		0 - 1 - 2 - 5 (main)
+		*/
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT count(*) from dolt_log('^main', 'new-branch');",
+				Expected: []sql.Row{{2}}, // 4, 3
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main..new-branch');",
+				Expected: []sql.Row{{2}}, // 4, 3
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main...new-branch');",
+				Expected: []sql.Row{{3}}, // 5, 4, 3
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('new-branch', '--not', 'main');",
+				Expected: []sql.Row{{2}}, // 4, 3
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('new-branch', '^main');",
+				Expected: []sql.Row{{2}}, // 4, 3
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^new-branch', 'main');",
+				Expected: []sql.Row{{1}}, // 5
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--not', 'new-branch');",
+				Expected: []sql.Row{{1}}, // 5
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^main', 'main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main..main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main...main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--not', 'main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^main~', 'main');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^main^', 'main');",
+				Expected: []sql.Row{{1}}, // 5
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^main', 'main^');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^main', @Commit3);",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('^new-branch', @Commit5);",
+				Expected: []sql.Row{{1}}, // 5
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log(@Commit3, '--not', @Commit2);",
+				Expected: []sql.Row{{1}}, // 3
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log(@Commit4, '--not', @Commit2);",
+				Expected: []sql.Row{{2}}, // 4, 3
+			},
+		},
+	},
+	{
+		Name: "basic case with one revision, row content",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+
+			"call dolt_checkout('-b', 'new-branch')",
+			"insert into t values (3, 'three', 'four');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t again', '--author', 'John Doe <johndoe@example.com>');",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash = @Commit2, commit_hash = @Commit1, committer, email, message from dolt_log();",
+				Expected: []sql.Row{
+					{true, false, "billy bob", "bigbillieb@fake.horse", "inserting into t"},
+					{false, true, "billy bob", "bigbillieb@fake.horse", "creating table t"},
+					{false, false, "billy bob", "bigbillieb@fake.horse", "checkpoint enginetest database mydb"},
+					{false, false, "billy bob", "bigbillieb@fake.horse", "Initialize data repository"},
+				},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit2, committer, email, message from dolt_log('main') limit 1;",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit3, committer, email, message from dolt_log('new-branch') limit 1;",
+				Expected: []sql.Row{{true, "John Doe", "johndoe@example.com", "inserting into t again"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit1, committer, email, message from dolt_log(@Commit1) limit 1;",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "creating table t"}},
+			},
+		},
+	},
+	{
+		Name: "basic case with more than one revision or revision range, row content",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t 2');",
+
+			"call dolt_checkout('-b', 'new-branch');",
+			"insert into t values (3, 'three', 'four');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into t 3', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (4, 'four', 'five');",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into t 4', '--author', 'John Doe <johndoe@example.com>');",
+
+			"call dolt_checkout('main');",
+			"insert into t values (5, 'five', 'six');",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting into t 5');",
+		},
+		/* Commit graph:
+		          3 - 4 (new-branch)
+		         /
+		0 - 1 - 2 - 5 (main)
+		*/
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('^main', 'new-branch');",
+				Expected: []sql.Row{
+					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
+					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
+				},
+			},
+			{
+				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('main..new-branch');",
+				Expected: []sql.Row{
+					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
+					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
+				},
+			},
+			{
+				Query: "SELECT commit_hash = @Commit5, commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('main...new-branch');",
+				Expected: []sql.Row{
+					{true, false, false, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"},
+					{false, true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
+					{false, false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
+				},
+			},
+			{
+				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('new-branch', '--not', 'main');",
+				Expected: []sql.Row{
+					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
+					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
+				},
+			},
+			{
+				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('new-branch', '^main');",
+				Expected: []sql.Row{
+					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
+					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
+				},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log('^new-branch', 'main');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+			},
+			{
+				Query:    "SELECT * from dolt_log('^main', 'main');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log('^main~', 'main');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log( 'main', '--not', 'main~');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit3, committer, email, message from dolt_log('^main', @Commit3);",
+				Expected: []sql.Row{{true, "John Doe", "johndoe@example.com", "inserting into t 3"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit3, committer, email, message from dolt_log(@Commit3, '--not', @Commit2);",
+				Expected: []sql.Row{{true, "John Doe", "johndoe@example.com", "inserting into t 3"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log('^new-branch', @Commit5);",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log(@Commit5, '--not', @Commit4);",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+			},
+		},
+	},
+	{
+		Name: "min parents, max parents, merges, show parents, decorate",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"call dolt_checkout('-b', 'branch1')",
+			"insert into t values(0,0);",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting 0,0');",
+
+			"call dolt_checkout('main')",
+			"call dolt_checkout('-b', 'branch2')",
+			"insert into t values(1,1);",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting 1,1');",
+
+			"call dolt_checkout('main')",
+			"call dolt_merge('branch1')", // fast-forward merge
+			"set @MergeCommit = '';",
+			"call dolt_merge_hash_out(@MergeCommit, 'branch2')", // actual merge with commit
+			"call dolt_tag('v1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('--merges');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('--min-parents', '2');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('main', '--min-parents', '2');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main');",
+				Expected: []sql.Row{{6}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--min-parents', '1');", // Should show everything except first commit
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--min-parents', '1', '--merges');", // --merges overrides --min-parents
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--max-parents', '1');", // Should show everything except the merge
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT commit_hash = @MergeCommit from dolt_log('main', '--min-parents', '2', '--max-parents', '2');",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main', '--max-parents', '0');",
+				Expected: []sql.Row{{"Initialize data repository"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--merges', '--max-parents', '1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('branch1..main', '--min-parents', '2');",
+				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('--min-parents', '5');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT commit_hash = @MergeCommit, SUBSTRING_INDEX(parents, ', ', 1) = @Commit2, SUBSTRING_INDEX(parents, ', ', -1) = @Commit3 from dolt_log('main', '--parents', '--merges');",
+				Expected: []sql.Row{{true, true, true}}, // shows two parents for merge commit
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit3, parents = @Commit1 from dolt_log('branch2', '--parents') LIMIT 1;", // shows one parent for non-merge commit
+				Expected: []sql.Row{{true, true}},
+			},
+			{
+				Query:    "SELECT commit_hash = @MergeCommit, SUBSTRING_INDEX(parents, ', ', 1) = @Commit2, SUBSTRING_INDEX(parents, ', ', -1) = @Commit3 from dolt_log('branch1..main', '--parents', '--merges') LIMIT 1;",
+				Expected: []sql.Row{{true, true, true}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit2, parents = @Commit1 from dolt_log('branch2..branch1', '--parents') LIMIT 1;",
+				Expected: []sql.Row{{true, true}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('--decorate', 'short') LIMIT 1;",
+				Expected: []sql.Row{{"HEAD -> main, tag: v1"}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('--decorate', 'full') LIMIT 1;",
+				Expected: []sql.Row{{"HEAD -> refs/heads/main, tag: refs/tags/v1"}},
+			},
+			{
+				Query:    "SELECT commit_hash = @Commit2, parents = @Commit1, refs from dolt_log('branch2..branch1', '--parents', '--decorate', 'short') LIMIT 1;",
+				Expected: []sql.Row{{true, true, "HEAD -> branch1"}},
+			},
+		},
+	},
+	{
+		Name: "decorate with multiple refs",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"call dolt_tag('v0');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting 1');",
+			"call dolt_branch('b1');",
+			"call dolt_tag('v1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message, refs from dolt_log('--decorate', 'short') LIMIT 2;",
+				Expected: []sql.Row{{"inserting 1", "HEAD -> main, b1, tag: v1"}, {"creating table t", "tag: v0"}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('--decorate', 'full') LIMIT 1;",
+				Expected: []sql.Row{{"HEAD -> refs/heads/main, refs/heads/b1, tag: refs/tags/v1"}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('b1', '--decorate', 'short') LIMIT 1;",
+				Expected: []sql.Row{{"HEAD -> b1, main, tag: v1"}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('v1', '--decorate', 'short') LIMIT 1;",
+				Expected: []sql.Row{{"HEAD, b1, main, tag: v1"}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('main~', '--decorate', 'short') LIMIT 1;",
+				Expected: []sql.Row{{"HEAD, tag: v0"}},
+			},
+			{
+				Query:    "SELECT refs from dolt_log('v0..b1', '--decorate', 'short');",
+				Expected: []sql.Row{{"HEAD -> b1, main, tag: v1"}},
+			},
+		},
+	},
+	{
+		Name: "multiple included and excluded revisions",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'main 1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'main 2');",
+
+			"call dolt_checkout('-b', 'featureA');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'featureA 1');",
+			"call dolt_checkout('-b', 'release/1.0');",
+			"insert into t values (100);",
+			"call dolt_commit('-am', 'release 1');",
+
+			"call dolt_checkout('main');",
+			"call dolt_checkout('-b', 'featureB');",
+			"insert into t values (20);",
+			"call dolt_commit('-am', 'featureB 1');",
+			"call dolt_tag('v1');",
+			"insert into t values (21);",
+			"call dolt_commit('-am', 'featureB 2');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('featureA', 'featureB', '--not', 'main') order by message;",
+				Expected: []sql.Row{{"featureA 1"}, {"featureB 1"}, {"featureB 2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('featureA', 'featureB', '--not', 'main', 'release/1.0') order by message;",
+				Expected: []sql.Row{{"featureB 1"}, {"featureB 2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('featureA', 'featureB', '--not', 'main', 'v1') order by message;",
+				Expected: []sql.Row{{"featureA 1"}, {"featureB 2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('featureA', '^main', 'featureB', '^release/1.0') order by message;",
+				Expected: []sql.Row{{"featureB 1"}, {"featureB 2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('featureB', '^main', '--not', 'v1');",
+				Expected: []sql.Row{{"featureB 2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('release/1.0', 'featureB', '--not', 'featureB~1', 'HEAD') order by message;",
+				Expected: []sql.Row{{"featureA 1"}, {"featureB 2"}, {"release 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('featureA', 'featureB', '--not', 'main', '--merges');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('featureA', 'featureB');",
+				Expected: []sql.Row{{7}},
+			},
+			{
+				Query:       "SELECT * from dolt_log('main..featureA', 'featureB');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main..featureA', '--not', 'v1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('^featureA', '--not', 'v1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --data-only and --schema-only",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"create table t2 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create t2');",
+			"call dolt_commit('--allow-empty', '-m', 'empty');",
+			"update t set c1 = 2 where pk = 1;",
+			"call dolt_commit('-am', 'update 1');",
+			"create table t3 (pk int primary key);",
+			"insert into t3 values (1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create t3 with data');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('main', '--data-only');",
+				Expected: []sql.Row{{"create t3 with data"}, {"update 1"}, {"insert 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('--schema-only');",
+				Expected: []sql.Row{{"create t3 with data"}, {"create t2"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main~3..main', '--data-only');",
+				Expected: []sql.Row{{"create t3 with data"}, {"update 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main~2', '--schema-only');",
+				Expected: []sql.Row{{"create t2"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main', '^main~4', '--schema-only');",
+				Expected: []sql.Row{{"create t3 with data"}, {"create t2"}},
+			},
+			{
+				Query:       "SELECT * from dolt_log('--data-only', '--schema-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --show-root",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"call dolt_commit('--allow-empty', '-m', 'empty');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT count(*) from dolt_log('--show-root') where root_hash regexp '^[0-9a-v]{32}$';",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				// the empty commit has the same root as its parent
+				Query:    "SELECT count(*), count(distinct root_hash) from dolt_log('main~2..main', '--show-root');",
+				Expected: []sql.Row{{2, 1}},
+			},
+			{
+				Query:    "SELECT count(*), count(distinct root_hash) from dolt_log('main~3..main', '--show-root');",
+				Expected: []sql.Row{{3, 2}},
+			},
+			{
+				Query:    "SELECT message, root_hash is not null from dolt_log('--show-root', '--data-only');",
+				Expected: []sql.Row{{"insert 1", true}},
+			},
+			{
+				Query:    "SELECT message, parents = '' from dolt_log('main~1', '--show-root', '--parents') where root_hash is not null limit 1;",
+				Expected: []sql.Row{{"insert 1", false}},
+			},
+			{
+				Query:       "SELECT * from dolt_log('--show-root', '--group-by-day');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --group-by-day",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1', '--date', '2022-08-06T10:00:00');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2', '--date', '2022-08-06T14:00:00');",
+			"call dolt_commit('--allow-empty', '-m', 'empty', '--date', '2022-08-07T12:00:00');",
+			"call dolt_checkout('-b', 'branch1');",
+			"insert into t values (3, 3);",
+			"call dolt_commit('-am', 'insert 3', '--date', '2022-08-09T12:00:00');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT date_format(date, '%Y-%m-%d'), commit_count from dolt_log('main~3..main', '--group-by-day');",
+				Expected: []sql.Row{
+					{"2022-08-07", int64(1)},
+					{"2022-08-06", int64(2)},
+				},
+			},
+			{
+				Query: "SELECT date_format(date, '%Y-%m-%d'), commit_count from dolt_log('main~3..branch1', '--group-by-day');",
+				Expected: []sql.Row{
+					{"2022-08-09", int64(1)},
+					{"2022-08-07", int64(1)},
+					{"2022-08-06", int64(2)},
+				},
+			},
+			{
+				Query: "SELECT date_format(date, '%Y-%m-%d'), commit_count from dolt_log('branch1', '^main', '--group-by-day');",
+				Expected: []sql.Row{
+					{"2022-08-09", int64(1)},
+				},
+			},
+			{
+				Query: "SELECT date_format(date, '%Y-%m-%d'), commit_count from dolt_log('main~3..main', '--group-by-day', '--data-only');",
+				Expected: []sql.Row{
+					{"2022-08-06", int64(2)},
+				},
+			},
+			{
+				Query:    "SELECT sum(commit_count) from dolt_log('--group-by-day');",
+				Expected: []sql.Row{{float64(6)}},
+			},
+			{
+				Query:       "SELECT * from dolt_log('--group-by-day', '--parents');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --contained-in",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'feature 1');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'feature 2');",
+			"call dolt_checkout('main');",
+			"call dolt_merge('feature~');",
+			"call dolt_checkout('-b', 'release/1.0');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT message, in_main from dolt_log('feature', '^main~', '--contained-in', 'main');",
+				Expected: []sql.Row{
+					{"feature 2", false},
+					{"feature 1", true},
+				},
+			},
+			{
+				Query: "SELECT message, in_main from dolt_log('feature', '--contained-in', 'main') where in_main = false;",
+				Expected: []sql.Row{
+					{"feature 2", false},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('feature', '--contained-in', 'main') where in_main;",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "SELECT message, `in_release/1.0` from dolt_log('feature', '--contained-in', 'release/1.0') limit 2;",
+				Expected: []sql.Row{{"feature 2", false}, {"feature 1", true}},
+			},
+			{
+				Query:    "SELECT message, in_feature from dolt_log('--contained-in', 'feature') limit 1;",
+				Expected: []sql.Row{{"feature 1", true}},
+			},
+			{
+				Query:       "SELECT * from dolt_log('feature', '--contained-in', 'nonexistent');",
+				ExpectedErr: sqle.ErrUnknownCommit,
+			},
+			{
+				Query:       "SELECT * from dolt_log('--contained-in', 'main', '--group-by-day');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --all and --simplify-by-decoration",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_tag('v1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'main 1');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'feature 1');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'feature 2');",
+			"call dolt_checkout('main');",
+			"insert into t values (4);",
+			"call dolt_commit('-am', 'main 2');",
+			"call dolt_tag('v2', 'HEAD~');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT count(*) from dolt_log();",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('--all');",
+				Expected: []sql.Row{{7}},
+			},
+			{
+				Query: "SELECT message from dolt_log('--all', '--simplify-by-decoration') order by message;",
+				Expected: []sql.Row{
+					{"create t"},
+					{"feature 2"},
+					{"main 1"},
+					{"main 2"},
+				},
+			},
+			{
+				Query: "SELECT message, refs from dolt_log('--all', '--simplify-by-decoration', '--decorate', 'short') order by message;",
+				Expected: []sql.Row{
+					{"create t", "tag: v1"},
+					{"feature 2", "feature"},
+					{"main 1", "tag: v2"},
+					{"main 2", "HEAD -> main"},
+				},
+			},
+			{
+				Query: "SELECT message from dolt_log('--simplify-by-decoration');",
+				Expected: []sql.Row{
+					{"main 2"},
+					{"main 1"},
+					{"create t"},
+				},
+			},
+			{
+				Query: "SELECT message from dolt_log('feature', '--simplify-by-decoration');",
+				Expected: []sql.Row{
+					{"feature 2"},
+					{"main 1"},
+					{"create t"},
+				},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--simplify-by-decoration');",
+				Expected: []sql.Row{{"feature 2"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --author",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'john 1', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'jane 1', '--author', 'Jane Roe <janeroe@example.com>');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'john 2', '--author', 'John Doe <johndoe@example.com>');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('feature', '--author', 'John Doe');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--author', 'john doe');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--author', 'ROE');",
+				Expected: []sql.Row{{"jane 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--author', 'johndoe@example.com');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('feature', '--author', 'example.com');",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('--author', 'John');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--not', 'main', '--author', 'doe', '--min-parents', '1');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --committer",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t', '--author', 'John Doe <johndoe@example.com>');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'john 1', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'jane 1', '--author', 'Jane Roe <janeroe@example.com>');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'john 2', '--author', 'John Doe <johndoe@example.com>');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('feature', '--committer', 'JOHN DOE');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--committer', 'john');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '^main', '--committer', 'janeroe@');",
+				Expected: []sql.Row{{"jane 1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--not', 'main', '--committer', 'example.com', '--author', 'doe');",
+				Expected: []sql.Row{{"john 2"}, {"john 1"}},
+			},
+			{
+				// a commit records a single name and email, so both filters must match it
+				Query:    "SELECT count(*) from dolt_log('feature', '--committer', 'jane', '--author', 'john');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main...feature', '--author', 'jane');",
+				Expected: []sql.Row{{"jane 1"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --since and --until",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t', '--date', '2022-08-06T12:00:00');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'one', '--date', '2022-08-06T12:00:01', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'two', '--date', '2022-08-06T12:00:02', '--author', 'Jane Roe <janeroe@example.com>');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'three', '--date', '2022-08-06T12:00:04', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (4);",
+			"call dolt_commit('-am', 'four', '--date', '2022-08-06T12:00:05');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('feature', '--since', '2022-08-06T12:00:01', '--until', '2022-08-06T12:00:04');",
+				Expected: []sql.Row{{"three"}, {"two"}, {"one"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--since', '2022-08-06T12:00:02');",
+				Expected: []sql.Row{{"four"}, {"three"}, {"two"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--since', '2022-08-06', '--until', '2022-08-06T12:00:01');",
+				Expected: []sql.Row{{"one"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main..feature', '--since', '2022-08-07');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--until', '2022-08-06T12:00:03');",
+				Expected: []sql.Row{{"two"}, {"one"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--not', 'main', '--since', '2022-08-06T12:00:02', '--until', '2022-08-06T12:00:04');",
+				Expected: []sql.Row{{"three"}, {"two"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--since', '2022-08-06T12:00:01', '--until', '2022-08-06T12:00:04', '--author', 'doe');",
+				Expected: []sql.Row{{"three"}, {"one"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--since', '2022-08-06T12:00:00Z', '--until', '2022-08-06T12:00:01Z');",
+				Expected: []sql.Row{{"one"}, {"create t"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --max-count",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'one');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'two', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'three');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('branch1', '--max-count', '2');",
+				Expected: []sql.Row{{"three"}, {"two"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('branch1', '-n', '1');",
+				Expected: []sql.Row{{"three"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('branch1', '--number', '1');",
+				Expected: []sql.Row{{"three"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('branch1', '--max-count', '0');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('--max-count', '100');",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..branch1', '--max-count', '2');",
+				Expected: []sql.Row{{"three"}, {"two"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('branch1', '--not', 'main', '--max-count', '5');",
+				Expected: []sql.Row{{"three"}, {"two"}, {"one"}},
+			},
+			{
+				// the limit applies to the commits that match the other options
+				Query:    "SELECT message from dolt_log('branch1', '--max-count', '1', '--author', 'john');",
+				Expected: []sql.Row{{"two"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('-n', '2', 'branch1', '--oneline');",
+				Expected: []sql.Row{{"three"}, {"two"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --abbrev",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'one');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT commit_hash = left(hashof('HEAD'), 8), length(commit_hash) from dolt_log('--abbrev', '8', '-n', '1');",
+				Expected: []sql.Row{{true, 8}},
+			},
+			{
+				Query:    "SELECT commit_hash = hashof('HEAD'), parents = left(hashof('HEAD~'), 4) from dolt_log('--oneline', '--abbrev', '4', '--parents', '-n', '1');",
+				Expected: []sql.Row{{false, true}},
+			},
+			{
+				Query:    "SELECT commit_hash = hashof('HEAD') from dolt_log('--abbrev', '32', '-n', '1');",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('--abbrev', '10') where length(commit_hash) = 10;",
+				Expected: []sql.Row{{4}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --grep",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'BugFix: first');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'add a feature', '--author', 'John Doe <johndoe@example.com>');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'another bugfix', '--author', 'John Doe <johndoe@example.com>');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('feature', '--grep', 'bugfix');",
+				Expected: []sql.Row{{"another bugfix"}, {"BugFix: first"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--grep', 'FEATURE');",
+				Expected: []sql.Row{{"add a feature"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('feature', '--grep', 'no such message');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--grep', 'a');",
+				Expected: []sql.Row{{"another bugfix"}, {"add a feature"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--grep', 'bugfix', '--author', 'john');",
+				Expected: []sql.Row{{"another bugfix"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--not', 'main', '--grep', 'bugfix', '--max-count', '1');",
+				Expected: []sql.Row{{"another bugfix"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --tables",
+		SetUpScript: []string{
+			"create table t1 (pk int primary key);",
+			"create table t2 (pk int primary key);",
+			"call dolt_commit('-Am', 'create t1 and t2');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t1 values (1);",
+			"call dolt_commit('-am', 'insert into t1');",
+			"insert into t2 values (1);",
+			"call dolt_commit('-am', 'insert into t2');",
+			"create table t3 (pk int primary key);",
+			"call dolt_commit('-Am', 'create t3');",
+			"alter table t1 add column c int;",
+			"call dolt_commit('-am', 'alter t1');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('feature', '--tables', 't1');",
+				Expected: []sql.Row{{"alter t1"}, {"insert into t1"}, {"create t1 and t2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--tables', 't2,t3');",
+				Expected: []sql.Row{{"create t3"}, {"insert into t2"}, {"create t1 and t2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--tables', 'T3');",
+				Expected: []sql.Row{{"create t3"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--tables', 't1');",
+				Expected: []sql.Row{{"alter t1"}, {"insert into t1"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('feature', '--not', 'main', '--tables', 't2');",
+				Expected: []sql.Row{{"insert into t2"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main..feature', '--tables', 't1', '--schema-only');",
+				Expected: []sql.Row{{"alter t1"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--tables', 't3');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('feature', '--tables', 'no_such_table');",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --change-type",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"insert into t values (1, 1), (2, 2);",
+			"call dolt_commit('-am', 'insert rows');",
+			"update t set c = 10 where pk = 1;",
+			"call dolt_commit('-am', 'update row');",
+			"delete from t where pk = 2;",
+			"call dolt_commit('-am', 'delete row');",
+			"insert into t values (3, 3);",
+			"update t set c = 100 where pk = 1;",
+			"call dolt_commit('-am', 'insert and update rows');",
+			"create table u (pk int primary key);",
+			"insert into u values (1);",
+			"call dolt_commit('-Am', 'insert into u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message from dolt_log('main', '--tables', 't', '--change-type', 'added');",
+				Expected: []sql.Row{{"insert and update rows"}, {"insert rows"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main', '--tables', 't', '--change-type', 'modified');",
+				Expected: []sql.Row{{"insert and update rows"}, {"update row"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main', '--tables', 't', '--change-type', 'REMOVED');",
+				Expected: []sql.Row{{"delete row"}},
+			},
+			{
+				Query:    "SELECT message from dolt_log('main', '--tables', 't,u', '--change-type', 'added');",
+				Expected: []sql.Row{{"insert into u"}, {"insert and update rows"}, {"insert rows"}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_log('main', '--tables', 'u', '--change-type', 'removed');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--tables', 't', '--change-type', 'deleted');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_log('main', '--change-type', 'added');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "dolt_log with --cherry-mark and --cherry",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2');",
+			"update t set v = 10 where pk = 1;",
+			"call dolt_commit('-am', 'update 1');",
+			"call dolt_checkout('main');",
+			"call dolt_cherry_pick('feature~2');",
+			"insert into t values (3, 3);",
+			"call dolt_commit('-am', 'insert 3');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2 again');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT message, cherry from dolt_log('main...feature', '--cherry-mark') order by message;",
+				Expected: []sql.Row{{"insert 1", "="}, {"insert 1", "="}, {"insert 2", "="}, {"insert 2 again", "="}, {"insert 3", "+"}, {"update 1", "+"}},
+			},
+			{
+				Query:    "SELECT message, cherry from dolt_log('main...feature', '--cherry') order by message;",
+				Expected: []sql.Row{{"insert 1", "="}, {"insert 2", "="}, {"update 1", "+"}},
+			},
+			{
+				Query:    "SELECT message, cherry from dolt_log('feature...main', '--cherry') order by message;",
+				Expected: []sql.Row{{"insert 1", "="}, {"insert 2 again", "="}, {"insert 3", "+"}},
+			},
+			{
+				Query:    "SELECT message, cherry from dolt_log('main...feature', '--cherry', '--grep', 'update');",
+				Expected: []sql.Row{{"update 1", "+"}},
+			},
+			{
+				Query:          "SELECT cherry from dolt_log('main...feature');",
+				ExpectedErrStr: `column "cherry" could not be found in any table in scope`,
+			},
+		},
+	},
+	{
+		Name: "commit_order follows the commit graph when dates don't",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int);",
+			"call dolt_add('.');",
+			"call dolt_commit_hash_out(@Create, '-m', 'create t', '--date', '2022-01-01T12:00:00');",
+			"call dolt_checkout('-b', 'b1');",
+			"insert into t values (2, 2);",
+			"call dolt_commit_hash_out(@Insert2, '-am', 'insert 2', '--date', '2022-01-04T12:00:00');",
+			"update t set v = 20 where pk = 2;",
+			"call dolt_commit_hash_out(@Update2, '-am', 'update 2', '--date', '2022-01-02T12:00:00');",
+			"call dolt_checkout('main');",
+			"insert into t values (1, 1);",
+			"call dolt_commit_hash_out(@Insert1, '-am', 'insert 1', '--date', '2022-01-05T12:00:00');",
+			"call dolt_merge('b1', '--no-commit');",
+			"call dolt_commit_hash_out(@Merge, '-am', 'merge b1', '--date', '2022-01-03T12:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT commit_order FROM dolt_log('main') WHERE commit_hash = @Create;",
+				Expected: []sql.Row{{uint64(3)}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log('main') WHERE commit_order >= 3 ORDER BY date DESC;",
+				Expected: []sql.Row{{"insert 1"}, {"insert 2"}, {"merge b1"}, {"update 2"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log('main') WHERE commit_order >= 3 ORDER BY commit_order DESC, message;",
+				Expected: []sql.Row{{"merge b1"}, {"update 2"}, {"insert 1"}, {"insert 2"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log('main', '--parents') WHERE commit_order > 4 ORDER BY commit_order DESC;",
+				Expected: []sql.Row{{"merge b1"}, {"update 2"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log WHERE commit_order >= 3 ORDER BY commit_order DESC, message;",
+				Expected: []sql.Row{{"merge b1"}, {"update 2"}, {"insert 1"}, {"insert 2"}, {"create t"}},
+			},
+			{
+				Query:    "SELECT message, commit_order FROM dolt_log WHERE commit_hash = @Update2;",
+				Expected: []sql.Row{{"update 2", uint64(5)}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_log WHERE commit_order > (SELECT commit_order FROM dolt_log WHERE commit_hash = @Insert2);",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+}
+
+var LargeJsonObjectScriptTests = []queries.ScriptTest{
+	{
+		Name: "JSON under max length limit",
+		SetUpScript: []string{
+			"create table t (j JSON)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    `insert into t set j= concat('[', repeat('"word",', 10000000), '"word"]')`,
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1}}},
+			},
+		},
+	},
+	{
+		Name: "JSON over max length limit",
+		SetUpScript: []string{
+			"create table t (j JSON)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       `insert into t set j= concat('[', repeat('"word",', 50000000), '"word"]')`,
+				ExpectedErr: types.ErrLengthTooLarge,
+			},
+		},
+	},
+}
+
+var DoltTagTestScripts = []queries.ScriptTest{
+	{
+		Name: "dolt-tag: SQL create tags",
+		SetUpScript: []string{
+			"CREATE TABLE test(pk int primary key);",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0),(1),(2);",
+			"CALL DOLT_COMMIT('-am','created table test')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_TAG('v1', 'HEAD')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT tag_name, IF(CHAR_LENGTH(tag_hash) < 0, NULL, 'not null'), tagger, email, IF(date IS NULL, NULL, 'not null'), message from dolt_tags",
+				Expected: []sql.Row{{"v1", "not null", "billy bob", "bigbillieb@fake.horse", "not null", ""}},
+			},
+			{
+				Query:    "CALL DOLT_TAG('v2', '-m', 'create tag v2')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT tag_name, message from dolt_tags",
+				Expected: []sql.Row{{"v1", ""}, {"v2", "create tag v2"}},
+			},
+		},
+	},
+	{
+		Name: "dolt-tag: SQL delete tags",
+		SetUpScript: []string{
+			"CREATE TABLE test(pk int primary key);",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0),(1),(2);",
+			"CALL DOLT_COMMIT('-am','created table test')",
+			"CALL DOLT_TAG('v1', '-m', 'create tag v1')",
+			"CALL DOLT_TAG('v2', '-m', 'create tag v2')",
+			"CALL DOLT_TAG('v3', '-m', 'create tag v3')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT tag_name, message from dolt_tags",
+				Expected: []sql.Row{{"v1", "create tag v1"}, {"v2", "create tag v2"}, {"v3", "create tag v3"}},
+			},
+			{
+				Query:    "CALL DOLT_TAG('-d','v1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT tag_name, message from dolt_tags",
+				Expected: []sql.Row{{"v2", "create tag v2"}, {"v3", "create tag v3"}},
+			},
+			{
+				Query:    "CALL DOLT_TAG('-d','v2','v3')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT tag_name, message from dolt_tags",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "dolt_tags creator and created_at",
+		SetUpScript: []string{
+			"create table tag_meta_t (pk int primary key);",
+			"call dolt_commit('-Am', 'create table');",
+			"call dolt_tag('t1');",
+			"insert into tag_meta_t values (1);",
+			"call dolt_commit('-am', 'insert', '--tag', 't2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select tag_name, creator, created_at is not null from dolt_tags order by tag_name;",
+				Expected: []sql.Row{
+					{"t1", "root", true},
+					{"t2", "root", true},
+				},
+			},
+			{
+				Query:    "call dolt_tag('-d', 't1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select tag_name from dolt_tags order by tag_name;",
+				Expected: []sql.Row{{"t2"}},
+			},
+		},
+	},
+	{
+		Name: "dolt-tag: SQL use a tag as a ref for merge",
+		SetUpScript: []string{
+			"CREATE TABLE test(pk int primary key);",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0),(1),(2);",
+			"CALL DOLT_COMMIT('-am','created table test')",
+			"DELETE FROM test WHERE pk = 0",
+			"INSERT INTO test VALUES (3)",
+			"CALL DOLT_COMMIT('-am','made changes')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_TAG('v1','HEAD')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('-b','other','HEAD^')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "INSERT INTO test VALUES (8), (9)",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 2}}},
+			},
+			{
+				Query:            "CALL DOLT_COMMIT('-am','made changes in other')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "CALL DOLT_MERGE('v1')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT * FROM test",
+				Expected: []sql.Row{{1}, {2}, {3}, {8}, {9}},
+			},
+		},
+	},
+	{
+		Name: "CALL DOLT_COMMIT('--tag') tags the new commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key);",
+			"CALL DOLT_COMMIT('-Am', 'add table t');",
+			"CALL DOLT_TAG('v1.1');",
+			"INSERT INTO t VALUES (1);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "CALL DOLT_COMMIT('-am', 'Release 1.2', '--tag', 'v1.2');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT tag_name, message FROM dolt_tags WHERE tag_hash = hashof('HEAD');",
+				Expected: []sql.Row{{"v1.2", ""}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log WHERE commit_hash = hashof('v1.2');",
+				Expected: []sql.Row{{"Release 1.2"}},
+			},
+			{
+				Query:    "INSERT INTO t VALUES (2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "CALL DOLT_COMMIT('-am', 'Release 1.1 again', '--tag', 'v1.1');",
+				ExpectedErrStr: "tag 'v1.1' already exists",
+			},
+			{
+				Query:          "CALL DOLT_COMMIT('-am', 'bad tag', '--tag', 'v1..3');",
+				ExpectedErrStr: "not a valid user tag name",
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"Release 1.2"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_tags;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+}
+
+var DoltRemoteTestScripts = []queries.ScriptTest{
+	{
+		Name: "dolt-remote: SQL add remotes",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_REMOTE('add','origin','file://../test')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT name, IF(CHAR_LENGTH(url) < 0, NULL, 'not null'), fetch_specs, params FROM DOLT_REMOTES",
+				Expected: []sql.Row{{"origin", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin/*"]`), types.MustJSON(`{}`)}},
 			},
 			{
-				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('main..new-branch');",
-				Expected: []sql.Row{
-					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
-					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
-				},
+				Query:          "CALL DOLT_REMOTE()",
+				ExpectedErrStr: "error: invalid argument, use 'dolt_remotes' system table to list remotes",
 			},
 			{
-				Query: "SELECT commit_hash = @Commit5, commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('main...new-branch');",
-				Expected: []sql.Row{
-					{true, false, false, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"},
-					{false, true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
-					{false, false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
-				},
+				Query:          "CALL DOLT_REMOTE('origin')",
+				ExpectedErrStr: "error: invalid argument",
 			},
 			{
-				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('new-branch', '--not', 'main');",
-				Expected: []sql.Row{
-					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
-					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
-				},
+				Query:          "INSERT INTO dolt_remotes (name, url) VALUES ('origin', 'file://../test')",
+				ExpectedErrStr: "the dolt_remotes table is read-only; use the dolt_remote stored procedure to edit remotes",
 			},
+		},
+	},
+	{
+		Name: "dolt-remote: SQL remove remotes",
+		SetUpScript: []string{
+			"CALL DOLT_REMOTE('add','origin1','file://.')",
+			"CALL DOLT_REMOTE('add','origin2','aws://[dynamo_db_table:s3_bucket]/repo_name')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT commit_hash = @Commit4, commit_hash = @Commit3, committer, email, message from dolt_log('new-branch', '^main');",
+				Query: "SELECT name, IF(CHAR_LENGTH(url) < 0, NULL, 'not null'), fetch_specs, params FROM DOLT_REMOTES",
 				Expected: []sql.Row{
-					{true, false, "John Doe", "johndoe@example.com", "inserting into t 4"},
-					{false, true, "John Doe", "johndoe@example.com", "inserting into t 3"},
-				},
+					{"origin1", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin1/*"]`), types.MustJSON(`{}`)},
+					{"origin2", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin2/*"]`), types.MustJSON(`{}`)}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log('^new-branch', 'main');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+				Query:    "CALL DOLT_REMOTE('remove','origin2')",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT * from dolt_log('^main', 'main');",
-				Expected: []sql.Row{},
+				Query:    "SELECT name, IF(CHAR_LENGTH(url) < 0, NULL, 'not null'), fetch_specs, params FROM DOLT_REMOTES",
+				Expected: []sql.Row{{"origin1", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin1/*"]`), types.MustJSON(`{}`)}},
 			},
+			// 'origin1' remote must exist in order this error to be returned; otherwise, no error from EOF
 			{
-				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log('^main~', 'main');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+				Query:          "DELETE FROM dolt_remotes WHERE name = 'origin1'",
+				ExpectedErrStr: "the dolt_remotes table is read-only; use the dolt_remote stored procedure to edit remotes",
 			},
+		},
+	},
+	{
+		Name: "dolt-remote: multi-repo test",
+		SetUpScript: []string{
+			"create database one",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log( 'main', '--not', 'main~');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+				Query:    "use one;",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit3, committer, email, message from dolt_log('^main', @Commit3);",
-				Expected: []sql.Row{{true, "John Doe", "johndoe@example.com", "inserting into t 3"}},
+				Query:    "CALL DOLT_REMOTE('add','test01','file:///foo');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit3, committer, email, message from dolt_log(@Commit3, '--not', @Commit2);",
-				Expected: []sql.Row{{true, "John Doe", "johndoe@example.com", "inserting into t 3"}},
+				Query:    "select count(*) from dolt_remotes where name='test01';",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log('^new-branch', @Commit5);",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+				Query:    "use mydb;",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit5, committer, email, message from dolt_log(@Commit5, '--not', @Commit4);",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "inserting into t 5"}},
+				Query:    "select count(*) from dolt_remotes where name='test01';",
+				Expected: []sql.Row{{0}},
 			},
 		},
 	},
-	//TODO: figure out how we were returning a commit from the function
-	/*{
-		Name: "min parents, merges, show parents, decorate",
-		SetUpScript: []string{
-			"create table t (pk int primary key, c1 int);",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"call dolt_checkout('-b', 'branch1')",
-			"insert into t values(0,0);",
-			"set @Commit2 = '';",
-		"call dolt_commit_hash_out(@Commit2, '-am', 'inserting 0,0');",
-
-			"call dolt_checkout('main')",
-			"call dolt_checkout('-b', 'branch2')",
-			"insert into t values(1,1);",
-			"set @Commit3 = '';",
-		"call dolt_commit_hash_out(@Commit3, '-am', 'inserting 1,1');",
-
-			"call dolt_checkout('main')",
-			"call dolt_merge('branch1')",               // fast-forward merge
-			"set @MergeCommit = dolt_merge('branch2')", // actual merge with commit
-			"call dolt_tag('v1')",
-		},
+	{
+		Name: "dolt-remote: add with --param",
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('--merges');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+				Query:    "CALL DOLT_REMOTE('add', 'origin', 'oss://bucket/repo_name', '--param', 'creds-profile=dev', 'oss-creds-file=/creds.json')",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('--min-parents', '2');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+				Query:    "SELECT name, params FROM dolt_remotes",
+				Expected: []sql.Row{{"origin", types.MustJSON(`{"oss-creds-profile": "dev", "oss-creds-file": "/creds.json"}`)}},
 			},
 			{
-				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('main', '--min-parents', '2');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+				Query:          "CALL DOLT_REMOTE('add', 'origin2', 'oss://bucket/repo_name', '--param', 'region=us-east-1')",
+				ExpectedErrStr: "unknown param 'region' for oss remotes, valid params are oss-creds-file, oss-creds-profile",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main');",
-				Expected: []sql.Row{{6}},
+				Query:          "CALL DOLT_REMOTE('add', 'origin2', 'oss://bucket/repo_name', '--param', 'creds-profile')",
+				ExpectedErrStr: "invalid remote param 'creds-profile', expected key=value",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main', '--min-parents', '1');", // Should show everything except first commit
-				Expected: []sql.Row{{5}},
+				Query:          "CALL DOLT_REMOTE('add', 'origin2', 'file:///tmp/repo', '--param', 'region=us-east-1')",
+				ExpectedErrStr: "--param is only valid for aws and oss remotes",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('main', '--min-parents', '1', '--merges');", // --merges overrides --min-parents
-				Expected: []sql.Row{{1}},
+				Query:    "select count(*) from dolt_remotes where name = 'origin2';",
+				Expected: []sql.Row{{0}},
 			},
+		},
+	},
+	{
+		Name: "dolt-remote: add with --verify",
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT commit_hash = @MergeCommit, committer, email, message from dolt_log('branch1..main', '--min-parents', '2');",
-				Expected: []sql.Row{{true, "billy bob", "bigbillieb@fake.horse", "Merge branch 'branch2' into main"}},
+				Query:          "CALL DOLT_REMOTE('add', 'origin', 'file:///dolt-remote-verify/does/not/exist', '--verify')",
+				ExpectedErrStr: "failed to verify remote 'origin': stat /dolt-remote-verify/does/not/exist: no such file or directory",
 			},
 			{
-				Query:    "SELECT count(*) from dolt_log('--min-parents', '5');",
+				Query:    "select count(*) from dolt_remotes where name='origin';",
 				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT commit_hash = @MergeCommit, SUBSTRING_INDEX(parents, ', ', 1) = @Commit2, SUBSTRING_INDEX(parents, ', ', -1) = @Commit3 from dolt_log('main', '--parents', '--merges');",
-				Expected: []sql.Row{{true, true, true}}, // shows two parents for merge commit
+				// without --verify, remotes are added without contacting them
+				Query:    "CALL DOLT_REMOTE('add', 'origin', 'file:///dolt-remote-verify/does/not/exist')",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit3, parents = @Commit1 from dolt_log('branch2', '--parents') LIMIT 1;", // shows one parent for non-merge commit
-				Expected: []sql.Row{{true, true}},
+				Query:    "select count(*) from dolt_remotes where name='origin';",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+}
+
+var DoltStashTestScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_stash and dolt_stash_pop",
+		SetUpScript: []string{
+			"CREATE TABLE t(pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'created table t');",
+			"UPDATE t SET c = 2 WHERE pk = 1;",
+			"INSERT INTO t VALUES (2, 2);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_STASH();",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT commit_hash = @MergeCommit, SUBSTRING_INDEX(parents, ', ', 1) = @Commit2, SUBSTRING_INDEX(parents, ', ', -1) = @Commit3 from dolt_log('branch1..main', '--parents', '--merges') LIMIT 1;",
-				Expected: []sql.Row{{true, true, true}},
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 1}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit2, parents = @Commit1 from dolt_log('branch2..branch1', '--parents') LIMIT 1;",
-				Expected: []sql.Row{{true, true}},
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT refs from dolt_log('--decorate', 'short') LIMIT 1;",
-				Expected: []sql.Row{{"HEAD -> main, tag: v1"}},
+				Query:    "SELECT name, branch, commit_hash = hashof('HEAD'), commit_message FROM dolt_stashes;",
+				Expected: []sql.Row{{"stash@{0}", "main", true, "created table t"}},
 			},
 			{
-				Query:    "SELECT refs from dolt_log('--decorate', 'full') LIMIT 1;",
-				Expected: []sql.Row{{"HEAD -> refs/heads/main, tag: refs/tags/v1"}},
+				Query:    "CALL DOLT_STASH_POP();",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT commit_hash = @Commit2, parents = @Commit1, refs from dolt_log('branch2..branch1', '--parents', '--decorate', 'short') LIMIT 1;",
-				Expected: []sql.Row{{true, true, "HEAD -> branch1"}},
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 2}, {2, 2}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_stashes;",
+				Expected: []sql.Row{{0}},
 			},
 		},
-	},*/
-}
-
-var LargeJsonObjectScriptTests = []queries.ScriptTest{
+	},
 	{
-		Name: "JSON under max length limit",
+		Name: "dolt_stash with untracked tables",
 		SetUpScript: []string{
-			"create table t (j JSON)",
+			"CREATE TABLE t(pk int primary key);",
+			"CALL DOLT_COMMIT('-Am', 'created table t');",
+			"CREATE TABLE u(pk int primary key);",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    `insert into t set j= concat('[', repeat('"word",', 10000000), '"word"]')`,
-				Expected: []sql.Row{{types.OkResult{RowsAffected: 1}}},
+				Query:          "CALL DOLT_STASH();",
+				ExpectedErrStr: "no local changes to save",
+			},
+			{
+				Query:    "CALL DOLT_STASH('--include-untracked');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CALL DOLT_STASH_POP('stash@{0}');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT table_name, staged, status FROM dolt_status;",
+				Expected: []sql.Row{{"u", false, "new table"}},
+			},
+			{
+				Query:          "CALL DOLT_STASH_POP();",
+				ExpectedErrStr: "No stash entries found.",
 			},
 		},
 	},
 	{
-		Name: "JSON over max length limit",
+		Name: "dolt_stash_pop with conflicts",
 		SetUpScript: []string{
-			"create table t (j JSON)",
+			"CREATE TABLE t(pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'created table t');",
+			"UPDATE t SET c = 2 WHERE pk = 1;",
+			"CALL DOLT_STASH();",
+			"UPDATE t SET c = 3 WHERE pk = 1;",
+			"SET dolt_allow_commit_conflicts = on;",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       `insert into t set j= concat('[', repeat('"word",', 50000000), '"word"]')`,
-				ExpectedErr: types.ErrLengthTooLarge,
+				Query:    "CALL DOLT_STASH_POP();",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT `table`, num_conflicts FROM dolt_conflicts;",
+				Expected: []sql.Row{{"t", uint64(1)}},
+			},
+			{
+				Query:    "SELECT base_c, our_c, their_c FROM dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 3, 2}},
+			},
+			{
+				Query:    "SELECT name FROM dolt_stashes;",
+				Expected: []sql.Row{{"stash@{0}"}},
 			},
 		},
 	},
 }
 
-var DoltTagTestScripts = []queries.ScriptTest{
+var DoltRebaseTestScripts = []queries.ScriptTest{
 	{
-		Name: "dolt-tag: SQL create tags",
+		Name: "dolt_rebase replays commits on top of upstream",
 		SetUpScript: []string{
-			"CREATE TABLE test(pk int primary key);",
-			"CALL DOLT_ADD('.')",
-			"INSERT INTO test VALUES (0),(1),(2);",
-			"CALL DOLT_COMMIT('-am','created table test')",
+			"CREATE TABLE t(pk int primary key, c int);",
+			"CALL DOLT_COMMIT('-Am', 'create table t');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-am', 'insert 1 on feature');",
+			"INSERT INTO t VALUES (3, 3);",
+			"CALL DOLT_COMMIT('-am', 'insert 3 on feature');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL DOLT_COMMIT('-am', 'insert 2 on main');",
+			"CALL DOLT_CHECKOUT('feature');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "CALL DOLT_TAG('v1', 'HEAD')",
+				Query:    "CALL DOLT_REBASE('main');",
 				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT tag_name, IF(CHAR_LENGTH(tag_hash) < 0, NULL, 'not null'), tagger, email, IF(date IS NULL, NULL, 'not null'), message from dolt_tags",
-				Expected: []sql.Row{{"v1", "not null", "billy bob", "bigbillieb@fake.horse", "not null", ""}},
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
 			},
 			{
-				Query:    "CALL DOLT_TAG('v2', '-m', 'create tag v2')",
+				Query:    "SELECT message FROM dolt_log LIMIT 4;",
+				Expected: []sql.Row{{"insert 3 on feature"}, {"insert 1 on feature"}, {"insert 2 on main"}, {"create table t"}},
+			},
+			{
+				Query:    "SELECT hashof('HEAD~2') = hashof('main');",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CALL DOLT_REBASE('main');",
 				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT tag_name, message from dolt_tags",
-				Expected: []sql.Row{{"v1", ""}, {"v2", "create tag v2"}},
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"insert 3 on feature"}},
+			},
+			{
+				Query:          "CALL DOLT_REBASE('--continue');",
+				ExpectedErrStr: "no rebase in progress",
 			},
 		},
 	},
 	{
-		Name: "dolt-tag: SQL delete tags",
+		Name: "dolt_rebase errors",
 		SetUpScript: []string{
-			"CREATE TABLE test(pk int primary key);",
-			"CALL DOLT_ADD('.')",
-			"INSERT INTO test VALUES (0),(1),(2);",
-			"CALL DOLT_COMMIT('-am','created table test')",
-			"CALL DOLT_TAG('v1', '-m', 'create tag v1')",
-			"CALL DOLT_TAG('v2', '-m', 'create tag v2')",
-			"CALL DOLT_TAG('v3', '-m', 'create tag v3')",
+			"CREATE TABLE t(pk int primary key, c int);",
+			"CALL DOLT_COMMIT('-Am', 'create table t');",
+			"CALL DOLT_BRANCH('other');",
+			"INSERT INTO t VALUES (1, 1);",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT tag_name, message from dolt_tags",
-				Expected: []sql.Row{{"v1", "create tag v1"}, {"v2", "create tag v2"}, {"v3", "create tag v3"}},
-			},
-			{
-				Query:    "CALL DOLT_TAG('-d','v1')",
-				Expected: []sql.Row{{0}},
+				Query:          "CALL DOLT_REBASE('other');",
+				ExpectedErrStr: "cannot rebase with uncommitted changes",
 			},
 			{
-				Query:    "SELECT tag_name, message from dolt_tags",
-				Expected: []sql.Row{{"v2", "create tag v2"}, {"v3", "create tag v3"}},
+				Query:          "CALL DOLT_REBASE();",
+				ExpectedErrStr: "error: specify the branch or commit to rebase onto",
 			},
 			{
-				Query:    "CALL DOLT_TAG('-d','v2','v3')",
-				Expected: []sql.Row{{0}},
+				Query:          "CALL DOLT_REBASE('--abort', '--continue');",
+				ExpectedErrStr: "error: --abort and --continue are mutually exclusive options.",
 			},
 			{
-				Query:    "SELECT tag_name, message from dolt_tags",
-				Expected: []sql.Row{},
+				Query:          "CALL DOLT_REBASE('--abort');",
+				ExpectedErrStr: "no rebase in progress",
 			},
 		},
 	},
 	{
-		Name: "dolt-tag: SQL use a tag as a ref for merge",
+		Name: "dolt_rebase stops with conflicts and continues",
 		SetUpScript: []string{
-			"CREATE TABLE test(pk int primary key);",
-			"CALL DOLT_ADD('.')",
-			"INSERT INTO test VALUES (0),(1),(2);",
-			"CALL DOLT_COMMIT('-am','created table test')",
-			"DELETE FROM test WHERE pk = 0",
-			"INSERT INTO test VALUES (3)",
-			"CALL DOLT_COMMIT('-am','made changes')",
+			"CREATE TABLE t(pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'create table t');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"UPDATE t SET c = 10 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update 1 on feature');",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL DOLT_COMMIT('-am', 'insert 2 on feature');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET c = 100 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update 1 on main');",
+			"CALL DOLT_CHECKOUT('feature');",
+			"SET dolt_allow_commit_conflicts = on;",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "CALL DOLT_TAG('v1','HEAD')",
-				Expected: []sql.Row{{0}},
+				Query:    "CALL DOLT_REBASE('main');",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:    "CALL DOLT_CHECKOUT('-b','other','HEAD^')",
+				Query:    "SELECT hashof('HEAD') = hashof('main');",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT base_c, our_c, their_c FROM dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 100, 10}},
+			},
+			{
+				Query:          "CALL DOLT_REBASE('--continue');",
+				ExpectedErrStr: "error: cannot continue the rebase with unresolved conflicts in table {'t'}",
+			},
+			{
+				Query:          "CALL DOLT_REBASE('main');",
+				ExpectedErrStr: "a rebase is already in progress, use --continue or --abort",
+			},
+			{
+				Query:    "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 't');",
 				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "INSERT INTO test VALUES (8), (9)",
-				Expected: []sql.Row{{types.OkResult{RowsAffected: 2}}},
+				Query:    "CALL DOLT_REBASE('--continue');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:            "CALL DOLT_COMMIT('-am','made changes in other')",
-				SkipResultsCheck: true,
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 10}, {2, 2}},
 			},
 			{
-				Query:    "CALL DOLT_MERGE('v1')",
-				Expected: []sql.Row{{0, 0}},
+				Query:    "SELECT message FROM dolt_log LIMIT 4;",
+				Expected: []sql.Row{{"insert 2 on feature"}, {"update 1 on feature"}, {"update 1 on main"}, {"create table t"}},
 			},
 			{
-				Query:    "SELECT * FROM test",
-				Expected: []sql.Row{{1}, {2}, {3}, {8}, {9}},
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
 			},
 		},
 	},
-}
-
-var DoltRemoteTestScripts = []queries.ScriptTest{
 	{
-		Name: "dolt-remote: SQL add remotes",
+		Name: "dolt_rebase --abort",
+		SetUpScript: []string{
+			"CREATE TABLE t(pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'create table t');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL DOLT_COMMIT('-am', 'insert 2 on feature');",
+			"UPDATE t SET c = 10 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update 1 on feature');",
+			"SET @OrigHead = hashof('HEAD');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET c = 100 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update 1 on main');",
+			"CALL DOLT_CHECKOUT('feature');",
+			"SET dolt_allow_commit_conflicts = on;",
+		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "CALL DOLT_REMOTE('add','origin','file://../test')",
-				Expected: []sql.Row{{0}},
+				Query:    "CALL DOLT_REBASE('main');",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:    "SELECT name, IF(CHAR_LENGTH(url) < 0, NULL, 'not null'), fetch_specs, params FROM DOLT_REMOTES",
-				Expected: []sql.Row{{"origin", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin/*"]`), types.MustJSON(`{}`)}},
+				Query:    "SELECT message FROM dolt_log LIMIT 2;",
+				Expected: []sql.Row{{"insert 2 on feature"}, {"update 1 on main"}},
 			},
 			{
-				Query:          "CALL DOLT_REMOTE()",
-				ExpectedErrStr: "error: invalid argument, use 'dolt_remotes' system table to list remotes",
+				Query:    "CALL DOLT_REBASE('--abort');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:          "CALL DOLT_REMOTE('origin')",
-				ExpectedErrStr: "error: invalid argument",
+				Query:    "SELECT hashof('HEAD') = @OrigHead;",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				Query:          "INSERT INTO dolt_remotes (name, url) VALUES ('origin', 'file://../test')",
-				ExpectedErrStr: "the dolt_remotes table is read-only; use the dolt_remote stored procedure to edit remotes",
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 10}, {2, 2}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_conflicts;",
+				Expected: []sql.Row{{0}},
 			},
 		},
 	},
 	{
-		Name: "dolt-remote: SQL remove remotes",
+		Name: "dolt_rebase aborts on conflicts that can't be committed",
 		SetUpScript: []string{
-			"CALL DOLT_REMOTE('add','origin1','file://.')",
-			"CALL DOLT_REMOTE('add','origin2','aws://[dynamo_db_table:s3_bucket]/repo_name')",
+			"CREATE TABLE t(pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'create table t');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"UPDATE t SET c = 10 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update 1 on feature');",
+			"SET @OrigHead = hashof('HEAD');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET c = 100 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update 1 on main');",
+			"CALL DOLT_CHECKOUT('feature');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT name, IF(CHAR_LENGTH(url) < 0, NULL, 'not null'), fetch_specs, params FROM DOLT_REMOTES",
-				Expected: []sql.Row{
-					{"origin1", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin1/*"]`), types.MustJSON(`{}`)},
-					{"origin2", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin2/*"]`), types.MustJSON(`{}`)}},
+				Query:          "CALL DOLT_REBASE('main');",
+				ExpectedErrStr: "conflicts in table {'t'}, the rebase was aborted. To resolve the conflicts of a rebase using the dolt_conflicts tables, set @@dolt_allow_commit_conflicts = 1",
 			},
 			{
-				Query:    "CALL DOLT_REMOTE('remove','origin2')",
-				Expected: []sql.Row{{0}},
+				Query:    "SELECT hashof('HEAD') = @OrigHead;",
+				Expected: []sql.Row{{true}},
 			},
 			{
-				Query:    "SELECT name, IF(CHAR_LENGTH(url) < 0, NULL, 'not null'), fetch_specs, params FROM DOLT_REMOTES",
-				Expected: []sql.Row{{"origin1", "not null", types.MustJSON(`["refs/heads/*:refs/remotes/origin1/*"]`), types.MustJSON(`{}`)}},
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 10}},
 			},
-			// 'origin1' remote must exist in order this error to be returned; otherwise, no error from EOF
 			{
-				Query:          "DELETE FROM dolt_remotes WHERE name = 'origin1'",
-				ExpectedErrStr: "the dolt_remotes table is read-only; use the dolt_remote stored procedure to edit remotes",
+				Query:          "CALL DOLT_REBASE('--continue');",
+				ExpectedErrStr: "no rebase in progress",
 			},
 		},
 	},
+}
+
+// DoltSystemTableCrossDatabaseScripts query the system tables of one database while another is the current database.
+// The two databases have different histories, so results read from the wrong database don't match.
+var DoltSystemTableCrossDatabaseScripts = []queries.ScriptTest{
 	{
-		Name: "dolt-remote: multi-repo test",
+		Name: "database qualified system tables",
 		SetUpScript: []string{
-			"create database one",
+			"create table t (pk int primary key, c int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'mydb: create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'mydb: insert row');",
+			"call dolt_branch('mybranch');",
+			"call dolt_tag('mytag');",
+			"call dolt_remote('add', 'myremote', 'file://../mydb-remote');",
+			"insert into t values (2, 2);",
+			"create database otherdb;",
+			"use otherdb;",
+			"create table t (pk int primary key, c int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'otherdb: create t');",
+			"insert into t values (10, 10), (20, 20);",
+			"call dolt_commit('-am', 'otherdb: insert rows');",
+			"update t set c = 21 where pk = 20;",
+			"call dolt_commit('-am', 'otherdb: update row');",
+			"set @otherHead = hashof('main');",
+			"call dolt_branch('otherbranch');",
+			"call dolt_tag('othertag');",
+			"call dolt_remote('add', 'otherremote', 'file://../otherdb-remote');",
+			"insert into t values (30, 30);",
+			"create table u (pk int primary key);",
+			"use mydb;",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "use one;",
-				Expected: []sql.Row{},
+				Query:    "select message from otherdb.dolt_log limit 1;",
+				Expected: []sql.Row{{"otherdb: update row"}},
 			},
 			{
-				Query:    "CALL DOLT_REMOTE('add','test01','file:///foo');",
-				Expected: []sql.Row{{0}},
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{"mydb: insert row"}},
 			},
 			{
-				Query:    "select count(*) from dolt_remotes where name='test01';",
-				Expected: []sql.Row{{1}},
+				Query:    "select name from otherdb.dolt_branches order by name;",
+				Expected: []sql.Row{{"main"}, {"otherbranch"}},
 			},
 			{
-				Query:    "use mydb;",
-				Expected: []sql.Row{},
+				Query:    "select name from dolt_branches order by name;",
+				Expected: []sql.Row{{"main"}, {"mybranch"}},
 			},
 			{
-				Query:    "select count(*) from dolt_remotes where name='test01';",
+				Query:    "select tag_name from otherdb.dolt_tags;",
+				Expected: []sql.Row{{"othertag"}},
+			},
+			{
+				Query:    "select name from otherdb.dolt_remotes;",
+				Expected: []sql.Row{{"otherremote"}},
+			},
+			{
+				Query:    "select name from dolt_remotes;",
+				Expected: []sql.Row{{"myremote"}},
+			},
+			{
+				Query:    "select table_name, staged, status from otherdb.dolt_status order by table_name;",
+				Expected: []sql.Row{{"t", false, "modified"}, {"u", false, "new table"}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status;",
+				Expected: []sql.Row{{"t", false, "modified"}},
+			},
+			{
+				Query:    "select table_name from otherdb.dolt_diff where commit_hash = 'WORKING' order by table_name;",
+				Expected: []sql.Row{{"t"}, {"u"}},
+			},
+			{
+				Query:    "select table_name, data_change from otherdb.dolt_diff where commit_hash = @otherHead;",
+				Expected: []sql.Row{{"t", true}},
+			},
+			{
+				Query:    "select pk, c from otherdb.dolt_history_t where commit_hash = @otherHead order by pk;",
+				Expected: []sql.Row{{10, 10}, {20, 21}},
+			},
+			{
+				Query:    "select count(*) from otherdb.dolt_history_t;",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select pk from otherdb.t as of 'WORKING' order by pk;",
+				Expected: []sql.Row{{10}, {20}, {30}},
+			},
+			{
+				Query:    "select pk from t as of 'WORKING' order by pk;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "insert into otherdb.dolt_ignore values ('generated_*', true);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select pattern from otherdb.dolt_ignore;",
+				Expected: []sql.Row{{"generated_*"}},
+			},
+			{
+				Query:    "select count(*) from dolt_ignore;",
 				Expected: []sql.Row{{0}},
 			},
 		},
@@ -3124,6 +6392,55 @@ var DoltAutoIncrementTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "merge abort",
+		SetUpScript: []string{
+			"create table t (a int primary key auto_increment, b int)",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'empty table')",
+			"call dolt_branch('branch1')",
+			"insert into t (b) values (1), (2)",
+			"call dolt_commit('-am', 'two values on main')",
+			"call dolt_checkout('branch1')",
+			"insert into t (b) values (3), (4)",
+			"call dolt_commit('-am', 'two values on branch1')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('branch1', '--no-commit')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "insert into t (b) values (5), (6)",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 2, InsertID: 5}}},
+			},
+			{
+				Query:    "call dolt_merge('--abort')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query: "select * from t order by a",
+				Expected: []sql.Row{
+					{1, 1},
+					{2, 2},
+				},
+			},
+			{
+				// highest value in any branch is 4, the values inserted during the merge are given out again
+				Query:    "insert into t (b) values (5)",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, InsertID: 5}}},
+			},
+			{
+				Query: "select * from t order by a",
+				Expected: []sql.Row{
+					{1, 1},
+					{2, 2},
+					{5, 5},
+				},
+			},
+		},
+	},
 }
 
 var BrokenAutoIncrementTests = []queries.ScriptTest{
@@ -3213,6 +6530,56 @@ var BrokenAutoIncrementTests = []queries.ScriptTest{
 }
 
 var DoltCommitTests = []queries.ScriptTest{
+	{
+		Name: "CALL DOLT_COMMIT with --author-date",
+		SetUpScript: []string{
+			"CALL DOLT_BRANCH('mirror');",
+			"USE `mydb/mirror`;",
+			"CREATE TABLE mirrored (pk int primary key);",
+			"CALL DOLT_COMMIT('-Am', 'create mirrored', '--date', '2022-08-06T12:00:00');",
+			"INSERT INTO mirrored VALUES (1);",
+			"CALL DOLT_COMMIT('-am', 'mirrored', '--date', '2022-08-06T12:00:00', '--author-date', '2022-08-01T09:30:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT message, date_format(date, '%Y-%m-%d %H:%i:%s'), date_format(author_date, '%Y-%m-%d %H:%i:%s') FROM dolt_log LIMIT 2;",
+				Expected: []sql.Row{
+					{"mirrored", "2022-08-06 12:00:00", "2022-08-01 09:30:00"},
+					{"create mirrored", "2022-08-06 12:00:00", "2022-08-06 12:00:00"},
+				},
+			},
+			{
+				Query:    "SELECT date_format(date, '%Y-%m-%d %H:%i:%s'), date_format(author_date, '%Y-%m-%d %H:%i:%s') FROM dolt_log('-n', '1');",
+				Expected: []sql.Row{{"2022-08-06 12:00:00", "2022-08-01 09:30:00"}},
+			},
+			{
+				// --amend keeps the author date of the amended commit
+				Query:            "CALL DOLT_COMMIT('--amend', '-m', 'mirrored again');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT message, date > author_date, date_format(author_date, '%Y-%m-%d %H:%i:%s') FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"mirrored again", true, "2022-08-01 09:30:00"}},
+			},
+			{
+				// --date sets both dates
+				Query:            "CALL DOLT_COMMIT('--amend', '-m', 'mirrored again', '--date', '2022-08-10T12:00:00');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT date_format(date, '%Y-%m-%d %H:%i:%s'), date_format(author_date, '%Y-%m-%d %H:%i:%s') FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"2022-08-10 12:00:00", "2022-08-10 12:00:00"}},
+			},
+			{
+				Query:          "CALL DOLT_COMMIT('--amend', '-m', 'mirrored again', '--author-date', 'yesterday');",
+				ExpectedErrStr: "error: 'yesterday' is not in a supported format.",
+			},
+			{
+				Query:    "USE mydb;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
 	{
 		Name: "CALL DOLT_COMMIT('-ALL') adds all tables (including new ones) to the commit.",
 		SetUpScript: []string{
@@ -3256,8 +6623,16 @@ var DoltCommitTests = []queries.ScriptTest{
 				Expected: []sql.Row{{"update table t"}},
 			},
 			{
-				Query:    "CALL DOLT_RESET('--hard');",
-				Expected: []sql.Row{{0}},
+				Query:    "SET @head = HASHOF('HEAD');",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:            "CALL DOLT_RESET('--hard');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT HASHOF('HEAD') = @head;",
+				Expected: []sql.Row{{true}},
 			},
 			{
 				Query:    "SELECT * from t;",
@@ -3273,8 +6648,16 @@ var DoltCommitTests = []queries.ScriptTest{
 				SkipResultsCheck: true,
 			},
 			{
-				Query:    "CALL DOLT_RESET('--hard');",
-				Expected: []sql.Row{{0}},
+				Query:    "SET @head = HASHOF('HEAD');",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:            "CALL DOLT_RESET('--hard');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT HASHOF('HEAD') = @head;",
+				Expected: []sql.Row{{true}},
 			},
 			{
 				Query:       "SELECT * from t;",
@@ -3300,8 +6683,16 @@ var DoltCommitTests = []queries.ScriptTest{
 				Expected: []sql.Row{{"add table 2"}},
 			},
 			{
-				Query:    "CALL DOLT_RESET('--hard');",
-				Expected: []sql.Row{{0}},
+				Query:    "SET @head = HASHOF('HEAD');",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:            "CALL DOLT_RESET('--hard');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT HASHOF('HEAD') = @head;",
+				Expected: []sql.Row{{true}},
 			},
 			{
 				Query:    "SELECT * from t2;",
@@ -3787,6 +7178,133 @@ var DoltIndexPrefixScripts = []queries.ScriptTest{
 	},
 }
 
+// DoltProcedureBranchScripts are tests of stored procedures with different definitions on different branches, which
+// must always be resolved from the root of the session's current branch
+var DoltProcedureBranchScripts = []queries.ScriptTest{
+	{
+		Name: "same-named procedures on different branches",
+		SetUpScript: []string{
+			"CREATE PROCEDURE p1() COMMENT 'on main' SELECT 'main';",
+			"CALL DOLT_COMMIT('-Am', 'main procedure');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"DROP PROCEDURE p1;",
+			"CREATE PROCEDURE p1() COMMENT 'on feature' SELECT 'feature';",
+			"CREATE PROCEDURE p2() SELECT 'feature only';",
+			"CALL DOLT_COMMIT('-Am', 'feature procedures');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL p1();",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "SELECT name FROM dolt_procedures ORDER BY name;",
+				Expected: []sql.Row{{"p1"}},
+			},
+			{
+				Query:    "SELECT routine_name, routine_definition FROM information_schema.routines WHERE routine_schema = 'mydb' ORDER BY routine_name;",
+				Expected: []sql.Row{{"p1", "SELECT 'main'"}},
+			},
+			{
+				Query:    "SHOW PROCEDURE STATUS WHERE Db = 'mydb';",
+				Expected: []sql.Row{{"mydb", "p1", "PROCEDURE", "", time.Unix(0, 0).UTC(), time.Unix(0, 0).UTC(), "DEFINER", "on main", "utf8mb4", "utf8mb4_0900_bin", "utf8mb4_0900_bin"}},
+			},
+			{
+				Query:          "CALL p2();",
+				ExpectedErrStr: "stored procedure \"p2\" does not exist",
+			},
+			{
+				Query:            "CALL DOLT_CHECKOUT('feature');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "CALL p1();",
+				Expected: []sql.Row{{"feature"}},
+			},
+			{
+				Query:    "CALL p2();",
+				Expected: []sql.Row{{"feature only"}},
+			},
+			{
+				Query:    "SELECT name FROM dolt_procedures ORDER BY name;",
+				Expected: []sql.Row{{"p1"}, {"p2"}},
+			},
+			{
+				Query:    "SELECT routine_name, routine_definition FROM information_schema.routines WHERE routine_schema = 'mydb' ORDER BY routine_name;",
+				Expected: []sql.Row{{"p1", "SELECT 'feature'"}, {"p2", "SELECT 'feature only'"}},
+			},
+			{
+				Query: "SHOW PROCEDURE STATUS WHERE Db = 'mydb';",
+				Expected: []sql.Row{
+					{"mydb", "p1", "PROCEDURE", "", time.Unix(0, 0).UTC(), time.Unix(0, 0).UTC(), "DEFINER", "on feature", "utf8mb4", "utf8mb4_0900_bin", "utf8mb4_0900_bin"},
+					{"mydb", "p2", "PROCEDURE", "", time.Unix(0, 0).UTC(), time.Unix(0, 0).UTC(), "DEFINER", "", "utf8mb4", "utf8mb4_0900_bin", "utf8mb4_0900_bin"},
+				},
+			},
+			{
+				Query:    "SHOW CREATE PROCEDURE p1;",
+				Expected: []sql.Row{{"p1", "", "CREATE PROCEDURE p1() COMMENT 'on feature' SELECT 'feature'", "utf8mb4", "utf8mb4_0900_bin", "utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "CALL `mydb/main`.p1();",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "DROP PROCEDURE p1;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "CALL p1();",
+				ExpectedErrStr: "stored procedure \"p1\" does not exist",
+			},
+			{
+				Query:    "CALL `mydb/main`.p1();",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:            "CALL DOLT_CHECKOUT('main');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "CALL p1();",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "SHOW CREATE PROCEDURE p1;",
+				Expected: []sql.Row{{"p1", "", "CREATE PROCEDURE p1() COMMENT 'on main' SELECT 'main'", "utf8mb4", "utf8mb4_0900_bin", "utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "SELECT routine_name FROM information_schema.routines WHERE routine_schema = 'mydb' ORDER BY routine_name;",
+				Expected: []sql.Row{{"p1"}},
+			},
+			{
+				Query:    "SHOW PROCEDURE STATUS LIKE 'p2';",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CALL `mydb/feature`.p2();",
+				Expected: []sql.Row{{"feature only"}},
+			},
+			{
+				Query:    "CALL p1() AS OF 'HEAD';",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "DROP PROCEDURE `mydb/feature`.p2;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "CALL `mydb/feature`.p2();",
+				ExpectedErrStr: "stored procedure \"p2\" does not exist",
+			},
+			{
+				Query:    "CALL p1();",
+				Expected: []sql.Row{{"main"}},
+			},
+		},
+	},
+}
+
 // DoltCallAsOf are tests of using CALL ... AS OF using commits
 var DoltCallAsOf = []queries.ScriptTest{
 	{
@@ -15,11 +15,16 @@
 package enginetest
 
 import (
+	"bytes"
+
 	"github.com/dolthub/go-mysql-server/enginetest/queries"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 var DiffSystemTableScriptTests = []queries.ScriptTest{
@@ -1386,711 +1391,705 @@ inner join t on to_pk = t.pk;`,
 			},
 		},
 	},
-}
-
-var DiffStatTableFunctionScriptTests = []queries.ScriptTest{
 	{
-		Name: "invalid arguments",
+		Name: "keys only",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"create table t (pk1 int, pk2 varchar(20), c1 varchar(20), c2 int, primary key (pk1, pk2));",
+			"create table keyless (c1 int, c2 varchar(20));",
+			"insert into t values (1, 'one', 'a', 1), (2, 'two', 'b', 2);",
+			"insert into keyless values (1, 'one'), (2, 'two');",
 			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+			"call dolt_commit('-am', 'creating tables');",
 
-			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+			"insert into t values (3, 'three', 'c', 3);",
+			"update t set c1 = 'z' where pk1 = 1;",
+			"delete from t where pk1 = 2;",
+			"insert into keyless values (3, 'three');",
+			"delete from keyless where c1 = 2;",
+			"call dolt_commit('-am', 'changing rows');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_diff_stat();",
-				ExpectedErr: sql.ErrInvalidArgumentNumber,
-			},
-			{
-				Query:       "SELECT * from dolt_diff_stat('t');",
-				ExpectedErr: sql.ErrInvalidArgumentNumber,
+				Query: "select * from dolt_diff('HEAD~', 'HEAD', 't', '--keys-only') order by coalesce(to_pk1, from_pk1);",
+				Expected: []sql.Row{
+					{1, "one", 1, "one", "modified"},
+					{nil, nil, 2, "two", "removed"},
+					{3, "three", nil, nil, "added"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat('t', @Commit1, @Commit2, 'extra');",
-				ExpectedErr: sql.ErrInvalidArgumentNumber,
+				Query: "select * from dolt_diff('HEAD~..HEAD', 't', '--keys-only') order by coalesce(to_pk1, from_pk1);",
+				Expected: []sql.Row{
+					{1, "one", 1, "one", "modified"},
+					{nil, nil, 2, "two", "removed"},
+					{3, "three", nil, nil, "added"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(null, null, null);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:       "select to_c1 from dolt_diff('HEAD~', 'HEAD', 't', '--keys-only');",
+				ExpectedErr: sql.ErrColumnNotFound,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(123, @Commit1, @Commit2);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:       "select to_commit from dolt_diff('HEAD~', 'HEAD', 't', '--keys-only');",
+				ExpectedErr: sql.ErrColumnNotFound,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat('t', 123, @Commit2);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query: "select diff_type, length(row_hash) from dolt_diff('HEAD~', 'HEAD', 'keyless', '--keys-only') order by diff_type;",
+				Expected: []sql.Row{
+					{"added", 32},
+					{"removed", 32},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat('t', @Commit1, 123);",
-				ExpectedErr: sql.ErrInvalidArgumentDetails,
+				Query:       "select to_c1 from dolt_diff('HEAD~', 'HEAD', 'keyless', '--keys-only');",
+				ExpectedErr: sql.ErrColumnNotFound,
 			},
 			{
-				Query:          "SELECT * from dolt_diff_stat('fake-branch', @Commit2, 't');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:    "select count(distinct row_hash) from dolt_diff('HEAD~', 'HEAD', 'keyless', '--keys-only');",
+				Expected: []sql.Row{{2}},
 			},
 			{
-				Query:          "SELECT * from dolt_diff_stat('fake-branch..main', 't');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 't', '--not-an-option');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
+		},
+	},
+	{
+		Name: "to only",
+		SetUpScript: []string{
+			"create table t (pk1 int, pk2 varchar(20), c1 varchar(20), c2 int, primary key (pk1, pk2));",
+			"create table keyless (c1 int, c2 varchar(20));",
+			"insert into t values (1, 'one', 'a', 1), (2, 'two', 'b', 2);",
+			"insert into keyless values (1, 'one'), (2, 'two');",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'creating tables');",
+
+			"insert into t values (3, 'three', 'c', 3);",
+			"update t set c1 = 'z' where pk1 = 1;",
+			"delete from t where pk1 = 2;",
+			"insert into keyless values (3, 'three');",
+			"delete from keyless where c1 = 2;",
+			"call dolt_commit('-am', 'changing rows');",
+
+			"alter table t add column c3 int;",
+			"update t set c3 = 10 where pk1 = 3;",
+			"call dolt_commit('-am', 'adding column c3');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "SELECT * from dolt_diff_stat(@Commit1, 'fake-branch', 't');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query: "select * from dolt_diff('HEAD~2', 'HEAD~', 't', '--to-only') order by pk1;",
+				Expected: []sql.Row{
+					{1, "one", "z", 1, "modified"},
+					{2, "two", nil, nil, "removed"},
+					{3, "three", "c", 3, "added"},
+				},
 			},
 			{
-				Query:          "SELECT * from dolt_diff_stat('main..fake-branch', 't');",
-				ExpectedErrStr: "branch not found: fake-branch",
+				Query: "select * from dolt_diff('HEAD~2..HEAD~', 't', '--to-only') order by pk1;",
+				Expected: []sql.Row{
+					{1, "one", "z", 1, "modified"},
+					{2, "two", nil, nil, "removed"},
+					{3, "three", "c", 3, "added"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 'doesnotexist');",
-				ExpectedErr: sql.ErrTableNotFound,
+				Query: "select * from dolt_diff('HEAD~2', 'HEAD', 't', '--to-only') order by pk1;",
+				Expected: []sql.Row{
+					{1, "one", "z", 1, nil, "modified"},
+					{2, "two", nil, nil, nil, "removed"},
+					{3, "three", "c", 3, 10, "added"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat('main^..main', 'doesnotexist');",
-				ExpectedErr: sql.ErrTableNotFound,
+				Query: "select pk1, c1, diff_type from dolt_diff('HEAD~2', 'HEAD~', 't', '--to-only') where diff_type != 'removed' order by pk1;",
+				Expected: []sql.Row{
+					{1, "z", "modified"},
+					{3, "c", "added"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(@Commit1, concat('fake', '-', 'branch'), 't');",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query:       "select to_c1 from dolt_diff('HEAD~2', 'HEAD~', 't', '--to-only');",
+				ExpectedErr: sql.ErrColumnNotFound,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(hashof('main'), @Commit2, 't');",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query:       "select from_commit from dolt_diff('HEAD~2', 'HEAD~', 't', '--to-only');",
+				ExpectedErr: sql.ErrColumnNotFound,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit2, LOWER('T'));",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query: "select * from dolt_diff('HEAD~2', 'HEAD~', 'keyless', '--to-only') order by c1;",
+				Expected: []sql.Row{
+					{2, "two", "removed"},
+					{3, "three", "added"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat('main..main~', LOWER('T'));",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+				Query:       "select * from dolt_diff('HEAD~2', 'HEAD~', 't', '--to-only', '--keys-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 		},
 	},
 	{
-		Name: "basic case with single table",
+		Name: "context rows",
 		SetUpScript: []string{
-			"set @Commit0 = HashOf('HEAD');",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '--allow-empty', '-m', 'creating table t');",
-
-			// create table t only
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'creating table t');",
-
-			// insert 1 row into t
-			"insert into t values(1, 'one', 'two');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting 1 into table t');",
-
-			// insert 2 rows into t and update two cells
-			"insert into t values(2, 'two', 'three'), (3, 'three', 'four');",
-			"update t set c1='uno', c2='dos' where pk=1;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting 2 into table t');",
+			"create table t (pk int primary key, c1 varchar(20));",
+			"create table keyless (c1 int);",
+			"insert into t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, 'e'), (6, 'f'), (7, 'g'), (8, 'h'), (9, 'i'), (10, 'j');",
+			"insert into keyless values (1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
 
-			// drop table t only
-			"drop table t;",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'drop table t');",
+			"update t set c1 = 'z' where pk = 5;",
+			"delete from t where pk = 8;",
+			"insert into t values (11, 'k');",
+			"insert into keyless values (2);",
+			"call dolt_commit('-am', 'changing rows');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				// table is added, no data diff, result is empty
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{},
+				Query: "select to_pk, to_c1, from_pk, from_c1, diff_type from dolt_diff('HEAD~', 'HEAD', 't', '--context', '1') order by coalesce(to_pk, from_pk);",
+				Expected: []sql.Row{
+					{4, "d", 4, "d", "context"},
+					{5, "z", 5, "e", "modified"},
+					{6, "f", 6, "f", "context"},
+					{7, "g", 7, "g", "context"},
+					{nil, nil, 8, "h", "removed"},
+					{9, "i", 9, "i", "context"},
+					{10, "j", 10, "j", "context"},
+					{11, "k", nil, nil, "added"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", 0, 1, 0, 0, 3, 0, 0, 0, 1, 0, 3}},
+				Query: "select coalesce(to_pk, from_pk), diff_type from dolt_diff('HEAD~', 'HEAD', 't', '--context', '2') order by coalesce(to_pk, from_pk);",
+				Expected: []sql.Row{
+					{3, "context"},
+					{4, "context"},
+					{5, "modified"},
+					{6, "context"},
+					{7, "context"},
+					{8, "removed"},
+					{9, "context"},
+					{10, "context"},
+					{11, "added"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", 0, 2, 0, 1, 6, 0, 2, 1, 3, 3, 9}},
+				Query:    "select count(*) from dolt_diff('HEAD~', 'HEAD', 't', '--context', '0');",
+				Expected: []sql.Row{{3}},
 			},
 			{
-				// change from and to commits
-				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit3, 't');",
-				Expected: []sql.Row{{"t", 0, 0, 2, 1, 0, 6, 2, 3, 1, 9, 3}},
+				Query:    "select count(*) from dolt_diff('HEAD~', 'HEAD', 't', '--context', '100');",
+				Expected: []sql.Row{{11}},
 			},
 			{
-				// table is dropped
-				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{"t", 0, 0, 3, 0, 0, 9, 0, 3, 0, 9, 0}},
+				Query:    "select from_commit, to_commit from dolt_diff('HEAD~', 'HEAD', 't', '--context', '1') where to_pk = 4;",
+				Expected: []sql.Row{{"HEAD~", "HEAD"}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit4, 't');",
-				Expected: []sql.Row{{"t", 0, 3, 0, 0, 9, 0, 0, 0, 3, 0, 9}},
+				Query: "select * from dolt_diff('HEAD~', 'HEAD', 't', '--context', '1', '--to-only') where pk < 6 order by pk;",
+				Expected: []sql.Row{
+					{4, "d", "context"},
+					{5, "z", "modified"},
+				},
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit5, 't');",
-				ExpectedErr: sql.ErrTableNotFound,
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 't', '--context', '-1');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query: `
-SELECT *
-from dolt_diff_stat(@Commit3, @Commit4, 't') 
-inner join t as of @Commit3 on rows_unmodified = t.pk;`,
-				Expected: []sql.Row{},
+				Query:          "select * from dolt_diff('HEAD~', 'HEAD', 'keyless', '--context', '1');",
+				ExpectedErrStr: "--context requires a table with a primary key, but keyless has none",
 			},
 		},
 	},
 	{
-		Name: "basic case with single keyless table",
+		Name: "upstream without an upstream branch",
 		SetUpScript: []string{
-			"set @Commit0 = HashOf('HEAD');",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '--allow-empty', '-m', 'creating table t');",
-
-			// create table t only
-			"create table t (id int, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'creating table t');",
-
-			// insert 1 row into t
-			"insert into t values(1, 'one', 'two');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting 1 into table t');",
-
-			// insert 2 rows into t and update two cells
-			"insert into t values(2, 'two', 'three'), (3, 'three', 'four');",
-			"update t set c1='uno', c2='dos' where id=1;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting 2 into table t');",
-
-			// drop table t only
-			"drop table t;",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'drop table t');",
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				// table is added, no data diff, result is empty
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", nil, 1, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
-			},
-			{
-				// TODO : (correct result is commented out)
-				//      update row for keyless table deletes the row and insert the new row
-				// 		this causes row added = 3 and row deleted = 1
-				Query: "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
-				//Expected:         []sql.Row{{"t", nil, 2, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
-				Expected: []sql.Row{{"t", nil, 3, 1, nil, nil, nil, nil, nil, nil, nil, nil}},
-			},
-			{
-				Query: "SELECT * from dolt_diff_stat(@Commit4, @Commit3, 't');",
-				//Expected:         []sql.Row{{"t", nil, 0, 2, nil, nil, nil, nil, nil, nil, nil, nil}},
-				Expected: []sql.Row{{"t", nil, 1, 3, nil, nil, nil, nil, nil, nil, nil, nil}},
+				Query:       "SELECT * from dolt_diff('--upstream', 't');",
+				ExpectedErr: sqle.ErrNoUpstreamForDiff,
 			},
 			{
-				// table is dropped
-				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{"t", nil, 0, 3, nil, nil, nil, nil, nil, nil, nil, nil}},
+				Query:       "SELECT * from dolt_diff('@{upstream}', 'HEAD', 't');",
+				ExpectedErr: sqle.ErrNoUpstreamForDiff,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit4, 't');",
-				Expected: []sql.Row{{"t", nil, 3, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
+				Query:       "SELECT * from dolt_diff('@{u}..HEAD', 't');",
+				ExpectedErr: sqle.ErrNoUpstreamForDiff,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit5, 't');",
-				ExpectedErr: sql.ErrTableNotFound,
+				Query:       "SELECT * from dolt_diff('HEAD~', 'HEAD', 't', '--upstream');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 		},
 	},
 	{
-		Name: "basic case with multiple tables",
+		Name: "diff against the parents of a conflicted merge",
 		SetUpScript: []string{
-			"set @Commit0 = HashOf('HEAD');",
-
-			// add table t with 1 row
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"insert into t values(1, 'one', 'two');",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting into table t');",
-
-			// add table t2 with 1 row
-			"create table t2 (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"insert into t2 values(100, 'hundred', 'hundert');",
-			"call dolt_add('.')",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into table t2');",
-
-			// changes on both tables
-			"insert into t values(2, 'two', 'three'), (3, 'three', 'four'), (4, 'four', 'five');",
-			"update t set c1='uno', c2='dos' where pk=1;",
-			"insert into t2 values(101, 'hundred one', 'one');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into table t');",
-
-			// changes on both tables
-			"delete from t where c2 = 'four';",
-			"update t2 set c2='zero' where pk=100;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into table t');",
-
-			// create keyless table
-			"create table keyless (id int);",
+			"create table merge_diff_t (pk int primary key, c1 varchar(20));",
+			"insert into merge_diff_t values (1, 'one'), (2, 'two');",
+			"call dolt_commit('-Am', 'creating table merge_diff_t');",
+			"call dolt_checkout('-b', 'merge_diff_theirs');",
+			"update merge_diff_t set c1 = 'theirs' where pk = 1;",
+			"insert into merge_diff_t values (3, 'three');",
+			"call dolt_commit('-am', 'changes on merge_diff_theirs');",
+			"call dolt_checkout('main');",
+			"update merge_diff_t set c1 = 'ours' where pk = 1;",
+			"insert into merge_diff_t values (4, 'four');",
+			"call dolt_commit('-am', 'changes on main');",
+			"set autocommit = 0;",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit0, @Commit1);",
-				Expected: []sql.Row{{"t", 0, 1, 0, 0, 3, 0, 0, 0, 1, 0, 3}},
+				Query:       "select * from dolt_diff('--merge-ours', 'merge_diff_t');",
+				ExpectedErr: sqle.ErrNoMergeForDiff,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2);",
-				Expected: []sql.Row{{"t2", 0, 1, 0, 0, 3, 0, 0, 0, 1, 0, 3}},
+				Query:    "call dolt_merge('merge_diff_theirs');",
+				Expected: []sql.Row{{0, 1}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3);",
-				Expected: []sql.Row{{"t", 0, 3, 0, 1, 9, 0, 2, 1, 4, 3, 12}, {"t2", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6}},
+				Query:    "select to_pk, to_c1, from_pk, from_c1, diff_type from dolt_diff('--merge-ours', 'merge_diff_t');",
+				Expected: []sql.Row{{3, "three", nil, nil, "added"}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4);",
-				Expected: []sql.Row{{"t", 3, 0, 1, 0, 0, 3, 0, 4, 3, 12, 9}, {"t2", 1, 0, 0, 1, 0, 0, 1, 2, 2, 6, 6}},
+				Query: "select to_pk, to_c1, from_pk, from_c1, diff_type from dolt_diff('--merge-theirs', 'merge_diff_t') order by coalesce(to_pk, from_pk);",
+				Expected: []sql.Row{
+					{1, "ours", 1, "theirs", "modified"},
+					{4, "four", nil, nil, "added"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit2);",
-				Expected: []sql.Row{{"t", 0, 0, 2, 1, 0, 6, 2, 3, 1, 9, 3}, {"t2", 0, 0, 1, 1, 0, 3, 1, 2, 1, 6, 3}},
+				Query:    "select count(*) from dolt_diff('--merge-theirs', 'merge_diff_t') where from_commit = hashof('merge_diff_theirs') and to_commit = 'WORKING';",
+				Expected: []sql.Row{{2}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit3, 'WORKING');",
-				Expected: []sql.Row{{"t", 3, 0, 1, 0, 0, 3, 0, 4, 3, 12, 9}, {"t2", 1, 0, 0, 1, 0, 0, 1, 2, 2, 6, 6}},
+				Query:    "select to_pk, diff_type from dolt_diff('merge_diff_t', '--merge-theirs', '--keys-only') order by to_pk;",
+				Expected: []sql.Row{{1, "modified"}, {4, "added"}},
 			},
-		},
-	},
-	{
-		Name: "WORKING and STAGED",
-		SetUpScript: []string{
-			"set @Commit0 = HashOf('HEAD');",
-
-			"create table t (pk int primary key, c1 text, c2 text);",
-			"call dolt_add('.')",
-			"insert into t values (1, 'one', 'two'), (2, 'three', 'four');",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting two rows into table t');",
-
-			"insert into t values (3, 'five', 'six');",
-			"delete from t where pk = 2",
-			"update t set c2 = '100' where pk = 1",
+			{
+				Query:       "select * from dolt_diff('--merge-ours', '--merge-theirs', 'merge_diff_t');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_diff('--merge-ours', '--upstream', 'merge_diff_t');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_diff('HEAD', 'WORKING', 'merge_diff_t', '--merge-ours');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:            "call dolt_merge('--abort');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:       "select * from dolt_diff('--merge-theirs', 'merge_diff_t');",
+				ExpectedErr: sqle.ErrNoMergeForDiff,
+			},
+		},
+	},
+	{
+		Name: "diff the working sets of two branches",
+		SetUpScript: []string{
+			"create table working_t (pk int primary key, c1 varchar(20));",
+			"insert into working_t values (1, 'one');",
+			"call dolt_commit('-Am', 'creating table working_t');",
+			"call dolt_branch('branch1');",
+			"insert into `mydb/branch1`.working_t values (2, 'branch1');",
+			"update `mydb/branch1`.working_t set c1 = 'uno' where pk = 1;",
+			"insert into working_t values (3, 'main');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, 'WORKING', 't')",
-				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
+				Query:    "select count(*) from dolt_diff('branch1', 'main', 'working_t');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('STAGED', 'WORKING', 't')",
-				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
+				Query: "select to_pk, to_c1, from_pk, from_c1, diff_type from dolt_diff('mydb/branch1', 'mydb/main', 'working_t', '--working') order by coalesce(to_pk, from_pk);",
+				Expected: []sql.Row{
+					{1, "one", 1, "uno", "modified"},
+					{nil, nil, 2, "branch1", "removed"},
+					{3, "main", nil, nil, "added"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('STAGED..WORKING', 't')",
-				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
+				Query: "select to_pk, from_pk, diff_type from dolt_diff('main', 'branch1', 'working_t', '--working') order by coalesce(to_pk, from_pk);",
+				Expected: []sql.Row{
+					{1, 1, "modified"},
+					{2, nil, "added"},
+					{nil, 3, "removed"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('WORKING', 'STAGED', 't')",
-				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
+				Query:    "select count(*) from dolt_diff('mydb/branch1', 'mydb/main', 'working_t', '--working') where from_commit = 'WORKING' and to_commit = 'WORKING';",
+				Expected: []sql.Row{{3}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('WORKING', 'WORKING', 't')",
-				Expected: []sql.Row{},
+				Query:    "select table_name, count(*) from dolt_diff('branch1', 'main', '--working', '--all-tables') group by table_name;",
+				Expected: []sql.Row{{"working_t", 3}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('WORKING..WORKING', 't')",
-				Expected: []sql.Row{},
+				Query:    "set autocommit = 0;",
+				Expected: []sql.Row{{}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('STAGED', 'STAGED', 't')",
-				Expected: []sql.Row{},
+				// the current branch's working set includes changes not yet committed in the transaction
+				Query:    "insert into working_t values (4, 'uncommitted');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
 			},
 			{
-				Query:            "call dolt_add('.')",
-				SkipResultsCheck: true,
+				Query:    "select to_pk, diff_type from dolt_diff('branch1', 'main', 'working_t', '--working') where to_pk = 4;",
+				Expected: []sql.Row{{4, "added"}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('WORKING', 'STAGED', 't')",
-				Expected: []sql.Row{},
+				Query:       "select * from dolt_diff('branch1', 'nosuchbranch', 'working_t', '--working');",
+				ExpectedErr: sqle.ErrWorkingDiffBranchNotFound,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('HEAD', 'STAGED', 't')",
-				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
+				Query:       "select * from dolt_diff('branch1', 'otherdb/main', 'working_t', '--working');",
+				ExpectedErr: sqle.ErrWorkingDiffBranchNotFound,
+			},
+			{
+				Query:       "select * from dolt_diff('branch1..main', 'working_t', '--working');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_diff('working_t', '--working', '--upstream');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 		},
 	},
 	{
-		Name: "diff with branch refs",
+		Name: "diff rows as SQL statements",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"insert into t values(1, 'one', 'two');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting row 1 into t in main');",
-
-			"CALL DOLT_checkout('-b', 'branch1');",
-			"alter table t drop column c2;",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'dropping column c2 in branch1');",
-
-			"delete from t where pk=1;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'deleting row 1 in branch1');",
-
-			"insert into t values (2, 'two');",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting row 2 in branch1');",
-
-			"CALL DOLT_checkout('main');",
-			"insert into t values (2, 'two', 'three');",
-			"set @Commit6 = '';",
-			"call dolt_commit_hash_out(@Commit6, '-am', 'inserting row 2 in main');",
-
-			"create table newtable (pk int primary key);",
-			"insert into newtable values (1), (2);",
-			"set @Commit7 = '';",
-			"call dolt_commit_hash_out(@Commit7, '-Am', 'new table newtable');",
+			"create table as_sql_t (pk int primary key, c1 varchar(20), c2 int);",
+			"insert into as_sql_t values (1, 'one', 1), (2, 'two', 2), (3, 'three', 3);",
+			"call dolt_commit('-Am', 'creating table as_sql_t');",
+			"call dolt_branch('as_sql_from');",
+			"update as_sql_t set c1 = 'uno' where pk = 1;",
+			"delete from as_sql_t where pk = 2;",
+			"insert into as_sql_t values (4, 'four', 4);",
+			"call dolt_commit('-am', 'changing as_sql_t');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_stat('main', 'branch1', 't');",
-				Expected: []sql.Row{{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_stat('main..branch1', 't');",
-				Expected: []sql.Row{{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2}},
-			},
-			{
-				Query: "SELECT * from dolt_diff_stat('main', 'branch1');",
+				Query: "select to_pk, from_pk, diff_type, statement from dolt_diff('HEAD~', 'HEAD', 'as_sql_t', '--as-sql') order by coalesce(to_pk, from_pk);",
 				Expected: []sql.Row{
-					{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2},
-					{"newtable", 0, 0, 2, 0, 0, 2, 0, 2, 0, 2, 0},
+					{1, 1, "modified", "UPDATE `as_sql_t` SET `c1`='uno' WHERE `pk`=1;"},
+					{nil, 2, "removed", "DELETE FROM `as_sql_t` WHERE `pk`=2;"},
+					{4, nil, "added", "INSERT INTO `as_sql_t` (`pk`,`c1`,`c2`) VALUES (4,'four',4);"},
 				},
 			},
 			{
-				Query: "SELECT * from dolt_diff_stat('main..branch1');",
+				Query: "select statement from dolt_patch('HEAD~', 'HEAD', 'as_sql_t') order by statement_order;",
 				Expected: []sql.Row{
-					{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2},
-					{"newtable", 0, 0, 2, 0, 0, 2, 0, 2, 0, 2, 0},
+					{"UPDATE `as_sql_t` SET `c1`='uno' WHERE `pk`=1;"},
+					{"DELETE FROM `as_sql_t` WHERE `pk`=2;"},
+					{"INSERT INTO `as_sql_t` (`pk`,`c1`,`c2`) VALUES (4,'four',4);"},
 				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('branch1', 'main', 't');",
-				Expected: []sql.Row{{"t", 0, 1, 0, 1, 4, 0, 1, 1, 2, 2, 6}},
+				Query: "select to_pk, diff_type, statement from dolt_diff('HEAD~', 'HEAD', 'as_sql_t', '--as-sql', '--context', '1') order by coalesce(to_pk, from_pk);",
+				Expected: []sql.Row{
+					{1, "modified", "UPDATE `as_sql_t` SET `c1`='uno' WHERE `pk`=1;"},
+					{nil, "removed", "DELETE FROM `as_sql_t` WHERE `pk`=2;"},
+					{3, "context", nil},
+					{4, "added", "INSERT INTO `as_sql_t` (`pk`,`c1`,`c2`) VALUES (4,'four',4);"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('branch1..main', 't');",
-				Expected: []sql.Row{{"t", 0, 1, 0, 1, 4, 0, 1, 1, 2, 2, 6}},
+				// the statements reproduce the to revision from the from revision
+				Query:            "call dolt_checkout('as_sql_from');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('main~2', 'branch1', 't');",
-				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
+				Query:    "UPDATE `as_sql_t` SET `c1`='uno' WHERE `pk`=1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('main~2..branch1', 't');",
-				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
+				Query:    "DELETE FROM `as_sql_t` WHERE `pk`=2;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
 			},
-
-			// Three dot
 			{
-				Query:    "SELECT * from dolt_diff_stat('main...branch1', 't');",
-				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
+				Query:    "INSERT INTO `as_sql_t` (`pk`,`c1`,`c2`) VALUES (4,'four',4);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('main...branch1');",
-				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
+				Query:    "select count(*) from dolt_diff('main', 'WORKING', 'as_sql_t');",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('branch1...main', 't');",
-				Expected: []sql.Row{{"t", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6}},
+				Query:            "call dolt_checkout('main');",
+				SkipResultsCheck: true,
 			},
 			{
-				Query: "SELECT * from dolt_diff_stat('branch1...main');",
-				Expected: []sql.Row{
-					{"t", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6},
-					{"newtable", 0, 2, 0, 0, 2, 0, 0, 0, 2, 0, 2},
-				},
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 'as_sql_t', '--as-sql', '--keys-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('branch1...main^');",
-				Expected: []sql.Row{{"t", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6}},
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 'as_sql_t', '--as-sql', '--json-diff');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('branch1...main', 'newtable');",
-				Expected: []sql.Row{{"newtable", 0, 2, 0, 0, 2, 0, 0, 0, 2, 0, 2}},
+				Query:            "alter table as_sql_t add column c3 int;",
+				SkipResultsCheck: true,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('main...main', 'newtable');",
-				Expected: []sql.Row{},
+				Query:          "select * from dolt_diff('HEAD', 'WORKING', 'as_sql_t', '--as-sql');",
+				ExpectedErrStr: "--as-sql requires the schema of as_sql_t to be the same at both revisions",
 			},
 		},
 	},
 	{
-		Name: "schema modification: drop and add column",
+		Name: "enum and set labels",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"create table t (pk int primary key, c1 enum('foo','bar','baz'), c2 set('a','b','c'));",
 			"call dolt_add('.');",
-			"insert into t values (1, 'one', 'two'), (2, 'two', 'three');",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting row 1, 2 into t');",
-
-			// drop 1 column and add 1 row
-			"alter table t drop column c2;",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'dropping column c2');",
-
-			// drop 1 column and add 1 row
-			"insert into t values (3, 'three');",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting row 3');",
-
-			// add 1 column and 1 row and update
-			"alter table t add column c2 varchar(20);",
-			"insert into t values (4, 'four', 'five');",
-			"update t set c2='foo' where pk=1;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'adding column c2, inserting, and updating data');",
+			"call dolt_commit('-m', 'creating table t');",
+			"insert into t values (1, 'foo', 'a,b');",
+			"call dolt_commit('-am', 'inserting into t');",
+			"update t set c1 = 'baz', c2 = 'c' where pk = 1;",
+			"call dolt_commit('-am', 'updating t');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{{"t", 0, 0, 0, 2, 0, 2, 0, 2, 2, 6, 4}},
+				Query:    "select to_c1, to_c2, from_c1, from_c2, diff_type from dolt_diff('HEAD~2', 'HEAD~1', 't');",
+				Expected: []sql.Row{{"foo", "a,b", nil, nil, "added"}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", 2, 1, 0, 0, 2, 0, 0, 2, 3, 4, 6}},
+				Query:    "select from_c1, from_c2, to_c1, to_c2 from dolt_diff('HEAD~1..HEAD', 't');",
+				Expected: []sql.Row{{"foo", "a,b", "baz", "c"}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit3, 't');",
-				Expected: []sql.Row{{"t", 0, 1, 0, 2, 2, 2, 0, 2, 3, 6, 6}},
+				Query:    "select to_pk from dolt_diff('HEAD~2', 'HEAD', 't') where to_c1 = 'baz';",
+				Expected: []sql.Row{{1}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", 2, 1, 0, 1, 6, 0, 1, 3, 4, 6, 12}},
+				Query:    "select from_c1, from_c2, to_c1, to_c2 from dolt_diff('HEAD~1', 'HEAD', 't', '--raw-enums');",
+				Expected: []sql.Row{{uint64(1), uint64(3), uint64(3), uint64(4)}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit4, 't');",
-				Expected: []sql.Row{{"t", 0, 2, 0, 2, 6, 0, 2, 2, 4, 6, 12}},
+				Query:    "select to_pk, from_pk, diff_type from dolt_diff('HEAD~1', 'HEAD', 't', '--keys-only');",
+				Expected: []sql.Row{{1, 1, "modified"}},
 			},
 		},
 	},
 	{
-		Name: "schema modification: rename columns",
+		Name: "blob summaries",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 int);",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"insert into t values(1, 'one', -1), (2, 'two', -2);",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
-
-			"alter table t rename column c2 to c3;",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'renaming column c2 to c3');",
-
-			"insert into t values (3, 'three', -3);",
-			"update t set c3=1 where pk=1;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting and updating data');",
-
-			"alter table t rename column c3 to c2;",
-			"insert into t values (4, 'four', -4);",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'renaming column c3 to c2, and inserting data');",
+			"create table t (pk int primary key, b longblob, vb varbinary(10), c1 varchar(10));",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"insert into t values (1, 'abc', 'abc', 'abc'), (2, repeat('x', 1048576), null, 'big');",
+			"call dolt_commit('-am', 'inserting into t');",
+			"update t set b = 'abd', vb = 'abd' where pk = 1;",
+			"update t set b = repeat('y', 2097152) where pk = 2;",
+			"call dolt_commit('-am', 'updating t');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{{"t", 0, 2, 0, 0, 6, 0, 0, 0, 2, 0, 6}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{},
+				Query: "select to_pk, to_b, to_vb, to_c1, diff_type from dolt_diff('HEAD~2', 'HEAD~1', 't') order by to_pk;",
+				Expected: []sql.Row{
+					{1, "3 bytes, rmnjb8cjc5tblj21ed4qs821649eduie", "3 bytes, rmnjb8cjc5tblj21ed4qs821649eduie", "abc", "added"},
+					{2, "1048576 bytes, " + hashOfRepeated('x', 1048576), nil, "big", "added"},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", 1, 1, 0, 1, 3, 0, 1, 2, 3, 6, 9}},
+				Query: "select from_b, to_b, from_vb, to_vb from dolt_diff('HEAD~1', 'HEAD', 't') order by to_pk;",
+				Expected: []sql.Row{
+					{"3 bytes, rmnjb8cjc5tblj21ed4qs821649eduie", "3 bytes, 3ac41gjqbjp2rao61jeol0uq5c7rpcde", "3 bytes, rmnjb8cjc5tblj21ed4qs821649eduie", "3 bytes, 3ac41gjqbjp2rao61jeol0uq5c7rpcde"},
+					{"1048576 bytes, " + hashOfRepeated('x', 1048576), "2097152 bytes, " + hashOfRepeated('y', 2097152), nil, nil},
+				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{"t", 3, 1, 0, 0, 3, 0, 0, 3, 4, 9, 12}},
+				Query:    "select length(from_b), length(to_b), from_vb, to_vb from dolt_diff('HEAD~1', 'HEAD', 't', '--full-blobs') order by to_pk;",
+				Expected: []sql.Row{{3, 3, []byte("abc"), []byte("abd")}, {1048576, 2097152, nil, nil}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit5, 't');",
-				Expected: []sql.Row{{"t", 0, 4, 0, 0, 12, 0, 0, 0, 4, 0, 12}},
+				Query:    "select to_pk, diff_type from dolt_diff('HEAD~1', 'HEAD', 't') where to_b like '2097152 bytes, %';",
+				Expected: []sql.Row{{2, "modified"}},
 			},
 		},
 	},
 	{
-		Name: "new table",
+		Name: "json patches",
 		SetUpScript: []string{
-			"create table t1 (a int primary key, b int)",
+			"create table t (pk int primary key, j json, c1 int);",
+			`insert into t values (1, '{"a": 1, "b": {"c": [1, 2]}}', 1), (2, '[1, 2]', 2), (3, null, 3), (5, '{"k": "v"}', 5);`,
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			`update t set j = '{"a": 1, "b": {"c": [1, 3], "d": "x"}}' where pk = 1;`,
+			`update t set j = '{"a": 1}', c1 = 20 where pk = 2;`,
+			`update t set j = '{"x": true}' where pk = 3;`,
+			`insert into t values (4, '{"new": 1}', 4);`,
+			"update t set c1 = 50 where pk = 5;",
+			"call dolt_commit('-am', 'updating t');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "select * from dolt_diff_stat('HEAD', 'WORKING')",
-				Expected: []sql.Row{},
+				Query: "select to_pk, to_j, from_j, j_patch, to_c1, from_c1, diff_type from dolt_diff('HEAD~', 'HEAD', 't', '--json-diff') order by to_pk;",
+				Expected: []sql.Row{
+					{1, nil, nil, types.MustJSON(`[{"op": "replace", "path": "/b/c/1", "value": 3}, {"op": "add", "path": "/b/d", "value": "x"}]`), 1, 1, "modified"},
+					{2, nil, nil, types.MustJSON(`[{"op": "replace", "path": "", "value": {"a": 1}}]`), 20, 2, "modified"},
+					{3, types.MustJSON(`{"x": true}`), nil, nil, 3, 3, "modified"},
+					{4, types.MustJSON(`{"new": 1}`), nil, nil, 4, nil, "added"},
+					{5, nil, nil, types.MustJSON(`[]`), 50, 5, "modified"},
+				},
 			},
 			{
-				Query:    "select * from dolt_diff_stat('WORKING', 'HEAD')",
-				Expected: []sql.Row{},
+				Query:    "select from_pk, from_j, j_patch, diff_type from dolt_diff('HEAD', 'HEAD~', 't', '--json-diff') where diff_type = 'removed';",
+				Expected: []sql.Row{{4, types.MustJSON(`{"new": 1}`), nil, "removed"}},
 			},
 			{
-				Query:            "insert into t1 values (1,2)",
-				SkipResultsCheck: true,
+				Query:    "select to_j, from_j from dolt_diff('HEAD~', 'HEAD', 't') where to_pk = 2;",
+				Expected: []sql.Row{{types.MustJSON(`{"a": 1}`), types.MustJSON(`[1, 2]`)}},
 			},
 			{
-				Query:    "select * from dolt_diff_stat('HEAD', 'WORKING', 't1')",
-				Expected: []sql.Row{{"t1", 0, 1, 0, 0, 2, 0, 0, 0, 1, 0, 2}},
+				Query:       "select j_patch from dolt_diff('HEAD~', 'HEAD', 't');",
+				ExpectedErr: sql.ErrColumnNotFound,
 			},
 			{
-				Query:    "select * from dolt_diff_stat('WORKING', 'HEAD', 't1')",
-				Expected: []sql.Row{{"t1", 0, 0, 1, 0, 0, 2, 0, 1, 0, 2, 0}},
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 't', '--json-diff', '--keys-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 't', '--json-diff', '--to-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 		},
-	},
-	{
-		Name: "dropped table",
+	}, {
+		Name: "all tables",
 		SetUpScript: []string{
-			"create table t1 (a int primary key, b int)",
-			"call dolt_add('.')",
-			"insert into t1 values (1,2)",
-			"call dolt_commit('-am', 'new table')",
-			"drop table t1",
-			"call dolt_commit('-am', 'dropped table')",
+			"create table t1 (a int primary key, b int);",
+			"insert into t1 values (1, 2), (2, 3);",
+			"create table t2 (pk int primary key, c varchar(10));",
+			"insert into t2 values (1, 'one');",
+			"create table t4 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"drop table t1;",
+			"create table t3 (pk int primary key, c int);",
+			"insert into t3 values (1, 10);",
+			"update t2 set c = 'uno' where pk = 1;",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'dropping t1, adding t3');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "select * from dolt_diff_stat('HEAD~', 'HEAD', 't1')",
-				Expected: []sql.Row{{"t1", 0, 0, 1, 0, 0, 2, 0, 1, 0, 2, 0}},
+				Query:    "select from_a, from_b, to_a, to_b, diff_type from dolt_diff('HEAD~', 'HEAD', 't1') order by from_a;",
+				Expected: []sql.Row{{1, 2, nil, nil, "removed"}, {2, 3, nil, nil, "removed"}},
 			},
 			{
-				Query:    "select * from dolt_diff_stat('HEAD', 'HEAD~', 't1')",
-				Expected: []sql.Row{{"t1", 0, 1, 0, 0, 2, 0, 0, 0, 1, 0, 2}},
+				Query:    "select to_pk, to_c, from_pk, from_c, diff_type from dolt_diff('HEAD~', 'HEAD', 't3');",
+				Expected: []sql.Row{{1, 10, nil, nil, "added"}},
 			},
-		},
-	},
-	{
-		Name: "renamed table",
-		SetUpScript: []string{
-			"create table t1 (a int primary key, b int)",
-			"call dolt_add('.')",
-			"insert into t1 values (1,2)",
-			"call dolt_commit('-am', 'new table')",
-			"alter table t1 rename to t2",
-			"call dolt_add('.')",
-			"insert into t2 values (3,4)",
-			"call dolt_commit('-am', 'renamed table')",
-		},
-		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "select * from dolt_diff_stat('HEAD~', 'HEAD', 't2')",
-				Expected: []sql.Row{{"t2", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
+				Query:    "select table_name, diff_type, count(*) from dolt_diff('HEAD~', 'HEAD', '--all-tables') group by table_name, diff_type order by table_name;",
+				Expected: []sql.Row{{"t1", "removed", 2}, {"t2", "modified", 1}, {"t3", "added", 1}},
 			},
 			{
-				Query:    "select * from dolt_diff_stat('HEAD~..HEAD', 't2')",
-				Expected: []sql.Row{{"t2", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
+				// c is a varchar in t2 and an int in t3, so it's output as text
+				Query: "select table_name, from_a, from_b, to_pk, to_c, from_c, diff_type from dolt_diff('HEAD~..HEAD', '--all-tables') order by table_name, from_a;",
+				Expected: []sql.Row{
+					{"t1", 1, 2, nil, nil, nil, "removed"},
+					{"t1", 2, 3, nil, nil, nil, "removed"},
+					{"t2", nil, nil, 1, "uno", "one", "modified"},
+					{"t3", nil, nil, 1, "10", nil, "added"},
+				},
 			},
 			{
-				// Old table name can be matched as well
-				Query:    "select * from dolt_diff_stat('HEAD~', 'HEAD', 't1')",
-				Expected: []sql.Row{{"t1", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
+				Query: "select table_name, to_pk, from_pk, from_a, diff_type from dolt_diff('HEAD~', 'HEAD', '--all-tables', '--keys-only') order by table_name, from_a;",
+				Expected: []sql.Row{
+					{"t1", nil, nil, 1, "removed"},
+					{"t1", nil, nil, 2, "removed"},
+					{"t2", 1, 1, nil, "modified"},
+					{"t3", 1, nil, nil, "added"},
+				},
 			},
 			{
-				// Old table name can be matched as well
-				Query:    "select * from dolt_diff_stat('HEAD~..HEAD', 't1')",
-				Expected: []sql.Row{{"t1", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
+				Query:    "select table_name, diff_type from dolt_diff('HEAD', 'HEAD', '--all-tables');",
+				Expected: []sql.Row{},
 			},
-		},
-	},
-	{
-		Name: "add multiple columns, then set and unset a value. Should not show a diff",
-		SetUpScript: []string{
-			"CREATE table t (pk int primary key);",
-			"Insert into t values (1);",
-			"CALL DOLT_ADD('.');",
-			"CALL DOLT_COMMIT('-am', 'setup');",
-			"alter table t add column col1 int;",
-			"alter table t add column col2 int;",
-			"CALL DOLT_ADD('.');",
-			"CALL DOLT_COMMIT('-am', 'add columns');",
-			"UPDATE t set col1 = 1 where pk = 1;",
-			"UPDATE t set col1 = null where pk = 1;",
-			"CALL DOLT_COMMIT('--allow-empty', '-am', 'fix short tuple');",
-		},
-		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_stat('HEAD~2', 'HEAD');",
-				Expected: []sql.Row{{"t", 1, 0, 0, 0, 2, 0, 0, 1, 1, 1, 3}},
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 't1', '--all-tables');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_stat('HEAD~', 'HEAD');",
-				Expected: []sql.Row{},
+				Query:       "select * from dolt_diff('HEAD~', '--all-tables');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
-		},
-	},
-	{
-		Name: "pk set change should throw an error for 3 argument dolt_diff_stat",
-		SetUpScript: []string{
-			"CREATE table t (pk int primary key);",
-			"INSERT INTO t values (1);",
-			"CALL DOLT_COMMIT('-Am', 'table with row');",
-			"ALTER TABLE t ADD col1 int not null default 0;",
-			"ALTER TABLE t drop primary key;",
-			"ALTER TABLE t add primary key (pk, col1);",
-			"CALL DOLT_COMMIT('-am', 'add secondary column with primary key');",
-		},
-		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "SELECT * from dolt_diff_stat('HEAD~', 'HEAD', 't');",
-				ExpectedErrStr: "failed to compute diff stat for table t: primary key set changed",
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', '--all-tables', '--to-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 		},
-	},
-	{
-		Name: "pk set change should report warning for 2 argument dolt_diff_stat",
+	}, {
+		Name: "overview",
 		SetUpScript: []string{
-			"CREATE table t (pk int primary key);",
-			"INSERT INTO t values (1);",
-			"CREATE table t2 (pk int primary key);",
-			"INSERT INTO t2 values (2);",
-			"CALL DOLT_COMMIT('-Am', 'multiple tables');",
-			"ALTER TABLE t ADD col1 int not null default 0;",
-			"ALTER TABLE t drop primary key;",
-			"ALTER TABLE t add primary key (pk, col1);",
-			"INSERT INTO t2 values (3), (4), (5);",
-			"CALL DOLT_COMMIT('-am', 'add secondary column with primary key to t');",
+			"create table ov_schema (pk int primary key, c int);",
+			"insert into ov_schema values (1, 1);",
+			"create table ov_data (pk int primary key, c int);",
+			"insert into ov_data values (1, 1);",
+			"create table ov_both (pk int primary key, c int);",
+			"insert into ov_both values (1, 1);",
+			"create table ov_same (pk int primary key, c int);",
+			"insert into ov_same values (1, 1);",
+			"create table ov_dropped (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"alter table ov_schema add column d int;",
+			"update ov_data set c = 2 where pk = 1;",
+			"alter table ov_both add column d int;",
+			"update ov_both set d = 3 where pk = 1;",
+			"drop table ov_dropped;",
+			"create table ov_added (pk int primary key);",
+			"create table ov_added_rows (pk int primary key);",
+			"insert into ov_added_rows values (1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'changing tables');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT * from dolt_diff_stat('HEAD~', 'HEAD')",
+				Query: "select table_name, schema_changed, data_changed from dolt_diff('HEAD~', 'HEAD', '--overview');",
 				Expected: []sql.Row{
-					{"t", 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-					{"t2", 1, 3, 0, 0, 3, 0, 0, 1, 4, 1, 4},
+					{"ov_added", true, false},
+					{"ov_added_rows", true, true},
+					{"ov_both", true, true},
+					{"ov_data", false, true},
+					{"ov_dropped", true, false},
+					{"ov_schema", true, false},
 				},
-				ExpectedWarning:       dtables.PrimaryKeyChangeWarningCode,
-				ExpectedWarningsCount: 1,
+			},
+			{
+				Query:    "select table_name from dolt_diff('HEAD~..HEAD', '--overview') where data_changed and not schema_changed;",
+				Expected: []sql.Row{{"ov_data"}},
+			},
+			{
+				Query:    "select * from dolt_diff('HEAD', 'HEAD', '--overview');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', 'ov_data', '--overview');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', '--overview', '--all-tables');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_diff('HEAD~', 'HEAD', '--overview', '--keys-only');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 		},
 	},
 }
 
-var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
+var DiffStatTableFunctionScriptTests = []queries.ScriptTest{
 	{
 		Name: "invalid arguments",
 		SetUpScript: []string{
@@ -2105,59 +2104,71 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_diff_summary();",
+				Query:       "SELECT * from dolt_diff_stat();",
 				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary('t');",
+				Query:       "SELECT * from dolt_diff_stat('t');",
 				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary('t', @Commit1, @Commit2, 'extra');",
+				Query:       "SELECT * from dolt_diff_stat('t', @Commit1, @Commit2, 'extra');",
 				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary(null, null, null);",
+				Query:       "SELECT * from dolt_diff_stat(null, null, null);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary(123, @Commit1, @Commit2);",
+				Query:       "SELECT * from dolt_diff_stat(123, @Commit1, @Commit2);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary('t', 123, @Commit2);",
+				Query:       "SELECT * from dolt_diff_stat('t', 123, @Commit2);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary('t', @Commit1, 123);",
+				Query:       "SELECT * from dolt_diff_stat('t', @Commit1, 123);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:          "SELECT * from dolt_diff_summary('fake-branch', @Commit2, 't');",
+				Query:          "SELECT * from dolt_diff_stat('fake-branch', @Commit2, 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:          "SELECT * from dolt_diff_summary('fake-branch..main', 't');",
+				Query:          "SELECT * from dolt_diff_stat('fake-branch..main', 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:          "SELECT * from dolt_diff_summary(@Commit1, 'fake-branch', 't');",
+				Query:          "SELECT * from dolt_diff_stat(@Commit1, 'fake-branch', 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:          "SELECT * from dolt_diff_summary('main..fake-branch', 't');",
+				Query:          "SELECT * from dolt_diff_stat('main..fake-branch', 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary(@Commit1, concat('fake', '-', 'branch'), 't');",
+				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 'doesnotexist');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_stat('main^..main', 'doesnotexist');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_stat(@Commit1, concat('fake', '-', 'branch'), 't');",
 				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary(hashof('main'), @Commit2, 't');",
+				Query:       "SELECT * from dolt_diff_stat(hashof('main'), @Commit2, 't');",
 				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
 			},
 			{
-				Query:       "SELECT * from dolt_diff_summary(@Commit1, @Commit2, LOWER('T'));",
+				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit2, LOWER('T'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_stat('main..main~', LOWER('T'));",
 				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
 			},
 		},
@@ -2193,39 +2204,41 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				// table does not exist, empty result
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 'doesnotexist');",
+				// table is added, no data diff, result is empty
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
 				Expected: []sql.Row{},
 			},
 			{
-				// table is added, no data changes
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{{"", "t", "added", false, true}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", 0, 1, 0, 0, 3, 0, 0, 0, 1, 0, 3}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", 0, 2, 0, 1, 6, 0, 2, 1, 3, 3, 9}},
 			},
 			{
 				// change from and to commits
-				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit3, 't');",
+				Expected: []sql.Row{{"t", 0, 0, 2, 1, 0, 6, 2, 3, 1, 9, 3}},
 			},
 			{
 				// table is dropped
-				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{"t", "", "dropped", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{"t", 0, 0, 3, 0, 0, 9, 0, 3, 0, 9, 0}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit4, 't');",
-				Expected: []sql.Row{{"", "t", "added", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit4, 't');",
+				Expected: []sql.Row{{"t", 0, 3, 0, 0, 9, 0, 0, 0, 3, 0, 9}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
+				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit5, 't');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query: `
+SELECT *
+from dolt_diff_stat(@Commit3, @Commit4, 't') 
+inner join t as of @Commit3 on rows_unmodified = t.pk;`,
 				Expected: []sql.Row{},
 			},
 		},
@@ -2262,33 +2275,38 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				// table is added, no data diff, result is empty
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{{"", "t", "added", false, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", nil, 1, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				// TODO : (correct result is commented out)
+				//      update row for keyless table deletes the row and insert the new row
+				// 		this causes row added = 3 and row deleted = 1
+				Query: "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
+				//Expected:         []sql.Row{{"t", nil, 2, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
+				Expected: []sql.Row{{"t", nil, 3, 1, nil, nil, nil, nil, nil, nil, nil, nil}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query: "SELECT * from dolt_diff_stat(@Commit4, @Commit3, 't');",
+				//Expected:         []sql.Row{{"t", nil, 0, 2, nil, nil, nil, nil, nil, nil, nil, nil}},
+				Expected: []sql.Row{{"t", nil, 1, 3, nil, nil, nil, nil, nil, nil, nil, nil}},
 			},
 			{
 				// table is dropped
-				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{"t", "", "dropped", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{"t", nil, 0, 3, nil, nil, nil, nil, nil, nil, nil, nil}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit4, 't');",
-				Expected: []sql.Row{{"", "t", "added", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit4, 't');",
+				Expected: []sql.Row{{"t", nil, 3, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
-				Expected: []sql.Row{},
+				Query:       "SELECT * from dolt_diff_stat(@Commit1, @Commit5, 't');",
+				ExpectedErr: sql.ErrTableNotFound,
 			},
 		},
 	},
@@ -2329,48 +2347,28 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit0, @Commit1);",
-				Expected: []sql.Row{{"", "t", "added", true, true}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2);",
-				Expected: []sql.Row{{"", "t2", "added", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit0, @Commit1);",
+				Expected: []sql.Row{{"t", 0, 1, 0, 0, 3, 0, 0, 0, 1, 0, 3}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary(@Commit2, @Commit3);",
-				Expected: []sql.Row{
-					{"t", "t", "modified", true, false},
-					{"t2", "t2", "modified", true, false},
-				},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2);",
+				Expected: []sql.Row{{"t2", 0, 1, 0, 0, 3, 0, 0, 0, 1, 0, 3}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary(@Commit3, @Commit4);",
-				Expected: []sql.Row{
-					{"t", "t", "modified", true, false},
-					{"t2", "t2", "modified", true, false},
-				},
+				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3);",
+				Expected: []sql.Row{{"t", 0, 3, 0, 1, 9, 0, 2, 1, 4, 3, 12}, {"t2", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary(@Commit0, @Commit4);",
-				Expected: []sql.Row{
-					{"", "t", "added", true, true},
-					{"", "t2", "added", true, true},
-				},
+				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4);",
+				Expected: []sql.Row{{"t", 3, 0, 1, 0, 0, 3, 0, 4, 3, 12, 9}, {"t2", 1, 0, 0, 1, 0, 0, 1, 2, 2, 6, 6}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary(@Commit4, @Commit2);",
-
-				Expected: []sql.Row{
-					{"t", "t", "modified", true, false},
-					{"t2", "t2", "modified", true, false},
-				},
+				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit2);",
+				Expected: []sql.Row{{"t", 0, 0, 2, 1, 0, 6, 2, 3, 1, 9, 3}, {"t2", 0, 0, 1, 1, 0, 3, 1, 2, 1, 6, 3}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary(@Commit3, 'WORKING');",
-				Expected: []sql.Row{
-					{"t", "t", "modified", true, false},
-					{"t2", "t2", "modified", true, false},
-					{"", "keyless", "added", false, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit3, 'WORKING');",
+				Expected: []sql.Row{{"t", 3, 0, 1, 0, 0, 3, 0, 4, 3, 12, 9}, {"t2", 1, 0, 0, 1, 0, 0, 1, 2, 2, 6, 6}},
 			},
 		},
 	},
@@ -2391,31 +2389,31 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, 'WORKING', 't')",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, 'WORKING', 't')",
+				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('STAGED', 'WORKING', 't')",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat('STAGED', 'WORKING', 't')",
+				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('STAGED..WORKING', 't')",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat('STAGED..WORKING', 't')",
+				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('WORKING', 'STAGED', 't')",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat('WORKING', 'STAGED', 't')",
+				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('WORKING', 'WORKING', 't')",
+				Query:    "SELECT * from dolt_diff_stat('WORKING', 'WORKING', 't')",
 				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('WORKING..WORKING', 't')",
+				Query:    "SELECT * from dolt_diff_stat('WORKING..WORKING', 't')",
 				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('STAGED', 'STAGED', 't')",
+				Query:    "SELECT * from dolt_diff_stat('STAGED', 'STAGED', 't')",
 				Expected: []sql.Row{},
 			},
 			{
@@ -2423,12 +2421,12 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 				SkipResultsCheck: true,
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('WORKING', 'STAGED', 't')",
+				Query:    "SELECT * from dolt_diff_stat('WORKING', 'STAGED', 't')",
 				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('HEAD', 'STAGED', 't')",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat('HEAD', 'STAGED', 't')",
+				Expected: []sql.Row{{"t", 0, 1, 1, 1, 3, 3, 1, 2, 2, 6, 6}},
 			},
 		},
 	},
@@ -2469,74 +2467,74 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_summary('main', 'branch1', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('main', 'branch1', 't');",
+				Expected: []sql.Row{{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('main..branch1', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('main..branch1', 't');",
+				Expected: []sql.Row{{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary('main', 'branch1');",
+				Query: "SELECT * from dolt_diff_stat('main', 'branch1');",
 				Expected: []sql.Row{
-					{"t", "t", "modified", true, true},
-					{"newtable", "", "dropped", true, true},
+					{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2},
+					{"newtable", 0, 0, 2, 0, 0, 2, 0, 2, 0, 2, 0},
 				},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary('main..branch1');",
+				Query: "SELECT * from dolt_diff_stat('main..branch1');",
 				Expected: []sql.Row{
-					{"t", "t", "modified", true, true},
-					{"newtable", "", "dropped", true, true},
+					{"t", 0, 0, 1, 1, 0, 4, 0, 2, 1, 6, 2},
+					{"newtable", 0, 0, 2, 0, 0, 2, 0, 2, 0, 2, 0},
 				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('branch1', 'main', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('branch1', 'main', 't');",
+				Expected: []sql.Row{{"t", 0, 1, 0, 1, 4, 0, 1, 1, 2, 2, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('branch1..main', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('branch1..main', 't');",
+				Expected: []sql.Row{{"t", 0, 1, 0, 1, 4, 0, 1, 1, 2, 2, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('main~2', 'branch1', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('main~2', 'branch1', 't');",
+				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('main~2..branch1', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('main~2..branch1', 't');",
+				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
 			},
 
 			// Three dot
 			{
-				Query:    "SELECT * from dolt_diff_summary('main...branch1', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('main...branch1', 't');",
+				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('main...branch1');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('main...branch1');",
+				Expected: []sql.Row{{"t", 0, 1, 1, 0, 2, 3, 0, 1, 1, 3, 2}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('branch1...main', 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat('branch1...main', 't');",
+				Expected: []sql.Row{{"t", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6}},
 			},
 			{
-				Query: "SELECT * from dolt_diff_summary('branch1...main');",
+				Query: "SELECT * from dolt_diff_stat('branch1...main');",
 				Expected: []sql.Row{
-					{"t", "t", "modified", true, false},
-					{"", "newtable", "added", true, true},
+					{"t", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6},
+					{"newtable", 0, 2, 0, 0, 2, 0, 0, 0, 2, 0, 2},
 				},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('branch1...main^');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat('branch1...main^');",
+				Expected: []sql.Row{{"t", 1, 1, 0, 0, 3, 0, 0, 1, 2, 3, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('branch1...main', 'newtable');",
-				Expected: []sql.Row{{"", "newtable", "added", true, true}},
+				Query:    "SELECT * from dolt_diff_stat('branch1...main', 'newtable');",
+				Expected: []sql.Row{{"newtable", 0, 2, 0, 0, 2, 0, 0, 0, 2, 0, 2}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('main...main', 'newtable');",
+				Query:    "SELECT * from dolt_diff_stat('main...main', 'newtable');",
 				Expected: []sql.Row{},
 			},
 		},
@@ -2550,51 +2548,43 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 			"set @Commit1 = '';",
 			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting row 1, 2 into t');",
 
-			// drop 1 column
+			// drop 1 column and add 1 row
 			"alter table t drop column c2;",
 			"set @Commit2 = '';",
 			"call dolt_commit_hash_out(@Commit2, '-am', 'dropping column c2');",
 
-			// add 1 row
+			// drop 1 column and add 1 row
 			"insert into t values (3, 'three');",
 			"set @Commit3 = '';",
 			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting row 3');",
 
-			// add 1 column
+			// add 1 column and 1 row and update
 			"alter table t add column c2 varchar(20);",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'adding column c2');",
-
-			// add 1 row and update
 			"insert into t values (4, 'four', 'five');",
 			"update t set c2='foo' where pk=1;",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting and updating data');",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'adding column c2, inserting, and updating data');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{{"t", 0, 0, 0, 2, 0, 2, 0, 2, 2, 6, 4}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", 2, 1, 0, 0, 2, 0, 0, 2, 3, 4, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", false, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit3, 't');",
+				Expected: []sql.Row{{"t", 0, 1, 0, 2, 2, 2, 0, 2, 3, 6, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit5, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", 2, 1, 0, 1, 6, 0, 1, 3, 4, 6, 12}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit4, 't');",
+				Expected: []sql.Row{{"t", 0, 2, 0, 2, 6, 0, 2, 2, 4, 6, 12}},
 			},
 		},
 	},
@@ -2606,23 +2596,19 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 			"set @Commit1 = '';",
 			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
 
-			// add rows
 			"insert into t values(1, 'one', -1), (2, 'two', -2);",
 			"set @Commit2 = '';",
 			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
 
-			// rename column
 			"alter table t rename column c2 to c3;",
 			"set @Commit3 = '';",
 			"call dolt_commit_hash_out(@Commit3, '-am', 'renaming column c2 to c3');",
 
-			// add row and update
 			"insert into t values (3, 'three', -3);",
 			"update t set c3=1 where pk=1;",
 			"set @Commit4 = '';",
 			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting and updating data');",
 
-			// rename column and add row
 			"alter table t rename column c3 to c2;",
 			"insert into t values (4, 'four', -4);",
 			"set @Commit5 = '';",
@@ -2630,28 +2616,27 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{{"t", 0, 2, 0, 0, 6, 0, 0, 0, 2, 0, 6}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", false, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", 1, 1, 0, 1, 3, 0, 1, 2, 3, 6, 9}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{"t", 3, 1, 0, 0, 3, 0, 0, 3, 4, 9, 12}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
-				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+				Query:    "SELECT * from dolt_diff_stat(@Commit1, @Commit5, 't');",
+				Expected: []sql.Row{{"t", 0, 4, 0, 0, 12, 0, 0, 0, 4, 0, 12}},
 			},
 		},
 	},
-
 	{
 		Name: "new table",
 		SetUpScript: []string{
@@ -2659,28 +2644,27 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "select * from dolt_diff_summary('HEAD', 'WORKING')",
-				Expected: []sql.Row{{"", "t1", "added", false, true}},
+				Query:    "select * from dolt_diff_stat('HEAD', 'WORKING')",
+				Expected: []sql.Row{},
 			},
 			{
-				Query:    "select * from dolt_diff_summary('WORKING', 'HEAD')",
-				Expected: []sql.Row{{"t1", "", "dropped", false, true}},
+				Query:    "select * from dolt_diff_stat('WORKING', 'HEAD')",
+				Expected: []sql.Row{},
 			},
 			{
 				Query:            "insert into t1 values (1,2)",
 				SkipResultsCheck: true,
 			},
 			{
-				Query:    "select * from dolt_diff_summary('HEAD', 'WORKING', 't1')",
-				Expected: []sql.Row{{"", "t1", "added", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD', 'WORKING', 't1')",
+				Expected: []sql.Row{{"t1", 0, 1, 0, 0, 2, 0, 0, 0, 1, 0, 2}},
 			},
 			{
-				Query:    "select * from dolt_diff_summary('WORKING', 'HEAD', 't1')",
-				Expected: []sql.Row{{"t1", "", "dropped", true, true}},
+				Query:    "select * from dolt_diff_stat('WORKING', 'HEAD', 't1')",
+				Expected: []sql.Row{{"t1", 0, 0, 1, 0, 0, 2, 0, 1, 0, 2, 0}},
 			},
 		},
 	},
-
 	{
 		Name: "dropped table",
 		SetUpScript: []string{
@@ -2693,16 +2677,15 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD', 't1')",
-				Expected: []sql.Row{{"t1", "", "dropped", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD~', 'HEAD', 't1')",
+				Expected: []sql.Row{{"t1", 0, 0, 1, 0, 0, 2, 0, 1, 0, 2, 0}},
 			},
 			{
-				Query:    "select * from dolt_diff_summary('HEAD', 'HEAD~', 't1')",
-				Expected: []sql.Row{{"", "t1", "added", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD', 'HEAD~', 't1')",
+				Expected: []sql.Row{{"t1", 0, 1, 0, 0, 2, 0, 0, 0, 1, 0, 2}},
 			},
 		},
 	},
-
 	{
 		Name: "renamed table",
 		SetUpScript: []string{
@@ -2717,61 +2700,22 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD', 't2')",
-				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
-			},
-			{
-				Query:    "select * from dolt_diff_summary('HEAD~..HEAD', 't2')",
-				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
-			},
-			{
-				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD')",
-				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD~', 'HEAD', 't2')",
+				Expected: []sql.Row{{"t2", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
 			},
 			{
-				Query:    "select * from dolt_diff_summary('HEAD~..HEAD')",
-				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD~..HEAD', 't2')",
+				Expected: []sql.Row{{"t2", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
 			},
 			{
 				// Old table name can be matched as well
-				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD', 't1')",
-				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD~', 'HEAD', 't1')",
+				Expected: []sql.Row{{"t1", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
 			},
 			{
 				// Old table name can be matched as well
-				Query:    "select * from dolt_diff_summary('HEAD~..HEAD', 't1')",
-				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
-			},
-		},
-	},
-	{
-		Name: "foreign key change",
-		SetUpScript: []string{
-			"create table test (id int primary key);",
-			"INSERT INTO test values (1), (2);",
-			"set @Commit1 = '';",
-			"CALL dolt_commit_hash_out(@Commit1, '-Am', 'create table test');",
-
-			"create table test2 (pk int primary key, test_id int);",
-			"alter table test2 add constraint fk_test_id foreign key (test_id) references test(id);",
-			"insert into test2 values (1, 1);",
-			"set @Commit2 = '';",
-			"CALL dolt_commit_hash_out(@Commit2, '-Am', 'table with foreign key and row');",
-
-			"alter table test2 drop foreign key fk_test_id;",
-		},
-		Assertions: []queries.ScriptTestAssertion{
-			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2);",
-				Expected: []sql.Row{{"", "test2", "added", true, true}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_summary('HEAD', 'WORKING');",
-				Expected: []sql.Row{{"test2", "test2", "modified", false, true}},
-			},
-			{
-				Query:    "SELECT * from dolt_diff_summary(@Commit1, 'WORKING');",
-				Expected: []sql.Row{{"", "test2", "added", true, true}},
+				Query:    "select * from dolt_diff_stat('HEAD~..HEAD', 't1')",
+				Expected: []sql.Row{{"t1", 1, 1, 0, 0, 2, 0, 0, 1, 2, 2, 4}},
 			},
 		},
 	},
@@ -2779,7 +2723,7 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		Name: "add multiple columns, then set and unset a value. Should not show a diff",
 		SetUpScript: []string{
 			"CREATE table t (pk int primary key);",
-			"insert into t values (1);",
+			"Insert into t values (1);",
 			"CALL DOLT_ADD('.');",
 			"CALL DOLT_COMMIT('-am', 'setup');",
 			"alter table t add column col1 int;",
@@ -2792,17 +2736,17 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT * from dolt_diff_summary('HEAD~2', 'HEAD');",
-				Expected: []sql.Row{{"t", "t", "modified", false, true}},
+				Query:    "SELECT * from dolt_diff_stat('HEAD~2', 'HEAD');",
+				Expected: []sql.Row{{"t", 1, 0, 0, 0, 2, 0, 0, 1, 1, 1, 3}},
 			},
 			{
-				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD');",
+				Query:    "SELECT * from dolt_diff_stat('HEAD~', 'HEAD');",
 				Expected: []sql.Row{},
 			},
 		},
 	},
 	{
-		Name: "pk set change should throw an error for 3 argument dolt_diff_summary",
+		Name: "pk set change should throw an error for 3 argument dolt_diff_stat",
 		SetUpScript: []string{
 			"CREATE table t (pk int primary key);",
 			"INSERT INTO t values (1);",
@@ -2814,13 +2758,13 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', 't');",
-				ExpectedErrStr: "failed to compute diff summary for table t: primary key set changed",
+				Query:          "SELECT * from dolt_diff_stat('HEAD~', 'HEAD', 't');",
+				ExpectedErrStr: "failed to compute diff stat for table t: primary key set changed",
 			},
 		},
 	},
 	{
-		Name: "pk set change should report warning for 2 argument dolt_diff_summary",
+		Name: "pk set change should report warning for 2 argument dolt_diff_stat",
 		SetUpScript: []string{
 			"CREATE table t (pk int primary key);",
 			"INSERT INTO t values (1);",
@@ -2835,24 +2779,47 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT * from dolt_diff_summary('HEAD~', 'HEAD')",
+				Query: "SELECT * from dolt_diff_stat('HEAD~', 'HEAD')",
 				Expected: []sql.Row{
-					{"t2", "t2", "modified", true, false},
+					{"t", 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+					{"t2", 1, 3, 0, 0, 3, 0, 0, 1, 4, 1, 4},
 				},
 				ExpectedWarning:       dtables.PrimaryKeyChangeWarningCode,
 				ExpectedWarningsCount: 1,
 			},
 		},
 	},
-}
-
-var PatchTableFunctionScriptTests = []queries.ScriptTest{
 	{
-		Name: "invalid arguments",
+		Name: "dolt_diff_stat rows_unmodified accounts for the rest of the rows",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5);",
+			"call dolt_commit('-Am', 'create table');",
+			"insert into t values (6, 6);",
+			"delete from t where pk = 1;",
+			"update t set c1 = 20 where pk = 2;",
+			"call dolt_commit('-am', 'change table');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT rows_unmodified, rows_added, rows_deleted, rows_modified from dolt_diff_stat('HEAD~', 'HEAD', 't');",
+				Expected: []sql.Row{{3, 1, 1, 1}},
+			},
+			{
+				Query:    "SELECT rows_added + rows_modified + rows_unmodified, new_row_count, rows_deleted + rows_modified + rows_unmodified, old_row_count from dolt_diff_stat('HEAD~', 'HEAD', 't');",
+				Expected: []sql.Row{{5, 5, 5, 5}},
+			},
+		},
+	},
+}
+
+var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
 			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
 
 			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
@@ -2861,71 +2828,59 @@ var PatchTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:       "SELECT * from dolt_patch();",
+				Query:       "SELECT * from dolt_diff_summary();",
 				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:       "SELECT * from dolt_patch('t');",
+				Query:       "SELECT * from dolt_diff_summary('t');",
 				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:       "SELECT * from dolt_patch('t', @Commit1, @Commit2, 'extra');",
+				Query:       "SELECT * from dolt_diff_summary('t', @Commit1, @Commit2, 'extra');",
 				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:       "SELECT * from dolt_patch(null, null, null);",
+				Query:       "SELECT * from dolt_diff_summary(null, null, null);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:       "SELECT * from dolt_patch(123, @Commit1, @Commit2);",
+				Query:       "SELECT * from dolt_diff_summary(123, @Commit1, @Commit2);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:       "SELECT * from dolt_patch('t', 123, @Commit2);",
+				Query:       "SELECT * from dolt_diff_summary('t', 123, @Commit2);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:       "SELECT * from dolt_patch('t', @Commit1, 123);",
+				Query:       "SELECT * from dolt_diff_summary('t', @Commit1, 123);",
 				ExpectedErr: sql.ErrInvalidArgumentDetails,
 			},
 			{
-				Query:          "SELECT * from dolt_patch('fake-branch', @Commit2, 't');",
+				Query:          "SELECT * from dolt_diff_summary('fake-branch', @Commit2, 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:          "SELECT * from dolt_patch('fake-branch..main', 't');",
+				Query:          "SELECT * from dolt_diff_summary('fake-branch..main', 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:          "SELECT * from dolt_patch(@Commit1, 'fake-branch', 't');",
+				Query:          "SELECT * from dolt_diff_summary(@Commit1, 'fake-branch', 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:          "SELECT * from dolt_patch('main..fake-branch', 't');",
+				Query:          "SELECT * from dolt_diff_summary('main..fake-branch', 't');",
 				ExpectedErrStr: "branch not found: fake-branch",
 			},
 			{
-				Query:       "SELECT * from dolt_patch(@Commit1, @Commit2, 'doesnotexist');",
-				ExpectedErr: sql.ErrTableNotFound,
-			},
-			{
-				Query:       "SELECT * from dolt_patch('main^..main', 'doesnotexist');",
-				ExpectedErr: sql.ErrTableNotFound,
-			},
-			{
-				Query:       "SELECT * from dolt_patch(@Commit1, concat('fake', '-', 'branch'), 't');",
-				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
-			},
-			{
-				Query:       "SELECT * from dolt_patch(hashof('main'), @Commit2, 't');",
+				Query:       "SELECT * from dolt_diff_summary(@Commit1, concat('fake', '-', 'branch'), 't');",
 				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
 			},
 			{
-				Query:       "SELECT * from dolt_patch(@Commit1, @Commit2, LOWER('T'));",
+				Query:       "SELECT * from dolt_diff_summary(hashof('main'), @Commit2, 't');",
 				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
 			},
 			{
-				Query:       "SELECT * from dolt_patch('main..main~', LOWER('T'));",
+				Query:       "SELECT * from dolt_diff_summary(@Commit1, @Commit2, LOWER('T'));",
 				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
 			},
 		},
@@ -2961,52 +2916,102 @@ var PatchTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				// table is added, no data diff, result is empty
-				Query:    "SELECT * from dolt_patch(@Commit1, @Commit2, 't') WHERE diff_type = 'data';",
+				// table does not exist, empty result
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 'doesnotexist');",
 				Expected: []sql.Row{},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, statement from dolt_patch(@Commit1, @Commit2, 't') WHERE diff_type = 'schema';",
-				Expected: []sql.Row{{1, "t", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"}},
+				// table is added, no data changes
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{{"", "t", "added", false, true}},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit2, @Commit3, 't');",
-				Expected: []sql.Row{{1, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (1,'one','two');"}},
+				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit3, @Commit4, 't');",
-				Expected: []sql.Row{
-					{1, "t", "data", "UPDATE `t` SET `c1`='uno',`c2`='dos' WHERE `pk`=1;"},
-					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
-					{3, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (3,'three','four');"},
-				},
+				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
 			},
 			{
 				// change from and to commits
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit4, @Commit3, 't');",
-				Expected: []sql.Row{
-					{1, "t", "data", "UPDATE `t` SET `c1`='one',`c2`='two' WHERE `pk`=1;"},
-					{2, "t", "data", "DELETE FROM `t` WHERE `pk`=2;"},
-					{3, "t", "data", "DELETE FROM `t` WHERE `pk`=3;"},
-				},
+				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
 			},
 			{
 				// table is dropped
-				Query:    "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit4, @Commit5, 't');",
-				Expected: []sql.Row{{1, "t", "schema", "DROP TABLE `t`;"}},
+				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{"t", "", "dropped", true, true}},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit1, @Commit4, 't');",
-				Expected: []sql.Row{
-					{1, "t", "schema", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (1,'uno','dos');"},
-					{3, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
-					{4, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (3,'three','four');"},
-				},
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit4, 't');",
+				Expected: []sql.Row{{"", "t", "added", true, true}},
 			},
 			{
-				Query:       "SELECT * from dolt_patch(@Commit1, @Commit5, 't');",
-				ExpectedErr: sql.ErrTableNotFound,
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "basic case with single keyless table",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '--allow-empty', '-m', 'creating table t');",
+
+			// create table t only
+			"create table t (id int, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'creating table t');",
+
+			// insert 1 row into t
+			"insert into t values(1, 'one', 'two');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting 1 into table t');",
+
+			// insert 2 rows into t and update two cells
+			"insert into t values(2, 'two', 'three'), (3, 'three', 'four');",
+			"update t set c1='uno', c2='dos' where id=1;",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting 2 into table t');",
+
+			// drop table t only
+			"drop table t;",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'drop table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// table is added, no data diff, result is empty
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{{"", "t", "added", false, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				// table is dropped
+				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{"t", "", "dropped", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit4, 't');",
+				Expected: []sql.Row{{"", "t", "added", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
+				Expected: []sql.Row{},
 			},
 		},
 	},
@@ -3047,377 +3052,1790 @@ var PatchTableFunctionScriptTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit0, @Commit1);",
-				Expected: []sql.Row{
-					{1, "t", "schema", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (1,'one','two');"},
-				},
+				Query:    "SELECT * from dolt_diff_summary(@Commit0, @Commit1);",
+				Expected: []sql.Row{{"", "t", "added", true, true}},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit1, @Commit2);",
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2);",
+				Expected: []sql.Row{{"", "t2", "added", true, true}},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary(@Commit2, @Commit3);",
 				Expected: []sql.Row{
-					{1, "t2", "schema", "CREATE TABLE `t2` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "t2", "data", "INSERT INTO `t2` (`pk`,`c1`,`c2`) VALUES (100,'hundred','hundert');"},
+					{"t", "t", "modified", true, false},
+					{"t2", "t2", "modified", true, false},
 				},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit2, @Commit3);",
+				Query: "SELECT * from dolt_diff_summary(@Commit3, @Commit4);",
 				Expected: []sql.Row{
-					{1, "t", "data", "UPDATE `t` SET `c1`='uno',`c2`='dos' WHERE `pk`=1;"},
-					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
-					{3, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (3,'three','four');"},
-					{4, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (4,'four','five');"},
-					{5, "t2", "data", "INSERT INTO `t2` (`pk`,`c1`,`c2`) VALUES (101,'hundred one','one');"},
+					{"t", "t", "modified", true, false},
+					{"t2", "t2", "modified", true, false},
 				},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit3, @Commit4);",
+				Query: "SELECT * from dolt_diff_summary(@Commit0, @Commit4);",
 				Expected: []sql.Row{
-					{1, "t", "data", "DELETE FROM `t` WHERE `pk`=3;"},
-					{2, "t2", "data", "UPDATE `t2` SET `c2`='zero' WHERE `pk`=100;"},
+					{"", "t", "added", true, true},
+					{"", "t2", "added", true, true},
 				},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit4, @Commit2);",
+				Query: "SELECT * from dolt_diff_summary(@Commit4, @Commit2);",
+
 				Expected: []sql.Row{
-					{1, "t", "data", "UPDATE `t` SET `c1`='one',`c2`='two' WHERE `pk`=1;"},
-					{2, "t", "data", "DELETE FROM `t` WHERE `pk`=2;"},
-					{3, "t", "data", "DELETE FROM `t` WHERE `pk`=4;"},
-					{4, "t2", "data", "UPDATE `t2` SET `c2`='hundert' WHERE `pk`=100;"},
-					{5, "t2", "data", "DELETE FROM `t2` WHERE `pk`=101;"},
+					{"t", "t", "modified", true, false},
+					{"t2", "t2", "modified", true, false},
 				},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit3, 'WORKING');",
+				Query: "SELECT * from dolt_diff_summary(@Commit3, 'WORKING');",
 				Expected: []sql.Row{
-					{1, "keyless", "schema", "CREATE TABLE `keyless` (\n  `id` int\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "t", "data", "DELETE FROM `t` WHERE `pk`=3;"},
-					{3, "t2", "data", "UPDATE `t2` SET `c2`='zero' WHERE `pk`=100;"},
-				},
+					{"t", "t", "modified", true, false},
+					{"t2", "t2", "modified", true, false},
+					{"", "keyless", "added", false, true}},
 			},
 		},
 	},
 	{
-		Name: "using WORKING and STAGED refs on RENAME, DROP and ADD column",
+		Name: "WORKING and STAGED",
 		SetUpScript: []string{
 			"set @Commit0 = HashOf('HEAD');",
-			"create table t (pk int primary key, c1 int, c2 int, c3 int, c4 int, c5 int comment 'tag:5');",
+
+			"create table t (pk int primary key, c1 text, c2 text);",
 			"call dolt_add('.')",
-			"insert into t values (0,1,2,3,4,5), (1,1,2,3,4,5);",
+			"insert into t values (1, 'one', 'two'), (2, 'three', 'four');",
 			"set @Commit1 = '';",
 			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting two rows into table t');",
-			"alter table t rename column c1 to c0",
-			"alter table t drop column c4",
-			"alter table t add c6 bigint",
+
+			"insert into t values (3, 'five', 'six');",
+			"delete from t where pk = 2",
+			"update t set c2 = '100' where pk = 1",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch(@Commit1, 'WORKING', 't')",
-				Expected: []sql.Row{
-					{1, "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
-					{2, "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
-					{3, "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
-				},
-				ExpectedWarning:       1235,
-				ExpectedWarningsCount: 1,
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, 'WORKING', 't')",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('STAGED', 'WORKING', 't')",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('STAGED..WORKING', 't')",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('WORKING', 'STAGED', 't')",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
 			},
 			{
-				Query: "SHOW WARNINGS;",
+				Query:    "SELECT * from dolt_diff_summary('WORKING', 'WORKING', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('WORKING..WORKING', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('STAGED', 'STAGED', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:            "call dolt_add('.')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('WORKING', 'STAGED', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD', 'STAGED', 't')",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+		},
+	},
+	{
+		Name: "diff with branch refs",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting row 1 into t in main');",
+
+			"CALL DOLT_checkout('-b', 'branch1');",
+			"alter table t drop column c2;",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'dropping column c2 in branch1');",
+
+			"delete from t where pk=1;",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'deleting row 1 in branch1');",
+
+			"insert into t values (2, 'two');",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting row 2 in branch1');",
+
+			"CALL DOLT_checkout('main');",
+			"insert into t values (2, 'two', 'three');",
+			"set @Commit6 = '';",
+			"call dolt_commit_hash_out(@Commit6, '-am', 'inserting row 2 in main');",
+
+			"create table newtable (pk int primary key);",
+			"insert into newtable values (1), (2);",
+			"set @Commit7 = '';",
+			"call dolt_commit_hash_out(@Commit7, '-Am', 'new table newtable');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_summary('main', 'branch1', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('main..branch1', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('main', 'branch1');",
+				Expected: []sql.Row{
+					{"t", "t", "modified", true, true},
+					{"newtable", "", "dropped", true, true},
+				},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('main..branch1');",
+				Expected: []sql.Row{
+					{"t", "t", "modified", true, true},
+					{"newtable", "", "dropped", true, true},
+				},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('branch1', 'main', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('branch1..main', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('main~2', 'branch1', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('main~2..branch1', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+
+			// Three dot
+			{
+				Query:    "SELECT * from dolt_diff_summary('main...branch1', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('main...branch1');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('branch1...main', 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('branch1...main');",
+				Expected: []sql.Row{
+					{"t", "t", "modified", true, false},
+					{"", "newtable", "added", true, true},
+				},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('branch1...main^');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('branch1...main', 'newtable');",
+				Expected: []sql.Row{{"", "newtable", "added", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('main...main', 'newtable');",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "schema modification: drop and add column",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.');",
+			"insert into t values (1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting row 1, 2 into t');",
+
+			// drop 1 column
+			"alter table t drop column c2;",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'dropping column c2');",
+
+			// add 1 row
+			"insert into t values (3, 'three');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting row 3');",
+
+			// add 1 column
+			"alter table t add column c2 varchar(20);",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'adding column c2');",
+
+			// add 1 row and update
+			"insert into t values (4, 'four', 'five');",
+			"update t set c2='foo' where pk=1;",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting and updating data');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", false, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit5, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+		},
+	},
+	{
+		Name: "schema modification: rename columns",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 int);",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			// add rows
+			"insert into t values(1, 'one', -1), (2, 'two', -2);",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+
+			// rename column
+			"alter table t rename column c2 to c3;",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'renaming column c2 to c3');",
+
+			// add row and update
+			"insert into t values (3, 'three', -3);",
+			"update t set c3=1 where pk=1;",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting and updating data');",
+
+			// rename column and add row
+			"alter table t rename column c3 to c2;",
+			"insert into t values (4, 'four', -4);",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'renaming column c3 to c2, and inserting data');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", false, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit5, 't');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+		},
+	},
+
+	{
+		Name: "new table",
+		SetUpScript: []string{
+			"create table t1 (a int primary key, b int)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_diff_summary('HEAD', 'WORKING')",
+				Expected: []sql.Row{{"", "t1", "added", false, true}},
+			},
+			{
+				Query:    "select * from dolt_diff_summary('WORKING', 'HEAD')",
+				Expected: []sql.Row{{"t1", "", "dropped", false, true}},
+			},
+			{
+				Query:            "insert into t1 values (1,2)",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select * from dolt_diff_summary('HEAD', 'WORKING', 't1')",
+				Expected: []sql.Row{{"", "t1", "added", true, true}},
+			},
+			{
+				Query:    "select * from dolt_diff_summary('WORKING', 'HEAD', 't1')",
+				Expected: []sql.Row{{"t1", "", "dropped", true, true}},
+			},
+		},
+	},
+
+	{
+		Name: "dropped table",
+		SetUpScript: []string{
+			"create table t1 (a int primary key, b int)",
+			"call dolt_add('.')",
+			"insert into t1 values (1,2)",
+			"call dolt_commit('-am', 'new table')",
+			"drop table t1",
+			"call dolt_commit('-am', 'dropped table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD', 't1')",
+				Expected: []sql.Row{{"t1", "", "dropped", true, true}},
+			},
+			{
+				Query:    "select * from dolt_diff_summary('HEAD', 'HEAD~', 't1')",
+				Expected: []sql.Row{{"", "t1", "added", true, true}},
+			},
+		},
+	},
+
+	{
+		Name: "renamed table",
+		SetUpScript: []string{
+			"create table t1 (a int primary key, b int)",
+			"call dolt_add('.')",
+			"insert into t1 values (1,2)",
+			"call dolt_commit('-am', 'new table')",
+			"alter table t1 rename to t2",
+			"call dolt_add('.')",
+			"insert into t2 values (3,4)",
+			"call dolt_commit('-am', 'renamed table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD', 't2')",
+				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+			},
+			{
+				Query:    "select * from dolt_diff_summary('HEAD~..HEAD', 't2')",
+				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+			},
+			{
+				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD')",
+				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+			},
+			{
+				Query:    "select * from dolt_diff_summary('HEAD~..HEAD')",
+				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+			},
+			{
+				// Old table name can be matched as well
+				Query:    "select * from dolt_diff_summary('HEAD~', 'HEAD', 't1')",
+				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+			},
+			{
+				// Old table name can be matched as well
+				Query:    "select * from dolt_diff_summary('HEAD~..HEAD', 't1')",
+				Expected: []sql.Row{{"t1", "t2", "renamed", true, true}},
+			},
+		},
+	},
+	{
+		Name: "foreign key change",
+		SetUpScript: []string{
+			"create table test (id int primary key);",
+			"INSERT INTO test values (1), (2);",
+			"set @Commit1 = '';",
+			"CALL dolt_commit_hash_out(@Commit1, '-Am', 'create table test');",
+
+			"create table test2 (pk int primary key, test_id int);",
+			"alter table test2 add constraint fk_test_id foreign key (test_id) references test(id);",
+			"insert into test2 values (1, 1);",
+			"set @Commit2 = '';",
+			"CALL dolt_commit_hash_out(@Commit2, '-Am', 'table with foreign key and row');",
+
+			"alter table test2 drop foreign key fk_test_id;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, @Commit2);",
+				Expected: []sql.Row{{"", "test2", "added", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD', 'WORKING');",
+				Expected: []sql.Row{{"test2", "test2", "modified", false, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary(@Commit1, 'WORKING');",
+				Expected: []sql.Row{{"", "test2", "added", true, true}},
+			},
+		},
+	},
+	{
+		Name: "add multiple columns, then set and unset a value. Should not show a diff",
+		SetUpScript: []string{
+			"CREATE table t (pk int primary key);",
+			"insert into t values (1);",
+			"CALL DOLT_ADD('.');",
+			"CALL DOLT_COMMIT('-am', 'setup');",
+			"alter table t add column col1 int;",
+			"alter table t add column col2 int;",
+			"CALL DOLT_ADD('.');",
+			"CALL DOLT_COMMIT('-am', 'add columns');",
+			"UPDATE t set col1 = 1 where pk = 1;",
+			"UPDATE t set col1 = null where pk = 1;",
+			"CALL DOLT_COMMIT('--allow-empty', '-am', 'fix short tuple');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~2', 'HEAD');",
+				Expected: []sql.Row{{"t", "t", "modified", false, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD');",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "pk set change should throw an error for 3 argument dolt_diff_summary",
+		SetUpScript: []string{
+			"CREATE table t (pk int primary key);",
+			"INSERT INTO t values (1);",
+			"CALL DOLT_COMMIT('-Am', 'table with row');",
+			"ALTER TABLE t ADD col1 int not null default 0;",
+			"ALTER TABLE t drop primary key;",
+			"ALTER TABLE t add primary key (pk, col1);",
+			"CALL DOLT_COMMIT('-am', 'add secondary column with primary key');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', 't');",
+				ExpectedErrStr: "failed to compute diff summary for table t: primary key set changed",
+			},
+		},
+	},
+	{
+		Name: "pk set change should report warning for 2 argument dolt_diff_summary",
+		SetUpScript: []string{
+			"CREATE table t (pk int primary key);",
+			"INSERT INTO t values (1);",
+			"CREATE table t2 (pk int primary key);",
+			"INSERT INTO t2 values (2);",
+			"CALL DOLT_COMMIT('-Am', 'multiple tables');",
+			"ALTER TABLE t ADD col1 int not null default 0;",
+			"ALTER TABLE t drop primary key;",
+			"ALTER TABLE t add primary key (pk, col1);",
+			"INSERT INTO t2 values (3), (4), (5);",
+			"CALL DOLT_COMMIT('-am', 'add secondary column with primary key to t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * from dolt_diff_summary('HEAD~', 'HEAD')",
+				Expected: []sql.Row{
+					{"t2", "t2", "modified", true, false},
+				},
+				ExpectedWarning:       dtables.PrimaryKeyChangeWarningCode,
+				ExpectedWarningsCount: 1,
+			},
+		},
+	},
+	{
+		Name: "include unchanged tables and totals",
+		SetUpScript: []string{
+			"create table t1 (pk int primary key, c1 int);",
+			"create table t2 (pk int primary key);",
+			"create table t3 (pk int primary key);",
+			"insert into t1 values (1, 1);",
+			"call dolt_commit('-Am', 'creating tables');",
+
+			"insert into t1 values (2, 2);",
+			"alter table t1 add column c2 int;",
+			"drop table t2;",
+			"create table t4 (id int primary key, c1 varchar(20));",
+			"call dolt_commit('-Am', 'changing tables');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * from dolt_diff_summary('HEAD~', 'HEAD');",
+				Expected: []sql.Row{
+					{"t2", "", "dropped", false, true},
+					{"t1", "t1", "modified", true, true},
+					{"", "t4", "added", false, true},
+				},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', '--include-unchanged');",
+				Expected: []sql.Row{
+					{"t2", "", "dropped", false, true},
+					{"t1", "t1", "modified", true, true},
+					{"t3", "t3", "unchanged", false, false},
+					{"", "t4", "added", false, true},
+				},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('HEAD~..HEAD', '--include-unchanged');",
+				Expected: []sql.Row{
+					{"t2", "", "dropped", false, true},
+					{"t1", "t1", "modified", true, true},
+					{"t3", "t3", "unchanged", false, false},
+					{"", "t4", "added", false, true},
+				},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', 't3');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', 't3', '--include-unchanged');",
+				Expected: []sql.Row{{"t3", "t3", "unchanged", false, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', 't1', '--include-unchanged');",
+				Expected: []sql.Row{{"t1", "t1", "modified", true, true}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', 'doesnotexist', '--include-unchanged');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD', 'HEAD', '--include-unchanged');",
+				Expected: []sql.Row{{"t1", "t1", "unchanged", false, false}, {"t3", "t3", "unchanged", false, false}, {"t4", "t4", "unchanged", false, false}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', '--totals');",
+				Expected: []sql.Row{{3, 1, 1, 0, 1, 0, 1, 3}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD~..HEAD', '--include-unchanged', '--totals');",
+				Expected: []sql.Row{{4, 1, 1, 0, 1, 1, 1, 3}},
+			},
+			{
+				Query:    "SELECT table_count, unchanged from dolt_diff_summary('HEAD~', 'HEAD', 't3', '--include-unchanged', '--totals');",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('HEAD', 'HEAD', '--totals');",
+				Expected: []sql.Row{{0, 0, 0, 0, 0, 0, 0, 0}},
+			},
+			{
+				Query:       "SELECT * from dolt_diff_summary('HEAD~', 'HEAD', '--unknown');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_summary('--include-unchanged');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+		},
+	},
+}
+
+var PatchTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two'), (2, 'two', 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "SELECT * from dolt_patch();",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_patch('t');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_patch('t', @Commit1, @Commit2, 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_patch(null, null, null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_patch(123, @Commit1, @Commit2);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_patch('t', 123, @Commit2);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_patch('t', @Commit1, 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "SELECT * from dolt_patch('fake-branch', @Commit2, 't');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_patch('fake-branch..main', 't');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_patch(@Commit1, 'fake-branch', 't');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:          "SELECT * from dolt_patch('main..fake-branch', 't');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:       "SELECT * from dolt_patch(@Commit1, @Commit2, 'doesnotexist');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * from dolt_patch('main^..main', 'doesnotexist');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * from dolt_patch(@Commit1, concat('fake', '-', 'branch'), 't');",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_patch(hashof('main'), @Commit2, 't');",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_patch(@Commit1, @Commit2, LOWER('T'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_patch('main..main~', LOWER('T'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+		},
+	},
+	{
+		Name: "basic case with single table",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '--allow-empty', '-m', 'creating table t');",
+
+			// create table t only
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'creating table t');",
+
+			// insert 1 row into t
+			"insert into t values(1, 'one', 'two');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting 1 into table t');",
+
+			// insert 2 rows into t and update two cells
+			"insert into t values(2, 'two', 'three'), (3, 'three', 'four');",
+			"update t set c1='uno', c2='dos' where pk=1;",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting 2 into table t');",
+
+			// drop table t only
+			"drop table t;",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'drop table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// table is added, no data diff, result is empty
+				Query:    "SELECT * from dolt_patch(@Commit1, @Commit2, 't') WHERE diff_type = 'data';",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, statement from dolt_patch(@Commit1, @Commit2, 't') WHERE diff_type = 'schema';",
+				Expected: []sql.Row{{1, "t", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit2, @Commit3, 't');",
+				Expected: []sql.Row{{1, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (1,'one','two');"}},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit3, @Commit4, 't');",
+				Expected: []sql.Row{
+					{1, "t", "data", "UPDATE `t` SET `c1`='uno',`c2`='dos' WHERE `pk`=1;"},
+					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
+					{3, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (3,'three','four');"},
+				},
+			},
+			{
+				// change from and to commits
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit4, @Commit3, 't');",
+				Expected: []sql.Row{
+					{1, "t", "data", "UPDATE `t` SET `c1`='one',`c2`='two' WHERE `pk`=1;"},
+					{2, "t", "data", "DELETE FROM `t` WHERE `pk`=2;"},
+					{3, "t", "data", "DELETE FROM `t` WHERE `pk`=3;"},
+				},
+			},
+			{
+				// table is dropped
+				Query:    "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit4, @Commit5, 't');",
+				Expected: []sql.Row{{1, "t", "schema", "DROP TABLE `t`;"}},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit1, @Commit4, 't');",
+				Expected: []sql.Row{
+					{1, "t", "schema", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (1,'uno','dos');"},
+					{3, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
+					{4, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (3,'three','four');"},
+				},
+			},
+			{
+				Query:       "SELECT * from dolt_patch(@Commit1, @Commit5, 't');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "basic case with multiple tables",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+
+			// add table t with 1 row
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"insert into t values(1, 'one', 'two');",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting into table t');",
+
+			// add table t2 with 1 row
+			"create table t2 (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"insert into t2 values(100, 'hundred', 'hundert');",
+			"call dolt_add('.')",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into table t2');",
+
+			// changes on both tables
+			"insert into t values(2, 'two', 'three'), (3, 'three', 'four'), (4, 'four', 'five');",
+			"update t set c1='uno', c2='dos' where pk=1;",
+			"insert into t2 values(101, 'hundred one', 'one');",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'inserting into table t');",
+
+			// changes on both tables
+			"delete from t where c2 = 'four';",
+			"update t2 set c2='zero' where pk=100;",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting into table t');",
+
+			// create keyless table
+			"create table keyless (id int);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit0, @Commit1);",
+				Expected: []sql.Row{
+					{1, "t", "schema", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (1,'one','two');"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit1, @Commit2);",
+				Expected: []sql.Row{
+					{1, "t2", "schema", "CREATE TABLE `t2` (\n  `pk` int NOT NULL,\n  `c1` varchar(20),\n  `c2` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "t2", "data", "INSERT INTO `t2` (`pk`,`c1`,`c2`) VALUES (100,'hundred','hundert');"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit2, @Commit3);",
+				Expected: []sql.Row{
+					{1, "t", "data", "UPDATE `t` SET `c1`='uno',`c2`='dos' WHERE `pk`=1;"},
+					{2, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
+					{3, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (3,'three','four');"},
+					{4, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (4,'four','five');"},
+					{5, "t2", "data", "INSERT INTO `t2` (`pk`,`c1`,`c2`) VALUES (101,'hundred one','one');"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit3, @Commit4);",
+				Expected: []sql.Row{
+					{1, "t", "data", "DELETE FROM `t` WHERE `pk`=3;"},
+					{2, "t2", "data", "UPDATE `t2` SET `c2`='zero' WHERE `pk`=100;"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit4, @Commit2);",
+				Expected: []sql.Row{
+					{1, "t", "data", "UPDATE `t` SET `c1`='one',`c2`='two' WHERE `pk`=1;"},
+					{2, "t", "data", "DELETE FROM `t` WHERE `pk`=2;"},
+					{3, "t", "data", "DELETE FROM `t` WHERE `pk`=4;"},
+					{4, "t2", "data", "UPDATE `t2` SET `c2`='hundert' WHERE `pk`=100;"},
+					{5, "t2", "data", "DELETE FROM `t2` WHERE `pk`=101;"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement from dolt_patch(@Commit3, 'WORKING');",
+				Expected: []sql.Row{
+					{1, "keyless", "schema", "CREATE TABLE `keyless` (\n  `id` int\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "t", "data", "DELETE FROM `t` WHERE `pk`=3;"},
+					{3, "t2", "data", "UPDATE `t2` SET `c2`='zero' WHERE `pk`=100;"},
+				},
+			},
+		},
+	},
+	{
+		Name: "using WORKING and STAGED refs on RENAME, DROP and ADD column",
+		SetUpScript: []string{
+			"set @Commit0 = HashOf('HEAD');",
+			"create table t (pk int primary key, c1 int, c2 int, c3 int, c4 int, c5 int comment 'tag:5');",
+			"call dolt_add('.')",
+			"insert into t values (0,1,2,3,4,5), (1,1,2,3,4,5);",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting two rows into table t');",
+			"alter table t rename column c1 to c0",
+			"alter table t drop column c4",
+			"alter table t add c6 bigint",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch(@Commit1, 'WORKING', 't')",
+				Expected: []sql.Row{
+					{1, "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
+					{2, "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
+					{3, "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
+				},
+				ExpectedWarning:       1235,
+				ExpectedWarningsCount: 1,
+			},
+			{
+				Query: "SHOW WARNINGS;",
+				Expected: []sql.Row{
+					{"Warning", 1235, "Incompatible schema change, skipping data diff for table 't'"},
+				},
+			},
+			{
+				Query: "SELECT * FROM dolt_patch('STAGED', 'WORKING', 't')",
+				Expected: []sql.Row{
+					{1, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
+					{2, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
+					{3, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
+				},
+			},
+			{
+				Query: "SELECT * FROM dolt_patch('STAGED..WORKING', 't')",
+				Expected: []sql.Row{
+					{1, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
+					{2, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
+					{3, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
+				},
+			},
+			{
+				Query: "SELECT * FROM dolt_patch('WORKING', 'STAGED', 't')",
+				Expected: []sql.Row{
+					{1, "WORKING", "STAGED", "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c0` TO `c1`;"},
+					{2, "WORKING", "STAGED", "t", "schema", "ALTER TABLE `t` DROP `c6`;"},
+					{3, "WORKING", "STAGED", "t", "schema", "ALTER TABLE `t` ADD `c4` int;"},
+				},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('WORKING', 'WORKING', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('WORKING..WORKING', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('STAGED', 'STAGED', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:            "call dolt_add('.')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('WORKING', 'STAGED', 't')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD', 'STAGED', 't')",
+				Expected: []sql.Row{
+					{1, "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
+					{2, "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
+					{3, "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
+				},
+			},
+		},
+	},
+	{
+		Name: "using branch refs different ways",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values(1, 'one', 'two');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting row 1 into t in main');",
+
+			"CALL DOLT_checkout('-b', 'branch1');",
+			"alter table t drop column c2;",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'dropping column c2 in branch1');",
+
+			"delete from t where pk=1;",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'deleting row 1 in branch1');",
+
+			"insert into t values (2, 'two');",
+			"set @Commit5 = '';",
+			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting row 2 in branch1');",
+
+			"CALL DOLT_checkout('main');",
+			"insert into t values (2, 'two', 'three');",
+			"set @Commit6 = '';",
+			"call dolt_commit_hash_out(@Commit6, '-am', 'inserting row 2 in main');",
+
+			"create table newtable (pk int primary key);",
+			"insert into newtable values (1), (2);",
+			"set @Commit7 = '';",
+			"call dolt_commit_hash_out(@Commit7, '-Am', 'new table newtable');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main', 'branch1', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+			},
+			{
+				Query:    "SHOW WARNINGS",
+				Expected: []sql.Row{{"Warning", 1235, "Incompatible schema change, skipping data diff for table 't'"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main..branch1', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main', 'branch1');",
+				Expected: []sql.Row{
+					{1, "newtable", "schema", "DROP TABLE `newtable`;"},
+					{2, "t", "schema", "ALTER TABLE `t` DROP `c2`;"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main..branch1');",
+				Expected: []sql.Row{
+					{1, "newtable", "schema", "DROP TABLE `newtable`;"},
+					{2, "t", "schema", "ALTER TABLE `t` DROP `c2`;"},
+				},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1', 'main', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` ADD `c2` varchar(20);"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1..main', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` ADD `c2` varchar(20);"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main~2', 'branch1', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main~2..branch1', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+			},
+			// Three dot
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main...branch1', 't');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main...branch1');",
+				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main', 't');",
+				Expected: []sql.Row{{1, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"}},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main');",
+				Expected: []sql.Row{
+					{1, "newtable", "schema", "CREATE TABLE `newtable` (\n  `pk` int NOT NULL,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (1);"},
+					{3, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (2);"},
+					{4, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
+				},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main^');",
+				Expected: []sql.Row{{1, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"}},
+			},
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main', 'newtable');",
+				Expected: []sql.Row{
+					{1, "newtable", "schema", "CREATE TABLE `newtable` (\n  `pk` int NOT NULL,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (1);"},
+					{3, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (2);"},
+				},
+			},
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main...main', 'newtable');",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "renamed table",
+		SetUpScript: []string{
+			"create table t1 (a int primary key, b int)",
+			"call dolt_add('.')",
+			"insert into t1 values (1,2)",
+			"call dolt_commit('-am', 'new table')",
+			"alter table t1 rename to t2",
+			"call dolt_add('.')",
+			"insert into t2 values (3,4)",
+			"call dolt_commit('-am', 'renamed table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~', 'HEAD', 't2')",
+				Expected: []sql.Row{
+					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
+					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
+				},
+			},
+			{
+				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~..HEAD', 't2')",
+				Expected: []sql.Row{
+					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
+					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
+				},
+			},
+			{
+				// Old table name can be matched as well
+				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~', 'HEAD', 't1')",
+				Expected: []sql.Row{
+					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
+					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
+				},
+			},
+			{
+				// Old table name can be matched as well
+				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~..HEAD', 't1')",
+				Expected: []sql.Row{
+					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
+					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
+				},
+			},
+		},
+	},
+	{
+		Name: "multi PRIMARY KEY and FOREIGN KEY",
+		SetUpScript: []string{
+			"CREATE TABLE parent (id int PRIMARY KEY, id_ext int, v1 int, v2 text COMMENT 'tag:1', INDEX v1 (v1));",
+			"CREATE TABLE child (id int primary key, v1 int);",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'new tables')",
+			"ALTER TABLE child ADD CONSTRAINT fk_named FOREIGN KEY (v1) REFERENCES parent(v1);",
+			"insert into parent values (0, 1, 2, NULL);",
+			"ALTER TABLE parent DROP PRIMARY KEY;",
+			"ALTER TABLE parent ADD PRIMARY KEY(id, id_ext);",
+			"call dolt_add('.')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~', 'WORKING')",
+				Expected: []sql.Row{
+					{1, "child", "schema", "CREATE TABLE `child` (\n  `id` int NOT NULL,\n  `v1` int,\n  PRIMARY KEY (`id`),\n  KEY `v1` (`v1`),\n  CONSTRAINT `fk_named` FOREIGN KEY (`v1`) REFERENCES `parent` (`v1`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{2, "parent", "schema", "CREATE TABLE `parent` (\n  `id` int NOT NULL,\n  `id_ext` int NOT NULL,\n  `v1` int,\n  `v2` text COMMENT 'tag:1',\n  PRIMARY KEY (`id`,`id_ext`),\n  KEY `v1` (`v1`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{3, "parent", "data", "INSERT INTO `parent` (`id`,`id_ext`,`v1`,`v2`) VALUES (0,1,2,NULL);"},
+				},
+			},
+			{
+				Query: "SELECT statement_order, to_commit_hash, table_name, diff_type, statement FROM dolt_patch('HEAD', 'STAGED')",
+				Expected: []sql.Row{
+					{1, "STAGED", "child", "schema", "ALTER TABLE `child` ADD INDEX `v1`(`v1`);"},
+					{2, "STAGED", "child", "schema", "ALTER TABLE `child` ADD CONSTRAINT `fk_named` FOREIGN KEY (`v1`) REFERENCES `parent` (`v1`);"},
+					{3, "STAGED", "parent", "schema", "ALTER TABLE `parent` DROP PRIMARY KEY;"},
+					{4, "STAGED", "parent", "schema", "ALTER TABLE `parent` ADD PRIMARY KEY (id,id_ext);"},
+				},
+				ExpectedWarningsCount: 2,
+			},
+			{
+				Query: "SHOW WARNINGS;",
+				Expected: []sql.Row{
+					{"Warning", 1235, "Incompatible schema change, skipping data diff for table 'child'"},
+					{"Warning", 1235, "Primary key sets differ between revisions for table 'parent', skipping data diff"},
+				},
+			},
+		},
+	},
+	{
+		Name: "CHECK CONSTRAINTS",
+		SetUpScript: []string{
+			"create table foo (pk int, c1 int, CHECK (c1 > 3), PRIMARY KEY (pk));",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD', 'WORKING')",
+				Expected: []sql.Row{{1, "foo", "schema", "CREATE TABLE `foo` (\n  `pk` int NOT NULL,\n  `c1` int,\n  PRIMARY KEY (`pk`),\n  CONSTRAINT `foo_chk_eq3jn5ra` CHECK ((c1 > 3))\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"}},
+			},
+		},
+	},
+	{
+		Name: "patch DDL changes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, a int, b int, c int)",
+			"insert into t values (1, null, 1, 1), (2, 2, null, 2), (3, 3, 3, 3)",
+			"CALL dolt_commit('-Am', 'new table t')",
+			"CALL dolt_checkout('-b', 'other')",
+			"alter table t drop column b",
+			"alter table t add column d int",
+			"delete from t where pk = 3",
+			"update t set a = 9 where a = NULL",
+			"insert into t values (7,7,7,7)",
+			"CALL dolt_commit('-am', 'modified table t')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT statement FROM dolt_patch('main', 'other', 't') ORDER BY statement_order",
+				Expected: []sql.Row{
+					{"ALTER TABLE `t` DROP `b`;"},
+					{"ALTER TABLE `t` ADD `d` int;"},
+				},
+			},
+		},
+	},
+}
+
+var TableMatchTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "basic table comparisons",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"create table t2 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"call dolt_branch('b1');",
+			"insert into t values (1, 1);",
+			"create table t3 (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'changing tables');",
+			"insert into t2 values (1);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select table_name, matches from dolt_table_match('main', 'main');",
+				Expected: []sql.Row{{"t", true}, {"t2", true}, {"t3", true}},
+			},
+			{
+				Query:    "select table_name, matches from dolt_table_match('b1', 'main');",
+				Expected: []sql.Row{{"t", false}, {"t2", true}, {"t3", false}},
+			},
+			{
+				Query:    "select table_name, to_hash is null from dolt_table_match('main', 'b1') where from_hash != to_hash or to_hash is null;",
+				Expected: []sql.Row{{"t", false}, {"t3", true}},
+			},
+			{
+				Query:    "select count(*) from dolt_table_match('b1', 'main') where from_hash = to_hash;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select table_name from dolt_table_match('HEAD', 'WORKING') where not matches;",
+				Expected: []sql.Row{{"t2"}},
+			},
+			{
+				Query:       "select * from dolt_table_match('main');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_table_match('main', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+}
+
+var StorageReportTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "storage by category",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20), index c1_idx (c1));",
+			"insert into t values (1, 'one'), (2, 'two'), (3, 'three');",
+			"create table t2 (pk int primary key, c1 int);",
+			"insert into t2 values (1, 1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"update t set c1 = 'uno' where pk = 1;",
+			"call dolt_commit('-am', 'updating t');",
+			"drop table t2;",
+			"call dolt_commit('-am', 'dropping t2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select table_name from dolt_storage_report();",
+				Expected: []sql.Row{{"t"}, {"t2"}},
+			},
+			{
+				Query:    "select live_data_bytes > 0, index_bytes > 0, historical_bytes > 0, chunk_count > 0 from dolt_storage_report() where table_name = 't';",
+				Expected: []sql.Row{{true, true, true, true}},
+			},
+			{
+				Query:    "select live_data_bytes, index_bytes, historical_bytes > 0 from dolt_storage_report() where table_name = 't2';",
+				Expected: []sql.Row{{uint64(0), uint64(0), true}},
+			},
+			{
+				Query:    "select table_name, live_data_bytes > 0 from dolt_storage_report('t');",
+				Expected: []sql.Row{{"t", true}},
+			},
+			{
+				Query:       "select * from dolt_storage_report('t', 't2');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_storage_report(123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
+	{
+		Name: "shared storage",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"insert into t values (1, 'one'), (2, 'two'), (3, 'three');",
+			"create table t_copy like t;",
+			"insert into t_copy select * from t;",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select table_name, shared_bytes > 0, chunk_count > 0 from dolt_storage_report();",
+				Expected: []sql.Row{{"t", true, true}, {"t_copy", false, false}},
+			},
+			{
+				// when scoped to a single table, chunks shared with other tables aren't reported as shared
+				Query:    "select shared_bytes, live_data_bytes > 0 from dolt_storage_report('t_copy');",
+				Expected: []sql.Row{{uint64(0), true}},
+			},
+		},
+	},
+}
+
+var BranchesContainingTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "branches containing a commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"set @created = hashof('HEAD');",
+			"call dolt_branch('old');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting 1');",
+			"set @inserted = hashof('HEAD');",
+			"call dolt_branch('release');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'inserting 2');",
+			"set @feature = hashof('HEAD');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select name from dolt_branches_containing(@created);",
+				Expected: []sql.Row{{"feature"}, {"main"}, {"old"}, {"release"}},
+			},
+			{
+				Query:    "select name from dolt_branches_containing(@inserted);",
+				Expected: []sql.Row{{"feature"}, {"main"}, {"release"}},
+			},
+			{
+				Query:    "select name, hash = @feature from dolt_branches_containing(@feature);",
+				Expected: []sql.Row{{"feature", true}},
+			},
+			{
+				Query:    "select name from dolt_branches_containing('release');",
+				Expected: []sql.Row{{"feature"}, {"main"}, {"release"}},
+			},
+			{
+				Query:    "select name from dolt_branches_containing('HEAD~');",
+				Expected: []sql.Row{{"feature"}, {"main"}, {"old"}, {"release"}},
+			},
+			{
+				Query:    "select name from dolt_branches where name in (select name from dolt_branches_containing(@inserted)) and name != 'main';",
+				Expected: []sql.Row{{"feature"}, {"release"}},
+			},
+			{
+				Query:       "select * from dolt_branches_containing('abcdefghijklmnopqrstuvabcdefghij');",
+				ExpectedErr: sqle.ErrUnknownCommit,
+			},
+			{
+				Query:       "select * from dolt_branches_containing('nonexistent');",
+				ExpectedErr: sqle.ErrUnknownCommit,
+			},
+			{
+				Query:       "select * from dolt_branches_containing();",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_branches_containing('main', 'feature');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+		},
+	},
+}
+
+var BranchesTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "branches merged and not merged into a revision",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('old');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting 1', '--author', 'John Doe <johndoe@example.com>', '--date', '2022-08-06T12:00:01');",
+			"call dolt_branch('release');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'inserting 2', '--author', 'Jane Roe <janeroe@example.com>', '--date', '2022-08-06T12:00:02');",
+			"set @feature = hashof('HEAD');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select name, latest_committer, merged from dolt_branches();",
+				Expected: []sql.Row{{"feature", "Jane Roe", false}, {"main", "John Doe", true}, {"old", "billy bob", true}, {"release", "John Doe", true}},
+			},
+			{
+				Query:    "select name, cast(latest_commit_date as char) from dolt_branches() where name in ('feature', 'release');",
+				Expected: []sql.Row{{"feature", "2022-08-06 12:00:02"}, {"release", "2022-08-06 12:00:01"}},
+			},
+			{
+				Query:    "select name, hash = hashof(name) from dolt_branches('--merged', 'main');",
+				Expected: []sql.Row{{"main", true}, {"old", true}, {"release", true}},
+			},
+			{
+				Query:    "select name, merged from dolt_branches('--no-merged', 'main');",
+				Expected: []sql.Row{{"feature", false}},
+			},
+			{
+				Query:    "select name from dolt_branches('--merged', 'feature');",
+				Expected: []sql.Row{{"feature"}, {"main"}, {"old"}, {"release"}},
+			},
+			{
+				Query:    "select count(*) from dolt_branches('--no-merged', @feature);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name from dolt_branches('--merged', 'main~');",
+				Expected: []sql.Row{{"old"}},
+			},
+			{
+				Query:    "select count(*) from dolt_branches('--remotes');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "select * from dolt_branches('--merged', 'main', '--no-merged', 'feature');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_branches('--merged', 'nonexistent');",
+				ExpectedErr: sqle.ErrUnknownCommit,
+			},
+			{
+				Query:       "select * from dolt_branches('main');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_branches('--merged');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_branches('--merged', null);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_branches('--merged', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_branches('--merged', concat('ma', 'in'));",
+				ExpectedErr: sqle.ErrInvalidNonLiteralArgument,
+			},
+		},
+	},
+}
+
+var RowHistoryTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "basic row history",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"insert into t values (1, 'one'), (2, 'two');",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'inserting rows');",
+			"update t set c1 = 'dos' where pk = 2;",
+			"call dolt_commit('-am', 'updating row 2');",
+			"update t set c1 = 'uno' where pk = 1;",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'updating row 1');",
+			"create table other (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table other');",
+			"delete from t where pk = 1;",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'deleting row 1');",
+			"insert into t values (1, 'one again');",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'inserting row 1 again');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select commit_hash = @Commit4, commit_hash = @Commit3, commit_hash = @Commit2, commit_hash = @Commit1, diff_type, to_pk, to_c1 from dolt_row_history('t', 1);",
 				Expected: []sql.Row{
-					{"Warning", 1235, "Incompatible schema change, skipping data diff for table 't'"},
+					{true, false, false, false, "added", 1, "one again"},
+					{false, true, false, false, "removed", nil, nil},
+					{false, false, true, false, "modified", 1, "uno"},
+					{false, false, false, true, "added", 1, "one"},
 				},
 			},
 			{
-				Query: "SELECT * FROM dolt_patch('STAGED', 'WORKING', 't')",
+				Query: "select diff_type, to_c1 from dolt_row_history('t', 2);",
 				Expected: []sql.Row{
-					{1, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
-					{2, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
-					{3, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
+					{"modified", "dos"},
+					{"added", "two"},
 				},
 			},
 			{
-				Query: "SELECT * FROM dolt_patch('STAGED..WORKING', 't')",
+				Query: "select diff_type, from_pk, from_c1, to_c1 from dolt_row_history('t', 1);",
 				Expected: []sql.Row{
-					{1, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
-					{2, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
-					{3, "STAGED", "WORKING", "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
+					{"added", nil, nil, "one again"},
+					{"removed", 1, "uno", nil},
+					{"modified", 1, "one", "uno"},
+					{"added", nil, nil, "one"},
 				},
 			},
 			{
-				Query: "SELECT * FROM dolt_patch('WORKING', 'STAGED', 't')",
+				Query: "select diff_type, from_c1, to_c1 from dolt_row_history('t', '{\"pk\": 2}');",
 				Expected: []sql.Row{
-					{1, "WORKING", "STAGED", "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c0` TO `c1`;"},
-					{2, "WORKING", "STAGED", "t", "schema", "ALTER TABLE `t` DROP `c6`;"},
-					{3, "WORKING", "STAGED", "t", "schema", "ALTER TABLE `t` ADD `c4` int;"},
+					{"modified", "two", "dos"},
+					{"added", nil, "two"},
 				},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('WORKING', 'WORKING', 't')",
-				Expected: []sql.Row{},
-			},
-			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('WORKING..WORKING', 't')",
-				Expected: []sql.Row{},
+				Query:    "select count(*) from dolt_row_history('t', 3);",
+				Expected: []sql.Row{{0}},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('STAGED', 'STAGED', 't')",
-				Expected: []sql.Row{},
+				// primary key values are converted to the type of the primary key column
+				Query:    "select count(*) from dolt_row_history('t', '1');",
+				Expected: []sql.Row{{4}},
 			},
 			{
-				Query:            "call dolt_add('.')",
-				SkipResultsCheck: true,
+				Query:       "select * from dolt_row_history('t');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('WORKING', 'STAGED', 't')",
-				Expected: []sql.Row{},
+				Query:       "select * from dolt_row_history('t', 1, 2);",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD', 'STAGED', 't')",
-				Expected: []sql.Row{
-					{1, "t", "schema", "ALTER TABLE `t` RENAME COLUMN `c1` TO `c0`;"},
-					{2, "t", "schema", "ALTER TABLE `t` DROP `c4`;"},
-					{3, "t", "schema", "ALTER TABLE `t` ADD `c6` bigint;"},
-				},
+				Query:       "select * from dolt_row_history('doesnotexist', 1);",
+				ExpectedErr: sql.ErrTableNotFound,
 			},
 		},
 	},
 	{
-		Name: "using branch refs different ways",
+		Name: "row history with a compound primary key and schema changes",
 		SetUpScript: []string{
-			"create table t (pk int primary key, c1 varchar(20), c2 varchar(20));",
-			"call dolt_add('.')",
-			"set @Commit1 = '';",
-			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
-
-			"insert into t values(1, 'one', 'two');",
-			"set @Commit2 = '';",
-			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting row 1 into t in main');",
-
-			"CALL DOLT_checkout('-b', 'branch1');",
-			"alter table t drop column c2;",
-			"set @Commit3 = '';",
-			"call dolt_commit_hash_out(@Commit3, '-am', 'dropping column c2 in branch1');",
-
-			"delete from t where pk=1;",
-			"set @Commit4 = '';",
-			"call dolt_commit_hash_out(@Commit4, '-am', 'deleting row 1 in branch1');",
-
-			"insert into t values (2, 'two');",
-			"set @Commit5 = '';",
-			"call dolt_commit_hash_out(@Commit5, '-am', 'inserting row 2 in branch1');",
-
-			"CALL DOLT_checkout('main');",
-			"insert into t values (2, 'two', 'three');",
-			"set @Commit6 = '';",
-			"call dolt_commit_hash_out(@Commit6, '-am', 'inserting row 2 in main');",
-
-			"create table newtable (pk int primary key);",
-			"insert into newtable values (1), (2);",
-			"set @Commit7 = '';",
-			"call dolt_commit_hash_out(@Commit7, '-Am', 'new table newtable');",
+			"create table t (pk1 int, pk2 varchar(10), c1 int, primary key (pk1, pk2));",
+			"insert into t values (1, 'a', 1), (1, 'b', 1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"alter table t add column c2 int;",
+			"call dolt_commit('-am', 'adding column c2');",
+			"update t set c2 = 2 where pk2 = 'a';",
+			"call dolt_commit('-am', 'updating c2');",
+			"update t set c1 = 10 where pk2 = 'b';",
+			"call dolt_commit('-am', 'updating c1');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main', 'branch1', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
-			},
-			{
-				Query:    "SHOW WARNINGS",
-				Expected: []sql.Row{{"Warning", 1235, "Incompatible schema change, skipping data diff for table 't'"}},
-			},
-			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main..branch1', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
-			},
-			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main', 'branch1');",
+				Query: "select diff_type, to_pk1, to_pk2, to_c1, to_c2 from dolt_row_history('t', 1, 'a');",
 				Expected: []sql.Row{
-					{1, "newtable", "schema", "DROP TABLE `newtable`;"},
-					{2, "t", "schema", "ALTER TABLE `t` DROP `c2`;"},
+					{"modified", 1, "a", 1, 2},
+					{"added", 1, "a", 1, nil},
 				},
 			},
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main..branch1');",
+				Query: "select diff_type, to_c1, to_c2 from dolt_row_history('t', 1, 'b');",
 				Expected: []sql.Row{
-					{1, "newtable", "schema", "DROP TABLE `newtable`;"},
-					{2, "t", "schema", "ALTER TABLE `t` DROP `c2`;"},
+					{"modified", 10, nil},
+					{"added", 1, nil},
 				},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1', 'main', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` ADD `c2` varchar(20);"}},
-			},
-			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1..main', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` ADD `c2` varchar(20);"}},
+				Query: "select diff_type, from_c1, to_c1 from dolt_row_history('t', '{\"PK2\": \"b\", \"pk1\": 1}');",
+				Expected: []sql.Row{
+					{"modified", 1, 10},
+					{"added", nil, 1},
+				},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main~2', 'branch1', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+				Query:          "select * from dolt_row_history('t', '{\"pk1\": 1}');",
+				ExpectedErrStr: "primary key given does not match the primary key of table t: (pk1, pk2)",
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main~2..branch1', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+				Query:          "select * from dolt_row_history('t', '{\"pk1\": 1, \"c1\": 1}');",
+				ExpectedErrStr: "primary key given does not match the primary key of table t: (pk1, pk2)",
 			},
-			// Three dot
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main...branch1', 't');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+				Query:          "select * from dolt_row_history('t', '{\"pk1\": 1, \"pk2\": \"a\", \"c1\": 1}');",
+				ExpectedErrStr: "primary key given does not match the primary key of table t: (pk1, pk2)",
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main...branch1');",
-				Expected: []sql.Row{{1, "t", "schema", "ALTER TABLE `t` DROP `c2`;"}},
+				Query:       "select * from dolt_row_history('t', '[1, \"a\"]');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
 			},
+		},
+	},
+	{
+		Name: "row history of a string primary key",
+		SetUpScript: []string{
+			"create table t (pk varchar(20) primary key, c1 int);",
+			"insert into t values ('{\"pk\": 1}', 1);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main', 't');",
-				Expected: []sql.Row{{1, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"}},
+				// strings are the key value of a string key, not a JSON object
+				Query:    "select diff_type, to_pk from dolt_row_history('t', '{\"pk\": 1}');",
+				Expected: []sql.Row{{"added", "{\"pk\": 1}"}},
 			},
+		},
+	},
+}
+
+var IndexDiffTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "index diff between commits",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 int, c3 int, index idx1 (c1), index idx2 (c2));",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"alter table t drop index idx1;",
+			"alter table t drop index idx2;",
+			"alter table t add unique index idx2 (c2);",
+			"alter table t add index idx3 (c3, c1);",
+			"call dolt_commit('-am', 'changing indexes');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main');",
+				Query: "select * from dolt_index_diff('HEAD~', 'HEAD', 't');",
 				Expected: []sql.Row{
-					{1, "newtable", "schema", "CREATE TABLE `newtable` (\n  `pk` int NOT NULL,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (1);"},
-					{3, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (2);"},
-					{4, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"},
+					{"idx1", "dropped", "KEY `idx1` (`c1`)", nil},
+					{"idx2", "modified", "KEY `idx2` (`c2`)", "UNIQUE KEY `idx2` (`c2`)"},
+					{"idx3", "added", nil, "KEY `idx3` (`c3`,`c1`)"},
 				},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main^');",
-				Expected: []sql.Row{{1, "t", "data", "INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (2,'two','three');"}},
-			},
-			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('branch1...main', 'newtable');",
+				Query: "select index_name, change_type from dolt_index_diff('HEAD', 'HEAD~', 'T');",
 				Expected: []sql.Row{
-					{1, "newtable", "schema", "CREATE TABLE `newtable` (\n  `pk` int NOT NULL,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (1);"},
-					{3, "newtable", "data", "INSERT INTO `newtable` (`pk`) VALUES (2);"},
+					{"idx1", "added"},
+					{"idx2", "modified"},
+					{"idx3", "dropped"},
 				},
 			},
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('main...main', 'newtable');",
+				Query:    "select * from dolt_index_diff('HEAD', 'HEAD', 't');",
 				Expected: []sql.Row{},
 			},
 		},
 	},
 	{
-		Name: "renamed table",
+		Name: "index diff of primary key changes",
 		SetUpScript: []string{
-			"create table t1 (a int primary key, b int)",
-			"call dolt_add('.')",
-			"insert into t1 values (1,2)",
-			"call dolt_commit('-am', 'new table')",
-			"alter table t1 rename to t2",
-			"call dolt_add('.')",
-			"insert into t2 values (3,4)",
-			"call dolt_commit('-am', 'renamed table')",
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"alter table t drop primary key;",
+			"alter table t add primary key (pk, c1);",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~', 'HEAD', 't2')",
+				Query: "select * from dolt_index_diff('HEAD', 'WORKING', 't');",
 				Expected: []sql.Row{
-					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
-					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
+					{"PRIMARY", "modified", "PRIMARY KEY (`pk`)", "PRIMARY KEY (`pk`,`c1`)"},
 				},
 			},
 			{
-				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~..HEAD', 't2')",
+				Query: "select * from dolt_index_diff('HEAD~', 'HEAD', 't');",
 				Expected: []sql.Row{
-					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
-					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
+					{"PRIMARY", "added", nil, "PRIMARY KEY (`pk`)"},
 				},
 			},
+		},
+	},
+	{
+		Name: "index diff errors",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "select * from dolt_index_diff('HEAD~', 'HEAD');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
 			{
-				// Old table name can be matched as well
-				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~', 'HEAD', 't1')",
-				Expected: []sql.Row{
-					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
-					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
-				},
+				Query:       "select * from dolt_index_diff('HEAD~', 'HEAD', 'doesnotexist');",
+				ExpectedErr: sql.ErrTableNotFound,
 			},
 			{
-				// Old table name can be matched as well
-				Query: "select statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~..HEAD', 't1')",
-				Expected: []sql.Row{
-					{1, "t2", "schema", "RENAME TABLE `t1` TO `t2`;"},
-					{2, "t2", "data", "INSERT INTO `t2` (`a`,`b`) VALUES (3,4);"},
-				},
+				Query:       "select * from dolt_index_diff('HEAD~', 'HEAD', 1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "select * from dolt_index_diff('HEAD~', 'doesnotexist', 't');",
+				ExpectedErrStr: "branch not found: doesnotexist",
 			},
 		},
 	},
+}
+
+var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 	{
-		Name: "multi PRIMARY KEY and FOREIGN KEY",
+		Name: "schema diff between commits",
 		SetUpScript: []string{
-			"CREATE TABLE parent (id int PRIMARY KEY, id_ext int, v1 int, v2 text COMMENT 'tag:1', INDEX v1 (v1));",
-			"CREATE TABLE child (id int primary key, v1 int);",
-			"call dolt_add('.')",
-			"call dolt_commit('-am', 'new tables')",
-			"ALTER TABLE child ADD CONSTRAINT fk_named FOREIGN KEY (v1) REFERENCES parent(v1);",
-			"insert into parent values (0, 1, 2, NULL);",
-			"ALTER TABLE parent DROP PRIMARY KEY;",
-			"ALTER TABLE parent ADD PRIMARY KEY(id, id_ext);",
-			"call dolt_add('.')",
+			"create table t (pk int primary key, c1 int, c2 varchar(20), c3 int default 1, c4 int);",
+			"create table dropme (pk int primary key, c1 int not null);",
+			"create table unchanged (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating tables');",
+			"alter table t rename column c1 to c1_renamed;",
+			"alter table t modify column c2 varchar(100);",
+			"alter table t alter column c3 set default 2;",
+			"alter table t drop column c4;",
+			"alter table t add column c5 int default 5;",
+			"drop table dropme;",
+			"create table newtable (pk int primary key, c1 int);",
+			"insert into unchanged values (1);",
+			"call dolt_commit('-Am', 'changing schemas');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD~', 'WORKING')",
+				Query: "select * from dolt_schema_diff('HEAD~', 'HEAD');",
 				Expected: []sql.Row{
-					{1, "child", "schema", "CREATE TABLE `child` (\n  `id` int NOT NULL,\n  `v1` int,\n  PRIMARY KEY (`id`),\n  KEY `v1` (`v1`),\n  CONSTRAINT `fk_named` FOREIGN KEY (`v1`) REFERENCES `parent` (`v1`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{2, "parent", "schema", "CREATE TABLE `parent` (\n  `id` int NOT NULL,\n  `id_ext` int NOT NULL,\n  `v1` int,\n  `v2` text COMMENT 'tag:1',\n  PRIMARY KEY (`id`,`id_ext`),\n  KEY `v1` (`v1`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{3, "parent", "data", "INSERT INTO `parent` (`id`,`id_ext`,`v1`,`v2`) VALUES (0,1,2,NULL);"},
+					{"dropme", "pk", "dropped", "int", nil, nil, nil},
+					{"dropme", "c1", "dropped", "int", nil, nil, nil},
+					{"newtable", "pk", "added", nil, "int", nil, nil},
+					{"newtable", "c1", "added", nil, "int", nil, nil},
+					{"t", "c1_renamed", "renamed", "int", "int", nil, nil},
+					{"t", "c2", "modified", "varchar(20)", "varchar(100)", nil, nil},
+					{"t", "c3", "modified", "int", "int", "1", "2"},
+					{"t", "c5", "added", nil, "int", nil, "5"},
+					{"t", "c4", "dropped", "int", nil, nil, nil},
 				},
 			},
 			{
-				Query: "SELECT statement_order, to_commit_hash, table_name, diff_type, statement FROM dolt_patch('HEAD', 'STAGED')",
+				Query: "select table_name, column_name, change_type from dolt_schema_diff('HEAD~..HEAD', 'T');",
 				Expected: []sql.Row{
-					{1, "STAGED", "child", "schema", "ALTER TABLE `child` ADD INDEX `v1`(`v1`);"},
-					{2, "STAGED", "child", "schema", "ALTER TABLE `child` ADD CONSTRAINT `fk_named` FOREIGN KEY (`v1`) REFERENCES `parent` (`v1`);"},
-					{3, "STAGED", "parent", "schema", "ALTER TABLE `parent` DROP PRIMARY KEY;"},
-					{4, "STAGED", "parent", "schema", "ALTER TABLE `parent` ADD PRIMARY KEY (id,id_ext);"},
+					{"t", "c1_renamed", "renamed"},
+					{"t", "c2", "modified"},
+					{"t", "c3", "modified"},
+					{"t", "c5", "added"},
+					{"t", "c4", "dropped"},
 				},
-				ExpectedWarningsCount: 2,
 			},
 			{
-				Query: "SHOW WARNINGS;",
+				Query: "select column_name, change_type from dolt_schema_diff('HEAD', 'HEAD~', 'dropme');",
 				Expected: []sql.Row{
-					{"Warning", 1235, "Incompatible schema change, skipping data diff for table 'child'"},
-					{"Warning", 1235, "Primary key sets differ between revisions for table 'parent', skipping data diff"},
+					{"pk", "added"},
+					{"c1", "added"},
 				},
 			},
+			{
+				Query:    "select * from dolt_schema_diff('HEAD~', 'HEAD', 'unchanged');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from dolt_schema_diff('HEAD', 'HEAD');",
+				Expected: []sql.Row{},
+			},
 		},
 	},
 	{
-		Name: "CHECK CONSTRAINTS",
+		Name: "schema diff of working set changes",
 		SetUpScript: []string{
-			"create table foo (pk int, c1 int, CHECK (c1 > 3), PRIMARY KEY (pk));",
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
+			"alter table t modify column c1 int not null;",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:    "SELECT statement_order, table_name, diff_type, statement FROM dolt_patch('HEAD', 'WORKING')",
-				Expected: []sql.Row{{1, "foo", "schema", "CREATE TABLE `foo` (\n  `pk` int NOT NULL,\n  `c1` int,\n  PRIMARY KEY (`pk`),\n  CONSTRAINT `foo_chk_eq3jn5ra` CHECK ((c1 > 3))\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"}},
+				Query:    "select * from dolt_schema_diff('HEAD', 'WORKING');",
+				Expected: []sql.Row{{"t", "c1", "modified", "int", "int", nil, nil}},
+			},
+			{
+				Query:    "select * from dolt_schema_diff('HEAD', 'STAGED');",
+				Expected: []sql.Row{},
 			},
 		},
 	},
 	{
-		Name: "patch DDL changes",
+		Name: "schema diff errors",
 		SetUpScript: []string{
-			"create table t (pk int primary key, a int, b int, c int)",
-			"insert into t values (1, null, 1, 1), (2, 2, null, 2), (3, 3, 3, 3)",
-			"CALL dolt_commit('-Am', 'new table t')",
-			"CALL dolt_checkout('-b', 'other')",
-			"alter table t drop column b",
-			"alter table t add column d int",
-			"delete from t where pk = 3",
-			"update t set a = 9 where a = NULL",
-			"insert into t values (7,7,7,7)",
-			"CALL dolt_commit('-am', 'modified table t')",
+			"create table t (pk int primary key);",
+			"call dolt_add('.');",
+			"call dolt_commit('-m', 'creating table t');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query: "SELECT statement FROM dolt_patch('main', 'other', 't') ORDER BY statement_order",
-				Expected: []sql.Row{
-					{"ALTER TABLE `t` DROP `b`;"},
-					{"ALTER TABLE `t` ADD `d` int;"},
-				},
+				Query:       "select * from dolt_schema_diff('HEAD~');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_schema_diff('HEAD~', 'HEAD', 't', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "select * from dolt_schema_diff('HEAD~', 'HEAD', 'doesnotexist');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "select * from dolt_schema_diff('HEAD~', 'HEAD', 1);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:          "select * from dolt_schema_diff('HEAD~', 'doesnotexist');",
+				ExpectedErrStr: "branch not found: doesnotexist",
 			},
 		},
 	},
@@ -5097,3 +6515,8 @@ var SystemTableIndexTests = []systabScript{
 		},
 	},
 }
+
+// hashOfRepeated returns the hash of |n| repetitions of |c|, as output by dolt_diff for a blob column
+func hashOfRepeated(c byte, n int) string {
+	return hash.Of(bytes.Repeat([]byte{c}, n)).String()
+}
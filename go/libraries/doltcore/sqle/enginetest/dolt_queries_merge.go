@@ -107,7 +107,7 @@ var MergeScripts = []queries.ScriptTest{
 			{
 				// No-FF-Merge
 				Query:    "CALL DOLT_MERGE('feature-branch', '-no-ff', '-m', 'this is a no-ff')",
-				Expected: []sql.Row{{1, 0}},
+				Expected: []sql.Row{{0, 0}},
 			},
 			{
 				Query:    "SELECT is_merging, source, target, unmerged_tables FROM DOLT_MERGE_STATUS;",
@@ -214,6 +214,71 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "CALL DOLT_MERGE with --no-commit stages the merge",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key)",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0),(1),(2);",
+			"CALL DOLT_COMMIT('-a', '-m', 'Step 1');",
+			"CALL DOLT_CHECKOUT('-b', 'feature-branch')",
+			"INSERT INTO test VALUES (3);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 3');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO test VALUES (4);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 4');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('feature-branch', '--no-commit')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT table_name, staged, status FROM dolt_status",
+				Expected: []sql.Row{{"test", true, "modified"}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{0}, {1}, {2}, {3}, {4}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--abort')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT is_merging FROM dolt_merge_status",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{0}, {1}, {2}, {4}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature-branch', '--no-commit')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:            "CALL DOLT_COMMIT('-m', 'merge feature-branch')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_commit_ancestors WHERE commit_hash = HASHOF('HEAD')",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1",
+				Expected: []sql.Row{{"merge feature-branch"}},
+			},
+		},
+	},
 	{
 		Name: "CALL DOLT_MERGE with conflicts can be correctly resolved when autocommit is off",
 		SetUpScript: []string{
@@ -389,7 +454,7 @@ var MergeScripts = []queries.ScriptTest{
 			{
 				// No-FF-Merge
 				Query:    "CALL DOLT_MERGE('feature-branch', '-no-ff', '-m', 'this is a no-ff')",
-				Expected: []sql.Row{{1, 0}},
+				Expected: []sql.Row{{0, 0}},
 			},
 			{
 				Query:    "SELECT is_merging, source, target, unmerged_tables FROM DOLT_MERGE_STATUS;",
@@ -407,12 +472,141 @@ var MergeScripts = []queries.ScriptTest{
 				Query:    "select message from dolt_log order by date DESC LIMIT 1;",
 				Expected: []sql.Row{{"this is a no-ff"}}, // includes the merge commit created by no-ff
 			},
+			{
+				Query:    "select count(*) from dolt_commit_ancestors where commit_hash = hashof('main');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select parent_hash = hashof('main~1'), parent_index from dolt_commit_ancestors where commit_hash = hashof('main') order by parent_index;",
+				Expected: []sql.Row{{true, 0}, {false, 1}},
+			},
+			{
+				Query:    "select parent_hash = hashof('feature-branch') from dolt_commit_ancestors where commit_hash = hashof('main') and parent_index = 1;",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select message from dolt_log('--merges');",
+				Expected: []sql.Row{{"this is a no-ff"}},
+			},
 			{
 				Query:    "CALL DOLT_CHECKOUT('-b', 'other-branch')",
 				Expected: []sql.Row{{0}},
 			},
 		},
 	},
+	{
+		Name: "CALL DOLT_MERGE squash",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key)",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0),(1),(2);",
+			"CALL DOLT_COMMIT('-a', '-m', 'Step 1');",
+			"CALL DOLT_CHECKOUT('-b', 'feature-branch')",
+			"INSERT INTO test VALUES (3);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 3');",
+			"INSERT INTO test VALUES (4);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 4');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO test VALUES (10);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 10');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('--squash', '--no-ff', 'feature-branch')",
+				ExpectedErrStr: "error: Flags '--squash' and '--no-ff' cannot be used together.\n",
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--squash', 'feature-branch')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT is_merging FROM DOLT_MERGE_STATUS;",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				// the changes of the branch are staged, with no merge in progress
+				Query:    "SELECT table_name, staged, status FROM dolt_status",
+				Expected: []sql.Row{{"test", true, "modified"}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{0}, {1}, {2}, {3}, {4}, {10}},
+			},
+			{
+				Query:            "CALL DOLT_COMMIT('-m', 'squashed feature-branch')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SELECT message FROM dolt_log WHERE commit_hash = hashof('main')",
+				Expected: []sql.Row{{"squashed feature-branch"}},
+			},
+			{
+				// a squash records an ordinary commit, rather than a merge commit
+				Query:    "SELECT COUNT(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('main')",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "CALL DOLT_MERGE ff-only",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key)",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0),(1),(2);",
+			"CALL DOLT_COMMIT('-a', '-m', 'Step 1');",
+			"CALL DOLT_BRANCH('ff-branch')",
+			"CALL DOLT_CHECKOUT('-b', 'feature-branch')",
+			"INSERT INTO test VALUES (3);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 3');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO test VALUES (10);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 10');",
+			"SET @HeadBefore = hashof('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('--ff-only', '--no-ff', 'feature-branch')",
+				ExpectedErrStr: "error: Flags '--ff-only' and '--no-ff' cannot be used together.\n",
+			},
+			{
+				Query:          "CALL DOLT_MERGE('--ff-only', 'feature-branch')",
+				ExpectedErrStr: "fatal: Not possible to fast-forward, aborting",
+			},
+			{
+				// the failed merge leaves HEAD and the working set alone
+				Query:    "SELECT hashof('main') = @HeadBefore",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "SELECT is_merging FROM DOLT_MERGE_STATUS;",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{0}, {1}, {2}, {10}},
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('ff-branch')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--ff-only', 'main')",
+				Expected: []sql.Row{{1, 0}},
+			},
+			{
+				Query:          "CALL DOLT_MERGE('--ff-only', 'feature-branch')",
+				ExpectedErrStr: "fatal: Not possible to fast-forward, aborting",
+			},
+		},
+	},
 	{
 		Name: "CALL DOLT_MERGE with no conflicts works",
 		SetUpScript: []string{
@@ -1179,6 +1373,149 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "CALL DOLT_MERGE with a merge strategy",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key, c0 int, c1 int);",
+			"INSERT INTO test VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'create table');",
+			"CALL DOLT_BRANCH('ours_branch');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"UPDATE test SET c0 = 10 WHERE pk = 1;",
+			"UPDATE test SET c1 = 20 WHERE pk = 2;",
+			"INSERT INTO test VALUES (3, 3, 3);",
+			"CALL DOLT_COMMIT('-am', 'update on feature');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE test SET c0 = 100 WHERE pk = 1;",
+			"UPDATE test SET c1 = 200 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'update on main');",
+			"CALL DOLT_CHECKOUT('ours_branch');",
+			"UPDATE test SET c0 = 1000 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'update on ours_branch');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('-s', 'recursive', 'feature');",
+				ExpectedErrStr: "error: invalid merge strategy 'recursive', expected 'ours' or 'theirs'",
+			},
+			{
+				Query:    "CALL DOLT_MERGE('-s', 'theirs', 'feature');",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_conflicts;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk;",
+				Expected: []sql.Row{{1, 10, 1}, {2, 2, 20}, {3, 3, 3}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"Merge branch 'feature' into main"}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('ours_branch');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--strategy', 'ours', 'feature');",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "SELECT * FROM test ORDER BY pk;",
+				Expected: []sql.Row{{1, 1000, 1}, {2, 2, 20}, {3, 3, 3}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "dolt_conflicts_preview: conflicting rows of a merge without merging",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key, c1 int, c2 int);",
+			"INSERT INTO test VALUES (1, 1, 1), (2, 2, 2), (3, 3, 3);",
+			"CALL DOLT_COMMIT('-Am', 'add test');",
+			"CALL DOLT_BRANCH('feature');",
+			"CALL DOLT_BRANCH('clean');",
+			"UPDATE test SET c1 = 10 WHERE pk = 1;",
+			"DELETE FROM test WHERE pk = 3;",
+			"INSERT INTO test VALUES (4, 4, 4);",
+			"CALL DOLT_COMMIT('-am', 'main changes');",
+			"CALL DOLT_CHECKOUT('feature');",
+			"UPDATE test SET c1 = 100 WHERE pk = 1;",
+			"UPDATE test SET c2 = 30 WHERE pk = 3;",
+			"INSERT INTO test VALUES (4, 40, 40);",
+			"CALL DOLT_COMMIT('-am', 'feature changes');",
+			"CALL DOLT_CHECKOUT('clean');",
+			"UPDATE test SET c2 = 20 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'clean changes');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO test VALUES (5, 5, 5);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_conflicts_preview('feature') ORDER BY our_diff_type, their_diff_type;",
+				Expected: []sql.Row{
+					{"test", nil, types.MustJSON(`{"pk": 4, "c1": 4, "c2": 4}`), "added", types.MustJSON(`{"pk": 4, "c1": 40, "c2": 40}`), "added"},
+					{"test", types.MustJSON(`{"pk": 1, "c1": 1, "c2": 1}`), types.MustJSON(`{"pk": 1, "c1": 10, "c2": 1}`), "modified", types.MustJSON(`{"pk": 1, "c1": 100, "c2": 1}`), "modified"},
+					{"test", types.MustJSON(`{"pk": 3, "c1": 3, "c2": 3}`), nil, "removed", types.MustJSON(`{"pk": 3, "c1": 3, "c2": 30}`), "modified"},
+				},
+			},
+			{
+				Query:    "SELECT * FROM dolt_conflicts_preview('clean');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM dolt_conflicts_preview('main~');",
+				Expected: []sql.Row{},
+			},
+			{
+				// the working set and merge state are left as they were
+				Query:    "SELECT * FROM dolt_status;",
+				Expected: []sql.Row{{"test", false, "modified"}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_merge_status;",
+				Expected: []sql.Row{{false, nil, nil, nil, nil}},
+			},
+			{
+				Query:    "SELECT count(*) FROM test;",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:          "SELECT * FROM dolt_conflicts_preview('doesnotexist');",
+				ExpectedErrStr: "branch not found: doesnotexist",
+			},
+			{
+				Query:       "SELECT * FROM dolt_conflicts_preview('feature', 'clean');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:            "CALL DOLT_COMMIT('-am', 'add row');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "SET dolt_allow_commit_conflicts = on;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature');",
+				Expected: []sql.Row{{0, 1}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_conflicts_test;",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
 }
 
 var Dolt1MergeScripts = []queries.ScriptTest{
@@ -1655,6 +1992,332 @@ var Dolt1MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_conflicts_resolve with --interactive-data",
+		SetUpScript: []string{
+			"set autocommit = 0;",
+			"create table t (pk int primary key, c1 int not null, c2 varchar(10), key c1_idx(c1));",
+			"insert into t values (1, 1, 'one'), (2, 2, 'two');",
+			"call dolt_commit('-Am', 'create table');",
+			"call dolt_checkout('-b', 'other');",
+			"update t set c1 = 10, c2 = 'theirs' where pk = 1;",
+			"update t set c1 = 20 where pk = 2;",
+			"call dolt_commit('-am', 'changes on other');",
+			"call dolt_checkout('main');",
+			"update t set c1 = 100, c2 = 'ours' where pk = 1;",
+			"update t set c1 = 200 where pk = 2;",
+			"call dolt_commit('-am', 'changes on main');",
+			"call dolt_merge('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select base_pk, our_c1, their_c1 from dolt_conflicts_t order by base_pk;",
+				Expected: []sql.Row{{1, 100, 10}, {2, 200, 20}},
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', '--ours', 't', '{\"pk\": 1}', '{\"c1\": 5}');",
+				ExpectedErrStr: "--interactive-data cannot be used with --ours or --theirs",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 1}');",
+				ExpectedErrStr: "--interactive-data takes a table, the primary key of the conflicting row as JSON, and the resolved row as JSON",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 1}', '{\"c3\": 5}');",
+				ExpectedErrStr: "invalid row {\"c3\": 5}: unknown column c3",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 1}', '{\"c2\": \"custom\"}');",
+				ExpectedErrStr: "column name 'c1' is non-nullable but attempted to set a value of null",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 1}', '{\"c1\": \"abc\"}');",
+				ExpectedErrStr: "invalid value for column c1: error: 'abc' is not a valid value for 'int'",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 1}', '{\"pk\": 2, \"c1\": 5}');",
+				ExpectedErrStr: "the row's value for column pk does not match the primary key {\"pk\": 1}",
+			},
+			{
+				Query:          "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 3}', '{\"c1\": 5}');",
+				ExpectedErrStr: "no conflict in table t for primary key {\"pk\": 3}",
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 1}', '{\"c1\": 55, \"c2\": \"custom\"}');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select base_pk, our_c1, their_c1 from dolt_conflicts_t;",
+				Expected: []sql.Row{{2, 200, 20}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, 55, "custom"}, {2, 200, "two"}},
+			},
+			{
+				Query:    "select pk from t where c1 = 55;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--interactive-data', 't', '{\"pk\": 2}', '{\"pk\": 2, \"c1\": 220, \"c2\": null}');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from dolt_conflicts;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from t where c1 = 220;",
+				Expected: []sql.Row{{2, 220, nil}},
+			},
+			{
+				Query:            "call dolt_commit('-am', 'resolved merge');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{"resolved merge"}},
+			},
+		},
+	},
+}
+
+var DoltRevertScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_revert undoes a commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_revert('HEAD~1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{2, 2}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{`Revert "insert 1"`}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_revert with multiple commits and a message",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2');",
+			"insert into t values (3, 3);",
+			"call dolt_commit('-am', 'insert 3');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_revert('HEAD', 'HEAD~2', '-m', 'undo inserts 1 and 3');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{2, 2}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~1..HEAD');",
+				Expected: []sql.Row{{"undo inserts 1 and 3"}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~2..HEAD~1');",
+				Expected: []sql.Row{{"insert 3"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_revert with conflicts",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"update t set c = 10 where pk = 1;",
+			"call dolt_commit('-am', 'update 1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_revert('HEAD~1');",
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error(),
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "set autocommit = 0;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "call dolt_revert('HEAD~1');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select our_pk, our_c, their_pk, their_c from dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 10, nil, nil}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{"update 1"}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--theirs', 't');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:            "call dolt_commit('-am', 'revert insert 1');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select message, (select count(*) from dolt_commit_ancestors a where a.commit_hash = l.commit_hash) from dolt_log l limit 1;",
+				Expected: []sql.Row{{"revert insert 1", 1}},
+			},
+		},
+	},
+}
+
+var DoltCherryPickScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_cherry_pick applies a commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"insert into t values (2, 2);",
+			"call dolt_commit('-am', 'insert 2');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "call dolt_cherry_pick('feature');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{2, 2}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~1..HEAD');",
+				Expected: []sql.Row{{"insert 2"}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "insert into t values (3, 3);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "call dolt_cherry_pick('feature~1');",
+				ExpectedErrStr: dprocedures.ErrCherryPickUncommittedChanges.Error(),
+			},
+		},
+	},
+	{
+		Name: "dolt_cherry_pick with --no-commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_cherry_pick('--no-commit', 'feature');",
+				Expected: []sql.Row{{""}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~1..HEAD');",
+				Expected: []sql.Row{{"create t"}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{{"t", true, "modified"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_cherry_pick with conflicts",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'create t');",
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-am', 'insert 1');",
+			"call dolt_checkout('main');",
+			"insert into t values (1, 10);",
+			"call dolt_commit('-am', 'insert 1 on main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_cherry_pick('feature');",
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error(),
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "set autocommit = 0;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "call dolt_cherry_pick('feature');",
+				Expected: []sql.Row{{""}},
+			},
+			{
+				Query:    "select our_pk, our_c, their_pk, their_c from dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 10, 1, 1}},
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--theirs', 't');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:            "call dolt_commit('-am', 'cherry-picked insert 1');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select message, (select count(*) from dolt_commit_ancestors a where a.commit_hash = l.commit_hash) from dolt_log l limit 1;",
+				Expected: []sql.Row{{"cherry-picked insert 1", 1}},
+			},
+		},
+	},
 }
 
 var KeylessMergeCVsAndConflictsScripts = []queries.ScriptTest{
@@ -2966,6 +3629,46 @@ var SchemaConflictScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "merge strategy resolves schema conflicts",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c0 varchar(20))",
+			"insert into t values (1, '1')",
+			"call dolt_commit('-Am', 'added table t')",
+			"call dolt_checkout('-b', 'other')",
+			"alter table t modify column c0 int",
+			"call dolt_commit('-am', 'altered t on branch other')",
+			"call dolt_checkout('main')",
+			"alter table t modify column c0 varchar(100)",
+			"call dolt_commit('-am', 'altered t on branch main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('-s', 'theirs', 'other')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "select * from dolt_schema_conflicts",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from dolt_status",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "show create table t",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c0` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "select * from t",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1",
+				Expected: []sql.Row{{"Merge branch 'other' into main"}},
+			},
+		},
+	},
 }
 
 // OldFormatMergeConflictsAndCVsScripts tests old format merge behavior
@@ -755,6 +755,68 @@ var DoltTransactionTests = []queries.TransactionTest{
 			},
 		},
 	},
+	{
+		Name: "uncommitted transaction changes are visible in working set system tables of the same session only",
+		SetUpScript: []string{
+			"create table users (id int primary key, name varchar(32))",
+			"insert into users values (1, 'tim'), (2, 'jim')",
+			"call dolt_commit('-A', '-m', 'initial commit')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set autocommit = off",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ update users set name = 'tim2' where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: uint64(1), Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ select * from dolt_status",
+				Expected: []sql.Row{{"users", false, "modified"}},
+			},
+			{
+				Query:    "/* client b */ select * from dolt_status",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select table_name, data_change, schema_change from dolt_diff where commit_hash = 'WORKING'",
+				Expected: []sql.Row{{"users", true, false}},
+			},
+			{
+				Query:    "/* client b */ select table_name from dolt_diff where commit_hash = 'WORKING'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select from_name, to_name, diff_type from dolt_diff_users where to_commit = 'WORKING'",
+				Expected: []sql.Row{{"tim", "tim2", "modified"}},
+			},
+			{
+				Query:    "/* client b */ select from_name, to_name, diff_type from dolt_diff_users where to_commit = 'WORKING'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select from_name, to_name from dolt_diff('HEAD', 'WORKING', 'users')",
+				Expected: []sql.Row{{"tim", "tim2"}},
+			},
+			{
+				Query:    "/* client b */ select from_name, to_name from dolt_diff('HEAD', 'WORKING', 'users')",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from dolt_status",
+				Expected: []sql.Row{{"users", false, "modified"}},
+			},
+			{
+				Query:    "/* client b */ select from_name, to_name, diff_type from dolt_diff_users where to_commit = 'WORKING'",
+				Expected: []sql.Row{{"tim", "tim2", "modified"}},
+			},
+		},
+	},
 }
 
 var DoltConflictHandlingTests = []queries.TransactionTest{
@@ -1345,6 +1407,87 @@ var DoltConflictHandlingTests = []queries.TransactionTest{
 }
 
 var DoltStoredProcedureTransactionTests = []queries.TransactionTest{
+	{
+		Name: "merges staged with --no-commit are seen by other sessions",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key, val int)",
+			"CALL DOLT_ADD('.')",
+			"INSERT INTO test VALUES (0, 0)",
+			"CALL DOLT_COMMIT('-a', '-m', 'Step 1');",
+			"CALL DOLT_CHECKOUT('-b', 'feature-branch')",
+			"INSERT INTO test VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 1');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO test VALUES (2, 2);",
+			"CALL DOLT_COMMIT('-a', '-m', 'add 2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ CALL DOLT_MERGE('feature-branch', '--no-commit')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "/* client a */ SELECT table_name, staged, status FROM dolt_status",
+				Expected: []sql.Row{{"test", true, "modified"}},
+			},
+			{
+				Query:    "/* client b */ SELECT is_merging FROM dolt_merge_status",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query:    "/* client b */ SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{0, 0}, {2, 2}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ SELECT is_merging, source FROM dolt_merge_status",
+				Expected: []sql.Row{{true, "feature-branch"}},
+			},
+			{
+				Query:    "/* client b */ SELECT table_name, staged, status FROM dolt_status",
+				Expected: []sql.Row{{"test", true, "modified"}},
+			},
+			{
+				Query:    "/* client b */ SELECT * FROM test ORDER BY pk",
+				Expected: []sql.Row{{0, 0}, {1, 1}, {2, 2}},
+			},
+			{
+				Query:            "/* client b */ CALL DOLT_COMMIT('-m', 'merge feature-branch')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ SELECT is_merging FROM dolt_merge_status",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query:    "/* client a */ SELECT COUNT(*) FROM dolt_commit_ancestors WHERE commit_hash = HASHOF('HEAD')",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
 	{
 		Name: "committed conflicts are seen by other sessions",
 		SetUpScript: []string{
@@ -2155,7 +2298,373 @@ var DoltConstraintViolationTransactionTests = []queries.TransactionTest{
 	//	},
 }
 
+var DatabaseWriteLockTests = []queries.TransactionTest{
+	{
+		Name: "write lock blocks writes from other sessions",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int)",
+			"insert into t values (1, 1)",
+			"call dolt_commit('-Am', 'new table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ select dolt_database_lock_status()",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:    "/* client a */ call dolt_lock_database('maintenance window')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select dolt_database_lock_status()",
+				Expected: []sql.Row{{"maintenance window (held by connection 1)"}},
+			},
+			{
+				Query:          "/* client b */ insert into t values (2, 2)",
+				ExpectedErrStr: "database locked: maintenance window (held by connection 1)",
+			},
+			{
+				Query:          "/* client b */ call dolt_branch('b1')",
+				ExpectedErrStr: "database locked: maintenance window (held by connection 1)",
+			},
+			{
+				Query:          "/* client b */ call dolt_checkout('-b', 'b2')",
+				ExpectedErrStr: "database locked: maintenance window (held by connection 1)",
+			},
+			{
+				Query:    "/* client b */ select count(*) from dolt_branches where name = 'b2'",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client b */ call dolt_lock_database('mine now')",
+				ExpectedErrStr: "database locked: maintenance window (held by connection 1)",
+			},
+			{
+				Query:          "/* client b */ call dolt_unlock_database()",
+				ExpectedErrStr: "database mydb is not locked by this connection",
+			},
+			{
+				Query:    "/* client a */ insert into t values (3, 3)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:            "/* client a */ call dolt_commit('-am', 'locked commit')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {3, 3}},
+			},
+			{
+				Query:    "/* client a */ call dolt_unlock_database()",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select dolt_database_lock_status()",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:    "/* client b */ insert into t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ call dolt_branch('b1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_checkout('-b', 'b2')",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "write lock blocks dolt_stash and dolt_stash_pop from other sessions",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int)",
+			"insert into t values (1, 1)",
+			"call dolt_commit('-Am', 'new table')",
+			"update t set c = 2 where pk = 1",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_lock_database('maintenance window')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client b */ call dolt_stash()",
+				ExpectedErrStr: "database locked: maintenance window (held by connection 1)",
+			},
+			{
+				Query:    "/* client b */ select count(*) from dolt_stashes",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ call dolt_stash()",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client b */ call dolt_stash_pop()",
+				ExpectedErrStr: "database locked: maintenance window (held by connection 1)",
+			},
+			{
+				Query:    "/* client b */ select count(*) from dolt_stashes",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client a */ call dolt_unlock_database()",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_stash_pop()",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select * from t",
+				Expected: []sql.Row{{1, 2}},
+			},
+		},
+	},
+	{
+		Name: "write lock applies to revision databases",
+		SetUpScript: []string{
+			"create table t (pk int primary key)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('b1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_lock_database('migration')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client b */ insert into `mydb/b1`.t values (1)",
+				ExpectedErrStr: "database locked: migration (held by connection 1)",
+			},
+			{
+				Query:    "/* client a */ insert into `mydb/b1`.t values (1)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+		},
+	},
+}
+
+// DoltMetricsTransactionTests check that the counters reported by dolt_metrics() move when transactions conflict.
+// Counters are shared by every test in the process, so each test compares them to values saved when it begins.
+var DoltMetricsTransactionTests = []queries.TransactionTest{
+	{
+		Name: "transaction merge and conflict metrics",
+		SetUpScript: []string{
+			"create table t (x int primary key, y int)",
+			"insert into t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "/* client a */ select name from dolt_metrics()",
+				Expected: []sql.Row{
+					{"dolt_transaction_commits_total"},
+					{"dolt_transaction_merges_total"},
+					{"dolt_transaction_merge_conflicts_total"},
+					{"dolt_transaction_retries_total"},
+					{"dolt_commit_total"},
+					{"dolt_merge_conflict_tables_total"},
+					{"dolt_gc_runs_total"},
+				},
+			},
+			{
+				Query:    "/* client a */ set @merges = (select value from dolt_metrics() where name = 'dolt_transaction_merges_total')",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ set @conflicts = (select value from dolt_metrics() where name = 'dolt_transaction_merge_conflicts_total')",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ insert into t values (3, 3)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				// client b's changes are merged with client a's
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select value - @merges = 1 from dolt_metrics() where name = 'dolt_transaction_merges_total'",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "/* client a */ select value - @conflicts = 0 from dolt_metrics() where name = 'dolt_transaction_merge_conflicts_total'",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into t values (4, 4)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ insert into t values (4, 5)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client b */ commit",
+				ExpectedErrStr: sql.ErrLockDeadlock.New(dsess.ErrRetryTransaction.Error()).Error(),
+			},
+			{
+				// the conflicting commit was merged before it was rolled back
+				Query:    "/* client a */ select value - @merges = 2 from dolt_metrics() where name = 'dolt_transaction_merges_total'",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "/* client a */ select value - @conflicts = 1 from dolt_metrics() where name = 'dolt_transaction_merge_conflicts_total'",
+				Expected: []sql.Row{{true}},
+			},
+		},
+	},
+	{
+		Name: "commit and merge conflict metrics",
+		SetUpScript: []string{
+			"create table t (x int primary key, y int)",
+			"insert into t values (1, 1)",
+			"call dolt_add('.')",
+			"call dolt_commit('-m', 'create t')",
+			"call dolt_branch('other')",
+			"update t set y = 2 where x = 1",
+			"call dolt_commit('-am', 'update on main')",
+			"call dolt_checkout('other')",
+			"update t set y = 3 where x = 1",
+			"call dolt_commit('-am', 'update on other')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set @commits = (select value from dolt_metrics() where name = 'dolt_commit_total')",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ set @conflictTables = (select value from dolt_metrics() where name = 'dolt_merge_conflict_tables_total')",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client b */ insert into t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:            "/* client b */ call dolt_commit('-am', 'insert on main')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ select value - @commits = 1 from dolt_metrics() where name = 'dolt_commit_total'",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "/* client a */ set autocommit = off",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ call dolt_merge('other')",
+				Expected: []sql.Row{{0, 1}},
+			},
+			{
+				Query:    "/* client a */ select value - @conflictTables = 1 from dolt_metrics() where name = 'dolt_merge_conflict_tables_total'",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+}
+
 var BranchIsolationTests = []queries.TransactionTest{
+	{
+		Name: "clients on different branches see their own branch's procedures",
+		SetUpScript: []string{
+			"create procedure p1() select 'main'",
+			"call dolt_commit('-Am', 'main procedure')",
+			"call dolt_checkout('-b', 'b1')",
+			"drop procedure p1",
+			"create procedure p1() select 'b1'",
+			"call dolt_commit('-Am', 'b1 procedure')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client b */ call dolt_checkout('b1')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ call p1()",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "/* client b */ call p1()",
+				Expected: []sql.Row{{"b1"}},
+			},
+			{
+				Query:    "/* client b */ drop procedure p1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ call p1()",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:    "/* client a */ select routine_name from information_schema.routines where routine_schema = 'mydb'",
+				Expected: []sql.Row{{"p1"}},
+			},
+			{
+				Query:          "/* client b */ call p1()",
+				ExpectedErrStr: "stored procedure \"p1\" does not exist",
+			},
+			{
+				Query:    "/* client b */ select routine_name from information_schema.routines where routine_schema = 'mydb'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:            "/* client b */ call dolt_checkout('main')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client b */ call p1()",
+				Expected: []sql.Row{{"main"}},
+			},
+			{
+				Query:            "/* client a */ call dolt_checkout('b1')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:          "/* client a */ call p1()",
+				ExpectedErrStr: "stored procedure \"p1\" does not exist",
+			},
+		},
+	},
 	{
 		Name: "clients can't see changes on other branch working sets made since transaction start",
 		SetUpScript: []string{
@@ -2371,3 +2880,185 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 		},
 	},
 }
+
+var DoltRebaseTransactionTests = []queries.TransactionTest{
+	{
+		Name: "a rebase stopped with conflicts is aborted by another session",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int)",
+			"insert into t values (1, 1)",
+			"call dolt_commit('-Am', 'create table t')",
+			"call dolt_checkout('-b', 'feature')",
+			"insert into t values (2, 2)",
+			"call dolt_commit('-am', 'insert 2 on feature')",
+			"update t set c = 10 where pk = 1",
+			"call dolt_commit('-am', 'update 1 on feature')",
+			"call dolt_checkout('main')",
+			"update t set c = 100 where pk = 1",
+			"call dolt_commit('-am', 'update 1 on main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_checkout('feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ set dolt_allow_commit_conflicts = on",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ call dolt_rebase('main')",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ call dolt_checkout('feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select message from dolt_log limit 2",
+				Expected: []sql.Row{{"insert 2 on feature"}, {"update 1 on main"}},
+			},
+			{
+				Query:          "/* client b */ call dolt_rebase('main')",
+				ExpectedErrStr: "a rebase is already in progress, use --continue or --abort",
+			},
+			{
+				Query:    "/* client b */ call dolt_rebase('--abort')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select message from dolt_log limit 3",
+				Expected: []sql.Row{{"update 1 on feature"}, {"insert 2 on feature"}, {"create table t"}},
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 2}},
+			},
+			{
+				Query:    "/* client a */ select count(*) from dolt_conflicts",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client a */ call dolt_rebase('--continue')",
+				ExpectedErrStr: "no rebase in progress",
+			},
+		},
+	},
+	{
+		Name: "a rebase stopped with conflicts is continued by another session",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int)",
+			"insert into t values (1, 1)",
+			"call dolt_commit('-Am', 'create table t')",
+			"call dolt_checkout('-b', 'feature')",
+			"update t set c = 10 where pk = 1",
+			"call dolt_commit('-am', 'update 1 on feature')",
+			"insert into t values (2, 2)",
+			"call dolt_commit('-am', 'insert 2 on feature')",
+			"insert into t values (3, 3)",
+			"call dolt_commit('-am', 'insert 3 on feature')",
+			"call dolt_checkout('main')",
+			"update t set c = 100 where pk = 1",
+			"call dolt_commit('-am', 'update 1 on main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_checkout('feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ set dolt_allow_commit_conflicts = on",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ call dolt_rebase('main')",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ call dolt_checkout('feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select base_c, our_c, their_c from dolt_conflicts_t",
+				Expected: []sql.Row{{1, 100, 10}},
+			},
+			{
+				Query:          "/* client b */ call dolt_rebase('--continue')",
+				ExpectedErrStr: "error: cannot continue the rebase with unresolved conflicts in table {'t'}",
+			},
+			{
+				Query:    "/* client b */ call dolt_conflicts_resolve('--theirs', 't')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_rebase('--continue')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select message from dolt_log limit 5",
+				Expected: []sql.Row{{"insert 3 on feature"}, {"insert 2 on feature"}, {"update 1 on feature"}, {"update 1 on main"}, {"create table t"}},
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:          "/* client a */ call dolt_rebase('--abort')",
+				ExpectedErrStr: "no rebase in progress",
+			},
+		},
+	},
+}
+
+var DoltAutoIncrementTransactionTests = []queries.TransactionTest{
+	{
+		Name: "merge abort doesn't give out values another session was given",
+		SetUpScript: []string{
+			"create table t (a int primary key auto_increment, b int)",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'empty table')",
+			"call dolt_branch('branch1')",
+			"insert into t (b) values (1), (2)",
+			"call dolt_commit('-am', 'two values on main')",
+			"call dolt_checkout('branch1')",
+			"insert into t (b) values (3), (4)",
+			"call dolt_commit('-am', 'two values on branch1')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ call dolt_merge('branch1', '--no-commit')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				Query:    "/* client a */ insert into t (b) values (5)",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, InsertID: 5}}},
+			},
+			{
+				Query:    "/* client b */ insert into t (b) values (6)",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, InsertID: 6}}},
+			},
+			{
+				Query:    "/* client a */ call dolt_merge('--abort')",
+				Expected: []sql.Row{{0, 0}},
+			},
+			{
+				// client b's transaction is still open, so the value it was given isn't given out again
+				Query:    "/* client a */ insert into t (b) values (7)",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, InsertID: 7}}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t order by a",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {6, 6}, {7, 7}},
+			},
+		},
+	},
+}
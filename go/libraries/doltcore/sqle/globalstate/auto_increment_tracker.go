@@ -20,6 +20,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -28,7 +29,10 @@ import (
 
 type AutoIncrementTracker struct {
 	sequences map[string]uint64
-	mu        *sync.Mutex
+	// allocators records, for each table, the session that has been given values from its sequence since the sequence
+	// was last reset. It's nil if more than one session has been given values.
+	allocators map[string]sql.Session
+	mu         *sync.Mutex
 }
 
 // NewAutoIncrementTracker returns a new autoincrement tracker for the roots given. All roots sets must be
@@ -37,8 +41,9 @@ type AutoIncrementTracker struct {
 // branches that don't have a local working set)
 func NewAutoIncrementTracker(ctx context.Context, roots ...doltdb.Rootish) (AutoIncrementTracker, error) {
 	ait := AutoIncrementTracker{
-		sequences: make(map[string]uint64),
-		mu:        &sync.Mutex{},
+		sequences:  make(map[string]uint64),
+		allocators: make(map[string]sql.Session),
+		mu:         &sync.Mutex{},
 	}
 
 	for _, ws := range roots {
@@ -83,8 +88,8 @@ func (a AutoIncrementTracker) Current(tableName string) uint64 {
 }
 
 // Next returns the next auto increment value for the table named using the provided value from an insert (which may
-// be null or 0, in which case it will be generated from the sequence).
-func (a AutoIncrementTracker) Next(tbl string, insertVal interface{}) (uint64, error) {
+// be null or 0, in which case it will be generated from the sequence) by the session in |ctx|.
+func (a AutoIncrementTracker) Next(ctx *sql.Context, tbl string, insertVal interface{}) (uint64, error) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -100,12 +105,14 @@ func (a AutoIncrementTracker) Next(tbl string, insertVal interface{}) (uint64, e
 	if given == 0 {
 		// |given| is 0 or NULL
 		a.sequences[tbl]++
+		a.addAllocator(tbl, ctx.Session)
 		return curr, nil
 	}
 
 	if given >= curr {
 		a.sequences[tbl] = given
 		a.sequences[tbl]++
+		a.addAllocator(tbl, ctx.Session)
 		return given, nil
 	}
 
@@ -113,6 +120,15 @@ func (a AutoIncrementTracker) Next(tbl string, insertVal interface{}) (uint64, e
 	return given, nil
 }
 
+// addAllocator records that |sess| was given a value from the sequence of the table named. Callers must hold |a.mu|.
+func (a AutoIncrementTracker) addAllocator(tableName string, sess sql.Session) {
+	if existing, ok := a.allocators[tableName]; ok && existing != sess {
+		a.allocators[tableName] = nil
+	} else {
+		a.allocators[tableName] = sess
+	}
+}
+
 // CoerceAutoIncrementValue converts |val| into an AUTO_INCREMENT sequence value
 func CoerceAutoIncrementValue(val interface{}) (uint64, error) {
 	switch typ := val.(type) {
@@ -167,8 +183,33 @@ func (a AutoIncrementTracker) DropTable(ctx context.Context, tableName string, w
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	return a.resetTable(ctx, strings.ToLower(tableName), wses...)
+}
+
+// ResetTable resets the auto increment value for the table named to the highest one in the working sets given, or to 1
+// if none of them has such a table. Unlike Set, this can move the sequence backwards, which is how values given out
+// for changes that have since been thrown away, like the ones in an aborted merge, are handed out again. The sequence
+// is only reset if no session other than the one in |ctx| has been given values from it since it was last reset,
+// since that session may still be writing them, and they must not be given out again.
+func (a AutoIncrementTracker) ResetTable(ctx *sql.Context, tableName string, wses ...*doltdb.WorkingSet) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tableName = strings.ToLower(tableName)
+	if sess, ok := a.allocators[tableName]; ok && sess != ctx.Session {
+		return nil
+	}
+
+	return a.resetTable(ctx, tableName, wses...)
+}
+
+// resetTable resets the sequence for the lower-cased table name given, like ResetTable, whether or not other sessions
+// have been given values from it. Callers must hold |a.mu|.
+func (a AutoIncrementTracker) resetTable(ctx context.Context, tableName string, wses ...*doltdb.WorkingSet) error {
+	delete(a.allocators, tableName)
+
 	// reset sequence to the minimum value
-	a.sequences[strings.ToLower(tableName)] = 1
+	a.sequences[tableName] = 1
 
 	// Get the new highest value from all tables in the working sets given
 	for _, ws := range wses {
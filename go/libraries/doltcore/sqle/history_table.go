@@ -27,12 +27,17 @@ import (
 	"github.com/dolthub/vitess/go/sqltypes"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
 )
 
 const (
@@ -44,6 +49,14 @@ const (
 
 	// CommitDateCol is the name of the column containing the commit date in the result set
 	CommitDateCol = "commit_date"
+
+	// CommitOrderCol is the name of the column containing the commit's height in the commit graph, which orders commits
+	// topologically regardless of their dates
+	CommitOrderCol = "commit_order"
+
+	// DeletedCol is the name of the column that is true for the rows recording a primary key that was deleted at a
+	// commit, which have only their primary key columns set. Those rows are only returned by queries that read it.
+	DeletedCol = "deleted"
 )
 
 var (
@@ -64,6 +77,7 @@ type HistoryTable struct {
 	doltTable     *DoltTable
 	commitFilters []sql.Expression
 	cmItr         doltdb.CommitItr
+	head          *doltdb.Commit
 	commitCheck   doltdb.CommitFilter
 	indexLookup   sql.IndexLookup
 	projectedCols []uint64
@@ -117,6 +131,10 @@ func (ht *HistoryTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLooku
 			}
 			metas = append(metas, meta)
 		}
+		hashes, commits, err := ht.commitsInHistory(ctx, hashes, commits)
+		if err != nil {
+			return nil, err
+		}
 		if len(hashes) == 0 {
 			return sql.PartitionsToPartitionIter(), nil
 		}
@@ -132,22 +150,105 @@ func (ht *HistoryTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLooku
 	return ht.Partitions(ctx)
 }
 
-// NewHistoryTable creates a history table
+// NewHistoryTable creates a history table. The history of the table starts at the commit that created it, so a
+// table of the same name that was dropped before it was created isn't included.
 func NewHistoryTable(table *DoltTable, ddb *doltdb.DoltDB, head *doltdb.Commit) sql.Table {
-	cmItr := doltdb.CommitItrForRoots(ddb, head)
+	cmItr := doltdb.CommitItrForRootsUntil(ddb, tableMissingAtCommit(table.Name()), head)
 
 	h := &HistoryTable{
 		doltTable: table,
 		cmItr:     cmItr,
+		head:      head,
 	}
 	return h
 }
 
+// commitsInHistory returns the commits of |hashes| and |commits| that are part of the table's history. Commits that
+// don't have the table are left out without walking any history. For the others, the history is walked from HEAD
+// until each of them is found, but not past the height of the lowest of them, since no commit below it can lead to it.
+func (ht *HistoryTable) commitsInHistory(ctx *sql.Context, hashes []hash.Hash, commits []*doltdb.Commit) ([]hash.Hash, []*doltdb.Commit, error) {
+	tableMissing := tableMissingAtCommit(ht.doltTable.Name())
+
+	remaining := make(map[hash.Hash]struct{}, len(hashes))
+	var minHeight uint64
+	for i, h := range hashes {
+		missing, err := tableMissing(ctx, h, commits[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		if missing {
+			continue
+		}
+
+		height, err := commits[i].Height()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(remaining) == 0 || height < minHeight {
+			minHeight = height
+		}
+		remaining[h] = struct{}{}
+	}
+
+	stop := func(ctx context.Context, h hash.Hash, cm *doltdb.Commit) (bool, error) {
+		height, err := cm.Height()
+		if err != nil {
+			return false, err
+		}
+		if height < minHeight {
+			return true, nil
+		}
+		return tableMissing(ctx, h, cm)
+	}
+
+	ddb := ht.doltTable.db.DbData().Ddb
+	iter := doltdb.CommitItrForRootsUntil(ddb, stop, ht.head)
+	found := make(map[hash.Hash]struct{}, len(remaining))
+	for len(remaining) > 0 {
+		h, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := remaining[h]; ok {
+			delete(remaining, h)
+			found[h] = struct{}{}
+		}
+	}
+
+	var inHistoryHashes []hash.Hash
+	var inHistoryCommits []*doltdb.Commit
+	for i, h := range hashes {
+		if _, ok := found[h]; ok {
+			inHistoryHashes = append(inHistoryHashes, h)
+			inHistoryCommits = append(inHistoryCommits, commits[i])
+		}
+	}
+	return inHistoryHashes, inHistoryCommits, nil
+}
+
+// tableMissingAtCommit returns a CommitFilter matching the commits that don't have the table named. The commits walked
+// for the table's history stop at these, since any earlier table of the same name was dropped and isn't the same table.
+func tableMissingAtCommit(tableName string) doltdb.CommitFilter {
+	return func(ctx context.Context, _ hash.Hash, cm *doltdb.Commit) (bool, error) {
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return false, err
+		}
+		_, _, ok, err := root.GetTableInsensitive(ctx, tableName)
+		if err != nil {
+			return false, err
+		}
+		return !ok, nil
+	}
+}
+
 // History table schema returns the corresponding history table schema for the base table given, which consists of
-// the table's schema with 3 additional columns
+// the table's schema with up to 5 additional columns
 func historyTableSchema(tableName string, table *DoltTable) sql.Schema {
 	baseSch := table.Schema().Copy()
-	newSch := make(sql.Schema, len(baseSch), len(baseSch)+3)
+	newSch := make(sql.Schema, len(baseSch), len(baseSch)+5)
 
 	for i, col := range baseSch {
 		// Returning a schema from a single table with multiple table names can confuse parts of the analyzer
@@ -172,12 +273,34 @@ func historyTableSchema(tableName string, table *DoltTable) sql.Schema {
 			Type:   types.Datetime,
 		},
 	)
+	if !historyMetaColShadowed(table.sch, CommitOrderCol) {
+		newSch = append(newSch, &sql.Column{
+			Name:   CommitOrderCol,
+			Source: tableName,
+			Type:   types.Uint64,
+		})
+	}
+	if !historyMetaColShadowed(table.sch, DeletedCol) {
+		newSch = append(newSch, &sql.Column{
+			Name:   DeletedCol,
+			Source: tableName,
+			Type:   types.Boolean,
+		})
+	}
 	return newSch
 }
 
+// historyMetaColShadowed returns whether the table has a column of its own named |name|. The commit_order and deleted
+// columns were added to history tables after tables could already have columns with those names, so for such a table
+// its own column is kept, and the history meta column is left out.
+func historyMetaColShadowed(sch schema.Schema, name string) bool {
+	_, ok := sch.GetAllCols().LowerNameToCol[name]
+	return ok
+}
+
 // HandledFilters returns the list of filters that will be handled by the table itself
 func (ht *HistoryTable) HandledFilters(filters []sql.Expression) []sql.Expression {
-	ht.commitFilters = dtables.FilterFilters(filters, dtables.ColumnPredicate(historyTableCommitMetaCols))
+	ht.commitFilters = dtables.FilterFilters(filters, dtables.ColumnPredicate(ht.commitMetaCols()))
 	return ht.commitFilters
 }
 
@@ -189,7 +312,7 @@ func (ht *HistoryTable) Filters() []sql.Expression {
 // WithFilters returns a new sql.Table instance with the filters applied. We handle filters on any commit columns.
 func (ht *HistoryTable) WithFilters(ctx *sql.Context, filters []sql.Expression) sql.Table {
 	ret := *ht
-	ret.commitFilters = dtables.FilterFilters(filters, dtables.ColumnPredicate(historyTableCommitMetaCols))
+	ret.commitFilters = dtables.FilterFilters(filters, dtables.ColumnPredicate(ht.commitMetaCols()))
 	return &ret
 }
 
@@ -231,7 +354,16 @@ func substituteWorkingHash(h hash.Hash, f []sql.Expression) []sql.Expression {
 	return ret
 }
 
-var historyTableCommitMetaCols = set.NewStrSet([]string{CommitHashCol, CommitDateCol, CommitterCol})
+var historyTableCommitMetaCols = set.NewStrSet([]string{CommitHashCol, CommitDateCol, CommitterCol, CommitOrderCol})
+
+// commitMetaCols returns the names of the commit columns whose filters are handled by the table. When the table has its
+// own commit_order column, filters on it are left to the rows.
+func (ht *HistoryTable) commitMetaCols() *set.StrSet {
+	if historyMetaColShadowed(ht.doltTable.sch, CommitOrderCol) {
+		return set.NewStrSet([]string{CommitHashCol, CommitDateCol, CommitterCol})
+	}
+	return historyTableCommitMetaCols
+}
 
 func commitFilterForExprs(ctx *sql.Context, filters []sql.Expression) (doltdb.CommitFilter, error) {
 	filters = transformFilters(ctx, filters...)
@@ -243,16 +375,21 @@ func commitFilterForExprs(ctx *sql.Context, filters []sql.Expression) (doltdb.Co
 			return false, err
 		}
 
+		height, err := cm.Height()
+		if err != nil {
+			return false, err
+		}
+
 		sc := sql.NewContext(ctx)
-		r := sql.Row{h.String(), meta.Name, meta.Time()}
+		r := sql.Row{h.String(), meta.Name, meta.Time(), height}
 
+		// a commit is kept only if every filter is true, as a Filter node would, so a NULL result filters it out
 		for _, filter := range filters {
-			res, err := filter.Eval(sc, r)
+			res, err := sql.EvaluateCondition(sc, filter, r)
 			if err != nil {
 				return false, err
 			}
-			b, ok := res.(bool)
-			if ok && !b {
+			if !sql.IsTrue(res) {
 				return true, nil
 			}
 		}
@@ -275,6 +412,8 @@ func transformFilters(ctx *sql.Context, filters ...sql.Expression) []sql.Express
 				return gf.WithIndex(1), transform.NewTree, nil
 			case CommitDateCol:
 				return gf.WithIndex(2), transform.NewTree, nil
+			case CommitOrderCol:
+				return gf.WithIndex(3), transform.NewTree, nil
 			default:
 				return gf, transform.SameTree, nil
 			}
@@ -298,6 +437,10 @@ func (ht *HistoryTable) WithProjections(colNames []string) sql.Table {
 				nt.projectedCols[i] = schema.HistoryCommitterTag
 			case CommitDateCol:
 				nt.projectedCols[i] = schema.HistoryCommitDateTag
+			case CommitOrderCol:
+				nt.projectedCols[i] = schema.HistoryCommitOrderTag
+			case DeletedCol:
+				nt.projectedCols[i] = schema.HistoryDeletedTag
 			default:
 			}
 		} else {
@@ -330,6 +473,10 @@ func (ht *HistoryTable) Projections() []string {
 				names[i] = CommitterCol
 			case schema.HistoryCommitDateTag:
 				names[i] = CommitDateCol
+			case schema.HistoryCommitOrderTag:
+				names[i] = CommitOrderCol
+			case schema.HistoryDeletedTag:
+				names[i] = DeletedCol
 			default:
 			}
 		}
@@ -342,7 +489,17 @@ func (ht *HistoryTable) ProjectedTags() []uint64 {
 		return ht.projectedCols
 	}
 	// Otherwise (no projection), return the tags for the underlying table with the extra meta tags appended
-	return append(ht.doltTable.ProjectedTags(), schema.HistoryCommitHashTag, schema.HistoryCommitterTag, schema.HistoryCommitDateTag)
+	tableTags := ht.doltTable.ProjectedTags()
+	tags := make([]uint64, len(tableTags), len(tableTags)+5)
+	copy(tags, tableTags)
+	tags = append(tags, schema.HistoryCommitHashTag, schema.HistoryCommitterTag, schema.HistoryCommitDateTag)
+	if !historyMetaColShadowed(ht.doltTable.sch, CommitOrderCol) {
+		tags = append(tags, schema.HistoryCommitOrderTag)
+	}
+	if !historyMetaColShadowed(ht.doltTable.sch, DeletedCol) {
+		tags = append(tags, schema.HistoryDeletedTag)
+	}
+	return tags
 }
 
 // Name returns the name of the history table
@@ -386,6 +543,18 @@ func (ht *HistoryTable) Schema() sql.Schema {
 				Source: ht.Name(),
 				Type:   types.Datetime,
 			}
+		} else if t == schema.HistoryCommitOrderTag {
+			projectedSch[i] = &sql.Column{
+				Name:   CommitOrderCol,
+				Source: ht.Name(),
+				Type:   types.Uint64,
+			}
+		} else if t == schema.HistoryDeletedTag {
+			projectedSch[i] = &sql.Column{
+				Name:   DeletedCol,
+				Source: ht.Name(),
+				Type:   types.Boolean,
+			}
 		} else {
 			panic("column not found")
 		}
@@ -429,8 +598,14 @@ type commitPartitioner struct {
 	cmItr doltdb.CommitItr
 }
 
-// Next returns the next partition and nil, io.EOF when complete
+// Next returns the next partition and nil, io.EOF when complete. Commits are walked lazily, one per call, so a query
+// that stops reading partitions early, like one with a LIMIT and no ORDER BY, never loads the older history. Stops
+// with the context's error once the query is cancelled or finished.
 func (cp commitPartitioner) Next(ctx *sql.Context) (sql.Partition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	h, cm, err := cp.cmItr.Next(ctx)
 
 	if err != nil {
@@ -451,10 +626,17 @@ type historyIter struct {
 	currPart         sql.RowIter
 	rowConverter     func(row sql.Row) sql.Row
 	nonExistentTable bool
+	// loadDeleted loads the rows of the primary keys deleted at this commit, which follow the rows of the table. It's
+	// only called once every row of the table has been read, and is nil after that.
+	loadDeleted func(ctx *sql.Context) ([]sql.Row, error)
+	partsDone   bool
+	deleted     []sql.Row
 }
 
 func newRowItrForTableAtCommit(ctx *sql.Context, table *DoltTable, h hash.Hash, cm *doltdb.Commit, lookup sql.IndexLookup, projections []uint64) (*historyIter, error) {
 	targetSchema := table.Schema()
+	targetSch := table.sch
+	targetCols := targetSch.GetAllCols()
 
 	root, err := cm.GetRootValue(ctx)
 	if err != nil {
@@ -466,7 +648,7 @@ func newRowItrForTableAtCommit(ctx *sql.Context, table *DoltTable, h hash.Hash,
 		return nil, err
 	}
 
-	_, _, ok, err := root.GetTableInsensitive(ctx, table.Name())
+	tbl, _, ok, err := root.GetTableInsensitive(ctx, table.Name())
 	if err != nil {
 		return nil, err
 	}
@@ -474,6 +656,7 @@ func newRowItrForTableAtCommit(ctx *sql.Context, table *DoltTable, h hash.Hash,
 		return &historyIter{nonExistentTable: true}, nil
 	}
 
+	tableName := table.Name()
 	table, err = table.LockedToRoot(ctx, root)
 	if err != nil {
 		return nil, err
@@ -508,12 +691,35 @@ func newRowItrForTableAtCommit(ctx *sql.Context, table *DoltTable, h hash.Hash,
 		}
 	}
 
-	converter := rowConverter(table.Schema(), targetSchema, h, meta, projections)
-	return &historyIter{
+	height, err := cm.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	converter := rowConverter(table.Schema(), targetSchema, h, meta, height, projections)
+	iter := &historyIter{
 		table:           histTable,
 		tablePartitions: partIter,
 		rowConverter:    converter,
-	}, nil
+	}
+	// The rows of deleted keys are only returned when the deleted column is read, so that they can be told apart from
+	// the table's rows, and so that queries that don't ask about deletions don't diff each commit against its parents.
+	if projectsDeleted(projections) {
+		iter.loadDeleted = func(ctx *sql.Context) ([]sql.Row, error) {
+			return deletedRows(ctx, tableName, tbl, cm, targetCols, h, meta, height, projections)
+		}
+	}
+	return iter, nil
+}
+
+// projectsDeleted returns whether the deleted column is one of the history columns projected
+func projectsDeleted(projections []uint64) bool {
+	for _, t := range projections {
+		if t == schema.HistoryDeletedTag {
+			return true
+		}
+	}
+	return false
 }
 
 // Next retrieves the next row. It will return io.EOF if it's the last row. After retrieving the last row, Close
@@ -523,9 +729,16 @@ func (i *historyIter) Next(ctx *sql.Context) (sql.Row, error) {
 		return nil, io.EOF
 	}
 
+	if i.partsDone {
+		return i.nextDeleted(ctx)
+	}
+
 	if i.currPart == nil {
 		nextPart, err := i.tablePartitions.Next(ctx)
-		if err != nil {
+		if err == io.EOF {
+			i.partsDone = true
+			return i.nextDeleted(ctx)
+		} else if err != nil {
 			return nil, err
 		}
 
@@ -540,7 +753,11 @@ func (i *historyIter) Next(ctx *sql.Context) (sql.Row, error) {
 
 	r, err := i.currPart.Next(ctx)
 	if err == io.EOF {
+		err = i.currPart.Close(ctx)
 		i.currPart = nil
+		if err != nil {
+			return nil, err
+		}
 		return i.Next(ctx)
 	} else if err != nil {
 		return nil, err
@@ -549,19 +766,56 @@ func (i *historyIter) Next(ctx *sql.Context) (sql.Row, error) {
 	return i.rowConverter(r), nil
 }
 
+// nextDeleted returns the next row of the primary keys deleted at this commit, loading them on the first call
+func (i *historyIter) nextDeleted(ctx *sql.Context) (sql.Row, error) {
+	if i.loadDeleted != nil {
+		rows, err := i.loadDeleted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		i.loadDeleted, i.deleted = nil, rows
+	}
+
+	if len(i.deleted) == 0 {
+		return nil, io.EOF
+	}
+	r := i.deleted[0]
+	i.deleted = i.deleted[1:]
+	return r, nil
+}
+
+// Close closes the partition being read and the table's partitions. A LIMIT without an ORDER BY closes the iterator
+// as soon as it has enough rows, so the rest of the table at this commit is never read.
 func (i *historyIter) Close(ctx *sql.Context) error {
-	return nil
+	if i.nonExistentTable {
+		return nil
+	}
+
+	var err error
+	if i.currPart != nil {
+		err = i.currPart.Close(ctx)
+		i.currPart = nil
+	}
+	if cerr := i.tablePartitions.Close(ctx); err == nil {
+		err = cerr
+	}
+	return err
 }
 
-func rowConverter(srcSchema, targetSchema sql.Schema, h hash.Hash, meta *datas.CommitMeta, projections []uint64) func(row sql.Row) sql.Row {
+func rowConverter(srcSchema, targetSchema sql.Schema, h hash.Hash, meta *datas.CommitMeta, height uint64, projections []uint64) func(row sql.Row) sql.Row {
 	srcToTarget := make(map[int]int)
+	// conversions holds the target type for source columns whose values must be converted to the current type
+	conversions := make(map[int]sql.Type)
 	for i, col := range targetSchema {
 		srcIdx := srcSchema.IndexOfColName(col.Name)
 		if srcIdx >= 0 {
-			// only add a conversion if the type is the same
-			// TODO: we could do a projection to convert between types in some cases
+			// only add a mapping if the type is the same, or if every value of the old type can be represented in the
+			// new one. Other columns are nil valued.
 			if srcSchema[srcIdx].Type.Equals(targetSchema[i].Type) {
 				srcToTarget[srcIdx] = i
+			} else if isLosslessConversion(srcSchema[srcIdx].Type, targetSchema[i].Type) {
+				srcToTarget[srcIdx] = i
+				conversions[srcIdx] = targetSchema[i].Type
 			}
 		}
 	}
@@ -576,12 +830,216 @@ func rowConverter(srcSchema, targetSchema sql.Schema, h hash.Hash, meta *datas.C
 				r[i] = meta.Time()
 			case schema.HistoryCommitHashTag:
 				r[i] = h.String()
+			case schema.HistoryCommitOrderTag:
+				r[i] = height
+			case schema.HistoryDeletedTag:
+				r[i] = false
 			default:
 				if j, ok := srcToTarget[i]; ok {
-					r[j] = row[i]
+					if toType, ok := conversions[i]; ok {
+						// the conversion is lossless, so a failure here means the value can't be represented after all
+						if converted, _, err := toType.Convert(row[i]); err == nil {
+							r[j] = converted
+						}
+					} else {
+						r[j] = row[i]
+					}
 				}
 			}
 		}
 		return r
 	}
 }
+
+// deletedRows returns the rows of the primary keys deleted from |tbl| at the commit |cm|: those in the table at every
+// parent of the commit, but not at the commit itself. A key deleted on one side of a merge is only deleted at the
+// commit that deleted it, not at the merge. The rows have |deleted| set, and only their primary key columns, which are
+// matched to the columns of |targetCols| by name. Keyless tables have no deleted rows, and neither do commits at which
+// the table was created or its primary key changed.
+func deletedRows(ctx *sql.Context, tableName string, tbl *doltdb.Table, cm *doltdb.Commit, targetCols *schema.ColCollection, h hash.Hash, meta *datas.CommitMeta, height uint64, projections []uint64) ([]sql.Row, error) {
+	if cm.NumParents() == 0 || !storetypes.IsFormat_DOLT(tbl.Format()) {
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := durable.ProllyMapFromIndex(idx)
+
+	parentRows := make([]prolly.Map, cm.NumParents())
+	for i := range parentRows {
+		parent, err := cm.GetParent(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		root, err := parent.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		parentTbl, _, ok, err := root.GetTableInsensitive(ctx, tableName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, nil
+		}
+		parentIdx, err := parentTbl.GetRowData(ctx)
+		if err != nil {
+			return nil, err
+		}
+		parentRows[i] = durable.ProllyMapFromIndex(parentIdx)
+		// keys can only be compared between maps with the same key descriptor
+		if !parentRows[i].KeyDesc().Equals(rows.KeyDesc()) {
+			return nil, nil
+		}
+	}
+
+	var keys []val.Tuple
+	err = prolly.DiffMaps(ctx, parentRows[0], rows, func(ctx context.Context, d tree.Diff) error {
+		if d.Type != tree.RemovedDiff {
+			return nil
+		}
+		for _, m := range parentRows[1:] {
+			ok, err := m.Has(ctx, val.Tuple(d.Key))
+			if err != nil || !ok {
+				return err
+			}
+		}
+		keys = append(keys, val.Tuple(d.Key))
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	// pkIdxs maps each projection of a primary key column to its field of the key, and conversions holds the target
+	// type of the fields whose values must be converted, as for the rows of the table
+	pkCols := sch.GetPKCols()
+	pkIdxs := make(map[int]int)
+	conversions := make(map[int]sql.Type)
+	for i, t := range projections {
+		col, ok := targetCols.TagToCol[t]
+		if !ok {
+			continue
+		}
+		j := pkCols.IndexOf(col.Name)
+		if j < 0 {
+			continue
+		}
+		fromType, toType := pkCols.GetByIndex(j).TypeInfo.ToSqlType(), col.TypeInfo.ToSqlType()
+		if fromType.Equals(toType) {
+			pkIdxs[i] = j
+		} else if isLosslessConversion(fromType, toType) {
+			pkIdxs[i] = j
+			conversions[i] = toType
+		}
+	}
+
+	kd, ns := rows.KeyDesc(), rows.NodeStore()
+	deleted := make([]sql.Row, len(keys))
+	for k, key := range keys {
+		r := make(sql.Row, len(projections))
+		for i, t := range projections {
+			switch t {
+			case schema.HistoryCommitterTag:
+				r[i] = meta.Name
+			case schema.HistoryCommitDateTag:
+				r[i] = meta.Time()
+			case schema.HistoryCommitHashTag:
+				r[i] = h.String()
+			case schema.HistoryCommitOrderTag:
+				r[i] = height
+			case schema.HistoryDeletedTag:
+				r[i] = true
+			default:
+				j, ok := pkIdxs[i]
+				if !ok {
+					continue
+				}
+				v, err := index.GetField(ctx, kd, j, key, ns)
+				if err != nil {
+					return nil, err
+				}
+				if toType, ok := conversions[i]; ok {
+					if v, _, err = toType.Convert(v); err != nil {
+						v = nil
+					}
+				}
+				r[i] = v
+			}
+		}
+		deleted[k] = r
+	}
+	return deleted, nil
+}
+
+// isLosslessConversion returns whether every value of type |from| can be represented in type |to| without loss, e.g.
+// INT to BIGINT or VARCHAR(20) to VARCHAR(100).
+func isLosslessConversion(from, to sql.Type) bool {
+	switch {
+	case types.IsInteger(from) && types.IsInteger(to):
+		fromBits, fromSigned := integerWidth(from)
+		toBits, toSigned := integerWidth(to)
+		if fromBits == 0 || toBits == 0 {
+			return false
+		}
+		if fromSigned == toSigned {
+			return toBits >= fromBits
+		}
+		// unsigned values fit in a strictly wider signed type, but negative values never fit in an unsigned type
+		return toSigned && toBits > fromBits
+	case types.IsFloat(from) && types.IsFloat(to):
+		return from.Type() == sqltypes.Float32 || to.Type() == sqltypes.Float64
+	case types.IsDecimal(from) && types.IsDecimal(to):
+		fromDec, toDec := from.(sql.DecimalType), to.(sql.DecimalType)
+		return toDec.Scale() >= fromDec.Scale() &&
+			toDec.Precision()-toDec.Scale() >= fromDec.Precision()-fromDec.Scale()
+	case types.IsText(from) && types.IsText(to):
+		if types.IsBinaryType(from) != types.IsBinaryType(to) {
+			return false
+		}
+		fromStr, toStr := from.(sql.StringType), to.(sql.StringType)
+		return fromStr.CharacterSet() == toStr.CharacterSet() && toStr.MaxCharacterLength() >= fromStr.MaxCharacterLength()
+	default:
+		return false
+	}
+}
+
+// integerWidth returns the width in bits of the integer type given, and whether it's signed. Returns a width of 0 for
+// types that aren't integers.
+func integerWidth(t sql.Type) (int, bool) {
+	switch t.Type() {
+	case sqltypes.Int8:
+		return 8, true
+	case sqltypes.Uint8:
+		return 8, false
+	case sqltypes.Int16:
+		return 16, true
+	case sqltypes.Uint16:
+		return 16, false
+	case sqltypes.Int24:
+		return 24, true
+	case sqltypes.Uint24:
+		return 24, false
+	case sqltypes.Int32:
+		return 32, true
+	case sqltypes.Uint32:
+		return 32, false
+	case sqltypes.Int64:
+		return 64, true
+	case sqltypes.Uint64:
+		return 64, false
+	default:
+		return 0, false
+	}
+}
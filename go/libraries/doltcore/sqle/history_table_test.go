@@ -0,0 +1,118 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const deepHistoryCommits = 50
+
+func TestHistoryTableLimitStopsWalkingCommits(t *testing.T) {
+	ctx, ht, walked := newDeepHistoryTable(t)
+
+	// a LIMIT without an ORDER BY stops reading after the rows it needs
+	partitions, err := ht.Partitions(ctx)
+	require.NoError(t, err)
+	rows := sql.NewTableRowIter(ctx, ht, partitions)
+	_, err = rows.Next(ctx)
+	require.NoError(t, err)
+	require.NoError(t, rows.Close(ctx))
+	assert.Equal(t, 1, *walked)
+
+	// once the query is done, no more commits are loaded
+	require.NoError(t, ht.cmItr.Reset(ctx))
+	*walked = 0
+	partitions, err = ht.Partitions(ctx)
+	require.NoError(t, err)
+	doneCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = partitions.Next(ctx.WithContext(doneCtx))
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, partitions.Close(ctx))
+	assert.Equal(t, 0, *walked)
+
+	// reading every row walks every commit with the table
+	require.NoError(t, ht.cmItr.Reset(ctx))
+	*walked = 0
+	partitions, err = ht.Partitions(ctx)
+	require.NoError(t, err)
+	require.NoError(t, drainIter(ctx, sql.NewTableRowIter(ctx, ht, partitions)))
+	assert.Equal(t, deepHistoryCommits+1, *walked)
+}
+
+// newDeepHistoryTable creates a table with a long history and returns its history table, along with the number of
+// commits it has walked so far.
+func newDeepHistoryTable(t *testing.T) (*sql.Context, *HistoryTable, *int) {
+	dEnv := dtestutils.CreateTestEnv()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir}
+	db, err := NewDatabase(context.Background(), "dolt", dEnv.DbData(), opts)
+	require.NoError(t, err)
+
+	engine, ctx, err := NewTestEngine(dEnv, context.Background(), db)
+	require.NoError(t, err)
+
+	queries := []string{
+		"create table deep (pk int primary key, v int)",
+		"insert into deep values (0, 0)",
+		"call dolt_commit('-Am', 'create table', '--author', 'John Doe <john@doe.com>')",
+	}
+	for i := 1; i <= deepHistoryCommits; i++ {
+		queries = append(queries,
+			fmt.Sprintf("update deep set v = %d", i),
+			fmt.Sprintf("call dolt_commit('-am', 'set v to %d', '--author', 'John Doe <john@doe.com>')", i))
+	}
+	for _, q := range queries {
+		_, iter, err := engine.Query(ctx, q)
+		require.NoError(t, err, q)
+		require.NoError(t, drainIter(ctx, iter), q)
+	}
+
+	tbl, ok, err := db.GetTableInsensitive(ctx, doltdb.DoltHistoryTablePrefix+"deep")
+	require.NoError(t, err)
+	require.True(t, ok)
+	ht := tbl.(*HistoryTable)
+
+	walked := 0
+	ht.cmItr = &countingCommitItr{CommitItr: ht.cmItr, count: &walked}
+	return ctx, ht, &walked
+}
+
+// countingCommitItr counts the commits returned by a CommitItr
+type countingCommitItr struct {
+	doltdb.CommitItr
+	count *int
+}
+
+func (itr *countingCommitItr) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, error) {
+	h, cm, err := itr.CommitItr.Next(ctx)
+	if err == nil {
+		*itr.count++
+	}
+	return h, cm, err
+}
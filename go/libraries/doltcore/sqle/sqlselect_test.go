@@ -754,6 +754,8 @@ func BasicSelectTests() []SelectTest {
 					"bigbillieb@fake.horse",
 					time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).In(LoadedLocalLocation()),
 					"Initialize data repository",
+					uint64(1),
+					time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).In(LoadedLocalLocation()),
 				},
 			},
 			ExpectedSqlSchema: sql.Schema{
@@ -762,6 +764,8 @@ func BasicSelectTests() []SelectTest {
 				&sql.Column{Name: "email", Type: gmstypes.Text},
 				&sql.Column{Name: "date", Type: gmstypes.Datetime},
 				&sql.Column{Name: "message", Type: gmstypes.Text},
+				&sql.Column{Name: "commit_order", Type: gmstypes.Uint64},
+				&sql.Column{Name: "author_date", Type: gmstypes.Datetime},
 			},
 		},
 		{
@@ -783,6 +787,7 @@ func BasicSelectTests() []SelectTest {
 					"billy bob", "bigbillieb@fake.horse",
 					time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).In(LoadedLocalLocation()),
 					"Initialize data repository",
+					nil, nil, nil,
 				},
 			},
 			ExpectedSqlSchema: sql.Schema{
@@ -792,6 +797,9 @@ func BasicSelectTests() []SelectTest {
 				&sql.Column{Name: "latest_committer_email", Type: gmstypes.Text},
 				&sql.Column{Name: "latest_commit_date", Type: gmstypes.Datetime},
 				&sql.Column{Name: "latest_commit_message", Type: gmstypes.Text},
+				&sql.Column{Name: "creator", Type: gmstypes.Text},
+				&sql.Column{Name: "created_at", Type: gmstypes.Datetime},
+				&sql.Column{Name: "description", Type: gmstypes.Text},
 			},
 		},
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	storetypes "github.com/dolthub/dolt/go/store/types"
 
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 )
@@ -166,6 +167,22 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.ShowBranchDatabases),
 			Default:           int8(0),
 		},
+		{ // If more than 0, procedures that would discard changes to more tables than this require --confirm.
+			Name:              dsess.DestructiveConfirmThreshold,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.DestructiveConfirmThreshold, 0, 9223372036854775807, false),
+			Default:           int64(0),
+		},
+		{ // The number of goroutines dolt_gc uses to read the chunks it keeps.
+			Name:              dsess.GCConcurrency,
+			Scope:             sql.SystemVariableScope_Both,
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.GCConcurrency, 1, 9223372036854775807, false),
+			Default:           int64(storetypes.DefaultGCConcurrency()),
+		},
 		{
 			Name:    dsess.DoltClusterAckWritesTimeoutSecs,
 			Dynamic: true,
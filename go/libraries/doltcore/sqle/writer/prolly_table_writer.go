@@ -188,7 +188,7 @@ func (w *prollyTableWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.
 
 // GetNextAutoIncrementValue implements TableWriter.
 func (w *prollyTableWriter) GetNextAutoIncrementValue(ctx *sql.Context, insertVal interface{}) (uint64, error) {
-	return w.aiTracker.Next(w.tableName, insertVal)
+	return w.aiTracker.Next(ctx, w.tableName, insertVal)
 }
 
 // SetAutoIncrementValue implements TableWriter.
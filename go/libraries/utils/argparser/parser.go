@@ -194,6 +194,11 @@ func (ap *ArgParser) sortedModalOptions() []string {
 }
 
 func (ap *ArgParser) matchModalOptions(arg string) (matches []*Option, rest string) {
+	// an argument naming a flag exactly is that flag, even when a value option's name or abbreviation is a prefix of it
+	if opt, ok := ap.nameOrAbbrevToOpt[arg]; ok && opt.OptType == OptionalFlag {
+		return []*Option{opt}, ""
+	}
+
 	rest = arg
 
 	// try to match longest options first
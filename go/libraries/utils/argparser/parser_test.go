@@ -93,6 +93,13 @@ func TestArgParser(t *testing.T) {
 			map[string]string{},
 			[]string{},
 		},
+		{
+			NewArgParserWithVariableArgs("test").SupportsString("strategy", "s", "", "").SupportsFlag("squash", "", ""),
+			[]string{"--squash", "-s", "ours"},
+			nil,
+			map[string]string{"squash": "", "strategy": "ours"},
+			[]string{},
+		},
 		{
 			NewArgParserWithMaxArgs("test", 1),
 			[]string{"foo", "bar"},
@@ -321,3 +321,37 @@ func validate(expectedDirs, expectedFiles, actualDirs, actualFiles []string, fsN
 		t.Error("fs:", fsName, "Expected files does not match actual files.", "\n\tactual  :", actualFiles, "\n\texpected:", expectedFiles)
 	}
 }
+
+func TestReadOnlyFS(t *testing.T) {
+	dir := test.TestDir("TestReadOnlyFS")
+	fp := filepath.Join(dir, testFilename)
+
+	for fsName, fs := range filesysetmsToTest {
+		t.Run(fsName, func(t *testing.T) {
+			require.NoError(t, fs.MkDirs(dir))
+			require.NoError(t, fs.WriteFile(fp, []byte(testString)))
+
+			rofs := NewReadOnlyFS(fs)
+			data, err := rofs.ReadFile(fp)
+			require.NoError(t, err)
+			require.Equal(t, testString, string(data))
+
+			require.ErrorIs(t, rofs.WriteFile(fp, []byte("overwritten")), ErrReadOnlyFS)
+			require.ErrorIs(t, rofs.MkDirs(filepath.Join(dir, "child")), ErrReadOnlyFS)
+			require.ErrorIs(t, rofs.DeleteFile(fp), ErrReadOnlyFS)
+			require.ErrorIs(t, rofs.Delete(dir, true), ErrReadOnlyFS)
+			require.ErrorIs(t, rofs.MoveFile(fp, filepath.Join(dir, movedFilename)), ErrReadOnlyFS)
+			_, err = rofs.OpenForWrite(fp, os.ModePerm)
+			require.ErrorIs(t, err, ErrReadOnlyFS)
+
+			wd, err := rofs.WithWorkingDir(dir)
+			require.NoError(t, err)
+			require.ErrorIs(t, wd.WriteFile(testFilename, []byte("overwritten")), ErrReadOnlyFS)
+
+			data, err = fs.ReadFile(fp)
+			require.NoError(t, err)
+			require.Equal(t, testString, string(data))
+			require.NoError(t, fs.Delete(dir, true))
+		})
+	}
+}
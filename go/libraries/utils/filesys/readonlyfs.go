@@ -0,0 +1,97 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesys
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrReadOnlyFS is returned by every write operation of a ReadOnlyFS.
+var ErrReadOnlyFS = errors.New("read-only file system")
+
+// ReadOnlyFS wraps a Filesys and rejects every write to it, e.g. for a database snapshot mounted read-only.
+type ReadOnlyFS struct {
+	Filesys
+}
+
+var _ Filesys = ReadOnlyFS{}
+
+// NewReadOnlyFS returns a Filesys that reads from |fs| and fails every write with ErrReadOnlyFS.
+func NewReadOnlyFS(fs Filesys) ReadOnlyFS {
+	if rofs, ok := fs.(ReadOnlyFS); ok {
+		return rofs
+	}
+	return ReadOnlyFS{Filesys: fs}
+}
+
+// OpenForWrite implements WritableFS
+func (fs ReadOnlyFS) OpenForWrite(fp string, perm os.FileMode) (io.WriteCloser, error) {
+	return nil, ErrReadOnlyFS
+}
+
+// OpenForWriteAppend implements WritableFS
+func (fs ReadOnlyFS) OpenForWriteAppend(fp string, perm os.FileMode) (io.WriteCloser, error) {
+	return nil, ErrReadOnlyFS
+}
+
+// WriteFile implements WritableFS
+func (fs ReadOnlyFS) WriteFile(fp string, data []byte) error {
+	return ErrReadOnlyFS
+}
+
+// MkDirs implements WritableFS
+func (fs ReadOnlyFS) MkDirs(path string) error {
+	return ErrReadOnlyFS
+}
+
+// DeleteFile implements WritableFS
+func (fs ReadOnlyFS) DeleteFile(path string) error {
+	return ErrReadOnlyFS
+}
+
+// Delete implements WritableFS
+func (fs ReadOnlyFS) Delete(path string, force bool) error {
+	return ErrReadOnlyFS
+}
+
+// MoveFile implements WritableFS
+func (fs ReadOnlyFS) MoveFile(srcPath, destPath string) error {
+	return ErrReadOnlyFS
+}
+
+// WithWorkingDir implements Filesys
+func (fs ReadOnlyFS) WithWorkingDir(path string) (Filesys, error) {
+	wd, err := fs.Filesys.WithWorkingDir(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReadOnlyFS(wd), nil
+}
+
+// IsOnReadOnlyMount returns whether |path| in |fs| is on a file system mounted read-only, e.g. a snapshot. Only the
+// local file system can be mounted read-only.
+func IsOnReadOnlyMount(fs Filesys, path string) bool {
+	lfs, ok := fs.(*localFS)
+	if !ok {
+		return false
+	}
+	absPath, err := lfs.Abs(path)
+	if err != nil {
+		return false
+	}
+	return isReadOnlyMount(absPath)
+}
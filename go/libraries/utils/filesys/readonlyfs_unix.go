@@ -0,0 +1,25 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package filesys
+
+import "golang.org/x/sys/unix"
+
+// isReadOnlyMount returns whether the existing file or directory at |absPath| is on a file system mounted read-only
+func isReadOnlyMount(absPath string) bool {
+	return unix.Access(absPath, unix.W_OK) == unix.EROFS
+}
@@ -141,6 +141,12 @@ func NewCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.Valu
 	return newCommitForValue(ctx, cs, vrw, ns, v, opts)
 }
 
+// NewOrphanCommitForValue creates a commit for |v| that has no parents, such as the first commit of a branch that
+// shares no history with any other branch.
+func NewOrphanCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.ValueReadWriter, ns tree.NodeStore, v types.Value, meta *CommitMeta) (*Commit, error) {
+	return newCommitForValue(ctx, cs, vrw, ns, v, CommitOptions{Meta: meta})
+}
+
 func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, parentsClosureAddr hash.Hash) (serial.Message, uint64) {
 	builder := flatbuffers.NewBuilder(1024)
 	vaddroff := builder.CreateByteVector(vaddr[:])
@@ -179,6 +185,7 @@ func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, pa
 	serial.CommitAddDescription(builder, descoff)
 	serial.CommitAddTimestampMillis(builder, opts.Meta.Timestamp)
 	serial.CommitAddUserTimestampMillis(builder, opts.Meta.UserTimestamp)
+	serial.CommitAddAuthorTimestampMillis(builder, opts.Meta.AuthorTimestamp)
 
 	bytes := serial.FinishMessage(builder, serial.CommitEnd(builder), []byte(serial.CommitFileID))
 	return bytes, maxheight + 1
@@ -333,7 +340,7 @@ func LoadCommitAddr(ctx context.Context, vr types.ValueReader, addr hash.Hash) (
 		return nil, err
 	}
 	if v == nil {
-		return nil, errors.New("target commit not found")
+		return nil, ErrCommitNotFound
 	}
 	return CommitFromValue(vr.Format(), v)
 }
@@ -557,6 +564,7 @@ func GetCommitMeta(ctx context.Context, cv types.Value) (*CommitMeta, error) {
 		ret.Description = string(cmsg.Description())
 		ret.Timestamp = cmsg.TimestampMillis()
 		ret.UserTimestamp = cmsg.UserTimestampMillis()
+		ret.AuthorTimestamp = cmsg.AuthorTimestampMillis()
 		return ret, nil
 	}
 	c, ok := cv.(types.Struct)
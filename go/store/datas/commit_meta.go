@@ -29,6 +29,7 @@ const (
 	commitMetaDescKey      = "desc"
 	commitMetaTimestampKey = "timestamp"
 	commitMetaUserTSKey    = "user_timestamp"
+	commitMetaAuthorTSKey  = "author_timestamp"
 	commitMetaVersionKey   = "metaversion"
 
 	commitMetaStName  = "metadata"
@@ -51,6 +52,8 @@ type CommitMeta struct {
 	Timestamp     uint64
 	Description   string
 	UserTimestamp int64
+	// AuthorTimestamp is the author date of the commit in milliseconds, or 0 if it's the same as UserTimestamp
+	AuthorTimestamp int64
 }
 
 // NewCommitMeta creates a CommitMeta instance from a name, email, and description and uses the current time for the
@@ -80,7 +83,7 @@ func NewCommitMetaWithUserTS(name, email, desc string, userTS time.Time) (*Commi
 	ms := uint64(CommitNowFunc().UnixMilli())
 	userMS := userTS.UnixMilli()
 
-	return &CommitMeta{n, e, ms, d, userMS}, nil
+	return &CommitMeta{n, e, ms, d, userMS, 0}, nil
 }
 
 func getRequiredFromSt(st types.Struct, k string) (types.Value, error) {
@@ -126,12 +129,21 @@ func CommitMetaFromNomsSt(st types.Struct) (*CommitMeta, error) {
 		userTS = types.Int(int64(uint64(ts.(types.Uint))))
 	}
 
+	authorTS, ok, err := st.MaybeGet(commitMetaAuthorTSKey)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		authorTS = types.Int(0)
+	}
+
 	return &CommitMeta{
 		string(n.(types.String)),
 		string(e.(types.String)),
 		uint64(ts.(types.Uint)),
 		string(d.(types.String)),
 		int64(userTS.(types.Int)),
+		int64(authorTS.(types.Int)),
 	}, nil
 }
 
@@ -144,6 +156,10 @@ func (cm *CommitMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, erro
 		commitMetaVersionKey:   types.String(commitMetaVersion),
 		commitMetaUserTSKey:    types.Int(cm.UserTimestamp),
 	}
+	// the author date is only written when it differs, so that the hashes of other commits don't change
+	if cm.AuthorTimestamp != 0 {
+		metadata[commitMetaAuthorTSKey] = types.Int(cm.AuthorTimestamp)
+	}
 
 	return types.NewStruct(nbf, commitMetaStName, metadata)
 }
@@ -153,6 +169,23 @@ func (cm *CommitMeta) Time() time.Time {
 	return time.UnixMilli(cm.UserTimestamp)
 }
 
+// AuthorTime returns the time at which the changes of the commit were authored, which is the same as Time unless an
+// author date was given
+func (cm *CommitMeta) AuthorTime() time.Time {
+	if cm.AuthorTimestamp == 0 {
+		return cm.Time()
+	}
+	return time.UnixMilli(cm.AuthorTimestamp)
+}
+
+// SetAuthorTime sets the author date of the commit, recording it only if it differs from the commit date
+func (cm *CommitMeta) SetAuthorTime(t time.Time) {
+	cm.AuthorTimestamp = 0
+	if ms := t.UnixMilli(); ms != cm.UserTimestamp {
+		cm.AuthorTimestamp = ms
+	}
+}
+
 // FormatTS takes the internal timestamp and turns it into a human readable string in the time.RubyDate format
 // which looks like: "Mon Jan 02 15:04:05 -0700 2006"
 func (cm *CommitMeta) FormatTS() string {
@@ -179,8 +179,9 @@ type GarbageCollector interface {
 	types.ValueReadWriter
 
 	// GC traverses the database starting at the Root and removes
-	// all unreferenced data from persistent storage.
-	GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error
+	// all unreferenced data from persistent storage, using at most
+	// |concurrency| goroutines to read the data it keeps.
+	GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, concurrency int, safepointF func() error) error
 }
 
 // CanUsePuller returns true if a datas.Puller can be used to pull data from one Database into another.  Not all
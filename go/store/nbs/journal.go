@@ -119,7 +119,11 @@ func (j *chunkJournal) bootstrapJournalWriter(ctx context.Context) (err error) {
 		return
 	}
 
-	j.wr, ok, err = openJournalWriter(ctx, j.path)
+	if j.backing.readOnly() {
+		j.wr, ok, err = openReadOnlyJournalWriter(ctx, j.path)
+	} else {
+		j.wr, ok, err = openJournalWriter(ctx, j.path)
+	}
 	if err != nil {
 		return err
 	} else if !ok {
@@ -439,12 +443,28 @@ func newJournalManifest(ctx context.Context, dir string) (m *journalManifest, er
 	} else if err != nil {
 		return nil, err
 	}
+	return openJournalManifest(ctx, dir, lock)
+}
+
+// newReadOnlyJournalManifest makes a new read-only file manifest without taking
+// the file lock, which would create the lock file if it doesn't exist.
+func newReadOnlyJournalManifest(ctx context.Context, dir string) (m *journalManifest, err error) {
+	return openJournalManifest(ctx, dir, nil)
+}
+
+// openJournalManifest makes a new file manifest, read-only if |lock| is nil.
+func openJournalManifest(ctx context.Context, dir string, lock *fslock.Lock) (m *journalManifest, err error) {
 	m = &journalManifest{dir: dir, lock: lock}
+	unlock := func() {
+		if lock != nil {
+			_ = lock.Unlock()
+		}
+	}
 
 	var f *os.File
 	f, err = openIfExists(filepath.Join(dir, manifestFileName))
 	if err != nil {
-		_ = lock.Unlock()
+		unlock()
 		return nil, err
 	} else if f == nil {
 		return m, nil
@@ -454,17 +474,17 @@ func newJournalManifest(ctx context.Context, dir string) (m *journalManifest, er
 			err = cerr // keep first error
 		}
 		if err != nil {
-			_ = lock.Unlock()
+			unlock()
 		}
 	}()
 
 	var ok bool
 	ok, _, err = m.ParseIfExists(ctx, &Stats{}, nil)
 	if err != nil {
-		_ = lock.Unlock()
+		unlock()
 		return nil, err
 	} else if !ok {
-		_ = lock.Unlock()
+		unlock()
 		return nil, ErrUnreadableManifest
 	}
 	return
@@ -18,6 +18,7 @@ import (
 	"context"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -59,6 +60,54 @@ func TestChunkJournalBlockStoreSuite(t *testing.T) {
 	})
 }
 
+func TestReadOnlyJournalingStore(t *testing.T) {
+	cacheOnce.Do(makeGlobalCaches)
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { file.RemoveAll(dir) })
+	q := NewUnlimitedMemQuotaProvider()
+	nbf := types.Format_Default.VersionString()
+
+	st, err := NewLocalJournalingStore(ctx, nbf, dir, q)
+	require.NoError(t, err)
+	c := chunks.NewChunk([]byte("snapshot"))
+	require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+	last, err := st.Root(ctx)
+	require.NoError(t, err)
+	ok, err := st.Commit(ctx, c.Hash(), last)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, st.Close())
+
+	// a snapshot may be taken without the lock file or the journal index
+	for _, name := range []string{lockFileName, journalIndexFileName} {
+		if err = os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			require.NoError(t, err)
+		}
+	}
+
+	ro, err := NewLocalReadOnlyJournalingStore(ctx, nbf, dir, q)
+	require.NoError(t, err)
+	root, err := ro.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, c.Hash(), root)
+	actual, err := ro.Get(ctx, c.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, c.Data(), actual.Data())
+
+	c2 := chunks.NewChunk([]byte("not allowed"))
+	require.NoError(t, ro.Put(ctx, c2, noopGetAddrs))
+	_, err = ro.Commit(ctx, c2.Hash(), root)
+	assert.Error(t, err)
+	require.NoError(t, ro.Close())
+
+	_, err = os.Stat(filepath.Join(dir, lockFileName))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, journalIndexFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestChunkJournalPersist(t *testing.T) {
 	ctx := context.Background()
 	j := makeTestChunkJournal(t)
@@ -70,6 +70,16 @@ func fileExists(path string) (bool, error) {
 }
 
 func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exists bool, err error) {
+	return openJournalFile(ctx, path, false)
+}
+
+// openReadOnlyJournalWriter opens an existing journal file for reading only. The returned journalWriter
+// never writes to the journal or its index, which may be on a read-only file system.
+func openReadOnlyJournalWriter(ctx context.Context, path string) (wr *journalWriter, exists bool, err error) {
+	return openJournalFile(ctx, path, true)
+}
+
+func openJournalFile(ctx context.Context, path string, readOnly bool) (wr *journalWriter, exists bool, err error) {
 	var f *os.File
 	if path, err = filepath.Abs(path); err != nil {
 		return nil, false, err
@@ -83,14 +93,19 @@ func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exi
 	} else if info.IsDir() {
 		return nil, true, fmt.Errorf("expected file %s found directory", chunkJournalName)
 	}
-	if f, err = os.OpenFile(path, os.O_RDWR, 0666); err != nil {
+	flag := os.O_RDWR
+	if readOnly {
+		flag = os.O_RDONLY
+	}
+	if f, err = os.OpenFile(path, flag, 0666); err != nil {
 		return nil, true, err
 	}
 
 	return &journalWriter{
-		buf:     make([]byte, 0, journalWriterBuffSize),
-		journal: f,
-		path:    path,
+		buf:      make([]byte, 0, journalWriterBuffSize),
+		journal:  f,
+		path:     path,
+		readOnly: readOnly,
 	}, true, nil
 }
 
@@ -154,6 +169,10 @@ type journalWriter struct {
 	index    *os.File
 	maxNovel int
 
+	// readOnly journals are opened without write access and
+	// never create, truncate or sync the journal or its index
+	readOnly bool
+
 	lock sync.RWMutex
 }
 
@@ -175,9 +194,11 @@ func (wr *journalWriter) bootstrapJournal(ctx context.Context) (last hash.Hash,
 	ok, err = fileExists(p)
 	if err != nil {
 		return
+	} else if ok && wr.readOnly {
+		wr.index, err = os.Open(p)
 	} else if ok {
 		wr.index, err = os.OpenFile(p, os.O_RDWR, 0666)
-	} else {
+	} else if !wr.readOnly {
 		wr.index, err = os.OpenFile(p, os.O_RDWR|os.O_CREATE, 0666)
 	}
 	if err != nil {
@@ -281,11 +302,14 @@ func (wr *journalWriter) bootstrapJournal(ctx context.Context) (last hash.Hash,
 // the index file and restarting the journal bootstrapping process without an index.
 // todo: make backup file?
 func (wr *journalWriter) corruptIndexRecovery(ctx context.Context) (err error) {
-	if _, err = wr.index.Seek(0, io.SeekStart); err != nil {
-		return
-	}
-	if err = wr.index.Truncate(0); err != nil {
-		return
+	// a read-only index is left as is and the whole journal is processed instead
+	if !wr.readOnly {
+		if _, err = wr.index.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+		if err = wr.index.Truncate(0); err != nil {
+			return
+		}
 	}
 	// reset bootstrapping state
 	wr.off, wr.indexed, wr.uncmpSz = 0, 0, 0
@@ -510,8 +534,10 @@ func (wr *journalWriter) Close() (err error) {
 	if wr.index != nil {
 		_ = wr.index.Close()
 	}
-	if cerr := wr.journal.Sync(); cerr != nil {
-		err = cerr
+	if !wr.readOnly {
+		if cerr := wr.journal.Sync(); cerr != nil {
+			err = cerr
+		}
 	}
 	if cerr := wr.journal.Close(); cerr != nil {
 		err = cerr
@@ -551,12 +551,29 @@ func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 }
 
 func NewLocalJournalingStore(ctx context.Context, nbfVers, dir string, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return newLocalJournalingStore(ctx, nbfVers, dir, q, false)
+}
+
+// NewLocalReadOnlyJournalingStore opens the journaling store in |dir| for reading only, e.g. from a snapshot mounted
+// read-only. No lock file is taken, the journal and its index are never written, and any attempt to commit a new root
+// fails.
+func NewLocalReadOnlyJournalingStore(ctx context.Context, nbfVers, dir string, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return newLocalJournalingStore(ctx, nbfVers, dir, q, true)
+}
+
+func newLocalJournalingStore(ctx context.Context, nbfVers, dir string, q MemoryQuotaProvider, readOnly bool) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 	if err := checkDir(dir); err != nil {
 		return nil, err
 	}
 
-	m, err := newJournalManifest(ctx, dir)
+	var m *journalManifest
+	var err error
+	if readOnly {
+		m, err = newReadOnlyJournalManifest(ctx, dir)
+	} else {
+		m, err = newJournalManifest(ctx, dir)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +113,27 @@ func walkOpaqueNodes(ctx context.Context, nd Node, ns NodeStore, cb NodeCb) erro
 	})
 }
 
+// ReachableNodeCb is called with each node visited by WalkReachableNodes, along with its address. Returning false
+// skips the nodes reachable from |nd|.
+type ReachableNodeCb func(ctx context.Context, addr hash.Hash, nd Node) (bool, error)
+
+// WalkReachableNodes runs a callback function on |nd|, whose address is |addr|, and on every node reachable from it,
+// including the nodes of out-of-band values referenced from leaf nodes, such as blobs.
+func WalkReachableNodes(ctx context.Context, addr hash.Hash, nd Node, ns NodeStore, cb ReachableNodeCb) error {
+	descend, err := cb(ctx, addr, nd)
+	if err != nil || !descend {
+		return err
+	}
+
+	return walkAddresses(ctx, nd, func(ctx context.Context, addr hash.Hash) error {
+		child, err := ns.Read(ctx, addr)
+		if err != nil {
+			return err
+		}
+		return WalkReachableNodes(ctx, addr, child, ns, cb)
+	})
+}
+
 func NodeFromBytes(msg []byte) (Node, error) {
 	keys, values, level, count, err := message.UnpackFields(msg)
 	return Node{
@@ -549,8 +549,19 @@ func makeBatches(hss []hash.HashSet, count int) [][]hash.Hash {
 	return res
 }
 
-// GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore
-func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error {
+// DefaultGCConcurrency returns the number of goroutines GC uses to walk the chunks it keeps when no limit is given:
+// one less than GOMAXPROCS, leaving a thread for copying those chunks.
+func DefaultGCConcurrency() int {
+	concurrency := runtime.GOMAXPROCS(0) - 1
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return concurrency
+}
+
+// GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore. |concurrency| bounds
+// the number of goroutines reading the chunks to keep; if it's less than 1, DefaultGCConcurrency is used.
+func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, concurrency int, safepointF func() error) error {
 	lvs.versOnce.Do(lvs.expectVersion)
 
 	lvs.transitionToOldGenGC()
@@ -584,7 +595,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 
 		newGenRefs.Insert(root)
 
-		err = lvs.gc(ctx, oldGenRefs, oldGen.HasMany, newGen, oldGen, nil, func() hash.HashSet {
+		err = lvs.gc(ctx, oldGenRefs, oldGen.HasMany, newGen, oldGen, concurrency, nil, func() hash.HashSet {
 			n := lvs.transitionToNewGenGC()
 			newGenRefs.InsertAll(n)
 			return make(hash.HashSet)
@@ -594,7 +605,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 			return err
 		}
 
-		err = lvs.gc(ctx, newGenRefs, oldGen.HasMany, newGen, newGen, safepointF, lvs.transitionToFinalizingGC)
+		err = lvs.gc(ctx, newGenRefs, oldGen.HasMany, newGen, newGen, concurrency, safepointF, lvs.transitionToFinalizingGC)
 		newGen.EndGC()
 		if err != nil {
 			return err
@@ -624,7 +635,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 
 		newGenRefs.Insert(root)
 
-		err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, collector, collector, safepointF, lvs.transitionToFinalizingGC)
+		err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, collector, collector, concurrency, safepointF, lvs.transitionToFinalizingGC)
 		collector.EndGC()
 		if err != nil {
 			return err
@@ -648,6 +659,7 @@ func (lvs *ValueStore) gc(ctx context.Context,
 	toVisit hash.HashSet,
 	hashFilter HashFilterFunc,
 	src, dest chunks.ChunkStoreGarbageCollector,
+	concurrency int,
 	safepointF func() error,
 	finalize func() hash.HashSet) error {
 	keepChunks := make(chan []hash.Hash, gcBuffSize)
@@ -666,9 +678,8 @@ func (lvs *ValueStore) gc(ctx context.Context,
 		}
 	}
 
-	concurrency := runtime.GOMAXPROCS(0) - 1
 	if concurrency < 1 {
-		concurrency = 1
+		concurrency = DefaultGCConcurrency()
 	}
 	walker := newParallelRefWalker(ctx, lvs.nbf, concurrency)
 
@@ -92,6 +92,27 @@ teardown() {
     [[ ! "$output" =~ "b3" ]] || false
 }
 
+@test "branch: deleting a branch merged into its upstream but not the current branch" {
+    mkdir -p remotes/origin
+    dolt remote add origin file://./remotes/origin
+    dolt sql -q "create table t1 (id int primary key);"
+    dolt commit -Am "initial commit"
+
+    dolt checkout -b b1
+    dolt sql -q "create table t2 (id int primary key);"
+    dolt commit -Am "new table"
+    dolt push --set-upstream origin b1
+    dolt checkout main
+
+    # the upstream's remote-tracking ref is used, so the remote isn't needed
+    rm -rf remotes/origin
+    dolt branch -d b1
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "b1" ]] || false
+}
+
 @test "branch: deleting an unmerged branch with no remote" {
     dolt sql -q "create table t1 (id int primary key);"
     dolt commit -Am "commit 1"
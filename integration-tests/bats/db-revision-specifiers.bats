@@ -135,3 +135,30 @@ SQL
     [ "$status" -ne "0" ]
     [[ "$output" =~ "$database_name/$commit is read-only" ]] || false
 }
+
+@test "db-revision-specifiers: table functions resolve refs in commit-qualified database revisions" {
+    commit=$(dolt sql -q "SELECT hashof('HEAD~1');" -r=csv | tail -1)
+
+    run dolt sql -r=csv << SQL
+use $database_name/$commit;
+select to_pk, diff_type from dolt_diff('main~', 'main', 'test') order by to_pk;
+SQL
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ ",removed" ]] || false
+
+    run dolt sql -r=csv << SQL
+use $database_name/$commit;
+select count(*) from dolt_patch('main~', 'main', 'test');
+SQL
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3" ]] || false
+    [[ ! "$output" =~ "detached head" ]] || false
+
+    # HEAD is the commit of the revision database
+    run dolt sql -r=csv << SQL
+use $database_name/$commit;
+select to_pk from dolt_diff('HEAD~', 'HEAD', 'test');
+SQL
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3" ]] || false
+}
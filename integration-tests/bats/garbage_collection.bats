@@ -83,6 +83,27 @@ CALL dolt_gc();
 SQL
 }
 
+@test "garbage_collection: call GC with a concurrency limit" {
+    dolt sql <<SQL
+CREATE TABLE t (pk int primary key);
+INSERT INTO t VALUES (1),(2),(3);
+CALL dolt_commit('-Am', 'new table with three rows');
+SQL
+
+    run dolt sql -q "SET @@dolt_gc_concurrency = 0"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can't be set to the value of '0'" ]] || false
+
+    dolt sql <<SQL
+SET @@dolt_gc_concurrency = 1;
+CALL dolt_gc();
+SQL
+
+    run dolt sql -q "SELECT COUNT(*) FROM t" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+}
+
 @test "garbage_collection: blob types work after GC" {
     dolt sql -q "create table t(pk int primary key, val text)"
     dolt sql -q "insert into t values (1, 'one'), (2, 'two');"
@@ -493,7 +493,7 @@ teardown() {
     ! [[ "$output" =~ "Commit2" ]] || false
 }
 
-@test "log: --merges, --parents, --min-parents option" {
+@test "log: --merges, --parents, --min-parents, --max-parents option" {
     dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
     dolt add -A
     dolt commit -m "Created table"
@@ -530,6 +530,19 @@ teardown() {
     [ $status -eq 0 ]
     [[ "$output" =~ "0,0" ]] || false
     [[ ! "$output" =~ "Initialize data repository" ]] || false
+
+    # Show everything but the merge commit
+    run dolt log --max-parents 1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0,0" ]] || false
+    [[ "$output" =~ "Initialize data repository" ]] || false
+    [[ ! "$output" =~ "Merged" ]] || false
+
+    # Only shows the first commit
+    run dolt log --oneline --max-parents 0
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "Initialize data repository" ]] || false
     
     # each commit gets its parents in the log
     run dolt log --parents
@@ -29,6 +29,33 @@ teardown() {
     [[ "$output" =~ "'file:///poop/' is not valid" ]] || false
 }
 
+@test "remotes-file-system: add a file system remote with --verify" {
+    mkdir remote
+    run dolt remote add --verify origin file://remote
+    [ $status -ne 0 ]
+    [[ "$output" =~ "is not a dolt database" ]] || false
+    [ -z "$(ls -A remote)" ]
+    run dolt remote -v
+    [[ ! "$output" =~ "origin" ]] || false
+
+    run dolt sql -q "call dolt_remote('add', 'origin', 'file://remote', '--verify')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "is not a dolt database" ]] || false
+
+    dolt remote add origin file://remote
+    dolt push origin main
+    dolt remote remove origin
+
+    dolt remote add --verify origin file://remote
+    run dolt remote -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "origin" ]] || false
+
+    dolt sql -q "call dolt_remote('add', 'origin2', 'file://remote', '--verify')"
+    run dolt remote -v
+    [[ "$output" =~ "origin2" ]] || false
+}
+
 @test "remotes-file-system: push, pull, and clone file based remotes" {
     # seed with some data
     dolt sql <<SQL
@@ -431,6 +431,39 @@ SQL
     [ "$status" -eq 0 ]
 }
 
+@test "remotes: dolt_diff against the upstream of the current branch" {
+    dolt remote add test-remote http://localhost:50051/test-org/test-repo
+    dolt sql -q "create table test (pk int primary key, c1 int)"
+    dolt sql -q "insert into test values (1, 1)"
+    dolt add .
+    dolt commit -m "Added test table"
+    dolt push --set-upstream test-remote main
+
+    dolt sql -q "insert into test values (2, 2)"
+    dolt sql -q "update test set c1 = 10 where pk = 1"
+    dolt commit -am "Changed test table"
+
+    run dolt sql -q "select to_pk, from_c1, to_c1, from_commit, diff_type from dolt_diff('--upstream', 'test') order by to_pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "$output" =~ "1,1,10,remotes/test-remote/main,modified" ]] || false
+    [[ "$output" =~ "2,,2,remotes/test-remote/main,added" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_diff('@{upstream}', 'HEAD', 'test')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    dolt push
+    run dolt sql -q "select count(*) from dolt_diff('@{u}..HEAD', 'test')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    dolt checkout -b no-upstream
+    run dolt sql -q "select * from dolt_diff('--upstream', 'test')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch no-upstream has no upstream branch" ]] || false
+}
+
 @test "remotes: push output" {
     dolt remote add test-remote http://localhost:50051/test-org/test-repo
     dolt checkout -b test-branch
@@ -2022,6 +2055,71 @@ SQL
     [[ "$output" =~ "Everything up-to-date." ]] || false
 }
 
+@test "remotes: call dolt_checkout with --track and -b sets upstream of the new branch and dolt_pull uses it" {
+    mkdir remote
+    mkdir repo1
+
+    cd repo1
+    dolt init
+    dolt remote add origin file://../remote
+    dolt sql -q "CREATE TABLE a (pk int primary key)"
+    dolt commit -Am "add table a"
+    dolt push --set-upstream origin main
+    dolt checkout -b other
+    dolt push --set-upstream origin other
+
+    cd ..
+    dolt clone file://./remote repo2
+
+    cd repo2
+    dolt sql -q "call dolt_checkout('-b', 'local', '--track', 'origin/other')"
+
+    cd ../repo1
+    dolt sql -q "INSERT INTO a VALUES (1)"
+    dolt commit -am "add a row"
+    dolt push origin other
+
+    cd ../repo2
+    run dolt sql -q "call dolt_checkout('local'); call dolt_pull(); select * from a;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    dolt checkout local
+    run dolt status
+    [[ "$output" =~ "Your branch is up to date with 'origin/other'." ]] || false
+}
+
+@test "remotes: call dolt_checkout with --track errors for a missing remote branch or an existing local branch" {
+    mkdir remote
+    mkdir repo1
+
+    cd repo1
+    dolt init
+    dolt remote add origin file://../remote
+    dolt sql -q "CREATE TABLE a (pk int)"
+    dolt commit -Am "add table a"
+    dolt push --set-upstream origin main
+    dolt checkout -b other
+    dolt push --set-upstream origin other
+
+    cd ..
+    dolt clone file://./remote repo2
+
+    cd repo2
+    run dolt sql -q "call dolt_checkout('--track', 'origin/missing')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "remote branch 'origin/missing' not found" ]] || false
+
+    dolt branch other
+    run dolt sql -q "call dolt_checkout('--track', 'origin/other')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "A branch named 'other' already exists" ]] || false
+
+    run dolt sql -q "call dolt_checkout('-f', '--track', 'origin/other'); select active_branch();"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "other" ]] || false
+}
+
 @test "remotes: call dolt_checkout with --track and no arg returns error" {
     run dolt sql -q "call dolt_checkout('--track')"
     [ "$status" -eq 1 ]
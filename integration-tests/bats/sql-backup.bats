@@ -14,6 +14,38 @@ teardown() {
     [ "$status" -ne 0 ]
     run dolt sql -q "CALL dolt_backup()"
     [ "$status" -ne 0 ]
+    [[ "$output" =~ "use 'dolt_backups' system table to list backups" ]] || false
+}
+
+@test "sql-backup: dolt_backups system table" {
+    mkdir bac1 bac2
+    dolt sql -q "call dolt_backup('add', 'bac1', 'file://./bac1')"
+    dolt sql -q "call dolt_backup('add', 'bac2', 'file://./bac2')"
+
+    run dolt sql -q "select name, params from dolt_backups order by name" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[1]}" = "bac1,{}" ]] || false
+    [[ "${lines[2]}" = "bac2,{}" ]] || false
+
+    run dolt sql -q "select url from dolt_backups where name = 'bac1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "file://" ]] || false
+    [[ "$output" =~ "/bac1" ]] || false
+
+    run dolt sql -q "insert into dolt_backups (name, url) values ('bac3', 'file://./bac3')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups" ]] || false
+
+    run dolt sql -q "delete from dolt_backups where name = 'bac1'"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "the dolt_backups table is read-only; use the dolt_backup stored procedure to edit backups" ]] || false
+
+    dolt sql -q "call dolt_backup('remove', 'bac1')"
+    run dolt sql -q "select name from dolt_backups" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[1]}" = "bac2" ]] || false
 }
 
 @test "sql-backup: dolt_backup add" {
@@ -136,6 +168,24 @@ teardown() {
     dolt sql -q "CALL dolt_backup('sync', 'hostedapidb-0')"
 }
 
+@test "sql-backup: dolt_backup sync includes working sets" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into t values (1)"
+
+    mkdir the_backup
+    dolt sql -q "call dolt_backup('add', 'bac1', 'file://./the_backup')"
+    dolt sql -q "call dolt_backup('sync', 'bac1')"
+
+    dolt backup restore file://./the_backup the_restore
+    cd the_restore
+    run dolt sql -q "select * from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1" ]] || false
+    run dolt status
+    [[ "$output" =~ "modified:         t" ]] || false
+}
+
 @test "sql-backup: dolt_backup sync-url" {
     mkdir the_backup
     dolt sql -q "call dolt_backup('sync-url', 'file://./the_backup')"
@@ -163,6 +163,25 @@ SQL
     [ $status -eq 0 ]
 }
 
+@test "sql-branch: CALL DOLT_BRANCH -d deletes a branch merged into its upstream" {
+    mkdir -p remotes/origin
+    dolt remote add origin file://./remotes/origin
+    dolt add . && dolt commit -m "1, 2, and 3 in test table"
+
+    dolt branch pushed_branch
+    dolt checkout pushed_branch
+    dolt commit --allow-empty -am 'empty commit'
+    dolt push --set-upstream origin pushed_branch
+    dolt checkout main
+
+    run dolt sql -q "CALL DOLT_BRANCH('-d', 'pushed_branch');"
+    [ $status -eq 0 ]
+
+    run dolt branch
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "pushed_branch" ]] || false
+}
+
 @test "sql-branch: CALL DOLT_BRANCH -d error cases" {
     dolt add . && dolt commit -m "1, 2, and 3 in test table"
     dolt branch new_branch
@@ -172,3 +191,63 @@ SQL
     [ $status -eq 1 ]
     [[ "$output" =~ "attempted to delete checked out branch" ]] || false
 }
+
+@test "sql-branch: dolt_branches shows who created a branch and when" {
+    dolt sql -q "CALL DOLT_BRANCH('sql_branch');"
+    dolt branch cli_branch
+
+    run dolt sql -q "SELECT name, creator, created_at IS NOT NULL FROM dolt_branches ORDER BY name" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "cli_branch,,true" ]] || false
+    [[ "$output" =~ "main,,false" ]] || false
+    [[ "$output" =~ "sql_branch,root,true" ]] || false
+
+    created=$(dolt sql -q "SELECT created_at FROM dolt_branches WHERE name = 'sql_branch'" -r csv | tail -n 1)
+    dolt branch -m sql_branch renamed_branch
+    run dolt sql -q "SELECT creator, created_at FROM dolt_branches WHERE name = 'renamed_branch'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "root,$created" ]] || false
+
+    # deleting and recreating a branch records a new creation
+    sleep 1
+    dolt branch -d renamed_branch
+    dolt sql -q "CALL DOLT_BRANCH('renamed_branch');"
+    run dolt sql -q "SELECT created_at FROM dolt_branches WHERE name = 'renamed_branch'" -r csv
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "$created" ]] || false
+}
+
+@test "sql-branch: dolt_branches table function lists remote-tracking branches with --remotes" {
+    mkdir -p remotes/origin
+    dolt remote add origin file://./remotes/origin
+    dolt commit -Am "initial commit"
+    dolt branch b1
+    dolt push origin main
+    dolt push origin b1
+
+    dolt checkout b1
+    dolt sql -q "INSERT INTO test VALUES (3);"
+    dolt commit -am "ahead of origin"
+    dolt push origin b1
+    dolt checkout main
+
+    run dolt sql -q "SELECT name, merged FROM dolt_branches('--remotes')" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "remotes/origin/b1,false" ]] || false
+    [[ "$output" =~ "remotes/origin/main,true" ]] || false
+
+    run dolt sql -q "SELECT name FROM dolt_branches('-r', '--merged', 'main')" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+    [[ ! "$output" =~ "remotes/origin/b1" ]] || false
+
+    run dolt sql -q "SELECT name FROM dolt_branches('--remotes', '--no-merged', 'main')" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "remotes/origin/b1" ]] || false
+    [[ ! "$output" =~ "remotes/origin/main" ]] || false
+
+    run dolt sql -q "SELECT name FROM dolt_branches()" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "b1" ]] || false
+    [[ ! "$output" =~ "remotes/" ]] || false
+}
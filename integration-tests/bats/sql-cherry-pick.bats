@@ -185,12 +185,45 @@ SQL
 
     run dolt sql -q "CALL DOLT_CHERRY_PICK('branch1')"
     [ "$status" -eq "1" ]
-    [[ "$output" =~ "conflicts in table" ]] || false
+    [[ "$output" =~ "Merge conflict detected, transaction rolled back" ]] || false
 
     run dolt status
     [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
 }
 
+@test "sql-cherry-pick: row data conflict is left in dolt_conflicts tables" {
+    dolt sql -q "INSERT INTO test VALUES (4,'f')"
+    dolt commit -am "add row on branch1"
+
+    dolt checkout main
+    dolt sql -q "INSERT INTO test VALUES (4,'k')"
+    dolt commit -am "add conflicting row on main"
+
+    run dolt sql -r csv << SQL
+SET @@dolt_allow_commit_conflicts = 1;
+CALL DOLT_CHERRY_PICK('branch1');
+SELECT our_v, their_v FROM dolt_conflicts_test;
+SQL
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "k,f" ]] || false
+
+    run dolt conflicts cat test
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "ours" ]] || false
+}
+
+@test "sql-cherry-pick: --no-commit stages the changes" {
+    dolt checkout main
+    run dolt sql -q "CALL DOLT_CHERRY_PICK('--no-commit', 'branch1')"
+    [ "$status" -eq "0" ]
+
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+
+    run dolt log -n 1
+    [[ ! "$output" =~ "Inserted 3" ]] || false
+}
+
 @test "sql-cherry-pick: commit with CREATE TABLE" {
     dolt sql -q "CREATE TABLE table_a (pk BIGINT PRIMARY KEY, v varchar(10))"
     dolt add .
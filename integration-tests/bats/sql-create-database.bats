@@ -321,3 +321,119 @@ SQL
     [[ "$output" =~ "def,metabase,utf8mb4,utf8mb4_unicode_ci,,NO" ]] || false
     cd ..
 }
+
+@test "sql-create-database: dolt_create_from creates a database from a template" {
+    dolt sql -q "create table t (pk int primary key, c1 varchar(20));"
+    dolt sql -q "insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "creating table t"
+    dolt sql -q "insert into t values (3, 'uncommitted');"
+
+    run dolt sql -q "call dolt_create_from('dolt_repo_$$', 'tenant_acme');"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "use tenant_acme; select * from t order by pk;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,one" ]] || false
+    [[ "$output" =~ "2,two" ]] || false
+    [[ ! "$output" =~ "uncommitted" ]] || false
+
+    # the new database starts with a single commit
+    run dolt sql -r csv -q "use tenant_acme; select count(*) from dolt_log;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ ! "$output" =~ "2" ]] || false
+
+    # the template is untouched
+    run dolt sql -r csv -q "select * from t where pk = 3;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3,uncommitted" ]] || false
+
+    run dolt sql -q "call dolt_create_from('dolt_repo_$$', 'tenant_acme');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can't create database tenant_acme; database exists" ]] || false
+
+    run dolt sql -q "call dolt_create_from('doesnotexist', 'tenant_other');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "database not found: doesnotexist" ]] || false
+    [ ! -d tenant_other ]
+}
+
+@test "sql-create-database: dolt_create_from with --preserve-history" {
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "creating table t"
+    dolt sql -q "insert into t values (1);"
+    dolt commit -am "inserting into t"
+
+    run dolt sql -q "call dolt_create_from('--preserve-history', 'dolt_repo_$$', 'tenant_acme');"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "use tenant_acme; select message from dolt_log;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserting into t" ]] || false
+    [[ "$output" =~ "creating table t" ]] || false
+
+    run dolt sql -r csv -q "use tenant_acme; select * from t;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "sql-create-database: dolt_create_from with --prefix" {
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "creating table t"
+
+    run dolt sql -q "call dolt_create_from('--prefix', 'tenant_', 'dolt_repo_$$', 'acme');"
+    [ "$status" -eq 0 ]
+    [ -d tenant_acme ]
+    [ ! -d acme ]
+
+    run dolt sql -r csv -q "use tenant_acme; show tables;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t" ]] || false
+
+    run dolt sql -q "call dolt_create_from('--prefix', 'tenant_', 'dolt_repo_$$', 'acme');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can't create database tenant_acme; database exists" ]] || false
+}
+
+@test "sql-create-database: dolt_create_database with --charset and --collate" {
+    run dolt sql -q "call dolt_create_database('latin', '--charset', 'latin1', '--collate', 'latin1_swedish_ci');"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "select default_character_set_name, default_collation_name from information_schema.schemata where schema_name = 'latin';"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "latin1,latin1_swedish_ci" ]] || false
+
+    # tables created in the new database inherit its charset and collation
+    run dolt sql -q "use latin; create table t (pk int primary key, c1 varchar(20)); show create table t;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "DEFAULT CHARSET=latin1 COLLATE=latin1_swedish_ci" ]] || false
+
+    # a charset alone uses its default collation
+    run dolt sql -q "call dolt_create_database('--charset', 'utf8mb4', 'utf8db');"
+    [ "$status" -eq 0 ]
+    run dolt sql -r csv -q "select default_character_set_name, default_collation_name from information_schema.schemata where schema_name = 'utf8db';"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "utf8mb4,utf8mb4_0900_ai_ci" ]] || false
+
+    run dolt sql -q "call dolt_create_database('latin', '--charset', 'latin1');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can't create database latin; database exists" ]] || false
+
+    run dolt sql -q "call dolt_create_database('badcollation', '--charset', 'latin1', '--collate', 'utf8mb4_0900_ai_ci');"
+    [ "$status" -eq 1 ]
+    [ ! -d badcollation ]
+
+    run dolt sql -q "call dolt_create_database('badcharset', '--charset', 'notacharset');"
+    [ "$status" -eq 1 ]
+    [ ! -d badcharset ]
+}
+
+@test "sql-create-database: SHOW CREATE DATABASE reports the charset and collation given to dolt_create_database" {
+    skip "go-mysql-server's SHOW CREATE DATABASE prints the server's default charset and collation for every database"
+    run dolt sql -q "call dolt_create_database('latin', '--charset', 'latin1', '--collate', 'latin1_swedish_ci');"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "show create database latin;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "DEFAULT CHARACTER SET latin1 COLLATE latin1_swedish_ci" ]] || false
+}
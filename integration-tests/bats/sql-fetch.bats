@@ -379,6 +379,42 @@ teardown() {
     [[ "$output" =~ "t2" ]] || false
 }
 
+@test "sql-fetch: CALL dolt_fetch returns the refs it updated" {
+    cd repo2
+    run dolt sql -q "CALL dolt_fetch('origin', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" = "success,remote_ref,old_hash,new_hash,update_type,chunks,bytes" ]] || false
+    [[ "${lines[1]}" =~ ^1,,,,,[1-9][0-9]*,[1-9][0-9]*$ ]] || false
+    [[ "${lines[2]}" =~ ^1,refs/remotes/origin/main,[0-9a-v]{32},[0-9a-v]{32},fast-forward,,$ ]] || false
+
+    run dolt sql -q "CALL dolt_fetch('origin', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1,,,,,0,0" ]] || false
+    [[ "${lines[2]}" =~ ^1,refs/remotes/origin/main,[0-9a-v]{32},[0-9a-v]{32},up-to-date,,$ ]] || false
+
+    cd ../repo1
+    dolt checkout -b new-branch
+    dolt push origin new-branch
+    cd ../repo2
+
+    run dolt sql -q "CALL dolt_fetch('origin', 'new-branch')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[2]}" =~ ^1,refs/remotes/origin/new-branch,,[0-9a-v]{32},new-branch,,$ ]] || false
+}
+
+@test "sql-fetch: CALL dolt_fetch with forced commit returns a forced update" {
+    cd repo2
+    dolt sql -q "create table t2 (a int)"
+    dolt add .
+    dolt commit -am "forced commit"
+    dolt push --force origin main
+    cd ../repo1
+
+    run dolt sql -q "CALL dolt_fetch('origin', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[2]}" =~ ^1,refs/remotes/origin/main,[0-9a-v]{32},[0-9a-v]{32},forced,,$ ]] || false
+}
+
 @test "sql-fetch: dolt_fetch unknown remote fails" {
     cd repo2
     dolt remote remove origin
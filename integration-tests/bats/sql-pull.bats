@@ -155,6 +155,19 @@ teardown() {
     [[ "$output" =~ "t1" ]] || false
 }
 
+@test "sql-pull: CALL dolt_pull returns the refs it updated" {
+    cd repo2
+    run dolt sql -q "CALL dolt_pull('origin')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" = "fast_forward,conflicts,remote_ref,old_hash,new_hash,update_type,chunks,bytes" ]] || false
+    [[ "${lines[1]}" =~ ^1,0,,,,,[1-9][0-9]*,[1-9][0-9]*$ ]] || false
+    [[ "$output" =~ "refs/remotes/origin/main,"[0-9a-v]{32}","[0-9a-v]{32}",fast-forward,," ]] || false
+
+    run dolt sql -q "CALL dolt_pull('origin')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/remotes/origin/main,"[0-9a-v]{32}","[0-9a-v]{32}",up-to-date,," ]] || false
+}
+
 @test "sql-pull: dolt_pull unknown remote fails" {
     cd repo2
     run dolt sql -q "call dolt_pull('unknown')"
@@ -305,3 +305,27 @@ teardown() {
     [ "$status" -eq 1 ]
     [[ "$output" =~ "invalid ref spec: ''" ]] || false
 }
+
+@test "sql-push: push a tag created from a captured commit hash" {
+    cd repo1
+    dolt sql <<SQL
+call dolt_checkout('-b', 'feature');
+insert into t1 values (1, 1);
+call dolt_commit_hash_out(@h, '-am', 'feature commit');
+call dolt_tag_hash_out(@tagged, 'v1', @h);
+call dolt_push('origin', 'v1');
+insert into t1 values (2, 2);
+call dolt_commit('-am', 'another feature commit');
+SQL
+
+    cd ../repo2
+    dolt fetch origin
+    run dolt sql -q "select count(*) from dolt_log('v1') where message = 'feature commit'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt sql -q "select * from t1 as of 'v1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
+    [[ ! "$output" =~ "2,2" ]] || false
+}
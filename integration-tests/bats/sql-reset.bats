@@ -553,6 +553,75 @@ SQL
     [[ "$output" = "$working_hash" ]] || false
 }
 
+@test "sql-reset: CALL DOLT_RESET --hard to an older commit updates ahead and behind counts of the upstream" {
+    mkdir remote
+    dolt remote add origin file://remote/
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "first"
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "second"
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "third"
+    dolt push -u origin main
+    target=$(dolt sql -q "SELECT hashof('HEAD~3')" -r csv | tail -n 1)
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is up to date with 'origin/main'" ]] || false
+
+    run dolt sql -q "CALL DOLT_RESET('--hard', 'HEAD~3')"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT hash FROM dolt_branches WHERE name = 'main'" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "$target" ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is behind 'origin/main' by 3 commits, and can be fast-forwarded." ]] || false
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt commit -am "diverged"
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch and 'origin/main' have diverged," ]] || false
+    [[ "$output" =~ "and have 1 and 3 different commits each, respectively." ]] || false
+
+    dolt sql -q "CALL DOLT_RESET('--hard', 'origin/main')"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is up to date with 'origin/main'" ]] || false
+}
+
+@test "sql-reset: DOLT_RESET reports the old and new head commits" {
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "inserting 1"
+    old=$(get_head_commit)
+    target=$(dolt sql -q "SELECT hashof('HEAD~1')" -r csv | tail -n 1)
+
+    run dolt sql -q "CALL DOLT_RESET('--soft', 'HEAD~1')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "status,old_head,new_head" ]
+    [ "${lines[1]}" = "0,$old,$target" ]
+
+    run get_head_commit
+    [ "$output" = "$target" ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+
+    run dolt sql -q "CALL DOLT_RESET('test')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0,$target,$target" ]
+}
+
 get_head_commit() {
     dolt log -n 1 | grep -m 1 commit | cut -c 13-44
 }
@@ -0,0 +1,68 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    TMPDIRS=$(pwd)/tmpdirs
+    mkdir -p $TMPDIRS/{rem1,repo1}
+
+    cd $TMPDIRS/repo1
+    dolt init
+    dolt remote add origin file://../rem1
+    dolt sql -q "create table t1 (a int primary key, b int)"
+    dolt sql -q "create table t2 (a int primary key)"
+    dolt add .
+    dolt commit -am "First commit"
+    dolt push origin main
+}
+
+teardown() {
+    teardown_common
+    rm -rf $TMPDIRS
+    cd $BATS_TMPDIR
+}
+
+@test "sql-verify-sync: all tables match after push" {
+    run dolt sql -q "call dolt_verify_sync('origin', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "table_name,local_hash,remote_hash,matches" ]] || false
+    [[ "$output" =~ "t1,".*",true" ]] || false
+    [[ "$output" =~ "t2,".*",true" ]] || false
+    [[ ! "$output" =~ "false" ]] || false
+}
+
+@test "sql-verify-sync: reports tables changed since push" {
+    dolt sql -q "insert into t1 values (1, 1)"
+    dolt sql -q "create table t3 (a int primary key)"
+    dolt add .
+    dolt commit -am "Second commit"
+
+    run dolt sql -q "call dolt_verify_sync('origin', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1,".*",false" ]] || false
+    [[ "$output" =~ "t2,".*",true" ]] || false
+    [[ "$output" =~ "t3,".*",,false" ]] || false
+
+    local_hash=$(dolt sql -q "select from_hash from dolt_table_match('main', 'main') where table_name = 't1'" -r csv | tail -n 1)
+    [[ "$output" =~ "t1,$local_hash," ]] || false
+
+    dolt push origin main
+    run dolt sql -q "call dolt_verify_sync('origin', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "false" ]] || false
+}
+
+@test "sql-verify-sync: errors" {
+    run dolt sql -q "call dolt_verify_sync('origin')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "remote and branch must be specified" ]] || false
+
+    run dolt sql -q "call dolt_verify_sync('unknown', 'main')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote" ]] || false
+
+    dolt branch feature
+    run dolt sql -q "call dolt_verify_sync('origin', 'feature')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to resolve branch 'feature' on remote 'origin'" ]] || false
+}
@@ -11,7 +11,7 @@ export const branchTests = [
   },
   {
     q: `CALL DOLT_CLEAN('mysqldump_table', 'warehouse')`,
-    res: [{ status: 0 }],
+    res: [{ status: 0, table_name: null }],
   },
   {
     q: `USE ::dbName`,